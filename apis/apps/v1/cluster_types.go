@@ -509,6 +509,17 @@ type ClusterComponentSpec struct {
 	// +optional
 	OfflineInstances []string `json:"offlineInstances,omitempty"`
 
+	// Specifies the policy to rebuild instances automatically when the nodes they are running on fail persistently.
+	//
+	// When enabled, an instance whose node has been NotReady or removed for longer than the grace period
+	// is rebuilt on another node through a RebuildInstance OpsRequest, either from a peer or from the latest backup.
+	// The rebuild is skipped when it may compromise the quorum of the Component.
+	//
+	// Defaults to nil, which means disabled.
+	//
+	// +optional
+	AutoHealing *InstanceAutoHealing `json:"autoHealing,omitempty"`
+
 	// Determines whether metrics exporter information is annotated on the Component's headless Service.
 	//
 	// If set to true, the following annotations will not be patched into the Service:
@@ -637,6 +648,48 @@ type ClusterSharding struct {
 	//
 	// +optional
 	Offline []string `json:"offline,omitempty"`
+
+	// Specifies the ServiceVersion and ComponentDefinition of particular shards, which take precedence over
+	// the ones defined in the template.
+	//
+	// It allows the shards to run different versions temporarily, for example, when a new ServiceVersion
+	// or ShardingDefinition is rolled out shard by shard.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ShardTemplates []ShardTemplate `json:"shardTemplates,omitempty"`
+}
+
+// ShardTemplate overrides the ServiceVersion and ComponentDefinition of the specified shards.
+type ShardTemplate struct {
+	// The name of the shard template.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+
+	// The names of the shards (components) that the template applies to.
+	//
+	// Like the `offline`, the full names of the components are expected, e.g. "mycluster-myshard-abc".
+	//
+	// +optional
+	Shards []string `json:"shards,omitempty"`
+
+	// Specifies the ServiceVersion of the shards.
+	//
+	// +kubebuilder:validation:MaxLength=32
+	// +optional
+	ServiceVersion string `json:"serviceVersion,omitempty"`
+
+	// Specifies the ComponentDefinition of the shards.
+	//
+	// The full name or regular expression is supported to match the ComponentDefinition.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	CompDef string `json:"compDef,omitempty"`
 }
 
 // ClusterService defines a service that is exposed externally, allowing entities outside the cluster to access it.
@@ -296,6 +296,17 @@ type ComponentSpec struct {
 	// +optional
 	OfflineInstances []string `json:"offlineInstances,omitempty"`
 
+	// Specifies the policy to rebuild instances automatically when the nodes they are running on fail persistently.
+	//
+	// When enabled, an instance whose node has been NotReady or removed for longer than the grace period
+	// is rebuilt on another node through a RebuildInstance OpsRequest, either from a peer or from the latest backup.
+	// The rebuild is skipped when it may compromise the quorum of the Component.
+	//
+	// Defaults to nil, which means disabled.
+	//
+	// +optional
+	AutoHealing *InstanceAutoHealing `json:"autoHealing,omitempty"`
+
 	// Defines runtimeClassName for all Pods managed by this Component.
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
@@ -362,8 +373,91 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
+
+	// Records the status of the latest execution of the multi-step lifecycle actions, for each pod which the actions are called for.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=podName
+	// +optional
+	LifecycleActions []LifecycleActionStatus `json:"lifecycleActions,omitempty"`
 }
 
+// LifecycleActionStatus represents the status of an execution of a multi-step lifecycle action.
+type LifecycleActionStatus struct {
+	// The name of the lifecycle action.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The name of the pod which the action is called for.
+	//
+	// +kubebuilder:validation:Required
+	PodName string `json:"podName"`
+
+	// The phase of the execution, which is one of Running, Succeeded and Failed.
+	//
+	// +optional
+	Phase ActionStepPhase `json:"phase,omitempty"`
+
+	// The status of the steps executed.
+	//
+	// +optional
+	Steps []ActionStepStatus `json:"steps,omitempty"`
+
+	// The time when the execution started.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the execution completed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ActionStepStatus represents the status of a step of the multi-step lifecycle action.
+type ActionStepStatus struct {
+	// The name of the step.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The phase of the step.
+	//
+	// +optional
+	Phase ActionStepPhase `json:"phase,omitempty"`
+
+	// The message of the step, which describes the error if it fails.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The time when the step started.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the step completed, or the compensation of it completed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ActionStepPhase defines the phase of a step of the multi-step lifecycle action.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeeded,Failed,RolledBack,RollbackFailed}
+type ActionStepPhase string
+
+const (
+	RunningActionStepPhase        ActionStepPhase = "Running"
+	SucceededActionStepPhase      ActionStepPhase = "Succeeded"
+	FailedActionStepPhase         ActionStepPhase = "Failed"
+	RolledBackActionStepPhase     ActionStepPhase = "RolledBack"
+	RollbackFailedActionStepPhase ActionStepPhase = "RollbackFailed"
+)
+
 type Sidecar struct {
 	// Name specifies the unique name of the sidecar.
 	//
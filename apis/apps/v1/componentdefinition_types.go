@@ -1523,7 +1523,7 @@ type ComponentLifecycleActions struct {
 	// Note: This field is immutable once it has been set.
 	//
 	// +optional
-	Switchover *Action `json:"switchover,omitempty"`
+	Switchover *StepAction `json:"switchover,omitempty"`
 
 	// Defines the procedure to add a new replica to the replication group.
 	//
@@ -1589,7 +1589,7 @@ type ComponentLifecycleActions struct {
 	// Note: This field is immutable once it has been set.
 	//
 	// +optional
-	MemberLeave *Action `json:"memberLeave,omitempty"`
+	MemberLeave *StepAction `json:"memberLeave,omitempty"`
 
 	// Defines the procedure to switch a replica into the read-only state.
 	//
@@ -1723,21 +1723,25 @@ type ComponentLifecycleActions struct {
 //     to access context information such as details about pods, components, the overall cluster state,
 //     or database connection credentials.
 //     These variables provide a dynamic and context-aware mechanism for script execution.
-//   - HTTPAction: Performs an HTTP request.
-//     HTTPAction is to be implemented in future version.
-//   - GRPCAction: In future version, Actions will support initiating gRPC calls.
-//     This allows developers to implement Actions using plugins written in programming language like Go,
-//     providing greater flexibility and extensibility.
+//   - HTTPAction: Performs an HTTP request against an endpoint exposed by the database in the same pod.
+//     The path, headers and body are rendered as templates with the same variables available to ExecAction,
+//     which allows engines that provide a REST admin API to be managed without forking a process.
+//   - GRPCAction: Initiates a unary gRPC call against an endpoint exposed by the database in the same pod.
+//     The request message is rendered as a JSON template and encoded using the descriptors
+//     obtained from the gRPC server reflection service.
 //
-// An action is considered successful on returning 0, or HTTP 200 for status HTTP(s) Actions.
-// Any other return value or HTTP status codes indicate failure,
+// An action is considered successful on returning 0, or HTTP 2xx (or one of the expected status codes)
+// for HTTP(s) Actions, or the OK status code for gRPC Actions.
+// Any other return value or status codes indicate failure,
 // and the action may be retried based on the configured retry policy.
 //
 //   - If an action exceeds the specified timeout duration, it will be terminated, and the action is considered failed.
 //   - If an action produces any data as output, it should be written to stdout,
-//     or included in the HTTP response payload for HTTP(s) actions.
+//     or included in the response payload for HTTP(s) and gRPC actions.
 //   - If an action encounters any errors, error messages should be written to stderr,
-//     or detailed in the HTTP response with the appropriate non-200 status code.
+//     or detailed in the HTTP response with the appropriate non-2xx status code.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.exec), has(self.http), has(self.grpc)].filter(x, x).size() <= 1",message="at most one of exec, http and grpc can be specified"
 type Action struct {
 	// Defines the command to run.
 	//
@@ -1746,6 +1750,20 @@ type Action struct {
 	// +optional
 	Exec *ExecAction `json:"exec,omitempty"`
 
+	// Defines the HTTP request to perform.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	HTTP *HTTPAction `json:"http,omitempty"`
+
+	// Defines the gRPC call to initiate.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	GRPC *GRPCAction `json:"grpc,omitempty"`
+
 	// Specifies the maximum duration in seconds that the Action is allowed to run.
 	//
 	// If the Action does not complete within this time frame, it will be terminated.
@@ -1766,6 +1784,22 @@ type Action struct {
 	// +optional
 	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
 
+	// Specifies the duration in seconds for which a successful result of the Action is cached by the kb-agent.
+	//
+	// Identical requests, that is, requests with the same parameters, received within this duration are answered
+	// with the cached result rather than executing the Action again.
+	// Identical requests received while the Action is running are always merged into a single execution,
+	// regardless of this field.
+	//
+	// It is intended for Actions that are idempotent and have no side effects, such as queries.
+	// Leave it unset to disable the caching.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CacheTTLSeconds int32 `json:"cacheTTLSeconds,omitempty"`
+
 	// Specifies the state that the cluster must reach before the Action is executed.
 	// Currently, this is only applicable to the `postProvision` action.
 	//
@@ -1863,6 +1897,127 @@ type ExecAction struct {
 	Container string `json:"container,omitempty"`
 }
 
+// HTTPAction describes an Action that performs an HTTP request.
+//
+// The request is sent by the kbagent running in the same pod, so the target endpoint is typically
+// the admin API of the database listening on the loopback interface.
+//
+// The `path`, the values of `headers` and the `body` are Go templates. They are rendered with the variables
+// available to the Action, the same ones that are exposed as environment variables to an ExecAction,
+// e.g. `{{ .KB_POD_NAME }}`.
+type HTTPAction struct {
+	// Specifies the host to connect to. Defaults to the loopback address of the pod.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Specifies the port to connect to.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Specifies the scheme to use for connecting to the host, HTTP or HTTPS. Defaults to HTTP.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Enum={HTTP,HTTPS}
+	// +kubebuilder:default=HTTP
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Specifies the HTTP method of the request. Defaults to GET.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Enum={GET,HEAD,POST,PUT,PATCH,DELETE}
+	// +kubebuilder:default=GET
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Specifies the path of the request, it can be a template.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Specifies the custom headers to set in the request, the values can be templates.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Headers []corev1.HTTPHeader `json:"headers,omitempty"`
+
+	// Specifies the body of the request, it can be a template.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Specifies the status codes that indicate a successful request.
+	// If not specified, any 2xx status code is considered successful.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+}
+
+// GRPCAction describes an Action that initiates a unary gRPC call.
+//
+// The call is initiated by the kbagent running in the same pod over a plaintext connection.
+// The target server must enable the gRPC server reflection service, which is used to resolve
+// the method and to encode the request and decode the response.
+//
+// The `request` is a Go template of the JSON representation of the request message.
+// It is rendered with the variables available to the Action, the same ones that are exposed
+// as environment variables to an ExecAction, e.g. `{{ .KB_POD_NAME }}`.
+// The output of the Action is the JSON representation of the response message.
+type GRPCAction struct {
+	// Specifies the host to connect to. Defaults to the loopback address of the pod.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Specifies the port to connect to.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Specifies the fully qualified name of the gRPC service, e.g. `etcdserverpb.Maintenance`.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Required
+	Service string `json:"service"`
+
+	// Specifies the name of the method to call, e.g. `Status`.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Required
+	Method string `json:"method"`
+
+	// Specifies the JSON representation of the request message, it can be a template.
+	// If not specified, an empty message will be sent.
+	//
+	// This field cannot be updated.
+	//
+	// +optional
+	Request string `json:"request,omitempty"`
+}
+
 // TargetPodSelector defines how to select pod(s) to execute an Action.
 // +enum
 // +kubebuilder:validation:Enum={Any,All,Role,Ordinal}
@@ -1875,6 +2030,97 @@ const (
 	OrdinalSelector TargetPodSelector = "Ordinal"
 )
 
+// StepAction defines an Action which can also be defined as an ordered list of steps, each of which is executed
+// by its own handler, and has an optional compensation handler to roll back on failure.
+// It allows complex procedures, such as switchover with fencing, to be built as reliable workflows.
+//
+// It is only supported by the `switchover` and `memberLeave` actions.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.exec), has(self.http), has(self.grpc), has(self.steps)].filter(x, x).size() <= 1",message="at most one of exec, http, grpc and steps can be specified"
+type StepAction struct {
+	Action `json:",inline"`
+
+	// Defines the ordered steps of the Action, as an alternative to the single exec, http or grpc handler.
+	//
+	// The steps are executed one by one, and the Action succeeds when all the steps succeed.
+	// If a step fails, the `onFailure` handlers of the failed step and the steps succeeded before it
+	// are executed in reverse order to roll back, and the Action is considered failed.
+	//
+	// The output of the Action is the output of the last step.
+	// The status of each step is surfaced in the status of the Component.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []ActionStep `json:"steps,omitempty"`
+}
+
+// ActionStep defines a step of the multi-step Action.
+type ActionStep struct {
+	// The name of the step, which must be unique within the Action.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Defines the handler of the step.
+	//
+	// The target Pod(s) of the step are selected by the `targetPodSelector` and `matchingKey` of the exec handler,
+	// or of the step itself for the http and grpc handlers, which can differ from the other steps.
+	ActionStepHandler `json:",inline"`
+
+	// Defines the compensation handler to roll back the step,
+	// which is executed when the step, or any step after it, fails.
+	//
+	// +optional
+	OnFailure *ActionStepHandler `json:"onFailure,omitempty"`
+}
+
+// ActionStepHandler defines how a step of the Action is executed.
+//
+// +kubebuilder:validation:XValidation:rule="[has(self.exec), has(self.http), has(self.grpc)].filter(x, x).size() == 1",message="exactly one of exec, http and grpc must be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.exec) || !has(self.targetPodSelector)",message="the target pods of the exec handler are selected by the exec.targetPodSelector"
+type ActionStepHandler struct {
+	// Defines the command to run.
+	//
+	// +optional
+	Exec *ExecAction `json:"exec,omitempty"`
+
+	// Defines the HTTP request to perform.
+	//
+	// +optional
+	HTTP *HTTPAction `json:"http,omitempty"`
+
+	// Defines the gRPC call to initiate.
+	//
+	// +optional
+	GRPC *GRPCAction `json:"grpc,omitempty"`
+
+	// Defines the criteria used to select the target Pod(s) for the http or grpc handler.
+	// The exec handler uses its own `targetPodSelector` instead.
+	//
+	// If not specified, the step will be executed in the pod where the Action is triggered.
+	//
+	// +optional
+	TargetPodSelector TargetPodSelector `json:"targetPodSelector,omitempty"`
+
+	// Used in conjunction with the `targetPodSelector` field to refine the selection of target pod(s),
+	// only those replicas whose role matches the `matchingKey` are selected when `targetPodSelector` is `Role`.
+	//
+	// +optional
+	MatchingKey string `json:"matchingKey,omitempty"`
+
+	// Specifies the maximum duration in seconds that the step is allowed to run.
+	//
+	// +kubebuilder:default=0
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
 type RetryPolicy struct {
 	// Defines the maximum number of retry attempts that should be made for a given Action.
 	// This value is set to 0 by default, indicating that no retries will be made.
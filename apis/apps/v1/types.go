@@ -611,8 +611,71 @@ type RollingUpdate struct {
 	//
 	// +optional
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// The maximum number of extra instances that can be created over the desired number of instances during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired instances (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up. Defaults to 0.
+	//
+	// When it is greater than 0, an instance to be recreated is replaced by a temporary surge instance first:
+	// the surge instance is created with the updated template, and joins the membership through the MemberJoin action.
+	// Only after it is available, the old instance leaves the membership through the MemberLeave action and is recreated.
+	// The surge instance leaves and is deleted once the recreated instance is available and has joined again.
+	// This keeps the capacity and redundancy intact during the update, at the cost of the extra resources.
+	//
+	// The instances updated in-place are not affected.
+	//
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// InstanceAutoHealing defines the policy to rebuild instances automatically on persistent node failures.
+type InstanceAutoHealing struct {
+	// The duration in seconds that the node of an instance must be NotReady or removed
+	// before the instance is considered failed and will be rebuilt.
+	//
+	// Defaults to 600.
+	//
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	GracePeriodSeconds int32 `json:"gracePeriodSeconds,omitempty"`
+
+	// Specifies where the data of the rebuilt instance comes from.
+	//
+	// - Peer: a new instance is created on another node, and its data is loaded from a healthy peer.
+	//   The failed instance is taken offline afterward.
+	// - Backup: the instance is rebuilt in place from the latest completed backup of the Component.
+	//
+	// Defaults to Peer.
+	//
+	// +kubebuilder:default=Peer
+	// +optional
+	Source InstanceAutoHealingSource `json:"source,omitempty"`
+
+	// The maximum number of instances that can be rebuilt concurrently.
+	//
+	// Defaults to 1.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRebuilds int32 `json:"maxConcurrentRebuilds,omitempty"`
 }
 
+// InstanceAutoHealingSource defines the data source to rebuild a failed instance.
+//
+// +enum
+// +kubebuilder:validation:Enum={Peer,Backup}
+type InstanceAutoHealingSource string
+
+const (
+	// PeerAutoHealingSource rebuilds the instance by loading data from a healthy peer.
+	PeerAutoHealingSource InstanceAutoHealingSource = "Peer"
+
+	// BackupAutoHealingSource rebuilds the instance from the latest completed backup.
+	BackupAutoHealingSource InstanceAutoHealingSource = "Backup"
+)
+
 type SchedulingPolicy struct {
 	// If specified, the Pod will be dispatched by specified scheduler.
 	// If not specified, the Pod will be dispatched by default scheduler.
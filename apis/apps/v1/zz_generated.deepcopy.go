@@ -40,6 +40,16 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(ExecAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCAction)
+		**out = **in
+	}
 	if in.RetryPolicy != nil {
 		in, out := &in.RetryPolicy, &out.RetryPolicy
 		*out = new(RetryPolicy)
@@ -117,6 +127,80 @@ func (in *ActionOutputMatcher) DeepCopy() *ActionOutputMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionStep) DeepCopyInto(out *ActionStep) {
+	*out = *in
+	in.ActionStepHandler.DeepCopyInto(&out.ActionStepHandler)
+	if in.OnFailure != nil {
+		in, out := &in.OnFailure, &out.OnFailure
+		*out = new(ActionStepHandler)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStep.
+func (in *ActionStep) DeepCopy() *ActionStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionStepHandler) DeepCopyInto(out *ActionStepHandler) {
+	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStepHandler.
+func (in *ActionStepHandler) DeepCopy() *ActionStepHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionStepHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionStepStatus) DeepCopyInto(out *ActionStepStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStepStatus.
+func (in *ActionStepStatus) DeepCopy() *ActionStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -378,6 +462,11 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutoHealing != nil {
+		in, out := &in.AutoHealing, &out.AutoHealing
+		*out = new(InstanceAutoHealing)
+		**out = **in
+	}
 	if in.DisableExporter != nil {
 		in, out := &in.DisableExporter, &out.DisableExporter
 		*out = new(bool)
@@ -600,6 +689,13 @@ func (in *ClusterSharding) DeepCopyInto(out *ClusterSharding) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ShardTemplates != nil {
+		in, out := &in.ShardTemplates, &out.ShardTemplates
+		*out = make([]ShardTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSharding.
@@ -1292,7 +1388,7 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 	}
 	if in.Switchover != nil {
 		in, out := &in.Switchover, &out.Switchover
-		*out = new(Action)
+		*out = new(StepAction)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.MemberJoin != nil {
@@ -1302,7 +1398,7 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 	}
 	if in.MemberLeave != nil {
 		in, out := &in.MemberLeave, &out.MemberLeave
-		*out = new(Action)
+		*out = new(StepAction)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Readonly != nil {
@@ -1551,6 +1647,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutoHealing != nil {
+		in, out := &in.AutoHealing, &out.AutoHealing
+		*out = new(InstanceAutoHealing)
+		**out = **in
+	}
 	if in.RuntimeClassName != nil {
 		in, out := &in.RuntimeClassName, &out.RuntimeClassName
 		*out = new(string)
@@ -1600,6 +1701,13 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.LifecycleActions != nil {
+		in, out := &in.LifecycleActions, &out.LifecycleActions
+		*out = make([]LifecycleActionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -2053,6 +2161,46 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCAction) DeepCopyInto(out *GRPCAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCAction.
+func (in *GRPCAction) DeepCopy() *GRPCAction {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAction) DeepCopyInto(out *HTTPAction) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]corev1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAction.
+func (in *HTTPAction) DeepCopy() *HTTPAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetwork) DeepCopyInto(out *HostNetwork) {
 	*out = *in
@@ -2132,6 +2280,21 @@ func (in *HostNetworkVars) DeepCopy() *HostNetworkVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceAutoHealing) DeepCopyInto(out *InstanceAutoHealing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceAutoHealing.
+func (in *InstanceAutoHealing) DeepCopy() *InstanceAutoHealing {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceAutoHealing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceTemplate) DeepCopyInto(out *InstanceTemplate) {
 	*out = *in
@@ -2236,6 +2399,36 @@ func (in *Issuer) DeepCopy() *Issuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleActionStatus) DeepCopyInto(out *LifecycleActionStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ActionStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleActionStatus.
+func (in *LifecycleActionStatus) DeepCopy() *LifecycleActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogConfig) DeepCopyInto(out *LogConfig) {
 	*out = *in
@@ -2607,6 +2800,11 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdate.
@@ -3042,6 +3240,26 @@ func (in *ServiceVars) DeepCopy() *ServiceVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardTemplate) DeepCopyInto(out *ShardTemplate) {
+	*out = *in
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardTemplate.
+func (in *ShardTemplate) DeepCopy() *ShardTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardingDefinition) DeepCopyInto(out *ShardingDefinition) {
 	*out = *in
@@ -3406,6 +3624,29 @@ func (in *SidecarDefinitionStatus) DeepCopy() *SidecarDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepAction) DeepCopyInto(out *StepAction) {
+	*out = *in
+	in.Action.DeepCopyInto(&out.Action)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ActionStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepAction.
+func (in *StepAction) DeepCopy() *StepAction {
+	if in == nil {
+		return nil
+	}
+	out := new(StepAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemAccount) DeepCopyInto(out *SystemAccount) {
 	*out = *in
@@ -229,7 +229,7 @@ func (r *ComponentDefinition) toV1LifecycleActions(cmpd *appsv1.ComponentDefinit
 	cmpd.Spec.LifecycleActions.PostProvision = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.PostProvision)
 	cmpd.Spec.LifecycleActions.PreTerminate = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.PreTerminate)
 	cmpd.Spec.LifecycleActions.MemberJoin = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.MemberJoin)
-	cmpd.Spec.LifecycleActions.MemberLeave = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.MemberLeave)
+	cmpd.Spec.LifecycleActions.MemberLeave = r.toV1LifecycleStepAction(r.Spec.LifecycleActions.MemberLeave)
 	cmpd.Spec.LifecycleActions.Readonly = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.Readonly)
 	cmpd.Spec.LifecycleActions.Readwrite = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.Readwrite)
 	cmpd.Spec.LifecycleActions.DataDump = r.toV1LifecycleActionHandler(r.Spec.LifecycleActions.DataDump)
@@ -249,7 +249,7 @@ func (r *ComponentDefinition) fromV1LifecycleActions(cmpd *appsv1.ComponentDefin
 	r.Spec.LifecycleActions.PostProvision = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.PostProvision, r.Spec.LifecycleActions.PostProvision)
 	r.Spec.LifecycleActions.PreTerminate = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.PreTerminate, r.Spec.LifecycleActions.PreTerminate)
 	r.Spec.LifecycleActions.MemberJoin = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.MemberJoin, r.Spec.LifecycleActions.MemberJoin)
-	r.Spec.LifecycleActions.MemberLeave = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.MemberLeave, r.Spec.LifecycleActions.MemberLeave)
+	r.Spec.LifecycleActions.MemberLeave = r.fromV1LifecycleStepAction(cmpd.Spec.LifecycleActions.MemberLeave, r.Spec.LifecycleActions.MemberLeave)
 	r.Spec.LifecycleActions.Readonly = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.Readonly, r.Spec.LifecycleActions.Readonly)
 	r.Spec.LifecycleActions.Readwrite = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.Readwrite, r.Spec.LifecycleActions.Readwrite)
 	r.Spec.LifecycleActions.DataDump = r.fromV1LifecycleActionHandler(cmpd.Spec.LifecycleActions.DataDump, r.Spec.LifecycleActions.DataDump)
@@ -278,6 +278,21 @@ func (r *ComponentDefinition) fromV1LifecycleActionHandler(action *appsv1.Action
 	}
 }
 
+func (r *ComponentDefinition) toV1LifecycleStepAction(handler *LifecycleActionHandler) *appsv1.StepAction {
+	action := r.toV1LifecycleActionHandler(handler)
+	if action == nil {
+		return nil
+	}
+	return &appsv1.StepAction{Action: *action}
+}
+
+func (r *ComponentDefinition) fromV1LifecycleStepAction(action *appsv1.StepAction, alphav1Action *LifecycleActionHandler) *LifecycleActionHandler {
+	if action == nil {
+		return alphav1Action
+	}
+	return r.fromV1LifecycleActionHandler(&action.Action, alphav1Action)
+}
+
 func (r *ComponentDefinition) toV1LifecycleRoleProbe(probe *RoleProbe) *appsv1.Probe {
 	if probe == nil || probe.CustomHandler == nil || probe.CustomHandler.Exec == nil {
 		return nil
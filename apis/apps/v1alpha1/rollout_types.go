@@ -61,6 +61,8 @@ func init() {
 }
 
 // RolloutSpec defines the desired state of Rollout
+//
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.abort) || !oldSelf.abort || (has(self.abort) && self.abort)",message="forbidden to revert spec.abort once it is true"
 type RolloutSpec struct {
 	// Specifies the target cluster of the Rollout.
 	//
@@ -75,6 +77,30 @@ type RolloutSpec struct {
 	// +optional
 	Components []RolloutComponent `json:"components,omitempty"`
 
+	// Specifies the target shardings to be rolled out.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=128
+	// +optional
+	Shardings []RolloutSharding `json:"shardings,omitempty"`
+
+	// Specifies whether the rollout is paused.
+	//
+	// If true, the rollout is frozen at the current replica, no more instances will be rolled out until it is resumed.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Specifies whether to abort the rollout.
+	//
+	// If true, the instances that have been rolled out are reverted to the original ServiceVersion and ComponentDefinition,
+	// and the canary instances are torn down. It takes no effect after the rollout succeeded.
+	//
+	// The abort is terminal, it can not be reverted once it is set to true.
+	//
+	// +optional
+	Abort bool `json:"abort,omitempty"`
+
 	// TODO: auto-reclaim the successful rollouts.
 }
 
@@ -104,6 +130,11 @@ type RolloutStatus struct {
 	//
 	// +optional
 	Components []RolloutComponentStatus `json:"components,omitempty"`
+
+	// Records the status information of all shardings within the Rollout.
+	//
+	// +optional
+	Shardings []RolloutShardingStatus `json:"shardings,omitempty"`
 }
 
 type RolloutComponent struct {
@@ -142,6 +173,47 @@ type RolloutComponent struct {
 	InstanceMeta *RolloutInstanceMeta `json:"instanceMeta,omitempty"`
 }
 
+// RolloutSharding rolls out the shards of a sharding one by one, or several at a time.
+//
+// The shards are rolled out in-place in the order of their names, the next shards are rolled out only after
+// the shards in flight are running and healthy.
+type RolloutSharding struct {
+	// Specifies the name of the sharding.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the target ServiceVersion of the sharding.
+	//
+	// +kubebuilder:validation:MaxLength=32
+	// +optional
+	ServiceVersion *string `json:"serviceVersion,omitempty"`
+
+	// Specifies the target ShardingDefinition of the sharding.
+	//
+	// The shards are rolled out to the ComponentDefinition defined in the template of the ShardingDefinition.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	ShardingDef *string `json:"shardingDef,omitempty"`
+
+	// The maximum number of shards that can be rolled out at the same time.
+	//
+	// Value can be an absolute number (ex: 5) or a percentage of the shards (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up. Defaults to 1.
+	//
+	// +optional
+	MaxConcurrentShards *intstr.IntOrString `json:"maxConcurrentShards,omitempty"`
+
+	// The number of seconds to wait before rolling out the next shards, after the shards in flight become healthy.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PerShardIntervalSeconds *int32 `json:"perShardIntervalSeconds,omitempty"`
+}
+
 type RolloutStrategy struct {
 	// In-place rollout strategy.
 	//
@@ -183,6 +255,13 @@ type RolloutStrategyReplace struct {
 	// +optional
 	ScaleDownDelaySeconds *int32 `json:"scaleDownDelaySeconds,omitempty"`
 
+	// The condition for promoting the new instance, which is checked before scaling down an old instance.
+	//
+	// Only the analysis is supported by the replace strategy now.
+	//
+	// +optional
+	PromoteCondition *RolloutPromoteCondition `json:"promoteCondition,omitempty"`
+
 	// TODO: policy to scale-down the old instances and retain the PVCs.
 }
 
@@ -199,6 +278,9 @@ type RolloutStrategyCreate struct {
 
 	// Specifies the promotion strategy for the component.
 	//
+	// The analysis in the promotion condition is not supported by the create strategy.
+	//
+	// +kubebuilder:validation:XValidation:rule="!has(self.condition) || !has(self.condition.analysis)",message="the analysis is not supported by the create strategy"
 	// +optional
 	Promotion *RolloutPromotion `json:"promotion,omitempty"`
 }
@@ -242,7 +324,112 @@ type RolloutPromoteCondition struct {
 	// +optional
 	Post *appsv1.Action `json:"post,omitempty"`
 
-	// TODO: variables can be used in the conditions.
+	// The analysis to decide whether to promote the new instances or to abort the rollout.
+	//
+	// The metrics are sampled periodically after the new instances are ready.
+	// The new instances are promoted when the succeeded samples reach the success threshold.
+	// The rollout is aborted and the new instances are rolled back to the original ServiceVersion and ComponentDefinition
+	// when the failed samples reach the failure threshold, or the success threshold is not reached within the window.
+	//
+	// It is supported by the replace strategy only.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+type RolloutAnalysis struct {
+	// The metrics to be sampled, a sample succeeds only when all the metrics succeed.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Metrics []RolloutAnalysisMetric `json:"metrics"`
+
+	// The interval in seconds between two samples.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// The number of samples to be taken at most, which is the window of the analysis.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Samples *int32 `json:"samples,omitempty"`
+
+	// The number of succeeded samples to promote the new instances.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// The number of failed samples to abort the rollout.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// RolloutAnalysisMetric defines a metric to be sampled and the conditions to evaluate the result.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.prometheus) != has(self.probe)",message="exactly one of prometheus and probe must be specified"
+type RolloutAnalysisMetric struct {
+	// The name of the metric.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+
+	// Queries the metric from a Prometheus-compatible server.
+	//
+	// The result of the query is a list of the sample values, the query must return a vector or a scalar.
+	//
+	// +optional
+	Prometheus *RolloutPrometheusMetric `json:"prometheus,omitempty"`
+
+	// The name of the probe whose latest outputs on the new instances are taken as the metric, e.g. roleProbe.
+	//
+	// The result of the probe is a list of the latest results on the new instances,
+	// each of which is a map with the keys: "instance", "code", "output" and "message".
+	//
+	// +kubebuilder:validation:MaxLength=32
+	// +optional
+	Probe *string `json:"probe,omitempty"`
+
+	// The CEL expression to check whether the metric succeeds, the result of the metric is available as `result`.
+	//
+	// For example: `result.all(x, x < 0.01)`.
+	//
+	// +kubebuilder:validation:Required
+	SuccessCondition string `json:"successCondition"`
+
+	// The CEL expression to check whether the metric fails, the result of the metric is available as `result`.
+	//
+	// If specified, the metric fails only when the expression is true, and a sample which neither succeeds nor fails
+	// is inconclusive. Otherwise, the metric fails when the success condition is not met.
+	//
+	// +optional
+	FailureCondition string `json:"failureCondition,omitempty"`
+}
+
+type RolloutPrometheusMetric struct {
+	// The address of the Prometheus-compatible server, e.g. http://prometheus.monitoring:9090.
+	// Only the HTTP and HTTPS addresses are supported, and the address must be in the allowlist of the controller if configured.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// The PromQL query to be executed.
+	//
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
 }
 
 type RolloutInstanceMeta struct {
@@ -268,14 +455,17 @@ type InstanceMeta struct {
 // RolloutState defines the state of the Rollout within the .status.state field.
 //
 // +enum
-// +kubebuilder:validation:Enum={Pending,Rolling,Succeed,Error}
+// +kubebuilder:validation:Enum={Pending,Rolling,Paused,Aborting,Aborted,Succeed,Error}
 type RolloutState string
 
 const (
-	PendingRolloutState RolloutState = "Pending"
-	RollingRolloutState RolloutState = "Rolling"
-	SucceedRolloutState RolloutState = "Succeed"
-	ErrorRolloutState   RolloutState = "Error"
+	PendingRolloutState  RolloutState = "Pending"
+	RollingRolloutState  RolloutState = "Rolling"
+	PausedRolloutState   RolloutState = "Paused"
+	AbortingRolloutState RolloutState = "Aborting"
+	AbortedRolloutState  RolloutState = "Aborted"
+	SucceedRolloutState  RolloutState = "Succeed"
+	ErrorRolloutState    RolloutState = "Error"
 )
 
 type RolloutComponentStatus struct {
@@ -319,6 +509,11 @@ type RolloutComponentStatus struct {
 	// +optional
 	ScaleDownInstances []string `json:"scaleDownInstances,omitempty"`
 
+	// The instances that are rolled back to the original ServiceVersion and ComponentDefinition.
+	//
+	// +optional
+	RolledBackInstances []string `json:"rolledBackInstances,omitempty"`
+
 	// The last time a component replica was scaled up successfully.
 	//
 	// +optional
@@ -328,4 +523,100 @@ type RolloutComponentStatus struct {
 	//
 	// +optional
 	LastScaleDownTimestamp metav1.Time `json:"lastScaleDownTimestamp,omitempty"`
+
+	// The status of the analysis of the new instances.
+	//
+	// +optional
+	Analysis *RolloutAnalysisStatus `json:"analysis,omitempty"`
+}
+
+// RolloutAnalysisPhase defines the phase of the analysis.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeeded,Failed}
+type RolloutAnalysisPhase string
+
+const (
+	RunningRolloutAnalysisPhase   RolloutAnalysisPhase = "Running"
+	SucceededRolloutAnalysisPhase RolloutAnalysisPhase = "Succeeded"
+	FailedRolloutAnalysisPhase    RolloutAnalysisPhase = "Failed"
+)
+
+type RolloutAnalysisStatus struct {
+	// The phase of the analysis.
+	//
+	// +optional
+	Phase RolloutAnalysisPhase `json:"phase,omitempty"`
+
+	// The number of new replicas being analyzed.
+	//
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// The number of samples taken.
+	//
+	// +optional
+	Samples int32 `json:"samples"`
+
+	// The number of succeeded samples.
+	//
+	// +optional
+	Successes int32 `json:"successes"`
+
+	// The number of failed samples.
+	//
+	// +optional
+	Failures int32 `json:"failures"`
+
+	// The last time a sample was taken.
+	//
+	// +optional
+	LastSampleTimestamp metav1.Time `json:"lastSampleTimestamp,omitempty"`
+
+	// Provides the details of the latest sample.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutShardingStatus struct {
+	// The name of the sharding.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The ServiceVersion of the sharding before the rollout.
+	//
+	// +kubebuilder:validation:Required
+	ServiceVersion string `json:"serviceVersion"`
+
+	// The ShardingDefinition of the sharding before the rollout.
+	//
+	// +optional
+	ShardingDef string `json:"shardingDef,omitempty"`
+
+	// The ComponentDefinition of the sharding before the rollout.
+	//
+	// +kubebuilder:validation:Required
+	CompDef string `json:"compDef"`
+
+	// The number of shards the sharding has.
+	//
+	// +optional
+	Shards int32 `json:"shards"`
+
+	// The shards that are being rolled out or have been rolled out.
+	//
+	// +optional
+	RollingShards []string `json:"rollingShards,omitempty"`
+
+	// The shards that have been rolled out successfully.
+	//
+	// +optional
+	RolledOutShards []string `json:"rolledOutShards,omitempty"`
+
+	// The last time a shard was rolled out successfully.
+	//
+	// +optional
+	LastRolledOutTimestamp metav1.Time `json:"lastRolledOutTimestamp,omitempty"`
 }
@@ -3631,6 +3631,89 @@ func (in *Rollout) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysis) DeepCopyInto(out *RolloutAnalysis) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]RolloutAnalysisMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysis.
+func (in *RolloutAnalysis) DeepCopy() *RolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisMetric) DeepCopyInto(out *RolloutAnalysisMetric) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(RolloutPrometheusMetric)
+		**out = **in
+	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisMetric.
+func (in *RolloutAnalysisMetric) DeepCopy() *RolloutAnalysisMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisStatus) DeepCopyInto(out *RolloutAnalysisStatus) {
+	*out = *in
+	in.LastSampleTimestamp.DeepCopyInto(&out.LastSampleTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisStatus.
+func (in *RolloutAnalysisStatus) DeepCopy() *RolloutAnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponent) DeepCopyInto(out *RolloutComponent) {
 	*out = *in
@@ -3675,8 +3758,18 @@ func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RolledBackInstances != nil {
+		in, out := &in.RolledBackInstances, &out.RolledBackInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.LastScaleUpTimestamp.DeepCopyInto(&out.LastScaleUpTimestamp)
 	in.LastScaleDownTimestamp.DeepCopyInto(&out.LastScaleDownTimestamp)
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutComponentStatus.
@@ -3741,6 +3834,21 @@ func (in *RolloutList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPrometheusMetric) DeepCopyInto(out *RolloutPrometheusMetric) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPrometheusMetric.
+func (in *RolloutPrometheusMetric) DeepCopy() *RolloutPrometheusMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPrometheusMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutPromoteCondition) DeepCopyInto(out *RolloutPromoteCondition) {
 	*out = *in
@@ -3754,6 +3862,11 @@ func (in *RolloutPromoteCondition) DeepCopyInto(out *RolloutPromoteCondition) {
 		*out = new(apisappsv1.Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPromoteCondition.
@@ -3801,6 +3914,67 @@ func (in *RolloutPromotion) DeepCopy() *RolloutPromotion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSharding) DeepCopyInto(out *RolloutSharding) {
+	*out = *in
+	if in.ServiceVersion != nil {
+		in, out := &in.ServiceVersion, &out.ServiceVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.ShardingDef != nil {
+		in, out := &in.ShardingDef, &out.ShardingDef
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxConcurrentShards != nil {
+		in, out := &in.MaxConcurrentShards, &out.MaxConcurrentShards
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PerShardIntervalSeconds != nil {
+		in, out := &in.PerShardIntervalSeconds, &out.PerShardIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSharding.
+func (in *RolloutSharding) DeepCopy() *RolloutSharding {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSharding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutShardingStatus) DeepCopyInto(out *RolloutShardingStatus) {
+	*out = *in
+	if in.RollingShards != nil {
+		in, out := &in.RollingShards, &out.RollingShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolledOutShards != nil {
+		in, out := &in.RolledOutShards, &out.RolledOutShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastRolledOutTimestamp.DeepCopyInto(&out.LastRolledOutTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutShardingStatus.
+func (in *RolloutShardingStatus) DeepCopy() *RolloutShardingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutShardingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 	*out = *in
@@ -3811,6 +3985,13 @@ func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Shardings != nil {
+		in, out := &in.Shardings, &out.Shardings
+		*out = make([]RolloutSharding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
@@ -3840,6 +4021,13 @@ func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Shardings != nil {
+		in, out := &in.Shardings, &out.Shardings
+		*out = make([]RolloutShardingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
@@ -3945,6 +4133,11 @@ func (in *RolloutStrategyReplace) DeepCopyInto(out *RolloutStrategyReplace) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PromoteCondition != nil {
+		in, out := &in.PromoteCondition, &out.PromoteCondition
+		*out = new(RolloutPromoteCondition)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategyReplace.
@@ -125,6 +125,17 @@ type BackupActionSpec struct {
 	// +optional
 	PreDeleteBackup *BaseJobActionSpec `json:"preDelete,omitempty"`
 
+	// Represents a custom action to verify that the backup is restorable.
+	//
+	// The backup is first restored by the `restore.prepareData` action into an emptyDir volume of a
+	// throwaway pod, which is mounted at the mount paths of the target volumes of the backup method.
+	// Then the action runs against the restored data, and is expected to run a validation command of
+	// the database engine. The backup is verified if both actions succeed.
+	// The environment variables DP_BACKUP_BASE_PATH and DP_BACKUP_NAME are provided.
+	//
+	// +optional
+	Verify *BaseJobActionSpec `json:"verify,omitempty"`
+
 	// Specifies the parameters used by the backup action
 	//
 	// +optional
@@ -210,8 +210,161 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the verification status of the backup.
+	//
+	// +optional
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+
+	// Records the copies of the backup replicated to other backup repositories
+	// by the BackupReplicationPolicies.
+	//
+	// +listType=map
+	// +listMapKey=backupRepoName
+	// +optional
+	Replicas []BackupReplicaStatus `json:"replicas,omitempty"`
+
+	// Records the time the backup data was deleted from the backup repository where it was created,
+	// since the backup expired while its replicas are retained.
+	// The backup can only be restored from the replicas since then, and is deleted once the replicas expire.
+	//
+	// +optional
+	PrimaryDeletionTimestamp *metav1.Time `json:"primaryDeletionTimestamp,omitempty"`
 }
 
+// BackupReplicaStatus records the status of a copy of the backup in another backup repository.
+type BackupReplicaStatus struct {
+	// The name of the backup repository where the replica is stored.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// The path of the replica in the backup repository.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// The phase of the replica.
+	//
+	// +optional
+	Phase BackupReplicaPhase `json:"phase,omitempty"`
+
+	// Records the time the last replication was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the last successful replication was completed.
+	// The replica can be used to restore once it is set.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// The date and time when the replica will be deleted from the backup repository,
+	// according to the retention period of the replication target.
+	// If not set, the replica is deleted along with the backup.
+	//
+	// +optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+
+	// Records the time range of the data in the replica. For continuous backups,
+	// this is the recoverable time range of the replica.
+	//
+	// +optional
+	TimeRange *BackupTimeRange `json:"timeRange,omitempty"`
+
+	// An error that caused the replication to fail.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// BackupReplicaPhase describes the phase of a backup replica.
+// +enum
+// +kubebuilder:validation:Enum={Replicating,Completed,Failed,Deleting,Deleted}
+type BackupReplicaPhase string
+
+const (
+	// BackupReplicaPhaseReplicating means the backup is being copied to the backup repository.
+	BackupReplicaPhaseReplicating BackupReplicaPhase = "Replicating"
+
+	// BackupReplicaPhaseCompleted means the last replication has been completed.
+	BackupReplicaPhaseCompleted BackupReplicaPhase = "Completed"
+
+	// BackupReplicaPhaseFailed means the last replication has failed.
+	BackupReplicaPhaseFailed BackupReplicaPhase = "Failed"
+
+	// BackupReplicaPhaseDeleting means the replica has expired and is being deleted.
+	BackupReplicaPhaseDeleting BackupReplicaPhase = "Deleting"
+
+	// BackupReplicaPhaseDeleted means the replica has expired and been deleted from the backup repository.
+	// It will not be replicated again.
+	BackupReplicaPhaseDeleted BackupReplicaPhase = "Deleted"
+)
+
+// BackupVerificationStatus records the verification status of a backup.
+type BackupVerificationStatus struct {
+	// Records the result of verifying the checksums of the backup data in the backup repository.
+	//
+	// +optional
+	Checksum *VerificationResult `json:"checksum,omitempty"`
+
+	// Records the result of restoring the backup in a throwaway pod and validating it.
+	//
+	// +optional
+	Restore *VerificationResult `json:"restore,omitempty"`
+
+	// Records the time the checksums of the backup data were recorded in the backup repository,
+	// by the backup job when the backup data is uploaded, or by the first checksum verification otherwise.
+	// The checksums are compared with by the subsequent checksum verifications.
+	//
+	// +optional
+	ChecksumRecordTimestamp *metav1.Time `json:"checksumRecordTimestamp,omitempty"`
+}
+
+// VerificationResult records the result of a backup verification.
+type VerificationResult struct {
+	// The phase of the verification.
+	//
+	// +optional
+	Phase VerificationPhase `json:"phase,omitempty"`
+
+	// Records the time the verification was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the verification was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// An error that caused the verification to fail.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// VerificationPhase describes the phase of a backup verification.
+// +enum
+// +kubebuilder:validation:Enum={Verifying,Verified,ChecksumRecorded,VerificationFailed}
+type VerificationPhase string
+
+const (
+	// VerificationPhaseVerifying means the backup is being verified.
+	VerificationPhaseVerifying VerificationPhase = "Verifying"
+
+	// VerificationPhaseVerified means the backup has been verified successfully.
+	VerificationPhaseVerified VerificationPhase = "Verified"
+
+	// VerificationPhaseChecksumRecorded means the checksums of the backup data, which are not recorded at upload time,
+	// have been recorded by the first checksum verification, but the backup has not been verified against them yet.
+	VerificationPhaseChecksumRecorded VerificationPhase = "ChecksumRecorded"
+
+	// VerificationPhaseFailed means the backup failed to pass the verification.
+	VerificationPhaseFailed VerificationPhase = "VerificationFailed"
+)
+
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
 // time range of recoverable data.
 type BackupTimeRange struct {
@@ -444,6 +597,23 @@ func (r *Backup) GetEndTime() *metav1.Time {
 	return s.CompletionTimestamp
 }
 
+// GetReplica gets the replica of the backup in the backup repository.
+func (r *Backup) GetReplica(backupRepoName string) *BackupReplicaStatus {
+	for i := range r.Status.Replicas {
+		if r.Status.Replicas[i].BackupRepoName == backupRepoName {
+			return &r.Status.Replicas[i]
+		}
+	}
+	return nil
+}
+
+// IsRestorable checks whether the backup can be restored from the replica, the replica is restorable
+// once it has been replicated successfully, even if it is being replicated again.
+func (r *BackupReplicaStatus) IsRestorable() bool {
+	return r.CompletionTimestamp != nil &&
+		r.Phase != BackupReplicaPhaseDeleting && r.Phase != BackupReplicaPhaseDeleted
+}
+
 func (r *Backup) GetTimeZone() string {
 	s := r.Status
 	if s.TimeRange != nil {
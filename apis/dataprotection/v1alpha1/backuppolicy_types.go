@@ -84,6 +84,21 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	RetentionPolicy BackupPolicyRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Specifies the grandfather-father-son (GFS) retention policy for the backups of this policy.
+	//
+	// An expired backup is retained if it is selected by the GFS policy,
+	// and a backup is retained as long as any retained incremental backup depends on it.
+	// It can be overridden by `backupSchedule.spec.schedules[*].gfsRetention` for the scheduled backups.
+	//
+	// +optional
+	GFSRetention *GFSRetentionPolicy `json:"gfsRetention,omitempty"`
+
+	// Specifies the policy to verify the integrity of the backups.
+	// The backups will not be verified if it is not set.
+	//
+	// +optional
+	Verification *BackupVerificationPolicy `json:"verification,omitempty"`
 }
 
 type BackupTarget struct {
@@ -343,6 +358,73 @@ const (
 	BackupPolicyRetentionPolicyNone BackupPolicyRetentionPolicy = ""
 )
 
+// BackupVerificationPolicy defines how to verify the integrity of the backups.
+type BackupVerificationPolicy struct {
+	// Specifies whether to verify the checksums of the backup data in the backup repository for each completed backup.
+	// The checksums are recorded by the backup job when the backup data is uploaded, and are compared with
+	// by the verification once the backup is completed, which is reported as `Verified` or `Failed`.
+	//
+	// For the backups whose checksums are not recorded at upload time, the first verification records them,
+	// which is reported as `ChecksumRecorded`, and the next verification compares with them.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Checksum *bool `json:"checksum,omitempty"`
+
+	// Specifies the interval in hours to verify the checksums of the completed backups against the recorded ones
+	// periodically. The checksums are verified only once if it is not set.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ChecksumIntervalHours int32 `json:"checksumIntervalHours,omitempty"`
+
+	// Specifies the interval in hours to verify the latest completed backup of each backup method
+	// by restoring it in a throwaway pod, which runs the `restore.prepareData` and `verify` actions of the ActionSet.
+	//
+	// The restore verification is disabled if it is not set, or the ActionSet has no `verify` or `restore.prepareData` action.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RestoreIntervalHours int32 `json:"restoreIntervalHours,omitempty"`
+}
+
+// GFSRetentionPolicy defines the grandfather-father-son backup retention policy.
+//
+// For each period type, the latest completed backup of each period is retained,
+// for the most recent N periods that have completed backups.
+// The periods are calculated by the stop time of the backups in UTC, and the weeks start on Monday.
+type GFSRetentionPolicy struct {
+	// Specifies the number of hours to retain the latest backup for each.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Hourly int32 `json:"hourly,omitempty"`
+
+	// Specifies the number of days to retain the latest backup for each.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Daily int32 `json:"daily,omitempty"`
+
+	// Specifies the number of weeks to retain the latest backup for each.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weekly int32 `json:"weekly,omitempty"`
+
+	// Specifies the number of months to retain the latest backup for each.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Monthly int32 `json:"monthly,omitempty"`
+
+	// Specifies the number of years to retain the latest backup for each.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Yearly int32 `json:"yearly,omitempty"`
+}
+
 // +genclient
 // +k8s:openapi-gen=true
 // +kubebuilder:object:root=true
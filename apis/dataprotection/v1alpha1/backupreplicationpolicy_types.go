@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupReplicationPolicySpec defines the desired state of BackupReplicationPolicy.
+type BackupReplicationPolicySpec struct {
+	// Specifies the backupPolicy whose backups are replicated.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	BackupPolicyName string `json:"backupPolicyName"`
+
+	// Specifies the backup methods whose backups are replicated.
+	// If empty, the backups of all backup methods are replicated.
+	//
+	// +optional
+	BackupMethods []string `json:"backupMethods,omitempty"`
+
+	// Specifies the backup repositories the backups are replicated to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=backupRepoName
+	Targets []BackupReplicationTarget `json:"targets"`
+
+	// Specifies the interval in minutes to sync the continuous backups to the targets,
+	// and to retry the failed replications.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SyncIntervalMinutes int32 `json:"syncIntervalMinutes,omitempty"`
+}
+
+// BackupReplicationTarget describes a backup repository the backups are replicated to.
+type BackupReplicationTarget struct {
+	// Specifies the name of the backup repository.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// Determines the duration for which the replicas in the backup repository should be kept,
+	// starting from the time the replication is completed.
+	// If not set, the replicas are kept as long as the backup.
+	//
+	// The backup is kept until all its replicas expire, as the replicas are tracked
+	// in `backup.status.replicas`.
+	// Sample duration format:
+	//
+	// - years: 	2y
+	// - months: 	6mo
+	// - days: 		30d
+	// - hours: 	12h
+	// - minutes: 	30m
+	//
+	// You can also combine the above durations. For example: 30d12h30m
+	//
+	// +optional
+	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
+}
+
+// BackupReplicationPolicyStatus defines the observed state of BackupReplicationPolicy.
+type BackupReplicationPolicyStatus struct {
+	// Describes the phase of the BackupReplicationPolicy.
+	//
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Represents the most recent generation observed for this BackupReplicationPolicy.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents an error that caused the BackupReplicationPolicy to be unavailable.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Namespaced,shortName=brp
+// +kubebuilder:printcolumn:name="BACKUP-POLICY",type=string,JSONPath=`.spec.backupPolicyName`
+// +kubebuilder:printcolumn:name="STATUS",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BackupReplicationPolicy is the Schema for the backupreplicationpolicies API.
+// It replicates the completed backups of a backupPolicy, including the continuous backups,
+// from the backup repository where they are created to the target backup repositories.
+type BackupReplicationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupReplicationPolicySpec   `json:"spec,omitempty"`
+	Status BackupReplicationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupReplicationPolicyList contains a list of BackupReplicationPolicy.
+type BackupReplicationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupReplicationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupReplicationPolicy{}, &BackupReplicationPolicyList{})
+}
+
+// GetTarget gets the replication target of the backup repository.
+func (p *BackupReplicationPolicy) GetTarget(backupRepoName string) *BackupReplicationTarget {
+	for i := range p.Spec.Targets {
+		if p.Spec.Targets[i].BackupRepoName == backupRepoName {
+			return &p.Spec.Targets[i]
+		}
+	}
+	return nil
+}
@@ -76,6 +76,16 @@ type BackupRepoSpec struct {
 	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-_]+/?)*$`
 	// +optional
 	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Specifies the maximum size of the data stored in the backup repository.
+	// Once the usage of the backup repository reaches the quota, new backups
+	// targeting the repository fail without running.
+	//
+	// The usage is computed periodically, so the data stored may exceed the quota
+	// between two computations.
+	//
+	// +optional
+	Quota *resource.Quantity `json:"quota,omitempty"`
 }
 
 // BackupRepoStatus defines the observed state of `BackupRepo`.
@@ -121,6 +131,66 @@ type BackupRepoStatus struct {
 	//
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
+
+	// Records the storage usage of the backup repository, which is computed periodically.
+	//
+	// +optional
+	Usage *BackupRepoUsage `json:"usage,omitempty"`
+}
+
+// BackupRepoUsage describes the storage usage of the backup repository.
+type BackupRepoUsage struct {
+	// Specifies the total size of the data stored in the backup repository,
+	// including the data not referenced by any backup.
+	//
+	// +optional
+	TotalSize resource.Quantity `json:"totalSize,omitempty"`
+
+	// Specifies the number of the backups stored in the backup repository.
+	//
+	// +optional
+	BackupCount int32 `json:"backupCount,omitempty"`
+
+	// Records the usage of the backups grouped by the clusters.
+	//
+	// +optional
+	Clusters []BackupRepoUsageItem `json:"clusters,omitempty"`
+
+	// Records the usage of the backups grouped by the backup policies.
+	//
+	// +optional
+	BackupPolicies []BackupRepoUsageItem `json:"backupPolicies,omitempty"`
+
+	// Records the time when the usage is computed.
+	//
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// BackupRepoUsageItem describes the storage usage of a group of backups.
+type BackupRepoUsageItem struct {
+	// Specifies the namespace of the group.
+	//
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Specifies the name of the group, which is the name of the cluster or the backup policy.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the size of the data stored by the backups of the group.
+	// The deduplicated size is used for the backups stored in the Kopia repository,
+	// the size of the Kopia repository is shared by its backups in proportion to
+	// their total sizes.
+	//
+	// +optional
+	Size resource.Quantity `json:"size,omitempty"`
+
+	// Specifies the number of the backups of the group.
+	//
+	// +optional
+	BackupCount int32 `json:"backupCount,omitempty"`
 }
 
 // +genclient
@@ -132,6 +202,8 @@ type BackupRepoStatus struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="STORAGEPROVIDER",type="string",JSONPath=".spec.storageProviderRef"
 // +kubebuilder:printcolumn:name="ACCESSMETHOD",type="string",JSONPath=".spec.accessMethod"
+// +kubebuilder:printcolumn:name="USED",type="string",JSONPath=".status.usage.totalSize"
+// +kubebuilder:printcolumn:name="QUOTA",type="string",JSONPath=".spec.quota"
 // +kubebuilder:printcolumn:name="DEFAULT",type="boolean",JSONPath=`.status.isDefault`
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
@@ -164,3 +236,11 @@ func (repo *BackupRepo) AccessByMount() bool {
 func (repo *BackupRepo) AccessByTool() bool {
 	return repo.Spec.AccessMethod == AccessMethodTool
 }
+
+// QuotaExceeded checks whether the usage of the backup repository reaches its quota.
+func (repo *BackupRepo) QuotaExceeded() bool {
+	if repo.Spec.Quota == nil || repo.Status.Usage == nil {
+		return false
+	}
+	return repo.Status.Usage.TotalSize.Cmp(*repo.Spec.Quota) >= 0
+}
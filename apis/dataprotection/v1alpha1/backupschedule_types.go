@@ -36,6 +36,16 @@ type BackupScheduleSpec struct {
 	// +kubebuilder:validation:Maximum=1440
 	StartingDeadlineMinutes *int64 `json:"startingDeadlineMinutes,omitempty"`
 
+	// Specifies how the schedules are executed. Supported values:
+	//
+	// - `CronJob`: each schedule is materialized as a CronJob whose pod creates the backups.
+	// - `Controller`: the schedules are evaluated by the dataprotection controller,
+	//   which creates the backups directly.
+	//
+	// +kubebuilder:default=CronJob
+	// +optional
+	ScheduleMode ScheduleMode `json:"scheduleMode,omitempty"`
+
 	// Defines the list of backup schedules.
 	//
 	// +kubebuilder:validation:Required
@@ -60,12 +70,30 @@ type SchedulePolicy struct {
 	// +kubebuilder:validation:Required
 	BackupMethod string `json:"backupMethod"`
 
-	// Specifies the cron expression for the schedule. The timezone is in UTC.
+	// Specifies the cron expression for the schedule. The timezone is in UTC
+	// unless `timeZone` is specified.
 	// see https://en.wikipedia.org/wiki/Cron.
 	//
 	// +kubebuilder:validation:Required
 	CronExpression string `json:"cronExpression"`
 
+	// Specifies the time zone in which the cron expression is evaluated, e.g. `Asia/Shanghai`.
+	// The name must be in the IANA time zone database. Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Specifies how to treat concurrent backups created by this schedule.
+	// It only takes effect when `scheduleMode` is `Controller`. Supported values:
+	//
+	// - `Forbid`: the scheduled run is postponed until the previous backup finishes.
+	// - `Replace`: the running backup is deleted and replaced by a new one.
+	// - `Allow`: the backups are allowed to run concurrently.
+	//
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy ScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
 	// Determines the duration for which the backup should be kept.
 	// KubeBlocks will remove all backups that are older than the RetentionPeriod.
 	// For example, RetentionPeriod of `30d` will keep only the backups of last 30 days.
@@ -83,6 +111,16 @@ type SchedulePolicy struct {
 	// +kubebuilder:default="7d"
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 
+	// Specifies the grandfather-father-son (GFS) retention policy for the backups created by this schedule.
+	// It overrides `backupPolicy.spec.gfsRetention`.
+	//
+	// The expired backups are retained if they are selected by the GFS policy. For example,
+	// a RetentionPeriod of `7d` with `monthly: 12` keeps all the backups of the last 7 days,
+	// and one backup for each of the last 12 months.
+	//
+	// +optional
+	GFSRetention *GFSRetentionPolicy `json:"gfsRetention,omitempty"`
+
 	// Specifies a list of name-value pairs representing parameters and their corresponding values.
 	// Parameters match the schema specified in the `actionset.spec.parametersSchema`
 	//
@@ -95,6 +133,35 @@ type SchedulePolicy struct {
 	Parameters []ParameterPair `json:"parameters,omitempty"`
 }
 
+// ScheduleMode defines how the backup schedules are executed.
+// +enum
+// +kubebuilder:validation:Enum={CronJob,Controller}
+type ScheduleMode string
+
+const (
+	// ScheduleModeCronJob executes the schedules by CronJobs.
+	ScheduleModeCronJob ScheduleMode = "CronJob"
+
+	// ScheduleModeController executes the schedules in the dataprotection controller.
+	ScheduleModeController ScheduleMode = "Controller"
+)
+
+// ScheduleConcurrencyPolicy describes how the concurrent backups of a schedule are handled.
+// +enum
+// +kubebuilder:validation:Enum={Allow,Forbid,Replace}
+type ScheduleConcurrencyPolicy string
+
+const (
+	// ScheduleConcurrencyAllow allows the backups to run concurrently.
+	ScheduleConcurrencyAllow ScheduleConcurrencyPolicy = "Allow"
+
+	// ScheduleConcurrencyForbid postpones the scheduled run until the previous backup finishes.
+	ScheduleConcurrencyForbid ScheduleConcurrencyPolicy = "Forbid"
+
+	// ScheduleConcurrencyReplace deletes the running backup and creates a new one.
+	ScheduleConcurrencyReplace ScheduleConcurrencyPolicy = "Replace"
+)
+
 // BackupScheduleStatus defines the observed state of BackupSchedule.
 type BackupScheduleStatus struct {
 	// Describes the phase of the BackupSchedule.
@@ -152,6 +219,17 @@ type ScheduleStatus struct {
 	//
 	// +optional
 	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Records the next time the backup will be scheduled.
+	// It is only recorded when `scheduleMode` is `Controller`.
+	//
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Records the name of the last backup created by the schedule.
+	//
+	// +optional
+	LastBackupName string `json:"lastBackupName,omitempty"`
 }
 
 // SchedulePhase represents the phase of a schedule.
@@ -200,3 +278,8 @@ func (s *SchedulePolicy) GetScheduleName() string {
 	}
 	return s.BackupMethod
 }
+
+// IsControllerMode returns true if the schedules are executed by the controller.
+func (r *BackupSchedule) IsControllerMode() bool {
+	return r.Spec.ScheduleMode == ScheduleModeController
+}
@@ -111,6 +111,13 @@ type BackupRef struct {
 
 	// Specifies the source target for restoration, identified by its name.
 	SourceTargetName string `json:"sourceTargetName,omitempty"`
+
+	// Specifies the backup repository to restore the backup from. It must hold a replica of the backup
+	// that is replicated by a BackupReplicationPolicy and recorded in `backup.status.replicas`.
+	// If not set, the backup is restored from the backup repository where it was created.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
 }
 
 type RestoreKubeResources struct {
@@ -288,6 +288,11 @@ func (in *BackupActionSpec) DeepCopyInto(out *BackupActionSpec) {
 		*out = new(BaseJobActionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(BaseJobActionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.WithParameters != nil {
 		in, out := &in.WithParameters, &out.WithParameters
 		*out = make([]string, len(*in))
@@ -545,6 +550,16 @@ func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GFSRetention != nil {
+		in, out := &in.GFSRetention, &out.GFSRetention
+		*out = new(GFSRetentionPolicy)
+		**out = **in
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicySpec.
@@ -701,6 +716,152 @@ func (in *BackupRef) DeepCopy() *BackupRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicaStatus) DeepCopyInto(out *BackupReplicaStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = (*in).DeepCopy()
+	}
+	if in.TimeRange != nil {
+		in, out := &in.TimeRange, &out.TimeRange
+		*out = new(BackupTimeRange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicaStatus.
+func (in *BackupReplicaStatus) DeepCopy() *BackupReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationPolicy) DeepCopyInto(out *BackupReplicationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationPolicy.
+func (in *BackupReplicationPolicy) DeepCopy() *BackupReplicationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupReplicationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationPolicyList) DeepCopyInto(out *BackupReplicationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupReplicationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationPolicyList.
+func (in *BackupReplicationPolicyList) DeepCopy() *BackupReplicationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupReplicationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationPolicySpec) DeepCopyInto(out *BackupReplicationPolicySpec) {
+	*out = *in
+	if in.BackupMethods != nil {
+		in, out := &in.BackupMethods, &out.BackupMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BackupReplicationTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationPolicySpec.
+func (in *BackupReplicationPolicySpec) DeepCopy() *BackupReplicationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationPolicyStatus) DeepCopyInto(out *BackupReplicationPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationPolicyStatus.
+func (in *BackupReplicationPolicyStatus) DeepCopy() *BackupReplicationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReplicationTarget) DeepCopyInto(out *BackupReplicationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReplicationTarget.
+func (in *BackupReplicationTarget) DeepCopy() *BackupReplicationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReplicationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupRepo) DeepCopyInto(out *BackupRepo) {
 	*out = *in
@@ -776,6 +937,11 @@ func (in *BackupRepoSpec) DeepCopyInto(out *BackupRepoSpec) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoSpec.
@@ -803,6 +969,11 @@ func (in *BackupRepoStatus) DeepCopyInto(out *BackupRepoStatus) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(BackupRepoUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoStatus.
@@ -815,6 +986,56 @@ func (in *BackupRepoStatus) DeepCopy() *BackupRepoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepoUsage) DeepCopyInto(out *BackupRepoUsage) {
+	*out = *in
+	out.TotalSize = in.TotalSize.DeepCopy()
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]BackupRepoUsageItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackupPolicies != nil {
+		in, out := &in.BackupPolicies, &out.BackupPolicies
+		*out = make([]BackupRepoUsageItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoUsage.
+func (in *BackupRepoUsage) DeepCopy() *BackupRepoUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepoUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRepoUsageItem) DeepCopyInto(out *BackupRepoUsageItem) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoUsageItem.
+func (in *BackupRepoUsageItem) DeepCopy() *BackupRepoUsageItem {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRepoUsageItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
 	*out = *in
@@ -1015,6 +1236,22 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			}
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]BackupReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrimaryDeletionTimestamp != nil {
+		in, out := &in.PrimaryDeletionTimestamp, &out.PrimaryDeletionTimestamp
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -1106,6 +1343,55 @@ func (in *BackupTimeRange) DeepCopy() *BackupTimeRange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationPolicy) DeepCopyInto(out *BackupVerificationPolicy) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationPolicy.
+func (in *BackupVerificationPolicy) DeepCopy() *BackupVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(VerificationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(VerificationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChecksumRecordTimestamp != nil {
+		in, out := &in.ChecksumRecordTimestamp, &out.ChecksumRecordTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseJobActionSpec) DeepCopyInto(out *BaseJobActionSpec) {
 	*out = *in
@@ -1254,6 +1540,21 @@ func (in *ExecActionTarget) DeepCopy() *ExecActionTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GFSRetentionPolicy) DeepCopyInto(out *GFSRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GFSRetentionPolicy.
+func (in *GFSRetentionPolicy) DeepCopy() *GFSRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GFSRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IncludeResource) DeepCopyInto(out *IncludeResource) {
 	*out = *in
@@ -1871,6 +2172,11 @@ func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.GFSRetention != nil {
+		in, out := &in.GFSRetention, &out.GFSRetention
+		*out = new(GFSRetentionPolicy)
+		**out = **in
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make([]ParameterPair, len(*in))
@@ -1899,6 +2205,10 @@ func (in *ScheduleStatus) DeepCopyInto(out *ScheduleStatus) {
 		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
 		*out = (*in).DeepCopy()
 	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleStatus.
@@ -2162,6 +2472,29 @@ func (in *ValueFrom) DeepCopy() *ValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationResult) DeepCopyInto(out *VerificationResult) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationResult.
+func (in *VerificationResult) DeepCopy() *VerificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionMapping) DeepCopyInto(out *VersionMapping) {
 	*out = *in
@@ -47,6 +47,7 @@ const (
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeSwitchAccessMode   = "SwitchingAccessMode"
 
 	// condition and event reasons
 	ReasonClusterPhaseMismatch  = "ClusterPhaseMismatch"
@@ -208,6 +209,17 @@ func NewSwitchoveringCondition(generation int64, message string) *metav1.Conditi
 	}
 }
 
+// NewSwitchAccessModeCondition creates a condition that the OpsRequest starts to switch the access mode of replicas.
+func NewSwitchAccessModeCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeSwitchAccessMode,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SwitchAccessModeStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to switch the access mode of replicas in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewVerticalScalingCondition creates a condition that the OpsRequest starts to vertical scale cluster
 func NewVerticalScalingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
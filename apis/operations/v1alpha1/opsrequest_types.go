@@ -24,6 +24,7 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 )
 
 // OpsRequestSpec defines the desired state of OpsRequest
@@ -226,6 +227,17 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists SwitchAccessMode objects, each specifying a Component and the access mode its replicas switch to.
+	// The readonly and readwrite lifecycle actions of the Component are used to freeze and thaw the writes.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.switchAccessMode"
+	SwitchAccessModeList []SwitchAccessMode `json:"switchAccessMode,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
@@ -296,6 +308,25 @@ type Instance struct {
 	TargetNodeName string `json:"targetNodeName,omitempty"`
 }
 
+type SwitchAccessMode struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the access mode that the replicas switch to.
+	//
+	// - ReadOnly: freezes the writes to the replicas by calling the readonly lifecycle action.
+	// - ReadWrite: thaws the writes to the replicas by calling the readwrite lifecycle action.
+	//
+	// +kubebuilder:validation:Required
+	Mode AccessMode `json:"mode"`
+
+	// Specifies the names of the instances (Pods) to switch.
+	// If not specified, all instances of the Component will be switched.
+	//
+	// +optional
+	Instances []string `json:"instances,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="(has(self.componentName) && !has(self.componentObjectName)) || (!has(self.componentName) && has(self.componentObjectName))",message="need to specified only componentName or componentObjectName"
 
 type Switchover struct {
@@ -527,6 +558,27 @@ type Reconfigure struct {
 	//
 	// +optional
 	Parameters []ParameterPair `json:"parameters,omitempty"`
+
+	// Specifies the configuration revision of the Component to revert to.
+	//
+	// The parameters of each configuration template are restored to the values recorded in that revision,
+	// which can be found in the `status.configurationStatus[*].reconcileDetail.currentRevision` of the ComponentParameter.
+	// It is mutually exclusive with `parameters`.
+	//
+	// +optional
+	RevertToRevision *int64 `json:"revertToRevision,omitempty"`
+
+	// Specifies the policy to roll back the parameter changes automatically
+	// if the Component becomes unavailable after the reconfiguration.
+	//
+	// +optional
+	RollbackPolicy *parametersv1alpha1.RollbackPolicy `json:"rollbackPolicy,omitempty"`
+
+	// Specifies the policy to apply the parameters to the canary instances first,
+	// and to roll back the parameters automatically if the canary instances become unhealthy.
+	//
+	// +optional
+	CanaryPolicy *parametersv1alpha1.CanaryPolicy `json:"canaryPolicy,omitempty"`
 }
 
 type CustomOps struct {
@@ -145,6 +145,8 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case SwitchAccessModeType:
+		return r.validateSwitchAccessMode(cluster)
 	}
 	return nil
 }
@@ -191,6 +193,23 @@ func (r *OpsRequest) validateRebuildInstance(cluster *appsv1.Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+// validateSwitchAccessMode validates spec.switchAccessMode when spec.type is SwitchAccessMode.
+// the existence of the instances and lifecycle actions will be checked in handler's Action() function.
+func (r *OpsRequest) validateSwitchAccessMode(cluster *appsv1.Cluster) error {
+	switchAccessModeList := r.Spec.SwitchAccessModeList
+	if len(switchAccessModeList) == 0 {
+		return notEmptyError("spec.switchAccessMode")
+	}
+	var compOpsList []ComponentOps
+	for _, v := range switchAccessModeList {
+		if v.Mode != ReadOnlyAccessMode && v.Mode != ReadWriteAccessMode {
+			return fmt.Errorf("invalid access mode %s for component %s", v.Mode, v.ComponentName)
+		}
+		compOpsList = append(compOpsList, v.ComponentOps)
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *appsv1.Cluster) error {
 	restartList := r.Spec.RestartList
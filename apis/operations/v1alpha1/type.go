@@ -72,7 +72,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,SwitchAccessMode,Custom}
 type OpsType string
 
 const (
@@ -88,8 +88,22 @@ const (
 	ExposeType            OpsType = "Expose"
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
-	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
-	CustomType            OpsType = "Custom"          // use opsDefinition
+	RebuildInstanceType   OpsType = "RebuildInstance"  // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
+	SwitchAccessModeType  OpsType = "SwitchAccessMode" // SwitchAccessModeType switches the replicas between the read-only and read-write states.
+	CustomType            OpsType = "Custom"           // use opsDefinition
+)
+
+// AccessMode defines the access mode of the replicas.
+// +enum
+// +kubebuilder:validation:Enum={ReadOnly,ReadWrite}
+type AccessMode string
+
+const (
+	// ReadOnlyAccessMode freezes the writes to the replicas, by calling the readonly lifecycle action.
+	ReadOnlyAccessMode AccessMode = "ReadOnly"
+
+	// ReadWriteAccessMode thaws the writes to the replicas, by calling the readwrite lifecycle action.
+	ReadWriteAccessMode AccessMode = "ReadWrite"
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
@@ -26,6 +26,7 @@ package v1alpha1
 import (
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	dataprotectionv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -1183,6 +1184,21 @@ func (in *Reconfigure) DeepCopyInto(out *Reconfigure) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RevertToRevision != nil {
+		in, out := &in.RevertToRevision, &out.RevertToRevision
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(parametersv1alpha1.RollbackPolicy)
+		**out = **in
+	}
+	if in.CanaryPolicy != nil {
+		in, out := &in.CanaryPolicy, &out.CanaryPolicy
+		*out = new(parametersv1alpha1.CanaryPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reconfigure.
@@ -1406,6 +1422,13 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SwitchAccessModeList != nil {
+		in, out := &in.SwitchAccessModeList, &out.SwitchAccessModeList
+		*out = make([]SwitchAccessMode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CustomOps != nil {
 		in, out := &in.CustomOps, &out.CustomOps
 		*out = new(CustomOps)
@@ -1423,6 +1446,27 @@ func (in *SpecificOpsRequest) DeepCopy() *SpecificOpsRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchAccessMode) DeepCopyInto(out *SwitchAccessMode) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchAccessMode.
+func (in *SwitchAccessMode) DeepCopy() *SwitchAccessMode {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchAccessMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Switchover) DeepCopyInto(out *Switchover) {
 	*out = *in
@@ -111,6 +111,13 @@ type ConfigTemplateItemDetail struct {
 	//
 	// +optional
 	ConfigFileParams map[string]ParametersInFile `json:"configFileParams,omitempty"`
+
+	// Specifies the policy to apply the changes of the configuration to the canary instances first.
+	//
+	// It is set by the Parameter that updates the configuration.
+	//
+	// +optional
+	CanaryPolicy *CanaryPolicy `json:"canaryPolicy,omitempty"`
 }
 
 // ComponentParameterSpec defines the desired state of ComponentConfiguration
@@ -182,6 +189,59 @@ type ReconcileDetail struct {
 	//
 	// +optional
 	ErrMessage string `json:"errMessage,omitempty"`
+
+	// Represents the result of applying each updated parameter by the SQL or HTTP reload action.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ParameterStatuses []ParameterReloadStatus `json:"parameterStatuses,omitempty"`
+
+	// Represents the status of the canary reconfiguration, if a canary policy is specified.
+	//
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+}
+
+// CanaryStatus represents the status of a canary reconfiguration.
+type CanaryStatus struct {
+	// Indicates the current stage of the canary reconfiguration.
+	//
+	// +optional
+	Stage CanaryStage `json:"stage,omitempty"`
+
+	// Lists the names of the canary instances.
+	//
+	// +optional
+	Instances []string `json:"instances,omitempty"`
+
+	// Represents the time when the bake time of the canary instances started.
+	//
+	// +optional
+	BakeStartTime *metav1.Time `json:"bakeStartTime,omitempty"`
+
+	// Provides a description of the stage, such as the reason of a failed canary.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ParameterReloadStatus represents the result of applying a parameter dynamically.
+type ParameterReloadStatus struct {
+	// Specifies the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Indicates whether the parameter has been applied successfully.
+	//
+	// +kubebuilder:validation:Required
+	Phase ParameterReloadPhase `json:"phase"`
+
+	// Provides the error message if the parameter fails to be applied.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type ConfigTemplateItemDetailStatus struct {
@@ -225,6 +285,56 @@ type ConfigTemplateItemDetailStatus struct {
 	//
 	// +optional
 	ReconcileDetail *ReconcileDetail `json:"reconcileDetail,omitempty"`
+
+	// Lists the instances whose live parameters drift from the rendered configuration.
+	//
+	// +listType=map
+	// +listMapKey=podName
+	// +optional
+	DriftedInstances []InstanceParametersDrift `json:"driftedInstances,omitempty"`
+}
+
+// InstanceParametersDrift represents the drifted parameters of an instance.
+type InstanceParametersDrift struct {
+	// Specifies the name of the pod.
+	//
+	// +kubebuilder:validation:Required
+	PodName string `json:"podName"`
+
+	// Lists the drifted parameters.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Parameters []ParameterDrift `json:"parameters,omitempty"`
+
+	// Represents the time when the drift was detected.
+	//
+	// +optional
+	DetectedTime *metav1.Time `json:"detectedTime,omitempty"`
+}
+
+// ParameterDrift represents a parameter whose live value differs from the rendered value.
+type ParameterDrift struct {
+	// Specifies the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the name of the configuration file the parameter belongs to.
+	//
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+
+	// The value rendered in the configuration file.
+	//
+	// +optional
+	Expected string `json:"expected,omitempty"`
+
+	// The live value queried from the instance.
+	//
+	// +optional
+	Actual string `json:"actual,omitempty"`
 }
 
 // ComponentParameterStatus defines the observed state of ComponentConfiguration
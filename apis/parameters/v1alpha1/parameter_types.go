@@ -87,6 +87,12 @@ type ParameterStatus struct {
 	// Records the status of a reconfiguring operation if `opsRequest.spec.type` equals to "Reconfiguring".
 	// +optional
 	ReconfiguringStatus []ComponentReconfiguringStatus `json:"componentReconfiguringStatus"`
+
+	// Records the time when all the parameters have been applied.
+	// It is the start of the rollback window if a rollback policy is specified.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type ComponentParametersSpec struct {
@@ -108,6 +114,71 @@ type ComponentParametersSpec struct {
 	//
 	// +optional
 	CustomTemplates map[string]ConfigTemplateExtension `json:"userConfigTemplates,omitempty"`
+
+	// Specifies the configuration revision of the Component to revert to.
+	//
+	// The parameters of each configuration template are restored to the values recorded in that revision.
+	// It is mutually exclusive with `parameters` and `userConfigTemplates`.
+	//
+	// +optional
+	RevertToRevision *int64 `json:"revertToRevision,omitempty"`
+
+	// Specifies the policy to roll back the parameter changes automatically
+	// if the Component becomes unavailable after the reconfiguration.
+	//
+	// +optional
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+
+	// Specifies the policy to apply the parameters to the canary instances first.
+	//
+	// The parameters are applied to the canary instances, and then to the rest instances if the canary instances
+	// stay healthy during the bake time, otherwise the parameters are rolled back to the previous revision.
+	//
+	// +optional
+	CanaryPolicy *CanaryPolicy `json:"canaryPolicy,omitempty"`
+}
+
+// RollbackPolicy defines when the parameter changes are rolled back automatically.
+type RollbackPolicy struct {
+	// Specifies the period after the parameters have been applied, during which the parameters are rolled back
+	// to the previous revision if the Component fails its availability check.
+	//
+	// +kubebuilder:validation:Required
+	Window metav1.Duration `json:"window"`
+}
+
+// CanaryPolicy defines how to apply the parameters to the canary instances first.
+//
+// The canary takes effect only if the parameters are applied instance by instance, that is, by the sync dynamic reload,
+// rolling or restartContainer policies. Otherwise, the parameters are applied to all instances at once.
+type CanaryPolicy struct {
+	// Specifies the role of the canary instances, such as "secondary".
+	// If not specified, the instances are selected in the reverse order of the rolling update,
+	// and the instances of the role with the highest update priority (e.g. the leader) are never selected.
+	// If there is no other instance, the canary is skipped and the parameters are applied to all instances.
+	//
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Specifies the number of canary instances.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Specifies the time to observe the canary instances after the parameters have been applied to them.
+	//
+	// During the bake time, the canary instances must stay ready and their containers must not restart.
+	//
+	// +kubebuilder:validation:Required
+	BakeTime metav1.Duration `json:"bakeTime"`
+
+	// Specifies whether to verify that the live values of the updated parameters on the canary instances
+	// equal the expected values, by the `queryParameters` action of the ParametersDefinition.
+	//
+	// +optional
+	VerifyParameters bool `json:"verifyParameters,omitempty"`
 }
 
 type ComponentReconfiguringStatus struct {
@@ -130,6 +201,11 @@ type ComponentReconfiguringStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	ParameterStatus []ReconfiguringStatus `json:"parameterStatus,omitempty"`
+
+	// Records the revision that the parameters of the Component have been rolled back to.
+	//
+	// +optional
+	RolledBackRevision string `json:"rolledBackRevision,omitempty"`
 }
 
 type ReconfiguringStatus struct {
@@ -20,6 +20,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 )
 
 // +genclient
@@ -68,6 +71,28 @@ type ParametersDefinitionSpec struct {
 	// +optional
 	ParametersSchema *ParametersSchema `json:"parametersSchema,omitempty"`
 
+	// Defines the validation rules that span multiple parameters or depend on the resources of the component,
+	// which can't be expressed by the `parametersSchema`.
+	//
+	// Each rule is a CEL expression evaluated against the merged parameters of the config file,
+	// and the update of parameters is rejected if any rule evaluates to false.
+	// For example, the following rules limit the `innodb_buffer_pool_size` to 75% of the memory limit,
+	// and ensure that the `min_wal_size` is not greater than the `max_wal_size`:
+	//
+	// ```yaml
+	// validationRules:
+	// - name: buffer-pool-size
+	//   expression: "quantity(parameters.innodb_buffer_pool_size) <= resources.limits.memory * 3 / 4"
+	//   message: "innodb_buffer_pool_size must be at most 75% of the memory limit"
+	// - name: wal-size
+	//   expression: "!has(parameters.min_wal_size) || !has(parameters.max_wal_size) || quantity(parameters.min_wal_size) <= quantity(parameters.max_wal_size)"
+	// ```
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ValidationRules []ParametersValidationRule `json:"validationRules,omitempty"`
+
 	// Specifies the dynamic reload (dynamic reconfiguration) actions supported by the engine.
 	// When set, the controller executes the scripts defined in these actions to handle dynamic parameter updates.
 	//
@@ -93,6 +118,28 @@ type ParametersDefinitionSpec struct {
 	// +optional
 	ReloadAction *ReloadAction `json:"reloadAction,omitempty"`
 
+	// Specifies the action to query the live values of the parameters from the running instances,
+	// which is executed by kbagent.
+	//
+	// The action is expected to output a JSON object that maps the parameter names to their values, for example:
+	// `{"max_connections": "1000", "innodb_buffer_pool_size": "134217728"}`.
+	// The values are compared with the rendered configuration file as strings,
+	// so the action should output them in the same format as the configuration file.
+	// Only the parameters present in both the output and the configuration file are compared.
+	//
+	// Note: The action is delivered to kbagent through the environment variables of the kbagent container,
+	// so adding, changing or removing this field changes the pod template of the Components that reference
+	// this ParametersDefinition, and results in a rolling restart of all their pods.
+	//
+	// +optional
+	QueryParameters *appsv1.Action `json:"queryParameters,omitempty"`
+
+	// Specifies how to detect and handle the drift between the rendered configuration and the running instances.
+	// It takes effect only if `queryParameters` is defined.
+	//
+	// +optional
+	DriftDetectionPolicy *DriftDetectionPolicy `json:"driftDetectionPolicy,omitempty"`
+
 	// TODO: migrate DownwardAPITriggeredActions to ComponentDefinition.spec.lifecycleActions
 	// Specifies a list of actions to execute specified commands based on Pod labels.
 	//
@@ -160,6 +207,56 @@ type ParametersDefinitionSpec struct {
 	ImmutableParameters []string `json:"immutableParameters,omitempty"`
 }
 
+// ParametersValidationRule defines a CEL rule to validate the parameters.
+type ParametersValidationRule struct {
+	// Specifies the name of the rule, which is used to identify the rule in the validation errors.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the CEL expression of the rule, which must evaluate to a boolean.
+	//
+	// The following variables are available in the expression:
+	//
+	// - `parameters`: a map of the merged parameters of the config file, the values are strings.
+	//   Use `has(parameters.name)` to check whether a parameter is set.
+	// - `resources`: the resources of the component, with the `limits` and `requests` maps,
+	//   the values of `cpu` are in millicores and the values of `memory` are in bytes, e.g. `resources.limits.memory`.
+	//   The missing resources are absent from the maps.
+	// - `replicas`: the number of replicas of the component.
+	//
+	// The `quantity(string)` function converts a value with a size unit (e.g. "128M", "1GB", "512Mi") to an integer,
+	// the units of databases such as K/M/G and KB/MB/GB are treated as powers of 1024.
+	//
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+
+	// Specifies the message returned when the rule is violated.
+	// Defaults to a message that contains the expression.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DriftDetectionPolicy defines how to detect and handle the configuration drift.
+type DriftDetectionPolicy struct {
+	// Specifies the interval to query the live parameters from the running instances.
+	// Defaults to 5 minutes.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Specifies how to handle the drifted parameters.
+	//
+	// - Alert: reports the drift through the conditions and events of the ComponentParameter.
+	// - Reapply: reports the drift and re-applies the rendered values of the drifted dynamic parameters to the instances.
+	//   The drifted static parameters are reported only, as applying them requires a restart.
+	//
+	// +kubebuilder:default=Alert
+	// +optional
+	Remediation DriftRemediation `json:"remediation,omitempty"`
+}
+
 type ParameterDeletedPolicy struct {
 
 	// Specifies the method to handle the deletion of a parameter.
@@ -225,6 +322,18 @@ type ReloadAction struct {
 	// +optional
 	AutoTrigger *AutoTrigger `json:"autoTrigger,omitempty"`
 
+	// Used to apply the updated parameters by executing SQL statements against the database.
+	// The reload is performed by the controller, and no config-manager sidecar is required.
+	//
+	// +optional
+	SQLTrigger *SQLTrigger `json:"sqlTrigger,omitempty"`
+
+	// Used to apply the updated parameters by calling an HTTP admin endpoint of the process.
+	// The reload is performed by the controller, and no config-manager sidecar is required.
+	//
+	// +optional
+	HTTPTrigger *HTTPTrigger `json:"httpTrigger,omitempty"`
+
 	// Used to match labels on the pod to determine whether a dynamic reload should be performed.
 	//
 	// In some scenarios, only specific pods (e.g., primary replicas) need to undergo a dynamic reload.
@@ -510,6 +619,134 @@ type AutoTrigger struct {
 	ProcessName string `json:"processName,omitempty"`
 }
 
+// SQLTrigger applies the updated parameters by executing SQL statements against the database.
+//
+// The statement is rendered once for each updated parameter and executed over a connection
+// to the target pod, using the credentials of the specified system account of the component.
+type SQLTrigger struct {
+	// Specifies the database engine, which determines the driver used to connect to the database.
+	//
+	// +kubebuilder:validation:Required
+	Engine SQLEngine `json:"engine"`
+
+	// Specifies the port of the database, either a container port name or a port number.
+	//
+	// +kubebuilder:validation:Required
+	Port intstr.IntOrString `json:"port"`
+
+	// Specifies the name of the system account used to connect to the database.
+	// The credentials are read from the account secret generated for the component.
+	//
+	// +kubebuilder:validation:Required
+	AccountName string `json:"accountName"`
+
+	// Specifies the database to connect to.
+	//
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Specifies whether the connection to the database is encrypted by SSL.
+	//
+	// - disable: the connection is not encrypted.
+	// - require: the connection is encrypted, and it fails if the database does not support SSL.
+	//   The certificate of the database is not verified.
+	//
+	// +kubebuilder:default=require
+	// +optional
+	SSLMode SQLSSLMode `json:"sslMode,omitempty"`
+
+	// Specifies a Go template of the SQL statement used to apply an updated parameter.
+	// The name and the value of the parameter are accessed via '.Name' and '.Value',
+	// which can be quoted as an identifier and a string literal by the 'sqlIdent' and 'sqlQuote' functions.
+	// The rendered statement is rejected if it is not exactly one statement, or contains comments.
+	//
+	// Example templates:
+	//
+	// ```yaml
+	// statement: SET GLOBAL {{ .Name }} = {{ .Value }}
+	// statement: ALTER SYSTEM SET {{ .Name | sqlIdent }} = {{ .Value | sqlQuote }}
+	// ```
+	//
+	// +kubebuilder:validation:Required
+	Statement string `json:"statement"`
+
+	// Specifies the SQL statement executed once after the updated parameters are applied,
+	// e.g. `SELECT pg_reload_conf()` after `ALTER SYSTEM` statements.
+	//
+	// +optional
+	ReloadStatement string `json:"reloadStatement,omitempty"`
+}
+
+// HTTPTrigger applies the updated parameters by calling an HTTP admin endpoint of the process.
+//
+// The request is sent once for each updated parameter, and any 2xx response code indicates success.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.accountName) || size(self.accountName) == 0 || (has(self.scheme) && self.scheme == 'HTTPS')",message="the basic authentication requires the HTTPS scheme"
+// +kubebuilder:validation:XValidation:rule="!has(self.accountName) || size(self.accountName) == 0 || has(self.caSecretKeyRef)",message="the basic authentication requires the CA to verify the endpoint"
+type HTTPTrigger struct {
+	// Specifies the port of the endpoint, either a container port name or a port number.
+	//
+	// +kubebuilder:validation:Required
+	Port intstr.IntOrString `json:"port"`
+
+	// Specifies the scheme used to connect to the endpoint.
+	// If HTTPS is used, the certificate of the endpoint is verified against the CA specified by `caSecretKeyRef`,
+	// or not verified if no CA is specified.
+	//
+	// +kubebuilder:validation:Enum={HTTP,HTTPS}
+	// +kubebuilder:default=HTTP
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// Specifies the HTTP method of the request.
+	//
+	// +kubebuilder:validation:Enum={GET,POST,PUT,PATCH}
+	// +kubebuilder:default=POST
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Specifies a Go template of the request path.
+	// The name and the value of the parameter are accessed via '.Name' and '.Value',
+	// which are escaped as path segments, e.g. `/config/{{ .Name }}`.
+	//
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Specifies a Go template of the request body.
+	// The name and the value of the parameter are accessed via '.Name' and '.Value', which are not escaped.
+	// The sprig functions are available in the template, use 'toJson' to encode them as JSON strings,
+	// or 'quote' to quote them in other formats.
+	//
+	// Example templates:
+	//
+	// ```yaml
+	// body: '{ {{ .Name | toJson }}: {{ .Value | toJson }} }'
+	// body: 'name={{ .Name | quote }}'
+	// ```
+	//
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Specifies the custom headers to be set in the request.
+	//
+	// +optional
+	Headers []corev1.HTTPHeader `json:"headers,omitempty"`
+
+	// Specifies the name of the system account used for basic authentication.
+	// The credentials are read from the account secret generated for the component,
+	// and are only sent over HTTPS to the endpoint verified by the CA specified by `caSecretKeyRef`.
+	//
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// Specifies the key of a secret which holds the PEM-encoded CA certificates used to verify the certificate of the endpoint.
+	// The secret is looked up in the namespace of the component, and it takes effect only if the HTTPS scheme is used.
+	// The host name of the certificate is not verified, since the endpoint is addressed by the pod IP.
+	//
+	// +optional
+	CASecretKeyRef *corev1.SecretKeySelector `json:"caSecretKeyRef,omitempty"`
+}
+
 // FileFormatConfig specifies the format of the configuration file and any associated parameters
 // that are specific to the chosen format.
 type FileFormatConfig struct {
@@ -58,6 +58,70 @@ const (
 	AutoType       DynamicReloadType = "auto"
 )
 
+// SQLEngine defines the database engine used by SQLTrigger.
+// +enum
+// +kubebuilder:validation:Enum={mysql,postgresql}
+type SQLEngine string
+
+const (
+	MySQLEngine      SQLEngine = "mysql"
+	PostgreSQLEngine SQLEngine = "postgresql"
+)
+
+// SQLSSLMode defines whether the connection of SQLTrigger is encrypted by SSL.
+// +enum
+// +kubebuilder:validation:Enum={disable,require}
+type SQLSSLMode string
+
+const (
+	SQLSSLModeDisable SQLSSLMode = "disable"
+	SQLSSLModeRequire SQLSSLMode = "require"
+)
+
+// ParameterReloadPhase defines the result of applying a parameter dynamically.
+// +enum
+// +kubebuilder:validation:Enum={Succeed,Failed}
+type ParameterReloadPhase string
+
+const (
+	ParameterReloadSucceed ParameterReloadPhase = "Succeed"
+	ParameterReloadFailed  ParameterReloadPhase = "Failed"
+)
+
+// DriftRemediation defines how to handle the drift between the rendered configuration and the running instances.
+// +enum
+// +kubebuilder:validation:Enum={Alert,Reapply}
+type DriftRemediation string
+
+const (
+	AlertDriftRemediation   DriftRemediation = "Alert"
+	ReapplyDriftRemediation DriftRemediation = "Reapply"
+)
+
+// CanaryStage defines the stage of a canary reconfiguration.
+// +enum
+// +kubebuilder:validation:Enum={Applying,Baking,Promoted,Skipped,Failed}
+type CanaryStage string
+
+const (
+	// CanaryApplyingStage indicates the parameters are being applied to the canary instances.
+	CanaryApplyingStage CanaryStage = "Applying"
+	// CanaryBakingStage indicates the parameters have been applied to the canary instances,
+	// and the canary instances are being checked during the bake time.
+	CanaryBakingStage CanaryStage = "Baking"
+	// CanaryPromotedStage indicates the canary instances passed the checks, and the parameters are being applied to the rest instances.
+	CanaryPromotedStage CanaryStage = "Promoted"
+	// CanarySkippedStage indicates the parameters can't be applied instance by instance, and are applied to all instances.
+	CanarySkippedStage CanaryStage = "Skipped"
+	// CanaryFailedStage indicates the canary instances failed the checks, and the parameters are rolled back.
+	CanaryFailedStage CanaryStage = "Failed"
+)
+
+const (
+	// ConfigurationDriftedCondition indicates whether the live parameters of any instance drift from the rendered configuration.
+	ConfigurationDriftedCondition = "ConfigurationDrifted"
+)
+
 // SignalType defines which signals are valid.
 // +enum
 // +kubebuilder:validation:Enum={SIGHUP,SIGINT,SIGQUIT,SIGILL,SIGTRAP,SIGABRT,SIGBUS,SIGFPE,SIGKILL,SIGUSR1,SIGSEGV,SIGUSR2,SIGPIPE,SIGALRM,SIGTERM,SIGSTKFLT,SIGCHLD,SIGCONT,SIGSTOP,SIGTSTP,SIGTTIN,SIGTTOU,SIGURG,SIGXCPU,SIGXFSZ,SIGVTALRM,SIGPROF,SIGWINCH,SIGIO,SIGPWR,SIGSYS}
@@ -46,6 +46,46 @@ func (in *AutoTrigger) DeepCopy() *AutoTrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryPolicy) DeepCopyInto(out *CanaryPolicy) {
+	*out = *in
+	out.BakeTime = in.BakeTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryPolicy.
+func (in *CanaryPolicy) DeepCopy() *CanaryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BakeStartTime != nil {
+		in, out := &in.BakeStartTime, &out.BakeStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfigDescription) DeepCopyInto(out *ComponentConfigDescription) {
 	*out = *in
@@ -237,6 +277,21 @@ func (in *ComponentParametersSpec) DeepCopyInto(out *ComponentParametersSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RevertToRevision != nil {
+		in, out := &in.RevertToRevision, &out.RevertToRevision
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		**out = **in
+	}
+	if in.CanaryPolicy != nil {
+		in, out := &in.CanaryPolicy, &out.CanaryPolicy
+		*out = new(CanaryPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParametersSpec.
@@ -322,6 +377,11 @@ func (in *ConfigTemplateItemDetail) DeepCopyInto(out *ConfigTemplateItemDetail)
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.CanaryPolicy != nil {
+		in, out := &in.CanaryPolicy, &out.CanaryPolicy
+		*out = new(CanaryPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigTemplateItemDetail.
@@ -345,7 +405,14 @@ func (in *ConfigTemplateItemDetailStatus) DeepCopyInto(out *ConfigTemplateItemDe
 	if in.ReconcileDetail != nil {
 		in, out := &in.ReconcileDetail, &out.ReconcileDetail
 		*out = new(ReconcileDetail)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftedInstances != nil {
+		in, out := &in.DriftedInstances, &out.DriftedInstances
+		*out = make([]InstanceParametersDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -391,6 +458,26 @@ func (in *DownwardAPIChangeTriggeredAction) DeepCopy() *DownwardAPIChangeTrigger
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionPolicy) DeepCopyInto(out *DriftDetectionPolicy) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionPolicy.
+func (in *DriftDetectionPolicy) DeepCopy() *DriftDetectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileFormatConfig) DeepCopyInto(out *FileFormatConfig) {
 	*out = *in
@@ -427,6 +514,32 @@ func (in *FormatterAction) DeepCopy() *FormatterAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
+	*out = *in
+	out.Port = in.Port
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]corev1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.CASecretKeyRef != nil {
+		in, out := &in.CASecretKeyRef, &out.CASecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTrigger.
+func (in *HTTPTrigger) DeepCopy() *HTTPTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IniConfig) DeepCopyInto(out *IniConfig) {
 	*out = *in
@@ -442,6 +555,30 @@ func (in *IniConfig) DeepCopy() *IniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceParametersDrift) DeepCopyInto(out *InstanceParametersDrift) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ParameterDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.DetectedTime != nil {
+		in, out := &in.DetectedTime, &out.DetectedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceParametersDrift.
+func (in *InstanceParametersDrift) DeepCopy() *InstanceParametersDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceParametersDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamConfigRenderer) DeepCopyInto(out *ParamConfigRenderer) {
 	*out = *in
@@ -590,6 +727,21 @@ func (in *ParameterDeletedPolicy) DeepCopy() *ParameterDeletedPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDrift) DeepCopyInto(out *ParameterDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDrift.
+func (in *ParameterDrift) DeepCopy() *ParameterDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterList) DeepCopyInto(out *ParameterList) {
 	*out = *in
@@ -622,6 +774,21 @@ func (in *ParameterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterReloadStatus) DeepCopyInto(out *ParameterReloadStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterReloadStatus.
+func (in *ParameterReloadStatus) DeepCopy() *ParameterReloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterReloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterSpec) DeepCopyInto(out *ParameterSpec) {
 	*out = *in
@@ -654,6 +821,10 @@ func (in *ParameterStatus) DeepCopyInto(out *ParameterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterStatus.
@@ -733,11 +904,26 @@ func (in *ParametersDefinitionSpec) DeepCopyInto(out *ParametersDefinitionSpec)
 		*out = new(ParametersSchema)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValidationRules != nil {
+		in, out := &in.ValidationRules, &out.ValidationRules
+		*out = make([]ParametersValidationRule, len(*in))
+		copy(*out, *in)
+	}
 	if in.ReloadAction != nil {
 		in, out := &in.ReloadAction, &out.ReloadAction
 		*out = new(ReloadAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = new(v1.Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetectionPolicy != nil {
+		in, out := &in.DriftDetectionPolicy, &out.DriftDetectionPolicy
+		*out = new(DriftDetectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DownwardAPIChangeTriggeredActions != nil {
 		in, out := &in.DownwardAPIChangeTriggeredActions, &out.DownwardAPIChangeTriggeredActions
 		*out = make([]DownwardAPIChangeTriggeredAction, len(*in))
@@ -864,6 +1050,21 @@ func (in *ParametersSchema) DeepCopy() *ParametersSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParametersValidationRule) DeepCopyInto(out *ParametersValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParametersValidationRule.
+func (in *ParametersValidationRule) DeepCopy() *ParametersValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ParametersValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Payload) DeepCopyInto(out *Payload) {
 	{
@@ -897,6 +1098,16 @@ func (in Payload) DeepCopy() Payload {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReconcileDetail) DeepCopyInto(out *ReconcileDetail) {
 	*out = *in
+	if in.ParameterStatuses != nil {
+		in, out := &in.ParameterStatuses, &out.ParameterStatuses
+		*out = make([]ParameterReloadStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileDetail.
@@ -960,6 +1171,16 @@ func (in *ReloadAction) DeepCopyInto(out *ReloadAction) {
 		*out = new(AutoTrigger)
 		**out = **in
 	}
+	if in.SQLTrigger != nil {
+		in, out := &in.SQLTrigger, &out.SQLTrigger
+		*out = new(SQLTrigger)
+		**out = **in
+	}
+	if in.HTTPTrigger != nil {
+		in, out := &in.HTTPTrigger, &out.HTTPTrigger
+		*out = new(HTTPTrigger)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TargetPodSelector != nil {
 		in, out := &in.TargetPodSelector, &out.TargetPodSelector
 		*out = new(metav1.LabelSelector)
@@ -977,6 +1198,38 @@ func (in *ReloadAction) DeepCopy() *ReloadAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLTrigger) DeepCopyInto(out *SQLTrigger) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLTrigger.
+func (in *SQLTrigger) DeepCopy() *SQLTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptConfig) DeepCopyInto(out *ScriptConfig) {
 	*out = *in
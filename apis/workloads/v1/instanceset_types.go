@@ -480,9 +480,19 @@ type MembershipReconfiguration struct {
 	// Defines the procedure for a controlled transition of a role to a new replica.
 	//
 	// +optional
-	Switchover *kbappsv1.Action `json:"switchover,omitempty"`
+	Switchover *kbappsv1.StepAction `json:"switchover,omitempty"`
 
-	// TODO: member join/leave
+	// Defines the procedure to add a new replica to the replication group.
+	// It is called for the surge instances created during the rolling update, and the instances they replaced.
+	//
+	// +optional
+	MemberJoin *kbappsv1.Action `json:"memberJoin,omitempty"`
+
+	// Defines the procedure to remove a replica from the replication group.
+	// It is called for the instances replaced by the surge instances during the rolling update, and the surge instances themselves.
+	//
+	// +optional
+	MemberLeave *kbappsv1.StepAction `json:"memberLeave,omitempty"`
 }
 
 type ConfigTemplate struct {
@@ -415,9 +415,19 @@ func (in *MembershipReconfiguration) DeepCopyInto(out *MembershipReconfiguration
 	*out = *in
 	if in.Switchover != nil {
 		in, out := &in.Switchover, &out.Switchover
+		*out = new(appsv1.StepAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemberJoin != nil {
+		in, out := &in.MemberJoin, &out.MemberJoin
 		*out = new(appsv1.Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MemberLeave != nil {
+		in, out := &in.MemberLeave, &out.MemberLeave
+		*out = new(appsv1.StepAction)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipReconfiguration.
@@ -115,6 +115,7 @@ func init() {
 	viper.SetDefault(constant.CfgKeyCtrlrMgrNS, "default")
 	viper.SetDefault(constant.KubernetesClusterDomainEnv, constant.DefaultDNSDomain)
 	viper.SetDefault(dptypes.CfgKeyGCFrequencySeconds, dptypes.DefaultGCFrequencySeconds)
+	viper.SetDefault(dptypes.CfgKeyBackupRepoUsageFrequencySeconds, dptypes.DefaultBackupRepoUsageFrequencySeconds)
 	viper.SetDefault(dptypes.CfgKeyWorkerServiceAccountName, "kubeblocks-dataprotection-worker")
 	viper.SetDefault(dptypes.CfgKeyExecWorkerServiceAccountName, "kubeblocks-dataprotection-exec-worker")
 	viper.SetDefault(dptypes.CfgKeyWorkerServiceAccountAnnotations, "{}")
@@ -346,6 +347,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&dpcontrollers.BackupReplicationPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-replication-policy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupReplicationPolicy")
+		os.Exit(1)
+	}
+
 	if err = (&dpcontrollers.BackupPolicyTemplateReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -366,6 +376,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = dpcontrollers.NewBackupRepoUsageReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupRepoUsage")
+		os.Exit(1)
+	}
+
 	if err = (&dpcontrollers.StorageProviderReconciler{
 		Client:          client,
 		Scheme:          mgr.GetScheme(),
@@ -391,6 +406,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = dpcontrollers.NewBackupVerificationReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupVerification")
+		os.Exit(1)
+	}
+
+	if err = dpcontrollers.NewBackupReplicationReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupReplication")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
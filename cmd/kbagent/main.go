@@ -57,6 +57,14 @@ func init() {
 	pflag.IntVar(&serverConfig.Concurrency, "max-concurrency", defaultMaxConcurrency,
 		fmt.Sprintf("The maximum number of concurrent connections the Server may serve, use the default value %d if <=0.", defaultMaxConcurrency))
 	pflag.BoolVar(&serverConfig.Logging, "api-logging", true, "Enable api logging for kb-agent request.")
+	pflag.StringVar(&serverConfig.TLSCertFile, "tls-cert-file", "", "The TLS certificate file, serve over TLS if provided.")
+	pflag.StringVar(&serverConfig.TLSKeyFile, "tls-key-file", "", "The TLS private key file.")
+	pflag.StringVar(&serverConfig.TLSCAFile, "tls-ca-file", "", "The CA file to verify the client certificates, require the client certificates if provided.")
+	pflag.StringVar(&serverConfig.TokenAudience, "auth-token-audience", "", "The audience of the bearer token, enable the token authentication if provided.")
+	pflag.StringSliceVar(&serverConfig.TokenUsers, "auth-token-users", nil, "The users allowed to access the kb-agent service with the bearer token.")
+	pflag.StringVar(&serverConfig.TLSClientCertFile, "tls-client-cert-file", "", "The TLS client certificate file used to connect to the streaming server of other replicas.")
+	pflag.StringVar(&serverConfig.TLSClientKeyFile, "tls-client-key-file", "", "The TLS client private key file.")
+	pflag.StringVar(&serverConfig.TokenFile, "auth-token-file", "", "The bearer token file used to connect to the streaming server of other replicas.")
 }
 
 func main() {
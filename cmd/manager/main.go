@@ -97,7 +97,7 @@ const (
 	extensionsFlagKey   flagName = "extensions"
 	experimentalFlagKey flagName = "experimental"
 	traceFlagKey        flagName = "trace"
-	parametersFlagKey   flagName = "parameters"
+	parametersFlagKey   flagName = constant.ParametersFlag
 
 	multiClusterKubeConfigFlagKey       flagName = "multi-cluster-kubeconfig"
 	multiClusterContextsFlagKey         flagName = "multi-cluster-contexts"
@@ -150,6 +150,9 @@ func init() {
 	viper.SetDefault(constant.KubernetesClusterDomainEnv, constant.DefaultDNSDomain)
 	viper.SetDefault(instanceset.MaxPlainRevisionCount, 1024)
 	viper.SetDefault(instanceset.FeatureGateIgnorePodVerticalScaling, false)
+	viper.SetDefault(instanceset.FeatureGateRoleProbeWatch, false)
+	viper.SetDefault(instanceset.RoleProbeWatchMaxConcurrency, 256)
+	viper.SetDefault(instanceset.FeatureGatePodDisruptionBudget, true)
 	viper.SetDefault(intctrlutil.FeatureGateEnableRuntimeMetrics, false)
 	viper.SetDefault(constant.CfgKBReconcileWorkers, 8)
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
@@ -505,6 +508,27 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "InstanceSet")
 			os.Exit(1)
 		}
+
+		if viper.GetBool(instanceset.FeatureGateRoleProbeWatch) {
+			if err = mgr.Add(&instanceset.PodRoleWatcher{
+				Client:         client,
+				MaxConcurrency: viper.GetInt(instanceset.RoleProbeWatchMaxConcurrency),
+			}); err != nil {
+				setupLog.Error(err, "unable to add pod role watcher")
+				os.Exit(1)
+			}
+		}
+
+		if viper.GetBool(instanceset.FeatureGatePodDisruptionBudget) {
+			if err = (&workloadscontrollers.NodeReconciler{
+				Client:   client,
+				Scheme:   mgr.GetScheme(),
+				Recorder: mgr.GetEventRecorderFor("node-controller"),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "Node")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if viper.GetBool(operationsFlagKey.viperName()) {
@@ -630,6 +654,14 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "ComponentParameter")
 			os.Exit(1)
 		}
+		if err = (&parameterscontrollers.ParameterDriftReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("parameter-drift-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ParameterDrift")
+			os.Exit(1)
+		}
 		if err = (&parameterscontrollers.ReconfigureReconciler{
 			Client:   client,
 			Scheme:   mgr.GetScheme(),
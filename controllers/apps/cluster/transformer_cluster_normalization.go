@@ -237,7 +237,32 @@ func (t *clusterNormalizationTransformer) resolveDefinitions4Shardings(transCtx
 			// set the componentDef and serviceVersion of template as resolved
 			transCtx.shardings[i].Template.ComponentDef = compDef.Name
 			transCtx.shardings[i].Template.ServiceVersion = serviceVersion
+			if err = t.resolveDefinitions4ShardTemplates(transCtx, transCtx.shardings[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *clusterNormalizationTransformer) resolveDefinitions4ShardTemplates(transCtx *clusterTransformContext, sharding *appsv1.ClusterSharding) error {
+	for i, tpl := range sharding.ShardTemplates {
+		compDefName := sharding.Template.ComponentDef
+		if len(tpl.CompDef) > 0 {
+			compDefName = tpl.CompDef
+		}
+		serviceVersion := sharding.Template.ServiceVersion
+		if len(tpl.ServiceVersion) > 0 {
+			serviceVersion = tpl.ServiceVersion
+		}
+		compDef, resolvedServiceVersion, err := resolveCompDefinitionNServiceVersion(transCtx.Context, transCtx.Client, compDefName, serviceVersion)
+		if err != nil {
+			return err
 		}
+		transCtx.componentDefs[compDef.Name] = compDef
+		// set the componentDef and serviceVersion of shard template as resolved
+		sharding.ShardTemplates[i].CompDef = compDef.Name
+		sharding.ShardTemplates[i].ServiceVersion = resolvedServiceVersion
 	}
 	return nil
 }
@@ -92,6 +92,10 @@ type ComponentReconciler struct {
 // read only + watch access
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts/status,verbs=get
 
@@ -155,6 +159,8 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentAccountTransformer{},
 			// handle the TLS
 			&componentTLSTransformer{},
+			// issue the TLS certificates for the kb-agent
+			&componentKBAgentTLSTransformer{},
 			// resolve and build vars for template and Env
 			&componentVarsTransformer{},
 			// provision component system accounts, depend on vars
@@ -171,6 +177,8 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentRBACTransformer{},
 			// handle component postProvision lifecycle action
 			&componentPostProvisionTransformer{},
+			// rebuild the instances on the persistently failed nodes
+			&componentAutoHealingTransformer{},
 			// update component status
 			&componentStatusTransformer{Client: r.Client},
 			// notify dependent components the possible spec changes
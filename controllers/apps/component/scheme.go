@@ -26,6 +26,7 @@ import (
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -35,6 +36,7 @@ func init() {
 	model.AddScheme(appsv1alpha1.AddToScheme)
 	model.AddScheme(appsv1.AddToScheme)
 	model.AddScheme(dpv1alpha1.AddToScheme)
+	model.AddScheme(opsv1alpha1.AddToScheme)
 	model.AddScheme(snapshotv1.AddToScheme)
 	model.AddScheme(workloads.AddToScheme)
 	// model.AddScheme(extensionsv1alpha1.AddToScheme)
@@ -0,0 +1,430 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+const (
+	autoHealingLabelKey = "apps.kubeblocks.io/auto-healing"
+
+	eventReasonAutoHealing = "AutoHealing"
+
+	autoHealingConditionType         = "AutoHealing"
+	autoHealingConditionReasonQuorum = "QuorumUnavailable"
+
+	defaultAutoHealingGracePeriodSeconds    = 600
+	defaultAutoHealingMaxConcurrentRebuilds = 1
+)
+
+// componentAutoHealingTransformer rebuilds the instances whose nodes have failed persistently,
+// by issuing RebuildInstance OpsRequests.
+type componentAutoHealingTransformer struct{}
+
+var _ graph.Transformer = &componentAutoHealingTransformer{}
+
+// failedInstance is an instance whose node is NotReady or removed.
+type failedInstance struct {
+	pod      *corev1.Pod
+	nodeName string
+	since    time.Time
+}
+
+func (t *componentAutoHealingTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	comp := transCtx.Component
+	if isCompDeleting(transCtx.ComponentOrig) || comp.Spec.AutoHealing == nil {
+		return nil
+	}
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp == nil || isCompStopped(synthesizedComp) {
+		return nil
+	}
+
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client,
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return err
+	}
+	failed, err := t.listFailedInstances(transCtx, pods)
+	if err != nil {
+		return err
+	}
+	if len(failed) == 0 {
+		meta.RemoveStatusCondition(&comp.Status.Conditions, autoHealingConditionType)
+		return nil
+	}
+
+	policy := comp.Spec.AutoHealing
+	now := time.Now()
+	gracePeriod := time.Duration(autoHealingGracePeriodSeconds(policy)) * time.Second
+	expired, waiting := splitFailedInstances(failed, gracePeriod, now)
+	if len(expired) == 0 {
+		return intctrlutil.NewDelayedRequeueError(waiting, "waiting for the grace period of failed instances to expire")
+	}
+
+	// the rebuild OpsRequest can only be applied when the component is failed or updating
+	if !slices.Contains([]appsv1.ComponentPhase{appsv1.FailedComponentPhase, appsv1.UpdatingComponentPhase},
+		transCtx.ComponentOrig.Status.Phase) {
+		return nil
+	}
+
+	if !hasAutoHealingQuorum(pods, failed, synthesizedComp.MinReadySeconds) {
+		message := fmt.Sprintf("skip to rebuild the failed instances since the healthy instances are less than a quorum of %d", len(pods)/2+1)
+		if len(pods) < 3 {
+			// the healthy instances can never be a quorum once an instance fails
+			message = fmt.Sprintf("the failed instances can't be rebuilt automatically since the component has only %d replicas, "+
+				"rebuild them manually", len(pods))
+		}
+		meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+			Type:               autoHealingConditionType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: comp.Generation,
+			Reason:             autoHealingConditionReasonQuorum,
+			Message:            message,
+		})
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, eventReasonAutoHealing, message)
+		return nil
+	}
+	meta.RemoveStatusCondition(&comp.Status.Conditions, autoHealingConditionType)
+
+	opsList, err := t.listAutoHealingOps(transCtx, synthesizedComp)
+	if err != nil {
+		return err
+	}
+	running := 0
+	existing := autoHealingOpsNames(opsList)
+	for _, ops := range opsList {
+		if !ops.IsComplete() {
+			running++
+		}
+	}
+
+	var backupName string
+	if policy.Source == appsv1.BackupAutoHealingSource {
+		backup, err := t.latestCompletedBackup(transCtx, synthesizedComp)
+		if err != nil {
+			return err
+		}
+		if backup == nil {
+			transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, eventReasonAutoHealing,
+				"skip to rebuild the failed instances since there is no completed backup")
+			return nil
+		}
+		backupName = backup.Name
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, inst := range expired {
+		if running >= autoHealingMaxConcurrentRebuilds(policy) {
+			break
+		}
+		ops := buildAutoHealingOpsRequest(synthesizedComp, policy, inst.pod, backupName)
+		if existing[ops.Name] {
+			continue
+		}
+		graphCli.Create(dag, ops)
+		transCtx.EventRecorder.Eventf(comp, corev1.EventTypeNormal, eventReasonAutoHealing,
+			"rebuild instance %s since its node %s has failed since %s", inst.pod.Name, inst.nodeName, inst.since.Format(time.RFC3339))
+		running++
+	}
+	if waiting > 0 {
+		return intctrlutil.NewDelayedRequeueError(waiting, "waiting for the grace period of failed instances to expire")
+	}
+	return nil
+}
+
+// listFailedInstances returns the instances whose nodes are NotReady or removed.
+func (t *componentAutoHealingTransformer) listFailedInstances(transCtx *componentTransformContext, pods []*corev1.Pod) ([]failedInstance, error) {
+	var failed []failedInstance
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		node, nodeName, err := t.getNodeOfInstance(transCtx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodeName) == 0 {
+			continue
+		}
+		if since, ok := nodeFailedSince(node, pod); ok {
+			failed = append(failed, failedInstance{pod: pod, nodeName: nodeName, since: since})
+		}
+	}
+	return failed, nil
+}
+
+// getNodeOfInstance returns the node the instance is bound to, and nil if the node has been removed.
+//
+// For the pod which has not been scheduled, the node is resolved from the node affinity of its local PVs,
+// since the pod can't be scheduled to other nodes until it is rebuilt.
+func (t *componentAutoHealingTransformer) getNodeOfInstance(transCtx *componentTransformContext, pod *corev1.Pod) (*corev1.Node, string, error) {
+	if len(pod.Spec.NodeName) > 0 {
+		node := &corev1.Node{}
+		err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: pod.Spec.NodeName}, node)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, pod.Spec.NodeName, nil
+			}
+			return nil, "", err
+		}
+		return node, node.Name, nil
+	}
+
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Namespace: pod.Namespace, Name: vol.PersistentVolumeClaim.ClaimName}, pvc)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, "", err
+		}
+		if len(pvc.Spec.VolumeName) == 0 {
+			continue
+		}
+		pv := &corev1.PersistentVolume{}
+		if err = transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, "", err
+		}
+		hostname := hostnameOfLocalPV(pv)
+		if len(hostname) == 0 {
+			continue
+		}
+		nodes := &corev1.NodeList{}
+		if err = transCtx.Client.List(transCtx.Context, nodes, client.MatchingLabels{corev1.LabelHostname: hostname}); err != nil {
+			return nil, "", err
+		}
+		if len(nodes.Items) == 0 {
+			return nil, hostname, nil
+		}
+		return &nodes.Items[0], nodes.Items[0].Name, nil
+	}
+	return nil, "", nil
+}
+
+func (t *componentAutoHealingTransformer) listAutoHealingOps(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) ([]opsv1alpha1.OpsRequest, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := transCtx.Client.List(transCtx.Context, opsList, client.InNamespace(synthesizedComp.Namespace),
+		client.MatchingLabels(autoHealingOpsLabels(synthesizedComp))); err != nil {
+		return nil, err
+	}
+	return opsList.Items, nil
+}
+
+func (t *componentAutoHealingTransformer) latestCompletedBackup(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := transCtx.Client.List(transCtx.Context, backupList, client.InNamespace(synthesizedComp.Namespace),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:    synthesizedComp.ClusterName,
+			constant.KBAppComponentLabelKey: synthesizedComp.Name,
+		}); err != nil {
+		return nil, err
+	}
+	var latest *dpv1alpha1.Backup
+	for i, backup := range backupList.Items {
+		// only the full and incremental backups can be used to rebuild an instance
+		backupType := dpv1alpha1.BackupType(backup.Labels[dptypes.BackupTypeLabelKey])
+		if backupType != dpv1alpha1.BackupTypeFull && backupType != dpv1alpha1.BackupTypeIncremental {
+			continue
+		}
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.CompletionTimestamp == nil ||
+			backup.Status.PrimaryDeletionTimestamp != nil {
+			continue
+		}
+		if latest == nil || backup.Status.CompletionTimestamp.After(latest.Status.CompletionTimestamp.Time) {
+			latest = &backupList.Items[i]
+		}
+	}
+	return latest, nil
+}
+
+// nodeFailedSince checks whether the node is NotReady or removed, and returns the time since when it failed.
+func nodeFailedSince(node *corev1.Node, pod *corev1.Pod) (time.Time, bool) {
+	if node == nil {
+		// the node has been removed, take the time the pod became not ready as the failure time
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status != corev1.ConditionTrue {
+				return cond.LastTransitionTime.Time, true
+			}
+		}
+		return pod.CreationTimestamp.Time, true
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			if cond.Status == corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return cond.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// hostnameOfLocalPV returns the hostname which the PV is pinned to by the node affinity.
+func hostnameOfLocalPV(pv *corev1.PersistentVolume) string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelHostname && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+				return expr.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// splitFailedInstances returns the instances that have failed longer than the grace period,
+// and the shortest time to wait for the others.
+func splitFailedInstances(failed []failedInstance, gracePeriod time.Duration, now time.Time) ([]failedInstance, time.Duration) {
+	var (
+		expired []failedInstance
+		waiting time.Duration
+	)
+	for _, inst := range failed {
+		remaining := inst.since.Add(gracePeriod).Sub(now)
+		if remaining <= 0 {
+			expired = append(expired, inst)
+			continue
+		}
+		if waiting == 0 || remaining < waiting {
+			waiting = remaining
+		}
+	}
+	return expired, waiting
+}
+
+// hasAutoHealingQuorum checks whether the healthy instances are a majority of all instances,
+// rebuilding the failed instances without a quorum may lose the data.
+func hasAutoHealingQuorum(pods []*corev1.Pod, failed []failedInstance, minReadySeconds int32) bool {
+	healthy := 0
+	for _, pod := range pods {
+		if slices.ContainsFunc(failed, func(inst failedInstance) bool { return inst.pod.Name == pod.Name }) {
+			continue
+		}
+		if intctrlutil.IsPodAvailable(pod, minReadySeconds) {
+			healthy++
+		}
+	}
+	return healthy >= len(pods)/2+1
+}
+
+func autoHealingGracePeriodSeconds(policy *appsv1.InstanceAutoHealing) int32 {
+	if policy.GracePeriodSeconds > 0 {
+		return policy.GracePeriodSeconds
+	}
+	return defaultAutoHealingGracePeriodSeconds
+}
+
+func autoHealingMaxConcurrentRebuilds(policy *appsv1.InstanceAutoHealing) int {
+	if policy.MaxConcurrentRebuilds > 0 {
+		return int(policy.MaxConcurrentRebuilds)
+	}
+	return defaultAutoHealingMaxConcurrentRebuilds
+}
+
+func autoHealingOpsLabels(synthesizedComp *component.SynthesizedComponent) map[string]string {
+	return map[string]string{
+		constant.AppInstanceLabelKey:    synthesizedComp.ClusterName,
+		constant.KBAppComponentLabelKey: synthesizedComp.Name,
+		autoHealingLabelKey:             "true",
+	}
+}
+
+// autoHealingOpsName generates a deterministic name for the instance, so that the same incarnation
+// of an instance is rebuilt at most once.
+func autoHealingOpsName(pod *corev1.Pod) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(pod.UID))
+	return fmt.Sprintf("%s-rebuild-%s", pod.Name, rand.SafeEncodeString(fmt.Sprint(hash.Sum32())))
+}
+
+func autoHealingOpsNames(opsList []opsv1alpha1.OpsRequest) map[string]bool {
+	names := make(map[string]bool, len(opsList))
+	for _, ops := range opsList {
+		names[ops.Name] = true
+	}
+	return names
+}
+
+func buildAutoHealingOpsRequest(synthesizedComp *component.SynthesizedComponent,
+	policy *appsv1.InstanceAutoHealing, pod *corev1.Pod, backupName string) *opsv1alpha1.OpsRequest {
+	labels := autoHealingOpsLabels(synthesizedComp)
+	labels[constant.OpsRequestTypeLabelKey] = string(opsv1alpha1.RebuildInstanceType)
+	return &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: synthesizedComp.Namespace,
+			Name:      autoHealingOpsName(pod),
+			Labels:    labels,
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			Type:        opsv1alpha1.RebuildInstanceType,
+			ClusterName: synthesizedComp.ClusterName,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				RebuildFrom: []opsv1alpha1.RebuildInstance{
+					{
+						ComponentOps: opsv1alpha1.ComponentOps{
+							ComponentName: synthesizedComp.Name,
+						},
+						Instances: []opsv1alpha1.Instance{
+							{
+								Name: pod.Name,
+							},
+						},
+						InPlace:    policy.Source == appsv1.BackupAutoHealingSource,
+						BackupName: backupName,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("component auto-healing transformer test", func() {
+	const (
+		clusterName = "test-cluster"
+		compName    = "comp"
+	)
+
+	var (
+		reader   *appsutil.MockReader
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+	)
+
+	newDAG := func(graphCli model.GraphClient, comp *appsv1.Component) *graph.DAG {
+		d := graph.NewDAG()
+		graphCli.Root(d, comp, comp, model.ActionStatusPtr())
+		return d
+	}
+
+	newNode := func(name string, ready corev1.ConditionStatus, since time.Time) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:               corev1.NodeReady,
+						Status:             ready,
+						LastTransitionTime: metav1.NewTime(since),
+					},
+				},
+			},
+		}
+	}
+
+	newPod := func(ordinal int, nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      fmt.Sprintf("%s-%s-%d", clusterName, compName, ordinal),
+				UID:       types.UID(fmt.Sprintf("uid-%d", ordinal)),
+				Labels:    constant.GetCompLabels(clusterName, compName),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             ready,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+					},
+				},
+			},
+		}
+	}
+
+	opsRequests := func() []client.Object {
+		graphCli := transCtx.Client.(model.GraphClient)
+		return graphCli.FindAll(dag, &opsv1alpha1.OpsRequest{})
+	}
+
+	BeforeEach(func() {
+		reader = &appsutil.MockReader{}
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				Labels:    constant.GetCompLabels(clusterName, compName),
+			},
+			Spec: appsv1.ComponentSpec{
+				AutoHealing: &appsv1.InstanceAutoHealing{
+					GracePeriodSeconds:    600,
+					Source:                appsv1.PeerAutoHealingSource,
+					MaxConcurrentRebuilds: 1,
+				},
+			},
+			Status: appsv1.ComponentStatus{
+				Phase: appsv1.FailedComponentPhase,
+			},
+		}
+		graphCli := model.NewGraphClient(reader)
+		dag = newDAG(graphCli, comp)
+		transCtx = &componentTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: record.NewFakeRecorder(10),
+			Logger:        logger,
+			Component:     comp,
+			ComponentOrig: comp.DeepCopy(),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: clusterName,
+				Name:        compName,
+				Replicas:    3,
+			},
+		}
+	})
+
+	Context("rebuild instances", func() {
+		It("disabled", func() {
+			transCtx.Component.Spec.AutoHealing = nil
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(BeEmpty())
+		})
+
+		It("rebuild the instance after the grace period", func() {
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-1", corev1.ConditionTrue),
+				newPod(2, "node-1", corev1.ConditionTrue),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			objs := opsRequests()
+			Expect(objs).Should(HaveLen(1))
+			ops := objs[0].(*opsv1alpha1.OpsRequest)
+			Expect(ops.Labels).Should(HaveKeyWithValue(autoHealingLabelKey, "true"))
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.RebuildInstanceType))
+			Expect(ops.Spec.RebuildFrom).Should(HaveLen(1))
+			Expect(ops.Spec.RebuildFrom[0].InPlace).Should(BeFalse())
+			Expect(ops.Spec.RebuildFrom[0].Instances[0].Name).Should(Equal(fmt.Sprintf("%s-%s-0", clusterName, compName)))
+		})
+
+		It("rebuild the instance whose node has been removed", func() {
+			reader.Objects = []client.Object{
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-1", corev1.ConditionTrue),
+				newPod(2, "node-1", corev1.ConditionTrue),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(HaveLen(1))
+		})
+
+		It("wait for the grace period", func() {
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Minute)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-1", corev1.ConditionTrue),
+				newPod(2, "node-1", corev1.ConditionTrue),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(opsRequests()).Should(BeEmpty())
+		})
+
+		It("skip without a quorum", func() {
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-0", corev1.ConditionFalse),
+				newPod(2, "node-1", corev1.ConditionTrue),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(BeEmpty())
+			cond := meta.FindStatusCondition(transCtx.Component.Status.Conditions, autoHealingConditionType)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(autoHealingConditionReasonQuorum))
+
+			By("the node recovers")
+			reader.Objects[0] = newNode("node-0", corev1.ConditionTrue, time.Now())
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(meta.FindStatusCondition(transCtx.Component.Status.Conditions, autoHealingConditionType)).Should(BeNil())
+		})
+
+		It("skip the component with less than 3 replicas", func() {
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-1", corev1.ConditionTrue),
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(BeEmpty())
+			cond := meta.FindStatusCondition(transCtx.Component.Status.Conditions, autoHealingConditionType)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Message).Should(ContainSubstring("only 2 replicas"))
+		})
+
+		It("limit the concurrent rebuilds", func() {
+			transCtx.Component.Spec.AutoHealing.MaxConcurrentRebuilds = 1
+			pod := newPod(0, "node-0", corev1.ConditionFalse)
+			running := buildAutoHealingOpsRequest(transCtx.SynthesizeComponent, transCtx.Component.Spec.AutoHealing,
+				newPod(3, "node-0", corev1.ConditionFalse), "")
+			running.Status.Phase = opsv1alpha1.OpsRunningPhase
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				pod,
+				newPod(1, "node-1", corev1.ConditionTrue),
+				newPod(2, "node-1", corev1.ConditionTrue),
+				running,
+			}
+
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(BeEmpty())
+
+			transCtx.Component.Spec.AutoHealing.MaxConcurrentRebuilds = 2
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(HaveLen(1))
+		})
+
+		It("rebuild from the latest backup", func() {
+			transCtx.Component.Spec.AutoHealing.Source = appsv1.BackupAutoHealingSource
+			reader.Objects = []client.Object{
+				newNode("node-0", corev1.ConditionFalse, time.Now().Add(-time.Hour)),
+				newNode("node-1", corev1.ConditionTrue, time.Now().Add(-time.Hour)),
+				newPod(0, "node-0", corev1.ConditionFalse),
+				newPod(1, "node-1", corev1.ConditionTrue),
+				newPod(2, "node-1", corev1.ConditionTrue),
+			}
+
+			By("no backup")
+			transformer := &componentAutoHealingTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(opsRequests()).Should(BeEmpty())
+
+			By("with completed backups")
+			backupTypes := []dpv1alpha1.BackupType{dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupTypeIncremental, dpv1alpha1.BackupTypeContinuous}
+			for i, completed := range []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-time.Hour), time.Now()} {
+				backup := &dpv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testCtx.DefaultNamespace,
+						Name:      fmt.Sprintf("backup-%d", i),
+						Labels: map[string]string{
+							constant.AppInstanceLabelKey:    clusterName,
+							constant.KBAppComponentLabelKey: compName,
+							dptypes.BackupTypeLabelKey:      string(backupTypes[i]),
+						},
+					},
+					Status: dpv1alpha1.BackupStatus{
+						Phase:               dpv1alpha1.BackupPhaseCompleted,
+						CompletionTimestamp: ptr.To(metav1.NewTime(completed)),
+					},
+				}
+				reader.Objects = append(reader.Objects, backup)
+			}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			objs := opsRequests()
+			Expect(objs).Should(HaveLen(1))
+			ops := objs[0].(*opsv1alpha1.OpsRequest)
+			Expect(ops.Spec.RebuildFrom[0].InPlace).Should(BeTrue())
+			Expect(ops.Spec.RebuildFrom[0].BackupName).Should(Equal("backup-1"))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
+)
+
+// componentKBAgentTLSTransformer issues the TLS certificates for the kb-agent if the mTLS auth mode is enabled.
+type componentKBAgentTLSTransformer struct{}
+
+var _ graph.Transformer = &componentKBAgentTLSTransformer{}
+
+func (t *componentKBAgentTLSTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	var (
+		transCtx        = ctx.(*componentTransformContext)
+		synthesizedComp = transCtx.SynthesizeComponent
+		graphCli, _     = transCtx.Client.(model.GraphClient)
+	)
+
+	secretObj, err := t.secretObject(transCtx, synthesizedComp)
+	if err != nil {
+		return err
+	}
+
+	if t.enabled(synthesizedComp) {
+		// the certificates are issued once, and never updated
+		if secretObj == nil {
+			secret, err := newTLSSecretWithName(transCtx.Component, synthesizedComp,
+				kbagent.TLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name))
+			if err != nil {
+				return err
+			}
+			if _, err = plan.ComposeKBAgentTLSCerts(*synthesizedComp, secret); err != nil {
+				return err
+			}
+			graphCli.Create(dag, secret)
+		}
+	} else if secretObj != nil {
+		graphCli.Delete(dag, secretObj)
+	}
+	return nil
+}
+
+func (t *componentKBAgentTLSTransformer) enabled(synthesizedComp *component.SynthesizedComponent) bool {
+	if kbagent.AuthMode() != kbagent.AuthModeMTLS {
+		return false
+	}
+	_, c := intctrlutil.GetContainerByName(synthesizedComp.PodSpec.Containers, kbagent.ContainerName)
+	return c != nil
+}
+
+func (t *componentKBAgentTLSTransformer) secretObject(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
+	secretKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      kbagent.TLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	secret := &corev1.Secret{}
+	err := transCtx.Client.Get(transCtx.Context, secretKey, secret)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return secret, nil
+}
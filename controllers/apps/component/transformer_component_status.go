@@ -40,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
 
@@ -81,6 +82,10 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 
 	t.init(transCtx, dag)
 
+	if err := t.reconcileLifecycleActions(transCtx); err != nil {
+		return err
+	}
+
 	workloadGeneration, err := t.workloadGeneration()
 	if err != nil {
 		return err
@@ -109,6 +114,17 @@ func (t *componentStatusTransformer) init(transCtx *componentTransformContext, d
 	t.dag = dag
 }
 
+// reconcileLifecycleActions surfaces the status of the latest executions of the multi-step lifecycle actions,
+// which are recorded in the annotations of the pods which the actions are called for.
+func (t *componentStatusTransformer) reconcileLifecycleActions(transCtx *componentTransformContext) error {
+	pods, err := component.ListOwnedPods(transCtx.Context, t.Client, t.comp.Namespace, t.synthesizeComp.ClusterName, t.synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	t.comp.Status.LifecycleActions = lifecycle.ActionStepStatuses(pods)
+	return nil
+}
+
 // reconcileStatus reconciles component status.
 func (t *componentStatusTransformer) reconcileStatus(transCtx *componentTransformContext) error {
 	if t.runningITS == nil {
@@ -241,9 +257,14 @@ func (t *componentStatusTransformer) hasScaleOutRunning(transCtx *componentTrans
 		return false, false, nil
 	}
 
-	// TODO: scale-out failed
-
-	return true, false, nil
+	// the data replication task of the replicas failed definitely
+	failedReplicas, err := component.GetReplicasStatusFunc(t.protoITS, func(status component.ReplicaStatus) bool {
+		return status.DataLoadFailed
+	})
+	if err != nil {
+		return false, false, err
+	}
+	return true, len(failedReplicas) > 0, nil
 }
 
 // hasVolumeExpansionRunning checks if the volume expansion is running.
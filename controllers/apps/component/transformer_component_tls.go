@@ -361,7 +361,10 @@ func tlsSecretName(clusterName, compName string) string {
 }
 
 func newTLSSecret(comp *appsv1.Component, synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
-	secretName := tlsSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	return newTLSSecretWithName(comp, synthesizedComp, tlsSecretName(synthesizedComp.ClusterName, synthesizedComp.Name))
+}
+
+func newTLSSecretWithName(comp *appsv1.Component, synthesizedComp *component.SynthesizedComponent, secretName string) (*corev1.Secret, error) {
 	secret := builder.NewSecretBuilder(synthesizedComp.Namespace, secretName).
 		// priority: static < dynamic < built-in
 		AddLabelsInMap(synthesizedComp.StaticLabels).
@@ -39,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -145,11 +146,20 @@ func (t *componentWorkloadTransformer) reconcileReplicasStatus(ctx context.Conte
 	// HACK: sync replicas status from runningITS to protoITS
 	component.BuildReplicasStatus(runningITS, protoITS)
 
-	replicas, err := func() ([]string, error) {
-		pods, err := component.ListOwnedPods(ctx, cli, namespace, clusterName, compName)
-		if err != nil {
-			return nil, err
+	pods, err := component.ListOwnedPods(ctx, cli, namespace, clusterName, compName)
+	if err != nil {
+		return err
+	}
+
+	hasMemberJoinDefined, hasDataActionDefined := hasMemberJoinNDataActionDefined(synthesizedComp.LifecycleActions)
+	if hasDataActionDefined {
+		// poll the progress of the data replication, rather than depending on the task events only
+		if err = component.PollNewReplicaTasks(ctx, cli, protoITS, clusterName, compName, pods); err != nil {
+			return err
 		}
+	}
+
+	replicas, err := func() ([]string, error) {
 		podNameSet := sets.New[string]()
 		for _, pod := range pods {
 			podNameSet.Insert(pod.Name)
@@ -166,8 +176,6 @@ func (t *componentWorkloadTransformer) reconcileReplicasStatus(ctx context.Conte
 	if err != nil {
 		return err
 	}
-
-	hasMemberJoinDefined, hasDataActionDefined := hasMemberJoinNDataActionDefined(synthesizedComp.LifecycleActions)
 	return component.StatusReplicasStatus(protoITS, replicas, hasMemberJoinDefined, hasDataActionDefined)
 }
 
@@ -454,6 +462,7 @@ func hasMemberJoinNDataActionDefined(lifecycleActions *appsv1.ComponentLifecycle
 		}
 		return true
 	}
-	return hasActionDefined([]*appsv1.Action{lifecycleActions.MemberJoin}),
+	// the data actions are streaming actions which require the exec handler
+	return lifecycle.IsActionDefined(lifecycleActions.MemberJoin),
 		hasActionDefined([]*appsv1.Action{lifecycleActions.DataDump, lifecycleActions.DataLoad})
 }
@@ -87,14 +87,18 @@ var _ = Describe("Component Workload Operations Test", func() {
 						Image: "test-image",
 					},
 				},
-				MemberLeave: &appsv1.Action{
-					Exec: &appsv1.ExecAction{
-						Image: "test-image",
+				MemberLeave: &appsv1.StepAction{
+					Action: appsv1.Action{
+						Exec: &appsv1.ExecAction{
+							Image: "test-image",
+						},
 					},
 				},
-				Switchover: &appsv1.Action{
-					Exec: &appsv1.ExecAction{
-						Image: "test-image",
+				Switchover: &appsv1.StepAction{
+					Action: appsv1.Action{
+						Exec: &appsv1.ExecAction{
+							Image: "test-image",
+						},
 					},
 				},
 			},
@@ -0,0 +1,455 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	defaultAnalysisIntervalSeconds  = 30
+	defaultAnalysisSamples          = 10
+	defaultAnalysisSuccessThreshold = 3
+	defaultAnalysisFailureThreshold = 1
+
+	// analysisSampleTimeout bounds the time of a sample of all the metrics, since the sample is taken in the reconciliation
+	analysisSampleTimeout = 15 * time.Second
+	analysisQueryTimeout  = 10 * time.Second
+	analysisProbeTimeout  = time.Second
+
+	maxPrometheusResponseBytes = 4 * 1024 * 1024
+
+	maxCachedConditions = 256
+)
+
+// analysisHTTPClient is dedicated to the queries of the analysis, it doesn't follow the redirects
+// to keep the queries to the allowed addresses.
+var analysisHTTPClient = &http.Client{
+	Timeout: analysisQueryTimeout,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+type analysisOutcome string
+
+const (
+	analysisSucceeded    analysisOutcome = "Succeeded"
+	analysisFailed       analysisOutcome = "Failed"
+	analysisInconclusive analysisOutcome = "Inconclusive"
+)
+
+// analyzeNewInstances analyzes the new instances of the component before promoting them, it returns nil only when
+// the analysis succeeded, and a requeue error if the analysis is still running.
+// The status of the analysis is recorded in the rollout status, and the failure of it is checked by isAnalysisFailed.
+func analyzeNewInstances(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, cond *appsv1alpha1.RolloutPromoteCondition, tplName string, newReplicas int32) error {
+	if cond == nil || cond.Analysis == nil {
+		return nil
+	}
+	status := rolloutCompStatus(rollout, comp.Name)
+	if status == nil {
+		return fmt.Errorf("the status of component %s is not initialized", comp.Name)
+	}
+
+	analysis := cond.Analysis
+	if status.Analysis == nil || status.Analysis.Replicas != newReplicas {
+		// the analysis starts over for every new instance
+		status.Analysis = &appsv1alpha1.RolloutAnalysisStatus{
+			Phase:    appsv1alpha1.RunningRolloutAnalysisPhase,
+			Replicas: newReplicas,
+		}
+	}
+	switch status.Analysis.Phase {
+	case appsv1alpha1.SucceededRolloutAnalysisPhase:
+		return nil
+	case appsv1alpha1.FailedRolloutAnalysisPhase:
+		return fmt.Errorf("the analysis of component %s failed: %s", comp.Name, status.Analysis.Message)
+	}
+
+	interval := time.Duration(ptr.Deref(analysis.IntervalSeconds, defaultAnalysisIntervalSeconds)) * time.Second
+	if !status.Analysis.LastSampleTimestamp.IsZero() {
+		if diff := time.Until(status.Analysis.LastSampleTimestamp.Add(interval)); diff > 0 {
+			return controllerutil.NewDelayedRequeueError(diff, fmt.Sprintf("wait for the next sample of the analysis for %s", diff.String()))
+		}
+	}
+
+	outcome, message := sampleAnalysis(transCtx, rollout, comp, analysis, tplName)
+	status.Analysis.Samples++
+	status.Analysis.LastSampleTimestamp = metav1.Now()
+	status.Analysis.Message = message
+	switch outcome {
+	case analysisSucceeded:
+		status.Analysis.Successes++
+	case analysisFailed:
+		status.Analysis.Failures++
+	}
+
+	switch {
+	case status.Analysis.Failures >= ptr.Deref(analysis.FailureThreshold, defaultAnalysisFailureThreshold):
+		status.Analysis.Phase = appsv1alpha1.FailedRolloutAnalysisPhase
+	case status.Analysis.Successes >= ptr.Deref(analysis.SuccessThreshold, defaultAnalysisSuccessThreshold):
+		status.Analysis.Phase = appsv1alpha1.SucceededRolloutAnalysisPhase
+		return nil
+	case status.Analysis.Samples >= ptr.Deref(analysis.Samples, defaultAnalysisSamples):
+		status.Analysis.Phase = appsv1alpha1.FailedRolloutAnalysisPhase
+		status.Analysis.Message = fmt.Sprintf("the success threshold is not reached within %d samples, last sample: %s",
+			status.Analysis.Samples, message)
+	default:
+		return controllerutil.NewDelayedRequeueError(interval, fmt.Sprintf("analyzing the new instances of component %s", comp.Name))
+	}
+	return fmt.Errorf("the analysis of component %s failed: %s", comp.Name, status.Analysis.Message)
+}
+
+func isAnalysisFailed(rollout *appsv1alpha1.Rollout, compName string) bool {
+	status := rolloutCompStatus(rollout, compName)
+	return status != nil && status.Analysis != nil && status.Analysis.Phase == appsv1alpha1.FailedRolloutAnalysisPhase
+}
+
+func rolloutCompStatus(rollout *appsv1alpha1.Rollout, compName string) *appsv1alpha1.RolloutComponentStatus {
+	for i, status := range rollout.Status.Components {
+		if status.Name == compName {
+			return &rollout.Status.Components[i]
+		}
+	}
+	return nil
+}
+
+// sampleAnalysis takes a sample of all the metrics, the sample succeeds only when all the metrics succeed,
+// and fails if any of the metrics fails.
+// The sample is bounded by analysisSampleTimeout, the metrics not measured in time are taken as inconclusive.
+func sampleAnalysis(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, analysis *appsv1alpha1.RolloutAnalysis, tplName string) (analysisOutcome, string) {
+	ctx, cancel := context.WithTimeout(transCtx.Context, analysisSampleTimeout)
+	defer cancel()
+
+	outcome := analysisSucceeded
+	messages := make([]string, 0)
+	for _, metric := range analysis.Metrics {
+		result, err := measureMetric(ctx, transCtx.Client, rollout, comp, metric, tplName)
+		if err == nil {
+			var metricOutcome analysisOutcome
+			metricOutcome, err = evaluateMetric(metric, result)
+			if err == nil {
+				messages = append(messages, fmt.Sprintf("%s: %s", metric.Name, metricOutcome))
+				if metricOutcome == analysisFailed {
+					outcome = analysisFailed
+				} else if metricOutcome == analysisInconclusive && outcome != analysisFailed {
+					outcome = analysisInconclusive
+				}
+				continue
+			}
+		}
+		// the error of measurement is taken as inconclusive, to tolerate the transient errors
+		messages = append(messages, fmt.Sprintf("%s: %s", metric.Name, err.Error()))
+		if outcome != analysisFailed {
+			outcome = analysisInconclusive
+		}
+	}
+	return outcome, strings.Join(messages, "; ")
+}
+
+func measureMetric(ctx context.Context, cli client.Reader, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, metric appsv1alpha1.RolloutAnalysisMetric, tplName string) (any, error) {
+	switch {
+	case metric.Prometheus != nil:
+		return queryPrometheus(ctx, metric.Prometheus)
+	case metric.Probe != nil:
+		return probeNewInstances(ctx, cli, rollout, comp, *metric.Probe, tplName)
+	default:
+		return nil, fmt.Errorf("neither prometheus nor probe is defined")
+	}
+}
+
+func evaluateMetric(metric appsv1alpha1.RolloutAnalysisMetric, result any) (analysisOutcome, error) {
+	if len(metric.FailureCondition) > 0 {
+		failed, err := evaluateCondition(metric.FailureCondition, result)
+		if err != nil {
+			return "", errors.Wrap(err, "failure condition")
+		}
+		if failed {
+			return analysisFailed, nil
+		}
+	}
+	succeed, err := evaluateCondition(metric.SuccessCondition, result)
+	if err != nil {
+		return "", errors.Wrap(err, "success condition")
+	}
+	switch {
+	case succeed:
+		return analysisSucceeded, nil
+	case len(metric.FailureCondition) > 0:
+		return analysisInconclusive, nil
+	default:
+		return analysisFailed, nil
+	}
+}
+
+// checkAnalysis checks whether the conditions of the metrics can be compiled, and the addresses of them are allowed.
+func checkAnalysis(analysis *appsv1alpha1.RolloutAnalysis) error {
+	for _, metric := range analysis.Metrics {
+		if metric.Prometheus != nil {
+			if err := checkPrometheusAddress(metric.Prometheus.Address); err != nil {
+				return errors.Wrapf(err, "invalid address of metric %s", metric.Name)
+			}
+		}
+		for _, expression := range []string{metric.SuccessCondition, metric.FailureCondition} {
+			if len(expression) == 0 {
+				continue
+			}
+			if _, err := compileCondition(expression); err != nil {
+				return errors.Wrapf(err, "invalid condition of metric %s", metric.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// conditionCache caches the compiled programs per expression, since the conditions are evaluated for every sample.
+var conditionCache = &celProgramCache{
+	programs: map[string]cel.Program{},
+}
+
+type celProgramCache struct {
+	mutex    sync.Mutex
+	programs map[string]cel.Program
+}
+
+func (c *celProgramCache) get(expression string) (cel.Program, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	prg, ok := c.programs[expression]
+	return prg, ok
+}
+
+func (c *celProgramCache) put(expression string, prg cel.Program) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.programs) >= maxCachedConditions {
+		c.programs = map[string]cel.Program{}
+	}
+	c.programs[expression] = prg
+}
+
+func compileCondition(expression string) (cel.Program, error) {
+	if prg, ok := conditionCache.get(expression); ok {
+		return prg, nil
+	}
+	env, err := cel.NewEnv(cel.Variable("result", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL program: %w", err)
+	}
+	conditionCache.put(expression, prg)
+	return prg, nil
+}
+
+func evaluateCondition(expression string, result any) (bool, error) {
+	prg, err := compileCondition(expression)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{"result": result})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+	ok, isBool := out.Value().(bool)
+	if !isBool {
+		return false, fmt.Errorf("expression did not return a boolean")
+	}
+	return ok, nil
+}
+
+// checkPrometheusAddress checks that the address is an HTTP or HTTPS address,
+// and it is in the allowlist if the allowlist is configured.
+func checkPrometheusAddress(address string) error {
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 || u.User != nil {
+		return fmt.Errorf("only the HTTP and HTTPS addresses without user info are supported: %s", address)
+	}
+	allowlist := viper.GetString(constant.CfgRolloutAnalysisPrometheusAddresses)
+	if len(strings.TrimSpace(allowlist)) == 0 {
+		return nil
+	}
+	for _, allowed := range strings.Split(allowlist, ",") {
+		a, err := url.Parse(strings.TrimSpace(allowed))
+		if err == nil && a.Scheme == u.Scheme && a.Host == u.Host {
+			return nil
+		}
+	}
+	return fmt.Errorf("the address is not in the allowlist: %s", address)
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheus executes the instant query, and returns the values of the samples in the result.
+func queryPrometheus(ctx context.Context, metric *appsv1alpha1.RolloutPrometheusMetric) ([]float64, error) {
+	if err := checkPrometheusAddress(metric.Address); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, analysisQueryTimeout)
+	defer cancel()
+
+	u := fmt.Sprintf("%s/api/v1/query?%s", strings.TrimSuffix(metric.Address, "/"), url.Values{"query": []string{metric.Query}}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := analysisHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(rsp.Body, maxPrometheusResponseBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &prometheusResponse{}
+	if err = json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("invalid response of query, status code: %d", rsp.StatusCode)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s", result.Error)
+	}
+	return parsePrometheusResult(result.Data.ResultType, result.Data.Result)
+}
+
+func parsePrometheusResult(resultType string, data json.RawMessage) ([]float64, error) {
+	parse := func(value []any) (float64, error) {
+		if len(value) != 2 {
+			return 0, fmt.Errorf("invalid sample value: %v", value)
+		}
+		str, ok := value[1].(string)
+		if !ok {
+			return 0, fmt.Errorf("invalid sample value: %v", value)
+		}
+		return strconv.ParseFloat(str, 64)
+	}
+	switch resultType {
+	case "scalar":
+		var value []any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		v, err := parse(value)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+	case "vector":
+		var samples []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(data, &samples); err != nil {
+			return nil, err
+		}
+		values := make([]float64, 0, len(samples))
+		for _, sample := range samples {
+			v, err := parse(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("the result type %s is not supported", resultType)
+	}
+}
+
+// probeNewInstances returns the latest results of the probe on the new instances.
+func probeNewInstances(ctx context.Context, cli client.Reader, rollout *appsv1alpha1.Rollout,
+	comp appsv1alpha1.RolloutComponent, probe, tplName string) ([]map[string]any, error) {
+	labels := constant.GetCompLabels(rollout.Spec.ClusterName, comp.Name)
+	labels[constant.KBAppInstanceTemplateLabelKey] = tplName
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(rollout.Namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]any, 0)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, analysisProbeTimeout+analysisQueryTimeout)
+		rsp, err := lifecycle.WatchProbe(probeCtx, cli, rollout.Namespace, rollout.Spec.ClusterName, comp.Name,
+			pod, probe, 0, analysisProbeTimeout)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if len(rsp.Events) == 0 {
+			return nil, fmt.Errorf("no result of probe %s on instance %s yet", probe, pod.Name)
+		}
+		event := rsp.Events[len(rsp.Events)-1].Event
+		results = append(results, map[string]any{
+			"instance": pod.Name,
+			"code":     int64(event.Code),
+			"output":   string(event.Output),
+			"message":  event.Message,
+		})
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no new instance found")
+	}
+	return results, nil
+}
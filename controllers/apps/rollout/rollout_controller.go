@@ -88,6 +88,7 @@ func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&rolloutMetaTransformer{},
 			&rolloutLoadTransformer{},
 			&rolloutSetupTransformer{},
+			&rolloutAbortTransformer{},
 			&rolloutTearDownTransformer{},
 			&rolloutInplaceTransformer{},
 			&rolloutReplaceTransformer{},
@@ -21,6 +21,8 @@ package rollout
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"slices"
 	"strings"
 
@@ -29,6 +31,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -446,6 +450,187 @@ var _ = Describe("rollout controller", func() {
 			})).Should(Succeed())
 		})
 
+		It("analysis - rollback", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.2"]}]}}`))
+			}))
+			defer server.Close()
+
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(appsv1alpha1.RolloutStrategy{
+						Replace: &appsv1alpha1.RolloutStrategyReplace{
+							PromoteCondition: &appsv1alpha1.RolloutPromoteCondition{
+								Analysis: &appsv1alpha1.RolloutAnalysis{
+									Metrics: []appsv1alpha1.RolloutAnalysisMetric{
+										{
+											Name: "error-rate",
+											Prometheus: &appsv1alpha1.RolloutPrometheusMetric{
+												Address: server.URL,
+												Query:   "error_rate",
+											},
+											SuccessCondition: "result.all(x, x < 0.1)",
+										},
+									},
+								},
+							},
+						},
+					}).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("creating the new pod")
+			mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to analyze
+
+			By("checking the rollout state as error")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.ErrorRolloutState))
+				g.Expect(rollout.Status.Components).Should(HaveLen(1))
+				g.Expect(rollout.Status.Components[0].Analysis).ShouldNot(BeNil())
+				g.Expect(rollout.Status.Components[0].Analysis.Phase).Should(Equal(appsv1alpha1.FailedRolloutAnalysisPhase))
+				g.Expect(rollout.Status.Components[0].Analysis.Failures).Should(Equal(int32(1)))
+				g.Expect(rollout.Status.Components[0].ScaleDownInstances).Should(BeEmpty())
+				g.Expect(rollout.Status.Components[0].RolledBackInstances).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("checking the new instances are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(spec.OfflineInstances).Should(BeEmpty())
+				g.Expect(spec.Instances[0].ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(0)))
+			})).Should(Succeed())
+		})
+
+		It("analysis - create strategy", func() {
+			By("creating a rollout with the analysis for the create strategy")
+			obj := testapps.NewRolloutFactory(testCtx.DefaultNamespace, rolloutName).
+				WithRandomName().
+				SetClusterName(clusterKey.Name).
+				AddComponent(compName).
+				SetServiceVersion(serviceVersion2).
+				SetStrategy(appsv1alpha1.RolloutStrategy{
+					Create: &appsv1alpha1.RolloutStrategyCreate{
+						Promotion: &appsv1alpha1.RolloutPromotion{
+							Auto: ptr.To(true),
+							Condition: &appsv1alpha1.RolloutPromoteCondition{
+								Analysis: &appsv1alpha1.RolloutAnalysis{
+									Metrics: []appsv1alpha1.RolloutAnalysisMetric{
+										{Name: "role", Probe: ptr.To("roleProbe"), SuccessCondition: "true"},
+									},
+								},
+							},
+						},
+					},
+				}).
+				GetObject()
+			err := testCtx.Create(testCtx.Ctx, obj)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("the analysis is not supported by the create strategy"))
+		})
+
+		It("paused", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(defaultReplaceStrategy).
+					SetReplicas(replicas).
+					SetPaused(true)
+			})
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as paused")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.PausedRolloutState))
+			})).Should(Succeed())
+
+			By("checking the cluster spec not changed")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.Instances).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("resuming the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Paused = false
+			})()).Should(Succeed())
+
+			By("checking the cluster spec been updated")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas + 1))
+				g.Expect(spec.Instances).Should(HaveLen(1))
+			})).Should(Succeed())
+		})
+
+		It("abort", func() {
+			createRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetServiceVersion(serviceVersion2).
+					SetStrategy(defaultReplaceStrategy).
+					SetReplicas(replicas)
+			})
+
+			By("creating pods for the component")
+			pods := mockCreatePods([]int32{0, 1, 2}, "")
+
+			mockClusterNCompRunning() // to up
+
+			By("creating the new pod")
+			newPods := mockCreatePods([]int32{10}, string(rolloutObj.UID[:8]))
+
+			mockClusterNCompRunning() // to down
+
+			By("checking the cluster spec after scale down")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.OfflineInstances).Should(HaveLen(1))
+				g.Expect(spec.OfflineInstances[0]).Should(Equal(pods[0].Name))
+			})).Should(Succeed())
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = true
+			})()).Should(Succeed())
+
+			By("checking the new instances are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				spec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(spec.Replicas).Should(Equal(replicas))
+				g.Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(spec.Instances[0].ServiceVersion).Should(Equal(serviceVersion1))
+				g.Expect(*spec.Instances[0].Replicas).Should(Equal(int32(1)))
+			})).Should(Succeed())
+
+			mockClusterNCompRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+				g.Expect(rollout.Status.Components[0].RolledBackInstances).Should(Equal([]string{newPods[0].Name}))
+			})).Should(Succeed())
+
+			By("checking the scaled down instances are kept offline")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].OfflineInstances).Should(Equal([]string{pods[0].Name}))
+			})).Should(Succeed())
+
+			By("checking the abort can not be reverted")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = false
+			})()).ShouldNot(Succeed())
+		})
+
 		It("tear down", func() {
 			createRolloutObj(func(f *testapps.MockRolloutFactory) {
 				f.SetServiceVersion(serviceVersion2).
@@ -504,6 +689,176 @@ var _ = Describe("rollout controller", func() {
 		})
 	})
 
+	Context("sharding", func() {
+		const (
+			shardingName = "shard"
+			shards       = 3
+		)
+
+		var (
+			shardKeys []client.ObjectKey
+		)
+
+		BeforeEach(func() {
+			By("creating a cluster object with sharding")
+			clusterObj = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+				WithRandomName().
+				AddSharding(shardingName, "", compDefName).
+				SetShards(shards).
+				Create(&testCtx).
+				GetObject()
+			clusterKey = client.ObjectKeyFromObject(clusterObj)
+
+			By("creating the shard objects")
+			shardKeys = make([]client.ObjectKey, 0)
+			for i := 0; i < shards; i++ {
+				shardObjName := constant.GenerateClusterComponentName(clusterKey.Name, fmt.Sprintf("%s-%d", shardingName, i))
+				shardObj := testapps.NewComponentFactory(testCtx.DefaultNamespace, shardObjName, compDefName).
+					AddLabels(constant.AppInstanceLabelKey, clusterKey.Name).
+					AddLabels(constant.KBAppShardingNameLabelKey, shardingName).
+					SetReplicas(replicas).
+					Create(&testCtx).
+					GetObject()
+				shardKeys = append(shardKeys, client.ObjectKeyFromObject(shardObj))
+			}
+		})
+
+		mockClusterNShardsRunning := func() {
+			By("mock cluster & shards as running")
+			for _, shardKey := range shardKeys {
+				Expect(testapps.GetAndChangeObjStatus(&testCtx, shardKey, func(comp *appsv1.Component) {
+					comp.Status.ObservedGeneration = comp.Generation
+					comp.Status.Phase = appsv1.RunningComponentPhase
+				})()).Should(Succeed())
+			}
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, clusterKey, func(cluster *appsv1.Cluster) {
+				cluster.Status.ObservedGeneration = cluster.Generation
+			})()).Should(Succeed())
+		}
+
+		createShardingRolloutObj := func(processor func(*testapps.MockRolloutFactory)) {
+			By("creating a rollout object for sharding")
+			f := testapps.NewRolloutFactory(testCtx.DefaultNamespace, rolloutName).
+				WithRandomName().
+				SetClusterName(clusterKey.Name).
+				AddSharding(shardingName).
+				SetShardingServiceVersion(serviceVersion2)
+			if processor != nil {
+				processor(f)
+			}
+			rolloutObj = f.Create(&testCtx).GetObject()
+			rolloutKey = client.ObjectKeyFromObject(rolloutObj)
+		}
+
+		checkRollingShards := func(n int) {
+			By(fmt.Sprintf("checking the %d shards been rolled out", n))
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				tpls := cluster.Spec.Shardings[0].ShardTemplates
+				g.Expect(tpls).Should(HaveLen(1))
+				g.Expect(tpls[0].ServiceVersion).Should(Equal(serviceVersion2))
+				g.Expect(tpls[0].Shards).Should(HaveLen(n))
+				for i := 0; i < n; i++ {
+					g.Expect(tpls[0].Shards[i]).Should(Equal(shardKeys[i].Name))
+				}
+			})).Should(Succeed())
+		}
+
+		It("shard by shard", func() {
+			createShardingRolloutObj(nil)
+
+			By("checking the status of sharding")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.Shardings).Should(HaveLen(1))
+				g.Expect(rollout.Status.Shardings[0].Name).Should(Equal(shardingName))
+			})).Should(Succeed())
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(1)
+
+			By("checking the rollout state as rolling")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.RollingRolloutState))
+				g.Expect(rollout.Status.Shardings[0].RollingShards).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			By("checking the next shard is not rolled out until the shard in flight is running")
+			Consistently(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.Shardings[0].ShardTemplates[0].Shards).Should(HaveLen(1))
+			})).Should(Succeed())
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(2)
+		})
+
+		It("max concurrent shards", func() {
+			createShardingRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetMaxConcurrentShards(intstr.FromString("50%"))
+			})
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(2)
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(3)
+		})
+
+		It("succeed", func() {
+			createShardingRolloutObj(func(f *testapps.MockRolloutFactory) {
+				f.SetMaxConcurrentShards(intstr.FromInt32(shards))
+			})
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(shards)
+
+			mockClusterNShardsRunning()
+
+			By("checking the template of sharding been updated")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.Shardings[0].ShardTemplates).Should(BeEmpty())
+				g.Expect(cluster.Spec.Shardings[0].Template.ServiceVersion).Should(Equal(serviceVersion2))
+			})).Should(Succeed())
+
+			mockClusterNShardsRunning()
+
+			By("checking the rollout state as succeed")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.SucceedRolloutState))
+				g.Expect(rollout.Status.Shardings[0].RolledOutShards).Should(HaveLen(shards))
+			})).Should(Succeed())
+		})
+
+		It("abort", func() {
+			createShardingRolloutObj(nil)
+
+			mockClusterNShardsRunning()
+
+			checkRollingShards(1)
+
+			By("aborting the rollout")
+			Expect(testapps.GetAndChangeObj(&testCtx, rolloutKey, func(rollout *appsv1alpha1.Rollout) {
+				rollout.Spec.Abort = true
+			})()).Should(Succeed())
+
+			By("checking the shard template been removed")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.Shardings[0].ShardTemplates).Should(BeEmpty())
+				g.Expect(cluster.Spec.Shardings[0].Template.ServiceVersion).ShouldNot(Equal(serviceVersion2))
+			})).Should(Succeed())
+
+			mockClusterNShardsRunning()
+
+			By("checking the rollout state as aborted")
+			Eventually(testapps.CheckObj(&testCtx, rolloutKey, func(g Gomega, rollout *appsv1alpha1.Rollout) {
+				g.Expect(rollout.Status.State).Should(Equal(appsv1alpha1.AbortedRolloutState))
+			})).Should(Succeed())
+		})
+	})
+
 	// Context("create", func() {
 	//	It("auto promotion", func() {
 	//	})
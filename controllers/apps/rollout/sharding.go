@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// rolloutShardingStatus returns the status of the sharding, or nil if it is not initialized.
+func rolloutShardingStatus(rollout *appsv1alpha1.Rollout, shardingName string) *appsv1alpha1.RolloutShardingStatus {
+	for i, status := range rollout.Status.Shardings {
+		if status.Name == shardingName {
+			return &rollout.Status.Shardings[i]
+		}
+	}
+	return nil
+}
+
+// rolloutShardTemplate returns the shard template of the rollout, which is used to override the shards rolled out.
+func rolloutShardTemplate(rollout *appsv1alpha1.Rollout, spec *appsv1.ClusterSharding) *appsv1.ShardTemplate {
+	tplName := string(rollout.UID[:8])
+	for i, tpl := range spec.ShardTemplates {
+		if tpl.Name == tplName {
+			return &spec.ShardTemplates[i]
+		}
+	}
+	return nil
+}
+
+// shardingServiceVersionNCompDef obtains the target service version and component definition of the sharding.
+func shardingServiceVersionNCompDef(transCtx *rolloutTransformContext,
+	sharding appsv1alpha1.RolloutSharding, spec *appsv1.ClusterSharding) (string, string) {
+	serviceVersion, compDef := spec.Template.ServiceVersion, spec.Template.ComponentDef
+	if sharding.ServiceVersion != nil {
+		serviceVersion = *sharding.ServiceVersion
+	}
+	if sharding.ShardingDef != nil {
+		shardingDef, ok := transCtx.ShardingDefs[*sharding.ShardingDef]
+		if ok && len(shardingDef.Spec.Template.CompDef) > 0 {
+			compDef = shardingDef.Spec.Template.CompDef
+		}
+	}
+	return serviceVersion, compDef
+}
+
+// maxConcurrentShards returns the number of shards that can be rolled out at the same time.
+func maxConcurrentShards(sharding appsv1alpha1.RolloutSharding, shards int) (int, error) {
+	if sharding.MaxConcurrentShards == nil {
+		return 1, nil
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(sharding.MaxConcurrentShards, shards, true)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get scaled value for max concurrent shards of sharding %s", sharding.Name)
+	}
+	return max(n, 1), nil
+}
+
+// pendingShards returns the shards that have not been rolled out yet.
+func pendingShards(transCtx *rolloutTransformContext, status *appsv1alpha1.RolloutShardingStatus) []*appsv1.Component {
+	pending := make([]*appsv1.Component, 0)
+	for _, comp := range transCtx.ShardingComps[status.Name] {
+		if !slices.Contains(status.RollingShards, comp.Name) {
+			pending = append(pending, comp)
+		}
+	}
+	return pending
+}
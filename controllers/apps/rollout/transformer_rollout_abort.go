@@ -0,0 +1,210 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// rolloutAbortTransformer reverts the instances that have been rolled out when the rollout is aborted.
+type rolloutAbortTransformer struct{}
+
+var _ graph.Transformer = &rolloutAbortTransformer{}
+
+func (t *rolloutAbortTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx := ctx.(*rolloutTransformContext)
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) || !isRolloutAborted(transCtx.Rollout) {
+		return nil
+	}
+	if err := t.abort(transCtx); err != nil {
+		return err
+	}
+	if transCtx.RolloutOrig.Status.State == appsv1alpha1.AbortedRolloutState {
+		t.tearDown(transCtx)
+	}
+	return nil
+}
+
+func (t *rolloutAbortTransformer) abort(transCtx *rolloutTransformContext) error {
+	if err := t.components(transCtx); err != nil {
+		return err
+	}
+	t.shardings(transCtx)
+	return nil
+}
+
+// tearDown cleans up the rollout after the instances have been reverted and the cluster is running,
+// the same as the teardown of a succeeded rollout.
+func (t *rolloutAbortTransformer) tearDown(transCtx *rolloutTransformContext) {
+	rollout := transCtx.Rollout
+	tplName := string(rollout.UID[:8])
+	for _, comp := range rollout.Spec.Components {
+		if comp.Strategy.Replace == nil {
+			continue
+		}
+		spec := transCtx.ClusterComps[comp.Name]
+		idx := slices.IndexFunc(spec.Instances, func(tpl appsv1.InstanceTemplate) bool {
+			return tpl.Name == tplName
+		})
+		// the ordinals of the scaled down instances may be reclaimed by the remaining old instances,
+		// so they are kept offline until all the instances have been replaced
+		if idx >= 0 && ptr.Deref(spec.Instances[idx].Replicas, 0) == spec.Replicas {
+			clearScaleDownInstances(rollout, comp, spec)
+		}
+	}
+}
+
+func (t *rolloutAbortTransformer) components(transCtx *rolloutTransformContext) error {
+	rollout := transCtx.Rollout
+	for _, comp := range rollout.Spec.Components {
+		var err error
+		switch {
+		case comp.Strategy.Inplace != nil:
+			err = t.inplace(transCtx, rollout, comp)
+		case comp.Strategy.Replace != nil:
+			err = rollbackReplaceInstances(transCtx, rollout, comp)
+		case comp.Strategy.Create != nil:
+			err = t.create(transCtx, rollout, comp)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *rolloutAbortTransformer) inplace(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
+	spec := transCtx.ClusterComps[comp.Name]
+	status := rolloutCompStatus(rollout, comp.Name)
+	if status == nil {
+		return nil // not started yet
+	}
+	if spec.ServiceVersion == status.ServiceVersion && spec.ComponentDef == status.CompDef {
+		return nil // not rolled out yet, or has been reverted
+	}
+	spec.ServiceVersion = status.ServiceVersion
+	spec.ComponentDef = status.CompDef
+	return recordRolledBackInstances(transCtx, rollout, comp, "")
+}
+
+// create tears down the canary instances.
+func (t *rolloutAbortTransformer) create(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
+	spec := transCtx.ClusterComps[comp.Name]
+	tplName := string(rollout.UID[:8])
+	idx := slices.IndexFunc(spec.Instances, func(tpl appsv1.InstanceTemplate) bool {
+		return tpl.Name == tplName
+	})
+	if idx < 0 {
+		return nil
+	}
+	if err := recordRolledBackInstances(transCtx, rollout, comp, tplName); err != nil {
+		return err
+	}
+	spec.Replicas -= ptr.Deref(spec.Instances[idx].Replicas, 0)
+	spec.Instances = slices.Delete(spec.Instances, idx, idx+1)
+	return nil
+}
+
+// shardings reverts the shards rolled out to the template of the sharding, by removing the shard template of the rollout.
+func (t *rolloutAbortTransformer) shardings(transCtx *rolloutTransformContext) {
+	tplName := string(transCtx.Rollout.UID[:8])
+	for _, sharding := range transCtx.Rollout.Spec.Shardings {
+		spec := transCtx.ClusterShardings[sharding.Name]
+		if spec == nil {
+			continue
+		}
+		spec.ShardTemplates = slices.DeleteFunc(spec.ShardTemplates, func(tpl appsv1.ShardTemplate) bool {
+			return tpl.Name == tplName
+		})
+	}
+}
+
+// rollbackReplaceInstances reverts the new instances to the original service version and component definition,
+// and tears down the new instance which has not replaced an old one yet.
+func rollbackReplaceInstances(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
+	spec := transCtx.ClusterComps[comp.Name]
+	status := rolloutCompStatus(rollout, comp.Name)
+	if status == nil {
+		return nil // not started yet
+	}
+	tplName := string(rollout.UID[:8])
+	idx := slices.IndexFunc(spec.Instances, func(tpl appsv1.InstanceTemplate) bool {
+		return tpl.Name == tplName
+	})
+	if idx < 0 {
+		return nil // not rolled out yet
+	}
+	tpl := &spec.Instances[idx]
+	tpl.ServiceVersion = status.ServiceVersion
+	tpl.CompDef = status.CompDef
+	if spec.Replicas > status.Replicas {
+		surge := min(spec.Replicas-status.Replicas, ptr.Deref(tpl.Replicas, 0))
+		spec.Replicas -= surge
+		tpl.Replicas = ptr.To(ptr.Deref(tpl.Replicas, 0) - surge)
+	}
+	return recordRolledBackInstances(transCtx, rollout, comp, tplName)
+}
+
+// recordRolledBackInstances records the instances of the component, or of the instance template if specified,
+// as rolled back in the status.
+func recordRolledBackInstances(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, tplName string) error {
+	labels := constant.GetCompLabels(rollout.Spec.ClusterName, comp.Name)
+	if len(tplName) > 0 {
+		labels[constant.KBAppInstanceTemplateLabelKey] = tplName
+	}
+	pods := &corev1.PodList{}
+	if err := transCtx.Client.List(transCtx.Context, pods, client.InNamespace(rollout.Namespace), client.MatchingLabels(labels)); err != nil {
+		return err
+	}
+	status := rolloutCompStatus(rollout, comp.Name)
+	if status == nil {
+		return fmt.Errorf("the status of component %s is not initialized", comp.Name)
+	}
+	for _, pod := range pods.Items {
+		if !slices.Contains(status.RolledBackInstances, pod.Name) {
+			status.RolledBackInstances = append(status.RolledBackInstances, pod.Name)
+		}
+	}
+	slices.Sort(status.RolledBackInstances)
+	return nil
+}
+
+func isRolloutAborted(rollout *appsv1alpha1.Rollout) bool {
+	return rollout.Spec.Abort
+}
+
+// isRolloutFrozen checks whether the rollout should not roll out more instances, since it is paused or aborted.
+func isRolloutFrozen(rollout *appsv1alpha1.Rollout) bool {
+	return rollout.Spec.Paused || rollout.Spec.Abort
+}
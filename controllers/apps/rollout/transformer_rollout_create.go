@@ -39,7 +39,7 @@ var _ graph.Transformer = &rolloutCreateTransformer{}
 
 func (t *rolloutCreateTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) || isRolloutFrozen(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
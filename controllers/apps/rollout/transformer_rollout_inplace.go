@@ -21,6 +21,7 @@ package rollout
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -38,7 +39,7 @@ var _ graph.Transformer = &rolloutInplaceTransformer{}
 
 func (t *rolloutInplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) || isRolloutFrozen(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -48,8 +49,7 @@ func (t *rolloutInplaceTransformer) rollout(transCtx *rolloutTransformContext) e
 	if err := t.components(transCtx); err != nil {
 		return err
 	}
-	// TODO: sharding
-	return nil
+	return t.shardings(transCtx)
 }
 
 func (t *rolloutInplaceTransformer) components(transCtx *rolloutTransformContext) error {
@@ -97,6 +97,69 @@ func (t *rolloutInplaceTransformer) component(transCtx *rolloutTransformContext,
 	return nil
 }
 
+func (t *rolloutInplaceTransformer) shardings(transCtx *rolloutTransformContext) error {
+	for _, sharding := range transCtx.Rollout.Spec.Shardings {
+		if err := t.sharding(transCtx, sharding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sharding rolls out the next shards in-place, after the shards in flight have been rolled out successfully.
+func (t *rolloutInplaceTransformer) sharding(transCtx *rolloutTransformContext, sharding appsv1alpha1.RolloutSharding) error {
+	rollout := transCtx.Rollout
+	spec := transCtx.ClusterShardings[sharding.Name]
+	status := rolloutShardingStatus(rollout, sharding.Name)
+	if status == nil {
+		return fmt.Errorf("the status of sharding %s is not initialized", sharding.Name)
+	}
+
+	pending := pendingShards(transCtx, status)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// the health gate between shards: all the shards, including the ones in flight, should be running
+	if len(status.RolledOutShards) < len(status.RollingShards) {
+		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration,
+			fmt.Sprintf("the shards of sharding %s in flight are not ready", sharding.Name))
+	}
+	for _, comp := range transCtx.ShardingComps[sharding.Name] {
+		if !checkClusterNShardRunning(transCtx, comp) {
+			return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration,
+				fmt.Sprintf("the shard %s of sharding %s is not ready", comp.Name, sharding.Name))
+		}
+	}
+	if sharding.PerShardIntervalSeconds != nil && !status.LastRolledOutTimestamp.IsZero() {
+		interval := time.Duration(*sharding.PerShardIntervalSeconds) * time.Second
+		if remaining := time.Until(status.LastRolledOutTimestamp.Add(interval)); remaining > 0 {
+			return controllerutil.NewDelayedRequeueError(remaining,
+				fmt.Sprintf("wait %s to roll out the next shards of sharding %s", remaining, sharding.Name))
+		}
+	}
+
+	n, err := maxConcurrentShards(sharding, len(transCtx.ShardingComps[sharding.Name]))
+	if err != nil {
+		return err
+	}
+	tpl := rolloutShardTemplate(rollout, spec)
+	if tpl == nil {
+		serviceVersion, compDef := shardingServiceVersionNCompDef(transCtx, sharding, spec)
+		spec.ShardTemplates = append(spec.ShardTemplates, appsv1.ShardTemplate{
+			Name:           string(rollout.UID[:8]),
+			ServiceVersion: serviceVersion,
+			CompDef:        compDef,
+		})
+		tpl = &spec.ShardTemplates[len(spec.ShardTemplates)-1]
+	}
+	for _, comp := range pending[:min(n, len(pending))] {
+		tpl.Shards = append(tpl.Shards, comp.Name)
+		status.RollingShards = append(status.RollingShards, comp.Name)
+	}
+	return nil
+}
+
 // serviceVersionNCompDef obtains the original service version and component definition.
 func serviceVersionNCompDef(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) (string, string) {
 	serviceVer, compDef := spec.ServiceVersion, spec.ComponentDef
@@ -21,6 +21,8 @@ package rollout
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -31,6 +33,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 const (
@@ -61,6 +64,14 @@ func (t *rolloutLoadTransformer) Transform(ctx graph.TransformContext, dag *grap
 	if err != nil {
 		return err
 	}
+	transCtx.ShardingComps, err = t.getNCheckShardingComponents(transCtx.Context, transCtx.Client, transCtx.Cluster, rollout)
+	if err != nil {
+		return err
+	}
+	transCtx.ShardingDefs, err = t.getNCheckShardingDefinitions(transCtx.Context, transCtx.Client, rollout)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -117,6 +128,48 @@ func (t *rolloutLoadTransformer) getNCheckComponent(ctx context.Context, cli cli
 	return comp, nil
 }
 
+func (t *rolloutLoadTransformer) getNCheckShardingComponents(ctx context.Context, cli client.Reader,
+	cluster *appsv1.Cluster, rollout *appsv1alpha1.Rollout) (map[string][]*appsv1.Component, error) {
+	if len(rollout.Spec.Shardings) == 0 {
+		return nil, nil
+	}
+	shardingComps := make(map[string][]*appsv1.Component)
+	for _, sharding := range rollout.Spec.Shardings {
+		comps, err := controllerutil.ListShardingComponents(ctx, cli, cluster, sharding.Name)
+		if err != nil {
+			return nil, err
+		}
+		objs := make([]*appsv1.Component, 0)
+		for i := range comps {
+			if comps[i].GetDeletionTimestamp().IsZero() {
+				objs = append(objs, &comps[i])
+			}
+		}
+		// the shards are rolled out in the order of their names
+		slices.SortFunc(objs, func(a, b *appsv1.Component) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		shardingComps[sharding.Name] = objs
+	}
+	return shardingComps, nil
+}
+
+func (t *rolloutLoadTransformer) getNCheckShardingDefinitions(ctx context.Context, cli client.Reader,
+	rollout *appsv1alpha1.Rollout) (map[string]*appsv1.ShardingDefinition, error) {
+	shardingDefs := make(map[string]*appsv1.ShardingDefinition)
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.ShardingDef == nil {
+			continue
+		}
+		shardingDef := &appsv1.ShardingDefinition{}
+		if err := cli.Get(ctx, types.NamespacedName{Name: *sharding.ShardingDef}, shardingDef); err != nil {
+			return nil, err
+		}
+		shardingDefs[shardingDef.Name] = shardingDef
+	}
+	return shardingDefs, nil
+}
+
 func checkClusterNCompRunning(transCtx *rolloutTransformContext, compName string) bool {
 	cluster := transCtx.ClusterOrig
 	compStatus := cluster.Status.Components[compName]
@@ -129,3 +182,12 @@ func checkClusterNCompRunning(transCtx *rolloutTransformContext, compName string
 	}
 	return compObj.Generation == compObj.Status.ObservedGeneration && compObj.Status.Phase == appsv1.RunningComponentPhase
 }
+
+// checkClusterNShardRunning checks whether the cluster has been reconciled, and the shard is running.
+func checkClusterNShardRunning(transCtx *rolloutTransformContext, comp *appsv1.Component) bool {
+	cluster := transCtx.ClusterOrig
+	if cluster.Generation != cluster.Status.ObservedGeneration {
+		return false
+	}
+	return comp.Generation == comp.Status.ObservedGeneration && comp.Status.Phase == appsv1.RunningComponentPhase
+}
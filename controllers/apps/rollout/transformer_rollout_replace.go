@@ -46,7 +46,7 @@ var _ graph.Transformer = &rolloutReplaceTransformer{}
 
 func (t *rolloutReplaceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) || isRolloutFrozen(transCtx.Rollout) {
 		return nil
 	}
 	return t.rollout(transCtx)
@@ -93,6 +93,9 @@ func (t *rolloutReplaceTransformer) rolling(transCtx *rolloutTransformContext,
 	if err != nil {
 		return err
 	}
+	if exist && isAnalysisFailed(rollout, comp.Name) {
+		return t.rollback(transCtx, rollout, comp)
+	}
 	if *tpl.Replicas == replicas && spec.Replicas == replicas {
 		return nil
 	}
@@ -131,6 +134,13 @@ func (t *rolloutReplaceTransformer) down(transCtx *rolloutTransformContext,
 		return err
 	}
 
+	if err := analyzeNewInstances(transCtx, rollout, comp, comp.Strategy.Replace.PromoteCondition, tpl.Name, *tpl.Replicas); err != nil {
+		if isAnalysisFailed(rollout, comp.Name) {
+			return t.rollback(transCtx, rollout, comp)
+		}
+		return err
+	}
+
 	instance, instTpl, err := t.pickInstanceToScaleDown(transCtx, spec, tpl)
 	if err != nil {
 		return err
@@ -160,6 +170,19 @@ func (t *rolloutReplaceTransformer) down(transCtx *rolloutTransformContext,
 	return nil
 }
 
+// rollback rolls back the new instances to the original service version and component definition,
+// and stops to replace the old instances.
+func (t *rolloutReplaceTransformer) rollback(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
+	if err := rollbackReplaceInstances(transCtx, rollout, comp); err != nil {
+		return err
+	}
+	status := rolloutCompStatus(rollout, comp.Name)
+	rollout.Status.Message = fmt.Sprintf("the analysis of component %s failed and the new instances are rolled back: %s",
+		comp.Name, status.Analysis.Message)
+	return nil
+}
+
 func (t *rolloutReplaceTransformer) checkDelaySeconds(rollout *appsv1alpha1.Rollout,
 	comp appsv1alpha1.RolloutComponent, newReplicas int32, scaleDown bool) error {
 	delaySeconds := comp.Strategy.Replace.PerInstanceIntervalSeconds
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/pkg/errors"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -52,6 +54,11 @@ func (t *rolloutSetupTransformer) Transform(ctx graph.TransformContext, dag *gra
 			return err
 		}
 	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if err := t.precheckSharding(sharding); err != nil {
+			return err
+		}
+	}
 
 	// check and add the rollout label to the cluster
 	if err := t.patchClusterLabel(transCtx, dag, rollout); err != nil {
@@ -85,6 +92,29 @@ func (t *rolloutSetupTransformer) precheck(comp appsv1alpha1.RolloutComponent) e
 	if cnt > 1 {
 		return fmt.Errorf("more than one rollout strategy is defined for component %s", comp.Name)
 	}
+
+	if comp.Strategy.Replace != nil && comp.Strategy.Replace.PromoteCondition != nil {
+		cond := comp.Strategy.Replace.PromoteCondition
+		if cond.Prev != nil || cond.Post != nil {
+			return fmt.Errorf("the prev and post conditions are not supported by the replace strategy, component %s", comp.Name)
+		}
+		if cond.Analysis != nil {
+			if err := checkAnalysis(cond.Analysis); err != nil {
+				return errors.Wrapf(err, "component %s", comp.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (t *rolloutSetupTransformer) precheckSharding(sharding appsv1alpha1.RolloutSharding) error {
+	// target serviceVersion & shardingDef
+	if sharding.ServiceVersion == nil && sharding.ShardingDef == nil {
+		return fmt.Errorf("neither serviceVersion nor shardingDef is defined for sharding %s", sharding.Name)
+	}
+	if _, err := maxConcurrentShards(sharding, 1); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -123,6 +153,11 @@ func (t *rolloutSetupTransformer) initRolloutStatus(transCtx *rolloutTransformCo
 			return err
 		}
 	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if err := t.initShardingStatus(transCtx, rollout, sharding); err != nil {
+			return err
+		}
+	}
 	if !reflect.DeepEqual(transCtx.RolloutOrig.Status, rollout.Status) {
 		graphCli.Status(dag, transCtx.RolloutOrig, rollout)
 		return graph.ErrPrematureStop
@@ -149,3 +184,22 @@ func (t *rolloutSetupTransformer) initCompStatus(transCtx *rolloutTransformConte
 	})
 	return nil
 }
+
+func (t *rolloutSetupTransformer) initShardingStatus(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) error {
+	spec := transCtx.ClusterShardings[sharding.Name]
+	if spec == nil {
+		return fmt.Errorf("the sharding %s is not found in cluster", sharding.Name)
+	}
+	if rolloutShardingStatus(rollout, sharding.Name) != nil {
+		return nil // has been initialized
+	}
+	rollout.Status.Shardings = append(rollout.Status.Shardings, appsv1alpha1.RolloutShardingStatus{
+		Name:           sharding.Name,
+		ServiceVersion: spec.Template.ServiceVersion,
+		ShardingDef:    spec.ShardingDef,
+		CompDef:        spec.Template.ComponentDef,
+		Shards:         spec.Shards,
+	})
+	return nil
+}
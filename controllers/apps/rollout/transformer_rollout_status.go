@@ -20,6 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package rollout
 
 import (
+	"reflect"
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,11 +46,19 @@ func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	}
 
 	rollout := transCtx.Rollout
-	state, err := t.components(transCtx, rollout)
+	compStates, err := t.components(transCtx, rollout)
 	if err != nil {
 		return err
 	}
-	// TODO: sharding
+	shardingStates := t.shardings(transCtx, rollout)
+	state := t.rolloutState(append(compStates, shardingStates...))
+
+	switch {
+	case isRolloutAborted(rollout):
+		state = t.abortState(transCtx, rollout)
+	case rollout.Spec.Paused && state != appsv1alpha1.SucceedRolloutState:
+		state = appsv1alpha1.PausedRolloutState
+	}
 
 	rollout.Status.ObservedGeneration = rollout.Generation
 	rollout.Status.State = state
@@ -57,16 +68,19 @@ func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	return nil
 }
 
-func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) (appsv1alpha1.RolloutState, error) {
+func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) ([]appsv1alpha1.RolloutState, error) {
 	states := make([]appsv1alpha1.RolloutState, 0)
 	for _, comp := range rollout.Spec.Components {
 		state, err := t.component(transCtx, rollout, comp)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		states = append(states, state)
 	}
+	return states, nil
+}
 
+func (t *rolloutStatusTransformer) rolloutState(states []appsv1alpha1.RolloutState) appsv1alpha1.RolloutState {
 	var (
 		hasError   = false
 		hasRolling = false
@@ -93,17 +107,17 @@ func (t *rolloutStatusTransformer) components(transCtx *rolloutTransformContext,
 	}
 	switch {
 	case hasError:
-		return appsv1alpha1.ErrorRolloutState, nil
+		return appsv1alpha1.ErrorRolloutState
 	case hasRolling:
-		return appsv1alpha1.RollingRolloutState, nil
+		return appsv1alpha1.RollingRolloutState
 	case allSucceed:
-		return appsv1alpha1.SucceedRolloutState, nil
+		return appsv1alpha1.SucceedRolloutState
 	case hasSucceed:
-		return appsv1alpha1.RollingRolloutState, nil
+		return appsv1alpha1.RollingRolloutState
 	case hasPending:
-		return appsv1alpha1.PendingRolloutState, nil
+		return appsv1alpha1.PendingRolloutState
 	default:
-		return "", nil
+		return ""
 	}
 }
 
@@ -147,6 +161,9 @@ func (t *rolloutStatusTransformer) replace(transCtx *rolloutTransformContext,
 	if rollingTpl == nil {
 		return appsv1alpha1.PendingRolloutState, nil
 	}
+	if isAnalysisFailed(rollout, comp.Name) {
+		return appsv1alpha1.ErrorRolloutState, nil
+	}
 
 	pods := &corev1.PodList{}
 	listOpts := []client.ListOption{
@@ -199,6 +216,79 @@ func (t *rolloutStatusTransformer) create(transCtx *rolloutTransformContext,
 	return "", createStrategyNotSupportedError
 }
 
+func (t *rolloutStatusTransformer) shardings(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) []appsv1alpha1.RolloutState {
+	states := make([]appsv1alpha1.RolloutState, 0)
+	for _, sharding := range rollout.Spec.Shardings {
+		states = append(states, t.sharding(transCtx, rollout, sharding))
+	}
+	return states
+}
+
+func (t *rolloutStatusTransformer) sharding(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) appsv1alpha1.RolloutState {
+	status := rolloutShardingStatus(rollout, sharding.Name)
+	if status == nil {
+		return appsv1alpha1.PendingRolloutState
+	}
+
+	// use the original cluster spec, the shards just put into the shard template are not applied yet
+	spec := t.shardingSpec(transCtx, sharding.Name)
+	if spec == nil {
+		return appsv1alpha1.PendingRolloutState
+	}
+	tpl := rolloutShardTemplate(rollout, spec)
+
+	comps := transCtx.ShardingComps[sharding.Name]
+	status.Shards = int32(len(comps))
+	for _, name := range status.RollingShards {
+		if slices.Contains(status.RolledOutShards, name) || tpl == nil || !slices.Contains(tpl.Shards, name) {
+			continue
+		}
+		idx := slices.IndexFunc(comps, func(comp *appsv1.Component) bool {
+			return comp.Name == name
+		})
+		// the shard has been deleted, or has been rolled out successfully
+		if idx < 0 || checkClusterNShardRunning(transCtx, comps[idx]) {
+			status.RolledOutShards = append(status.RolledOutShards, name)
+			status.LastRolledOutTimestamp = metav1.Now()
+		}
+	}
+
+	if len(status.RollingShards) == 0 {
+		return appsv1alpha1.PendingRolloutState
+	}
+	// the shard template is removed after all the shards have been rolled out
+	if tpl != nil || len(pendingShards(transCtx, status)) > 0 {
+		return appsv1alpha1.RollingRolloutState
+	}
+	for _, comp := range comps {
+		if !checkClusterNShardRunning(transCtx, comp) {
+			return appsv1alpha1.RollingRolloutState
+		}
+	}
+	return appsv1alpha1.SucceedRolloutState
+}
+
+// abortState checks whether the instances have been reverted, and the cluster and components are running.
+func (t *rolloutStatusTransformer) abortState(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) appsv1alpha1.RolloutState {
+	if !reflect.DeepEqual(transCtx.ClusterOrig.Spec, transCtx.Cluster.Spec) {
+		return appsv1alpha1.AbortingRolloutState
+	}
+	for _, comp := range rollout.Spec.Components {
+		if !checkClusterNCompRunning(transCtx, comp.Name) {
+			return appsv1alpha1.AbortingRolloutState
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		for _, comp := range transCtx.ShardingComps[sharding.Name] {
+			if !checkClusterNShardRunning(transCtx, comp) {
+				return appsv1alpha1.AbortingRolloutState
+			}
+		}
+	}
+	return appsv1alpha1.AbortedRolloutState
+}
+
 func (t *rolloutStatusTransformer) compSpec(transCtx *rolloutTransformContext, compName string) *appsv1.ClusterComponentSpec {
 	// use the original cluster spec
 	cluster := transCtx.ClusterOrig
@@ -210,6 +300,17 @@ func (t *rolloutStatusTransformer) compSpec(transCtx *rolloutTransformContext, c
 	return nil
 }
 
+func (t *rolloutStatusTransformer) shardingSpec(transCtx *rolloutTransformContext, shardingName string) *appsv1.ClusterSharding {
+	// use the original cluster spec
+	cluster := transCtx.ClusterOrig
+	for i, sharding := range cluster.Spec.Shardings {
+		if sharding.Name == shardingName {
+			return &cluster.Spec.Shardings[i]
+		}
+	}
+	return nil
+}
+
 func isRolloutSucceed(rollout *appsv1alpha1.Rollout) bool {
 	return rollout.Status.State == appsv1alpha1.SucceedRolloutState
 }
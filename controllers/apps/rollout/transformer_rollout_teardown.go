@@ -22,6 +22,7 @@ package rollout
 import (
 	"slices"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -33,7 +34,7 @@ var _ graph.Transformer = &rolloutTearDownTransformer{}
 
 func (t *rolloutTearDownTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
 	transCtx := ctx.(*rolloutTransformContext)
-	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) || isRolloutFrozen(transCtx.Rollout) {
 		return nil
 	}
 	return t.tearDown(transCtx)
@@ -43,8 +44,7 @@ func (t *rolloutTearDownTransformer) tearDown(transCtx *rolloutTransformContext)
 	if err := t.components(transCtx); err != nil {
 		return err
 	}
-	// TODO: sharding
-	return nil
+	return t.shardings(transCtx)
 }
 
 func (t *rolloutTearDownTransformer) components(transCtx *rolloutTransformContext) error {
@@ -88,20 +88,63 @@ func (t *rolloutTearDownTransformer) replace(transCtx *rolloutTransformContext,
 	if *tpl.Replicas == replicas && spec.Replicas == replicas && checkClusterNCompRunning(transCtx, comp.Name) {
 		spec.ServiceVersion = tpl.ServiceVersion
 		spec.ComponentDef = tpl.CompDef
-		spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
-			for _, status := range rollout.Status.Components {
-				if status.Name == comp.Name {
-					return slices.Contains(status.ScaleDownInstances, instance)
-				}
-			}
-			return false
-		})
+		clearScaleDownInstances(rollout, comp, spec)
 	}
 	return nil
 }
 
+// clearScaleDownInstances removes the old instances scaled down by the rollout from the offline instances of the component.
+func clearScaleDownInstances(rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent, spec *appsv1.ClusterComponentSpec) {
+	status := rolloutCompStatus(rollout, comp.Name)
+	if status == nil {
+		return
+	}
+	spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+		return slices.Contains(status.ScaleDownInstances, instance)
+	})
+}
+
 func (t *rolloutTearDownTransformer) create(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) error {
 	// TODO: impl
 	return createStrategyNotSupportedError
 }
+
+func (t *rolloutTearDownTransformer) shardings(transCtx *rolloutTransformContext) error {
+	rollout := transCtx.Rollout
+	for _, sharding := range rollout.Spec.Shardings {
+		if err := t.sharding(transCtx, rollout, sharding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sharding updates the template of the sharding and removes the shard template of the rollout,
+// after all the shards have been rolled out successfully.
+func (t *rolloutTearDownTransformer) sharding(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) error {
+	spec := transCtx.ClusterShardings[sharding.Name]
+	tpl := rolloutShardTemplate(rollout, spec)
+	status := rolloutShardingStatus(rollout, sharding.Name)
+	if tpl == nil || status == nil {
+		return nil
+	}
+	if len(pendingShards(transCtx, status)) > 0 || len(status.RolledOutShards) < len(status.RollingShards) {
+		return nil
+	}
+	for _, comp := range transCtx.ShardingComps[sharding.Name] {
+		if !checkClusterNShardRunning(transCtx, comp) {
+			return nil
+		}
+	}
+	if sharding.ShardingDef != nil {
+		spec.ShardingDef = *sharding.ShardingDef
+	}
+	spec.Template.ServiceVersion = tpl.ServiceVersion
+	spec.Template.ComponentDef = tpl.CompDef
+	spec.ShardTemplates = slices.DeleteFunc(spec.ShardTemplates, func(shardTpl appsv1.ShardTemplate) bool {
+		return shardTpl.Name == string(rollout.UID[:8])
+	})
+	return nil
+}
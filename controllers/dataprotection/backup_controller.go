@@ -54,6 +54,7 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
@@ -227,6 +228,16 @@ func (r *BackupReconciler) deleteBackupFiles(reqCtx intctrlutil.RequestCtx, back
 	}
 	deleter.WorkerServiceAccount = saName
 
+	// delete the replicas of the backup in other backup repositories first
+	if deleted, err := r.deleteBackupReplicas(reqCtx, deleter, backup); err != nil || !deleted {
+		return err
+	}
+
+	// the backup files have been deleted by the gc when the backup expired
+	if backup.Status.PrimaryDeletionTimestamp != nil {
+		return deleteBackup()
+	}
+
 	status, err := deleter.DeleteBackupFiles(backup)
 	switch status {
 	case dpbackup.DeletionStatusSucceeded:
@@ -248,6 +259,38 @@ func (r *BackupReconciler) deleteBackupFiles(reqCtx intctrlutil.RequestCtx, back
 	return err
 }
 
+// deleteBackupReplicas deletes the replicas of the backup replicated by the BackupReplicationPolicies,
+// and returns true if all the replicas have been deleted.
+func (r *BackupReconciler) deleteBackupReplicas(reqCtx intctrlutil.RequestCtx,
+	deleter *dpbackup.Deleter, backup *dpv1alpha1.Backup) (bool, error) {
+	deleted := true
+	for i := range backup.Status.Replicas {
+		replica := &backup.Status.Replicas[i]
+		if replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted {
+			continue
+		}
+		status, err := deleter.DeleteReplicaFiles(backup, replica)
+		switch status {
+		case dpbackup.DeletionStatusSucceeded:
+			continue
+		case dpbackup.DeletionStatusFailed:
+			failureReason := err.Error()
+			if backup.Status.FailureReason == failureReason {
+				return false, nil
+			}
+			backupPatch := client.MergeFrom(backup.DeepCopy())
+			backup.Status.FailureReason = failureReason
+			r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteBackupReplicaFailed", failureReason)
+			return false, r.Status().Patch(reqCtx.Ctx, backup, backupPatch)
+		case dpbackup.DeletionStatusUnknown:
+			return false, err
+		}
+		// wait for the deletion jobs completed
+		deleted = false
+	}
+	return deleted, nil
+}
+
 // handleDeletingPhase handles the deletion of backup. It will delete the backup CR
 // and the backup workload(job).
 func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (ctrl.Result, error) {
@@ -291,6 +334,12 @@ func (r *BackupReconciler) handleNewPhase(
 	if err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
 	}
+	// fail the new backup early if the backup repo is full, the running backups are not affected.
+	if repo := request.BackupRepo; repo != nil && repo.QuotaExceeded() {
+		err = dperrors.NewBackupRepoQuotaExceeded(repo.Name,
+			repo.Status.Usage.TotalSize.String(), repo.Spec.Quota.String())
+		return r.updateStatusIfFailed(reqCtx, backup.DeepCopy(), backup, err)
+	}
 	// record the status.target/status.targets infos for continuous backup.
 	if err = r.recordBackupStatusTargets(reqCtx, request); err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
@@ -32,6 +32,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
@@ -1436,6 +1437,27 @@ var _ = Describe("Backup Controller test", func() {
 				})).Should(Succeed())
 			})
 		})
+
+		Context("backup repo with quota", func() {
+			It("should fail if the backup repo has exceeded its quota", func() {
+				By("setting the quota and the usage of the backup repo")
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(repo), func(repo *dpv1alpha1.BackupRepo) {
+					quota := resource.MustParse("1Gi")
+					repo.Spec.Quota = &quota
+				})).Should(Succeed())
+				Eventually(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(repo), func(repo *dpv1alpha1.BackupRepo) {
+					repo.Status.Usage = &dpv1alpha1.BackupRepoUsage{TotalSize: resource.MustParse("2Gi")}
+				})).Should(Succeed())
+				By("creating backup")
+				_ = testdp.NewFakeBackupPolicy(&testCtx, nil)
+				backup := testdp.NewFakeBackup(&testCtx, nil)
+				By("checking backup, it should fail because the backup repo is full")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, backup *dpv1alpha1.Backup) {
+					g.Expect(backup.Status.Phase).Should(BeEquivalentTo(dpv1alpha1.BackupPhaseFailed))
+					g.Expect(backup.Status.FailureReason).Should(ContainSubstring("has exceeded its quota"))
+				})).Should(Succeed())
+			})
+		})
 	})
 
 	When("use kopia", func() {
@@ -284,6 +284,7 @@ func (r *backupPolicyAndScheduleBuilder) buildBackupSchedule(
 			CronExpression:  s.CronExpression,
 			Enabled:         s.Enabled,
 			RetentionPeriod: s.RetentionPeriod,
+			GFSRetention:    s.GFSRetention,
 			Name:            name,
 			Parameters:      s.Parameters,
 		})
@@ -317,6 +318,7 @@ func (r *backupPolicyAndScheduleBuilder) syncBackupSchedule(backupSchedule *dpv1
 			CronExpression:  s.CronExpression,
 			Enabled:         s.Enabled,
 			RetentionPeriod: s.RetentionPeriod,
+			GFSRetention:    s.GFSRetention,
 			Name:            name,
 			Parameters:      s.Parameters,
 		})
@@ -0,0 +1,416 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const defaultReplicationSyncInterval = 30 * time.Minute
+
+// BackupReplicationReconciler replicates the backups to the target backup repositories of the
+// BackupReplicationPolicies, and deletes the replicas when they expire.
+type BackupReplicationReconciler struct {
+	client.Client
+	Scheme   *k8sruntime.Scheme
+	Recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+func NewBackupReplicationReconciler(mgr ctrl.Manager) *BackupReplicationReconciler {
+	return &BackupReplicationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-replication-controller"),
+		clock:    clock.RealClock{},
+	}
+}
+
+// SetupWithManager sets up the BackupReplicationReconciler using the supplied manager.
+func (r *BackupReplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("backupreplication").
+		For(&dpv1alpha1.Backup{}).
+		Owns(&batchv1.Job{}).
+		Watches(&dpv1alpha1.BackupReplicationPolicy{}, handler.EnqueueRequestsFromMapFunc(r.parseReplicationPolicy)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupreplicationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// replicate the backups to other backup repositories.
+func (r *BackupReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backup replication", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the replicas of the deleting backup are deleted by the backup controller
+	if !backup.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	policies, err := r.getReplicationPolicies(reqCtx.Ctx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(policies) == 0 && len(backup.Status.Replicas) == 0 {
+		return intctrlutil.Reconciled()
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to get worker service account")
+	}
+	replicator := &dpbackup.Replicator{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	deleter := &dpbackup.Deleter{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+
+	var requeueAfter time.Duration
+	updateRequeueAfter := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+
+	// replicate the backup to the targets
+	if r.isBackupReplicable(backup) {
+		replicated := map[string]bool{backup.Status.BackupRepoName: true}
+		for _, policy := range policies {
+			for _, target := range policy.Spec.Targets {
+				if replicated[target.BackupRepoName] {
+					continue
+				}
+				replicated[target.BackupRepoName] = true
+				d, err := r.replicate(reqCtx, replicator, backup, policy, target)
+				if err != nil {
+					return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+				}
+				updateRequeueAfter(d)
+			}
+		}
+	}
+
+	// delete the expired replicas
+	for _, replica := range backup.Status.Replicas {
+		d, err := r.deleteExpiredReplica(reqCtx, deleter, backup, replica)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		updateRequeueAfter(d)
+	}
+
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next replication")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// isBackupReplicable checks whether the backup data is stored in the backup repository and can be replicated.
+// The continuous backup is replicated while it is running.
+func (r *BackupReplicationReconciler) isBackupReplicable(backup *dpv1alpha1.Backup) bool {
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+	case dpv1alpha1.BackupPhaseRunning:
+		if !isContinuousBackup(backup) {
+			return false
+		}
+	default:
+		return false
+	}
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		return false
+	}
+	if backup.Status.PrimaryDeletionTimestamp != nil {
+		return false
+	}
+	return len(backup.Status.BackupRepoName) > 0 && len(backup.Status.Path) > 0
+}
+
+// getReplicationPolicies gets the available BackupReplicationPolicies which replicate the backup.
+func (r *BackupReplicationReconciler) getReplicationPolicies(ctx context.Context,
+	backup *dpv1alpha1.Backup) ([]*dpv1alpha1.BackupReplicationPolicy, error) {
+	policyList := &dpv1alpha1.BackupReplicationPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(backup.Namespace)); err != nil {
+		return nil, err
+	}
+	var policies []*dpv1alpha1.BackupReplicationPolicy
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if !policy.DeletionTimestamp.IsZero() || !policy.Status.Phase.IsAvailable() {
+			continue
+		}
+		if policy.Spec.BackupPolicyName != backup.Spec.BackupPolicyName {
+			continue
+		}
+		if len(policy.Spec.BackupMethods) > 0 && !slices.Contains(policy.Spec.BackupMethods, backup.Spec.BackupMethod) {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	// the earliest policy wins if the backup repository is targeted by multiple policies
+	slices.SortFunc(policies, func(a, b *dpv1alpha1.BackupReplicationPolicy) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	return policies, nil
+}
+
+// replicate replicates the backup to the target, and returns the duration to wait for the next replication.
+func (r *BackupReplicationReconciler) replicate(reqCtx intctrlutil.RequestCtx,
+	replicator *dpbackup.Replicator,
+	backup *dpv1alpha1.Backup,
+	policy *dpv1alpha1.BackupReplicationPolicy,
+	target dpv1alpha1.BackupReplicationTarget) (time.Duration, error) {
+	syncInterval := defaultReplicationSyncInterval
+	if policy.Spec.SyncIntervalMinutes > 0 {
+		syncInterval = time.Duration(policy.Spec.SyncIntervalMinutes) * time.Minute
+	}
+	now := r.clock.Now()
+
+	var replica *dpv1alpha1.BackupReplicaStatus
+	if existing := backup.GetReplica(target.BackupRepoName); existing != nil {
+		replica = existing.DeepCopy()
+	}
+	switch {
+	case replica == nil:
+		replica = &dpv1alpha1.BackupReplicaStatus{BackupRepoName: target.BackupRepoName}
+	case replica.Phase == dpv1alpha1.BackupReplicaPhaseReplicating:
+		return r.checkReplication(reqCtx, replicator, backup, replica, target, syncInterval)
+	case replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleting,
+		replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted:
+		return 0, nil
+	case replica.Phase == dpv1alpha1.BackupReplicaPhaseCompleted && !isReplicaOutdated(backup, replica):
+		// update the expiration if the continuous backup is stopped
+		expiration, err := buildReplicaExpiration(backup, replica, target)
+		if err != nil {
+			return 0, err
+		}
+		if !expiration.Equal(replica.Expiration) {
+			replica.Expiration = expiration
+			return 0, r.patchReplica(reqCtx, backup, replica)
+		}
+		return 0, nil
+	default:
+		// the failed or outdated replica is replicated again after the sync interval
+		if replica.StartTimestamp != nil {
+			if remaining := replica.StartTimestamp.Add(syncInterval).Sub(now); remaining > 0 {
+				return remaining, nil
+			}
+		}
+	}
+
+	// start a new replication
+	replica.Path = backup.Status.Path
+	replica.Phase = dpv1alpha1.BackupReplicaPhaseReplicating
+	replica.StartTimestamp = &metav1.Time{Time: now}
+	replica.FailureReason = ""
+	if err := r.patchReplica(reqCtx, backup, replica); err != nil {
+		return 0, err
+	}
+	return r.checkReplication(reqCtx, replicator, backup, replica, target, syncInterval)
+}
+
+// checkReplication runs the replication and records the result in the backup status.
+func (r *BackupReplicationReconciler) checkReplication(reqCtx intctrlutil.RequestCtx,
+	replicator *dpbackup.Replicator,
+	backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus,
+	target dpv1alpha1.BackupReplicationTarget,
+	syncInterval time.Duration) (time.Duration, error) {
+	phase, timeRange, replicateErr := replicator.Replicate(backup, replica)
+	switch phase {
+	case dpv1alpha1.BackupReplicaPhaseCompleted:
+		replica.Phase = phase
+		replica.CompletionTimestamp = &metav1.Time{Time: r.clock.Now()}
+		replica.TimeRange = timeRange
+		expiration, err := buildReplicaExpiration(backup, replica, target)
+		if err != nil {
+			return 0, err
+		}
+		replica.Expiration = expiration
+		if err = r.patchReplica(reqCtx, backup, replica); err != nil {
+			return 0, err
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupReplicated",
+			fmt.Sprintf("the backup is replicated to the backup repo %s", replica.BackupRepoName))
+		return 0, replicator.CleanupReplication(backup, replica)
+	case dpv1alpha1.BackupReplicaPhaseFailed:
+		replica.Phase = phase
+		if replicateErr != nil {
+			replica.FailureReason = replicateErr.Error()
+		}
+		if err := r.patchReplica(reqCtx, backup, replica); err != nil {
+			return 0, err
+		}
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupReplicationFailed", replica.FailureReason)
+		return syncInterval, replicator.CleanupReplication(backup, replica)
+	}
+	// wait for the replication job completed
+	return 0, replicateErr
+}
+
+// deleteExpiredReplica deletes the replica from its backup repository if it has expired,
+// and returns the duration to wait for the expiration otherwise.
+func (r *BackupReplicationReconciler) deleteExpiredReplica(reqCtx intctrlutil.RequestCtx,
+	deleter *dpbackup.Deleter,
+	backup *dpv1alpha1.Backup,
+	replica dpv1alpha1.BackupReplicaStatus) (time.Duration, error) {
+	if replica.Expiration == nil ||
+		replica.Phase == dpv1alpha1.BackupReplicaPhaseReplicating ||
+		replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted {
+		return 0, nil
+	}
+	if remaining := replica.Expiration.Sub(r.clock.Now()); remaining > 0 {
+		return remaining, nil
+	}
+
+	if replica.Phase != dpv1alpha1.BackupReplicaPhaseDeleting {
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleting
+		if err := r.patchReplica(reqCtx, backup, &replica); err != nil {
+			return 0, err
+		}
+	}
+	status, err := deleter.DeleteReplicaFiles(backup, &replica)
+	switch status {
+	case dpbackup.DeletionStatusSucceeded:
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseDeleted
+		replica.FailureReason = ""
+		if err = r.patchReplica(reqCtx, backup, &replica); err != nil {
+			return 0, err
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupReplicaDeleted",
+			fmt.Sprintf("the expired replica in the backup repo %s is deleted", replica.BackupRepoName))
+		job := &batchv1.Job{}
+		if err = r.Get(reqCtx.Ctx, dpbackup.BuildDeleteReplicaFilesJobKey(backup, replica.BackupRepoName), job); err != nil {
+			return 0, client.IgnoreNotFound(err)
+		}
+		return 0, intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job)
+	case dpbackup.DeletionStatusFailed:
+		failureReason := err.Error()
+		if replica.FailureReason == failureReason {
+			return 0, nil
+		}
+		replica.FailureReason = failureReason
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "DeleteBackupReplicaFailed", failureReason)
+		return 0, r.patchReplica(reqCtx, backup, &replica)
+	}
+	// wait for the deletion job completed
+	return 0, err
+}
+
+// patchReplica records the replica in the backup status.
+func (r *BackupReplicationReconciler) patchReplica(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) error {
+	patch := client.MergeFrom(backup.DeepCopy())
+	if existing := backup.GetReplica(replica.BackupRepoName); existing != nil {
+		*existing = *replica
+	} else {
+		backup.Status.Replicas = append(backup.Status.Replicas, *replica)
+	}
+	return r.Status().Patch(reqCtx.Ctx, backup, patch)
+}
+
+func (r *BackupReplicationReconciler) parseReplicationPolicy(ctx context.Context, object client.Object) []reconcile.Request {
+	policy := object.(*dpv1alpha1.BackupReplicationPolicy)
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(ctx, backupList, client.InNamespace(policy.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: policy.Spec.BackupPolicyName}); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, backup := range backupList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&backup)})
+	}
+	return requests
+}
+
+func isContinuousBackup(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous)
+}
+
+// isReplicaOutdated checks whether the replica of the continuous backup falls behind the backup.
+func isReplicaOutdated(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) bool {
+	if !isContinuousBackup(backup) {
+		return false
+	}
+	backupEnd := backup.GetEndTime()
+	if backupEnd == nil {
+		return false
+	}
+	return replica.TimeRange == nil || replica.TimeRange.End == nil || replica.TimeRange.End.Before(backupEnd)
+}
+
+// buildReplicaExpiration builds the expiration of the replica according to the retention period of the target.
+// Like the backups, the expiration is not set for the running continuous backup.
+func buildReplicaExpiration(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus,
+	target dpv1alpha1.BackupReplicationTarget) (*metav1.Time, error) {
+	duration, err := target.RetentionPeriod.ToDuration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse retention period %s, %v", target.RetentionPeriod, err)
+	}
+	if duration.Seconds() == 0 || replica.CompletionTimestamp == nil {
+		return nil, nil
+	}
+	if isContinuousBackup(backup) && backup.Status.Phase == dpv1alpha1.BackupPhaseRunning {
+		return nil, nil
+	}
+	return &metav1.Time{Time: replica.CompletionTimestamp.Add(duration)}, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// BackupReplicationPolicyReconciler reconciles a BackupReplicationPolicy object
+type BackupReplicationPolicyReconciler struct {
+	client.Client
+	Scheme   *k8sruntime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupreplicationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupreplicationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupreplicationpolicies/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the backupreplicationpolicy closer to the desired state.
+func (r *BackupReplicationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backupReplicationPolicy", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	policy := &dpv1alpha1.BackupReplicationPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, policy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	if err := r.validatePolicy(reqCtx, policy); err != nil {
+		return r.patchStatusUnavailable(reqCtx, policy, err)
+	}
+	return r.patchStatusAvailable(reqCtx, policy)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupReplicationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		For(&dpv1alpha1.BackupReplicationPolicy{}).
+		Watches(&dpv1alpha1.BackupRepo{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupRepo)).
+		Complete(r)
+}
+
+// validatePolicy checks the backup policy, the backup methods and the target backup repositories.
+func (r *BackupReplicationPolicyReconciler) validatePolicy(reqCtx intctrlutil.RequestCtx,
+	policy *dpv1alpha1.BackupReplicationPolicy) error {
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: policy.Namespace, Name: policy.Spec.BackupPolicyName}, backupPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("backup policy %s is not found", policy.Spec.BackupPolicyName)
+		}
+		return err
+	}
+	for _, method := range policy.Spec.BackupMethods {
+		if dputils.GetBackupMethodByName(method, backupPolicy) == nil {
+			return fmt.Errorf("backup method %s is not found in backup policy %s",
+				method, backupPolicy.Name)
+		}
+	}
+	for _, target := range policy.Spec.Targets {
+		if _, err := target.RetentionPeriod.ToDuration(); err != nil {
+			return fmt.Errorf("invalid retention period %s of target %s, %v",
+				target.RetentionPeriod, target.BackupRepoName, err)
+		}
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: target.BackupRepoName}, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("backup repo %s is not found", target.BackupRepoName)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// patchStatusAvailable patches the status phase of the backup replication policy to available.
+func (r *BackupReplicationPolicyReconciler) patchStatusAvailable(reqCtx intctrlutil.RequestCtx,
+	policy *dpv1alpha1.BackupReplicationPolicy) (ctrl.Result, error) {
+	if policy.Status.Phase != dpv1alpha1.AvailablePhase ||
+		policy.Status.ObservedGeneration != policy.Generation {
+		patch := client.MergeFrom(policy.DeepCopy())
+		policy.Status.ObservedGeneration = policy.Generation
+		policy.Status.Phase = dpv1alpha1.AvailablePhase
+		policy.Status.FailureReason = ""
+		if err := r.Client.Status().Patch(reqCtx.Ctx, policy, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.Reconciled()
+}
+
+// patchStatusUnavailable patches the status phase of the backup replication policy to unavailable.
+func (r *BackupReplicationPolicyReconciler) patchStatusUnavailable(reqCtx intctrlutil.RequestCtx,
+	policy *dpv1alpha1.BackupReplicationPolicy,
+	err error) (ctrl.Result, error) {
+	policyDeepCopy := policy.DeepCopy()
+	policy.Status.ObservedGeneration = policy.Generation
+	policy.Status.Phase = dpv1alpha1.UnavailablePhase
+	policy.Status.FailureReason = err.Error()
+	if !reflect.DeepEqual(policy.Status, policyDeepCopy.Status) {
+		if patchErr := r.Client.Status().Patch(reqCtx.Ctx, policy, client.MergeFrom(policyDeepCopy)); patchErr != nil {
+			return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "")
+		}
+		r.Recorder.Event(policy, corev1.EventTypeWarning, "BackupReplicationPolicyUnavailable", err.Error())
+	}
+	// requeue with backoff, as the missing backup policy or backup repo may be created later
+	return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+}
+
+func (r *BackupReplicationPolicyReconciler) parseBackupRepo(ctx context.Context, object client.Object) []reconcile.Request {
+	repo := object.(*dpv1alpha1.BackupRepo)
+	policyList := &dpv1alpha1.BackupReplicationPolicyList{}
+	if err := r.Client.List(ctx, policyList); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if policy.GetTarget(repo.Name) != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+)
+
+var _ = Describe("Backup Replication Policy Controller", func() {
+	const replicationPolicyName = "test-replication-policy"
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupReplicationPolicySignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS)
+
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
+		testapps.ClearResources(&testCtx, generics.StorageProviderSignature, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		_ = testdp.NewFakeCluster(&testCtx)
+	})
+
+	AfterEach(cleanEnv)
+
+	When("creating backup replication policy", func() {
+		var repoName string
+
+		BeforeEach(func() {
+			By("creating an actionSet")
+			_ = testdp.NewFakeActionSet(&testCtx, nil)
+
+			By("creating storage provider")
+			_ = testdp.NewFakeStorageProvider(&testCtx, nil)
+
+			By("creating backup repo")
+			repo, _ := testdp.NewFakeBackupRepo(&testCtx, nil)
+			repoName = repo.Name
+
+			By("creating a backupPolicy")
+			_ = testdp.NewFakeBackupPolicy(&testCtx, nil)
+		})
+
+		checkPolicyPhase := func(policy *dpv1alpha1.BackupReplicationPolicy, phase dpv1alpha1.Phase) {
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(policy),
+				func(g Gomega, fetched *dpv1alpha1.BackupReplicationPolicy) {
+					g.Expect(fetched.Status.Phase).Should(Equal(phase))
+				})).Should(Succeed())
+		}
+
+		It("should be available", func() {
+			policy := testdp.NewBackupReplicationPolicyFactory(testCtx.DefaultNamespace, replicationPolicyName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethods(testdp.BackupMethodName).
+				AddTarget(repoName, "7d").
+				Create(&testCtx).GetObject()
+			checkPolicyPhase(policy, dpv1alpha1.AvailablePhase)
+		})
+
+		It("should be unavailable if the backup method does not exist", func() {
+			policy := testdp.NewBackupReplicationPolicyFactory(testCtx.DefaultNamespace, replicationPolicyName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethods("non-existent-method").
+				AddTarget(repoName, "7d").
+				Create(&testCtx).GetObject()
+			checkPolicyPhase(policy, dpv1alpha1.UnavailablePhase)
+		})
+
+		It("should be unavailable if the target backup repo does not exist", func() {
+			policy := testdp.NewBackupReplicationPolicyFactory(testCtx.DefaultNamespace, replicationPolicyName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				AddTarget("non-existent-repo", "7d").
+				Create(&testCtx).GetObject()
+			checkPolicyPhase(policy, dpv1alpha1.UnavailablePhase)
+		})
+	})
+})
@@ -150,6 +150,9 @@ type BackupRepoReconciler struct {
 // watch or update Restores
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;update;patch
 
+// watch BackupReplicationPolicies
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupreplicationpolicies,verbs=get;list;watch
+
 // create or delete StorageClasses
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;delete
 
@@ -287,6 +290,12 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return checkedRequeueWithError(err, reqCtx.Log,
 				"check associated restores failed")
 		}
+
+		// check associated backup replication policies, to create PVC in their namespaces
+		if err = r.prepareForAssociatedReplicationPolicies(reconCtx); err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"check associated backup replication policies failed")
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -1131,6 +1140,29 @@ func (r *BackupRepoReconciler) prepareForAssociatedRestores(reconCtx *reconcileC
 	return retErr
 }
 
+func (r *BackupRepoReconciler) prepareForAssociatedReplicationPolicies(reconCtx *reconcileContext) error {
+	policyList := &dpv1alpha1.BackupReplicationPolicyList{}
+	if err := r.Client.List(reconCtx.Ctx, policyList, multicluster.InControlContext()); err != nil {
+		return err
+	}
+	// the backups are replicated to the repo in the namespaces of the policies
+	namespaces := map[string]struct{}{}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if policy.GetTarget(reconCtx.repo.Name) != nil {
+			namespaces[policy.Namespace] = struct{}{}
+		}
+	}
+	// return any error to reconcile the repo
+	var retErr error
+	for namespace := range namespaces {
+		if err := r.prepareBackupRepoInNamespace(reconCtx, namespace); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	return retErr
+}
+
 func (r *BackupRepoReconciler) createRepoPVC(reconCtx *reconcileContext,
 	name, namespace string, extraAnnos map[string]string, mcOpt *multicluster.ClientOption) (*corev1.PersistentVolumeClaim, error) {
 
@@ -1502,6 +1534,17 @@ func (r *BackupRepoReconciler) mapRestoreToRepo(ctx context.Context, obj client.
 	return nil
 }
 
+func (r *BackupRepoReconciler) mapReplicationPolicyToRepos(ctx context.Context, obj client.Object) []ctrl.Request {
+	policy := obj.(*dpv1alpha1.BackupReplicationPolicy)
+	var requests []ctrl.Request
+	for _, target := range policy.Spec.Targets {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: target.BackupRepoName},
+		})
+	}
+	return requests
+}
+
 func (r *BackupRepoReconciler) mapProviderToRepos(ctx context.Context, obj client.Object) []ctrl.Request {
 	return r.providerRefMapper.mapToRequests(obj)
 }
@@ -1539,6 +1582,7 @@ func (r *BackupRepoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&dpv1alpha1.StorageProvider{}, handler.EnqueueRequestsFromMapFunc(r.mapProviderToRepos)).
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupToRepo)).
 		Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.mapRestoreToRepo)).
+		Watches(&dpv1alpha1.BackupReplicationPolicy{}, handler.EnqueueRequestsFromMapFunc(r.mapReplicationPolicyToRepos)).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToRepos)).
 		Owns(&storagev1.StorageClass{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
@@ -0,0 +1,696 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	repoUsageContainerName = "usage"
+	// the usage job prints a line in the format of "usage:<path>" before the stat output of each path
+	repoUsageOutputPrefix = "usage:"
+	// the usage job is failed by the job controller if it runs longer than the deadline
+	repoUsageJobDeadlineSeconds = 30 * 60
+
+	repoUsageMetricsNamespace = "dataprotection"
+	repoUsageMetricsSubsystem = "backup_repo"
+)
+
+// errUnexpectedUsageOutput indicates that the output of the usage job can not be parsed.
+var errUnexpectedUsageOutput = errors.New("unexpected output of the usage job")
+
+var (
+	backupRepoUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: repoUsageMetricsNamespace,
+		Subsystem: repoUsageMetricsSubsystem,
+		Name:      "used_bytes",
+		Help:      "The total size of the data stored in the backup repository.",
+	}, []string{"backup_repo"})
+
+	backupRepoQuotaBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: repoUsageMetricsNamespace,
+		Subsystem: repoUsageMetricsSubsystem,
+		Name:      "quota_bytes",
+		Help:      "The quota of the backup repository.",
+	}, []string{"backup_repo"})
+
+	backupRepoClusterUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: repoUsageMetricsNamespace,
+		Subsystem: repoUsageMetricsSubsystem,
+		Name:      "cluster_used_bytes",
+		Help:      "The size of the data stored in the backup repository by the backups of the cluster.",
+	}, []string{"backup_repo", "namespace", "cluster"})
+
+	backupRepoBackupPolicyUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: repoUsageMetricsNamespace,
+		Subsystem: repoUsageMetricsSubsystem,
+		Name:      "backup_policy_used_bytes",
+		Help:      "The size of the data stored in the backup repository by the backups of the backup policy.",
+	}, []string{"backup_repo", "namespace", "backup_policy"})
+
+	backupRepoBackupUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: repoUsageMetricsNamespace,
+		Subsystem: repoUsageMetricsSubsystem,
+		Name:      "backup_used_bytes",
+		Help:      "The size of the data stored in the backup repository by the backup.",
+	}, []string{"backup_repo", "namespace", "backup", "cluster", "backup_policy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(backupRepoUsedBytes, backupRepoQuotaBytes, backupRepoClusterUsedBytes,
+		backupRepoBackupPolicyUsedBytes, backupRepoBackupUsedBytes)
+}
+
+// BackupRepoUsageReconciler computes the storage usage of the backup repositories periodically.
+// The usage is computed by a job which stats the paths of the backups in the backup repository,
+// and is recorded in the status of the BackupRepo and exported as metrics.
+type BackupRepoUsageReconciler struct {
+	client.Client
+	Scheme     *k8sruntime.Scheme
+	Recorder   record.EventRecorder
+	RestConfig *rest.Config
+	clock      clock.Clock
+}
+
+func NewBackupRepoUsageReconciler(mgr ctrl.Manager) *BackupRepoUsageReconciler {
+	return &BackupRepoUsageReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("backup-repo-usage-controller"),
+		RestConfig: mgr.GetConfig(),
+		clock:      clock.RealClock{},
+	}
+}
+
+// SetupWithManager sets up the BackupRepoUsageReconciler using the supplied manager.
+func (r *BackupRepoUsageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("backuprepousage").
+		For(&dpv1alpha1.BackupRepo{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// backupUsage records the size of the data stored in the backup repository by the backup.
+type backupUsage struct {
+	backup *dpv1alpha1.Backup
+	size   int64
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuprepos,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuprepos/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// compute the storage usage of the backup repository.
+func (r *BackupRepoUsageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backup repo usage", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := r.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			deleteRepoUsageMetrics(req.Name)
+			return intctrlutil.Reconciled()
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !repo.DeletionTimestamp.IsZero() {
+		deleteRepoUsageMetrics(repo.Name)
+		return intctrlutil.Reconciled()
+	}
+	if repo.Spec.Quota != nil {
+		backupRepoQuotaBytes.WithLabelValues(repo.Name).Set(float64(repo.Spec.Quota.Value()))
+	} else {
+		backupRepoQuotaBytes.DeleteLabelValues(repo.Name)
+	}
+	if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
+		return intctrlutil.Reconciled()
+	}
+
+	frequency := getBackupRepoUsageFrequency()
+	job, err := r.getUsageJob(reqCtx, repo)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if job != nil {
+		return r.checkUsageJob(reqCtx, repo, job, frequency)
+	}
+
+	usage := repo.Status.Usage
+	if usage != nil && usage.LastUpdateTime != nil {
+		if remaining := usage.LastUpdateTime.Add(frequency).Sub(r.clock.Now()); remaining > 0 {
+			return intctrlutil.RequeueAfter(remaining, reqCtx.Log, "wait for the next computation")
+		}
+	}
+
+	backups, err := r.listBackups(reqCtx, repo)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(backups) == 0 {
+		// nothing is stored by the backups, no need to run the job
+		if err = r.updateUsage(reqCtx, repo, nil, nil); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(frequency, reqCtx.Log, "wait for the next computation")
+	}
+
+	namespace, err := r.getUsageJobNamespace(reqCtx, repo, backups)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if namespace == "" {
+		return intctrlutil.RequeueAfter(defaultCheckInterval, reqCtx.Log,
+			"wait for the backup repo to be prepared in the namespaces of the backups")
+	}
+	if err = r.createUsageJob(reqCtx, repo, namespace, buildUsagePaths(repo, backups)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// checkUsageJob checks the status of the usage job, and records the usage if the job is completed.
+// The failed job is retained until the next computation to avoid retrying it too frequently.
+func (r *BackupRepoUsageReconciler) checkUsageJob(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo,
+	job *batchv1.Job,
+	frequency time.Duration) (ctrl.Result, error) {
+	finished, jobStatus, failureReason := utils.IsJobFinished(job)
+	if !finished {
+		return intctrlutil.Reconciled()
+	}
+	if jobStatus == batchv1.JobFailed {
+		if remaining := job.CreationTimestamp.Add(frequency).Sub(r.clock.Now()); remaining > 0 {
+			r.Recorder.Event(repo, corev1.EventTypeWarning, "ComputeUsageFailed",
+				fmt.Sprintf("failed to compute the usage of the backup repo, job \"%s\" failed: %s", job.Name, failureReason))
+			return intctrlutil.RequeueAfter(remaining, reqCtx.Log, "wait for the next computation")
+		}
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	sizes, err := r.readUsageJobOutput(reqCtx.Ctx, job)
+	if errors.Is(err, errUnexpectedUsageOutput) {
+		r.Recorder.Event(repo, corev1.EventTypeWarning, "ComputeUsageFailed",
+			fmt.Sprintf("failed to compute the usage of the backup repo, job \"%s\": %s", job.Name, err.Error()))
+		if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(frequency, reqCtx.Log, "wait for the next computation")
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to read the output of the usage job")
+	}
+	backups, err := r.listBackups(reqCtx, repo)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.updateUsage(reqCtx, repo, backups, sizes); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(frequency, reqCtx.Log, "wait for the next computation")
+}
+
+// updateUsage records the usage in the status of the backup repo and updates the metrics.
+func (r *BackupRepoUsageReconciler) updateUsage(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo,
+	backups []*dpv1alpha1.Backup,
+	sizes map[string]int64) error {
+	usage, backupUsages := computeRepoUsage(repo, backups, sizes)
+	usage.LastUpdateTime = &metav1.Time{Time: r.clock.Now()}
+	patch := client.MergeFrom(repo.DeepCopy())
+	repo.Status.Usage = usage
+	if err := r.Status().Patch(reqCtx.Ctx, repo, patch); err != nil {
+		return err
+	}
+	updateRepoUsageMetrics(repo, backupUsages)
+	if repo.QuotaExceeded() {
+		r.Recorder.Event(repo, corev1.EventTypeWarning, "BackupRepoQuotaExceeded",
+			fmt.Sprintf("the backup repo has exceeded its quota, used %s of %s, new backups will fail",
+				usage.TotalSize.String(), repo.Spec.Quota.String()))
+	}
+	return nil
+}
+
+// getUsageJob gets the usage job of the backup repo, it returns nil if the job does not exist.
+func (r *BackupRepoUsageReconciler) getUsageJob(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo) (*batchv1.Job, error) {
+	jobList := &batchv1.JobList{}
+	if err := r.List(reqCtx.Ctx, jobList, client.MatchingLabels{
+		dataProtectionBackupRepoKey:      repo.Name,
+		dataProtectionBackupRepoUsageKey: trueVal,
+	}); err != nil {
+		return nil, err
+	}
+	for i := range jobList.Items {
+		if isOwned(repo, &jobList.Items[i]) {
+			return &jobList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// listBackups lists the backups which store data in the backup repo.
+func (r *BackupRepoUsageReconciler) listBackups(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo) ([]*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(reqCtx.Ctx, backupList, client.MatchingLabels{
+		dataProtectionBackupRepoKey: repo.Name,
+	}); err != nil {
+		return nil, err
+	}
+	var backups []*dpv1alpha1.Backup
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if backup.Status.BackupRepoName != repo.Name || backup.Status.Path == "" {
+			continue
+		}
+		if backupMethod := backup.Status.BackupMethod; backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+			continue
+		}
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+// getUsageJobNamespace returns the namespace to run the usage job, which is one of the namespaces
+// of the backups where the backup repo has been prepared. It returns empty if there is none.
+func (r *BackupRepoUsageReconciler) getUsageJobNamespace(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo, backups []*dpv1alpha1.Backup) (string, error) {
+	var namespaces []string
+	for _, backup := range backups {
+		if !slices.Contains(namespaces, backup.Namespace) {
+			namespaces = append(namespaces, backup.Namespace)
+		}
+	}
+	slices.Sort(namespaces)
+	for _, namespace := range namespaces {
+		var (
+			key client.ObjectKey
+			obj client.Object
+		)
+		if repo.AccessByTool() {
+			key = client.ObjectKey{Namespace: namespace, Name: repo.Status.ToolConfigSecretName}
+			obj = &corev1.Secret{}
+		} else {
+			key = client.ObjectKey{Namespace: namespace, Name: repo.Status.BackupPVCName}
+			obj = &corev1.PersistentVolumeClaim{}
+		}
+		exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client, key, obj)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return namespace, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *BackupRepoUsageReconciler) createUsageJob(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo, namespace string, paths []string) error {
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, namespace, nil)
+	if err != nil {
+		return err
+	}
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            repoUsageContainerName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"sh", "-c", buildRepoUsageScript(), repoUsageContainerName},
+		Args:            paths,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: saName,
+	}
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	// the kopia repository is accessed as normal files to get its physical size
+	utils.InjectDatasafed(&podSpec, repo, dpbackup.RepoVolumeMountPath, nil, "")
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      cutName(fmt.Sprintf("usage-%s-%s", repo.UID[:8], repo.Name)),
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:    dptypes.AppName,
+				dataProtectionBackupRepoKey:      repo.Name,
+				dataProtectionBackupRepoUsageKey: trueVal,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: podSpec,
+			},
+			BackoffLimit:          &dptypes.DefaultBackOffLimit,
+			ActiveDeadlineSeconds: pointer.Int64(repoUsageJobDeadlineSeconds),
+		},
+	}
+	if err = controllerutil.SetControllerReference(repo, job, r.Scheme); err != nil {
+		return err
+	}
+	reqCtx.Log.V(1).Info("create a job to compute the usage of the backup repo", "job", client.ObjectKeyFromObject(job))
+	return client.IgnoreAlreadyExists(r.Create(reqCtx.Ctx, job))
+}
+
+// readUsageJobOutput reads the sizes of the paths from the logs of the succeeded pod of the usage job.
+// Note: this function only reads logs of pod from the control cluster
+func (r *BackupRepoUsageReconciler) readUsageJobOutput(ctx context.Context, job *batchv1.Job) (map[string]int64, error) {
+	podList, err := utils.GetAssociatedPodsOfJob(ctx, r.Client, job.Namespace, job.Name)
+	if err != nil {
+		return nil, err
+	}
+	typedCli, err := corev1client.NewForConfig(r.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		stream, err := typedCli.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: repoUsageContainerName,
+		}).Stream(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+		return parseRepoUsageOutput(stream)
+	}
+	return nil, fmt.Errorf("no succeeded pod found for the job %s", job.Name)
+}
+
+func buildRepoUsageScript() string {
+	return fmt.Sprintf(`
+set -o errexit
+export PATH="$PATH:$DP_DATASAFED_BIN_PATH"
+for path in "$@"; do
+  if ! output=$(datasafed stat "${path}" 2>&1); then
+    # the path does not exist if the backup has not uploaded any data
+    if echo "${output}" | grep -qi "not found"; then
+      continue
+    fi
+    echo "failed to stat ${path}: ${output}" >&2
+    exit 1
+  fi
+  echo "%s${path}"
+  echo "${output}"
+done
+`, repoUsageOutputPrefix)
+}
+
+// parseRepoUsageOutput parses the output of the usage job into the sizes of the paths.
+// The job prints a line of "usage:<path>" followed by the output of "datasafed stat" for each path, e.g.:
+//
+//	usage:/default/backup-1
+//	TotalSize: 1024
+//	Entries: 3
+//	Dirs: 1
+//	Files: 2
+//
+// It fails if the total size of a path is missing or invalid, rather than taking the path as empty.
+func parseRepoUsageOutput(reader io.Reader) (map[string]int64, error) {
+	var (
+		sizes = map[string]int64{}
+		path  string
+	)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, repoUsageOutputPrefix) {
+			if path != "" {
+				return nil, fmt.Errorf("%w: no total size found in the stat output of the path %s", errUnexpectedUsageOutput, path)
+			}
+			path = strings.TrimPrefix(line, repoUsageOutputPrefix)
+			continue
+		}
+		fields := strings.Fields(line)
+		if path == "" || len(fields) != 2 || strings.TrimSuffix(fields[0], ":") != "TotalSize" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid total size in the stat output of the path %s: %s", errUnexpectedUsageOutput, path, fields[1])
+		}
+		sizes[path] = size
+		path = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if path != "" {
+		return nil, fmt.Errorf("%w: no total size found in the stat output of the path %s", errUnexpectedUsageOutput, path)
+	}
+	return sizes, nil
+}
+
+// getRepoRootPath returns the root path of the data stored in the backup repo.
+func getRepoRootPath(repo *dpv1alpha1.BackupRepo) string {
+	return filepath.Join("/", repo.Spec.PathPrefix)
+}
+
+// getBackupUsagePath returns the path to stat for the backup, the backups stored in the
+// Kopia repository share the path of the repository.
+func getBackupUsagePath(backup *dpv1alpha1.Backup) string {
+	if backup.Status.KopiaRepoPath != "" {
+		return filepath.Join("/", backup.Status.KopiaRepoPath)
+	}
+	return filepath.Join("/", backup.Status.Path)
+}
+
+// buildUsagePaths builds the paths to stat by the usage job, the root path of the repo comes first.
+func buildUsagePaths(repo *dpv1alpha1.BackupRepo, backups []*dpv1alpha1.Backup) []string {
+	var paths []string
+	for _, backup := range backups {
+		path := getBackupUsagePath(backup)
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return append([]string{getRepoRootPath(repo)}, paths...)
+}
+
+// computeRepoUsage computes the usage of the backup repo by the sizes of the paths.
+// The size of the Kopia repository is shared by its backups in proportion to their total sizes,
+// and the total size is used if the size of the backup path is unknown.
+func computeRepoUsage(repo *dpv1alpha1.BackupRepo,
+	backups []*dpv1alpha1.Backup,
+	sizes map[string]int64) (*dpv1alpha1.BackupRepoUsage, []backupUsage) {
+	reportedSize := func(backup *dpv1alpha1.Backup) int64 {
+		if backup.Status.TotalSize == "" {
+			return 0
+		}
+		quantity, err := resource.ParseQuantity(backup.Status.TotalSize)
+		if err != nil {
+			return 0
+		}
+		return quantity.Value()
+	}
+
+	// group the backups by the paths
+	var paths []string
+	pathBackups := map[string][]*dpv1alpha1.Backup{}
+	for _, backup := range backups {
+		path := getBackupUsagePath(backup)
+		if _, ok := pathBackups[path]; !ok {
+			paths = append(paths, path)
+		}
+		pathBackups[path] = append(pathBackups[path], backup)
+	}
+
+	var (
+		backupUsages []backupUsage
+		totalSize    int64
+	)
+	for _, path := range paths {
+		pathSize, measured := sizes[path]
+		group := pathBackups[path]
+		if !measured || len(group) == 1 {
+			for _, backup := range group {
+				size := pathSize
+				if !measured {
+					size = reportedSize(backup)
+				}
+				backupUsages = append(backupUsages, backupUsage{backup: backup, size: size})
+				totalSize += size
+			}
+			continue
+		}
+		// share the size of the Kopia repository
+		var totalWeight int64
+		for _, backup := range group {
+			totalWeight += reportedSize(backup)
+		}
+		for _, backup := range group {
+			var size int64
+			if totalWeight > 0 {
+				size = int64(float64(pathSize) * float64(reportedSize(backup)) / float64(totalWeight))
+			} else {
+				size = pathSize / int64(len(group))
+			}
+			backupUsages = append(backupUsages, backupUsage{backup: backup, size: size})
+		}
+		totalSize += pathSize
+	}
+	if rootSize, ok := sizes[getRepoRootPath(repo)]; ok {
+		totalSize = rootSize
+	}
+
+	type itemKey struct{ namespace, name string }
+	aggregate := func(getName func(backup *dpv1alpha1.Backup) string) []dpv1alpha1.BackupRepoUsageItem {
+		var keys []itemKey
+		items := map[itemKey]*dpv1alpha1.BackupRepoUsageItem{}
+		for _, u := range backupUsages {
+			key := itemKey{namespace: u.backup.Namespace, name: getName(u.backup)}
+			if key.name == "" {
+				continue
+			}
+			item, ok := items[key]
+			if !ok {
+				item = &dpv1alpha1.BackupRepoUsageItem{Namespace: key.namespace, Name: key.name}
+				items[key] = item
+				keys = append(keys, key)
+			}
+			item.Size.Add(*resource.NewQuantity(u.size, resource.BinarySI))
+			item.BackupCount++
+		}
+		slices.SortFunc(keys, func(a, b itemKey) int {
+			if a.namespace != b.namespace {
+				return strings.Compare(a.namespace, b.namespace)
+			}
+			return strings.Compare(a.name, b.name)
+		})
+		result := make([]dpv1alpha1.BackupRepoUsageItem, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, *items[key])
+		}
+		return result
+	}
+
+	usage := &dpv1alpha1.BackupRepoUsage{
+		TotalSize:   *resource.NewQuantity(totalSize, resource.BinarySI),
+		BackupCount: int32(len(backupUsages)),
+		Clusters:    aggregate(getBackupClusterName),
+		BackupPolicies: aggregate(func(backup *dpv1alpha1.Backup) string {
+			return backup.Spec.BackupPolicyName
+		}),
+	}
+	return usage, backupUsages
+}
+
+func getBackupClusterName(backup *dpv1alpha1.Backup) string {
+	return backup.Labels[constant.AppInstanceLabelKey]
+}
+
+func updateRepoUsageMetrics(repo *dpv1alpha1.BackupRepo, backupUsages []backupUsage) {
+	deleteRepoUsageMetrics(repo.Name)
+	if repo.Spec.Quota != nil {
+		backupRepoQuotaBytes.WithLabelValues(repo.Name).Set(float64(repo.Spec.Quota.Value()))
+	}
+	usage := repo.Status.Usage
+	backupRepoUsedBytes.WithLabelValues(repo.Name).Set(float64(usage.TotalSize.Value()))
+	for _, item := range usage.Clusters {
+		backupRepoClusterUsedBytes.WithLabelValues(repo.Name, item.Namespace, item.Name).Set(float64(item.Size.Value()))
+	}
+	for _, item := range usage.BackupPolicies {
+		backupRepoBackupPolicyUsedBytes.WithLabelValues(repo.Name, item.Namespace, item.Name).Set(float64(item.Size.Value()))
+	}
+	for _, u := range backupUsages {
+		backupRepoBackupUsedBytes.WithLabelValues(repo.Name, u.backup.Namespace, u.backup.Name,
+			getBackupClusterName(u.backup), u.backup.Spec.BackupPolicyName).Set(float64(u.size))
+	}
+}
+
+func deleteRepoUsageMetrics(repoName string) {
+	labels := prometheus.Labels{"backup_repo": repoName}
+	backupRepoUsedBytes.DeletePartialMatch(labels)
+	backupRepoQuotaBytes.DeletePartialMatch(labels)
+	backupRepoClusterUsedBytes.DeletePartialMatch(labels)
+	backupRepoBackupPolicyUsedBytes.DeletePartialMatch(labels)
+	backupRepoBackupUsedBytes.DeletePartialMatch(labels)
+}
+
+func getBackupRepoUsageFrequency() time.Duration {
+	frequencySeconds := viper.GetInt(dptypes.CfgKeyBackupRepoUsageFrequencySeconds)
+	if frequencySeconds <= 0 {
+		frequencySeconds = dptypes.DefaultBackupRepoUsageFrequencySeconds
+	}
+	return time.Duration(frequencySeconds) * time.Second
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("Backup Repo Usage Controller", func() {
+	const (
+		namespace = "default"
+		repoName  = "test-repo"
+	)
+
+	newBackup := func(name, cluster, policy, path, totalSize string) *dpv1alpha1.Backup {
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{constant.AppInstanceLabelKey: cluster},
+			},
+			Spec: dpv1alpha1.BackupSpec{BackupPolicyName: policy},
+			Status: dpv1alpha1.BackupStatus{
+				BackupRepoName: repoName,
+				Path:           path,
+				TotalSize:      totalSize,
+			},
+		}
+	}
+
+	It("should parse the output of the usage job", func() {
+		// the output of "datasafed stat" for each path
+		output := strings.Join([]string{
+			"+ datasafed stat /",
+			"usage:/",
+			"TotalSize: 1024",
+			"Entries: 3",
+			"Dirs: 1",
+			"Files: 2",
+			"usage:/default/backup-1",
+			"TotalSize: 100",
+			"Entries: 1",
+			"Dirs: 0",
+			"Files: 1",
+			"usage:/default/path:with:colons",
+			"TotalSize: 200",
+			"Entries: 1",
+			"Dirs: 0",
+			"Files: 1",
+		}, "\n")
+		sizes, err := parseRepoUsageOutput(strings.NewReader(output))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sizes).Should(Equal(map[string]int64{
+			"/":                         1024,
+			"/default/backup-1":         100,
+			"/default/path:with:colons": 200,
+		}))
+
+		By("no total size in the stat output")
+		_, err = parseRepoUsageOutput(strings.NewReader("usage:/\nEntries: 3\nusage:/default/backup-1\nTotalSize: 100"))
+		Expect(err).Should(MatchError(errUnexpectedUsageOutput))
+		_, err = parseRepoUsageOutput(strings.NewReader("usage:/default/backup-1"))
+		Expect(err).Should(MatchError(errUnexpectedUsageOutput))
+
+		By("invalid total size in the stat output")
+		_, err = parseRepoUsageOutput(strings.NewReader("usage:/\nTotalSize: invalid"))
+		Expect(err).Should(MatchError(errUnexpectedUsageOutput))
+	})
+
+	It("should build the paths to stat", func() {
+		repo := &dpv1alpha1.BackupRepo{Spec: dpv1alpha1.BackupRepoSpec{PathPrefix: "prefix"}}
+		backup1 := newBackup("backup-1", "cluster-1", "policy-1", "/prefix/default/backup-1", "")
+		backup2 := newBackup("backup-2", "cluster-1", "policy-1", "prefix/default/backup-2", "")
+		backup2.Status.KopiaRepoPath = "/prefix/default/kopia"
+		backup3 := newBackup("backup-3", "cluster-1", "policy-1", "/prefix/default/backup-3", "")
+		backup3.Status.KopiaRepoPath = "/prefix/default/kopia"
+		Expect(buildUsagePaths(repo, []*dpv1alpha1.Backup{backup1, backup2, backup3})).Should(Equal([]string{
+			"/prefix", "/prefix/default/backup-1", "/prefix/default/kopia",
+		}))
+	})
+
+	It("should compute the usage of the backup repo", func() {
+		repo := &dpv1alpha1.BackupRepo{ObjectMeta: metav1.ObjectMeta{Name: repoName}}
+		backup1 := newBackup("backup-1", "cluster-1", "policy-1", "/default/backup-1", "")
+		backup2 := newBackup("backup-2", "cluster-2", "policy-2", "/default/backup-2", "300")
+		backup3 := newBackup("backup-3", "cluster-2", "policy-3", "/default/backup-3", "300")
+		backup3.Status.KopiaRepoPath = "/default/kopia"
+		backup4 := newBackup("backup-4", "cluster-2", "policy-3", "/default/backup-4", "100")
+		backup4.Status.KopiaRepoPath = "/default/kopia"
+		sizes := map[string]int64{
+			"/":                 5000,
+			"/default/backup-1": 1000,
+			"/default/kopia":    400,
+		}
+
+		By("computing the usage")
+		usage, backupUsages := computeRepoUsage(repo,
+			[]*dpv1alpha1.Backup{backup1, backup2, backup3, backup4}, sizes)
+		Expect(usage.TotalSize.Value()).Should(BeEquivalentTo(5000))
+		Expect(usage.BackupCount).Should(BeEquivalentTo(4))
+
+		By("checking the size of the backups")
+		backupSizes := map[string]int64{}
+		for _, u := range backupUsages {
+			backupSizes[u.backup.Name] = u.size
+		}
+		// the reported total size is used if the path is not measured,
+		// and the size of the kopia repository is shared in proportion to the total sizes
+		Expect(backupSizes).Should(Equal(map[string]int64{
+			"backup-1": 1000,
+			"backup-2": 300,
+			"backup-3": 300,
+			"backup-4": 100,
+		}))
+
+		By("checking the usage of the clusters and the backup policies")
+		Expect(usage.Clusters).Should(HaveLen(2))
+		Expect(usage.Clusters[0].Name).Should(Equal("cluster-1"))
+		Expect(usage.Clusters[0].Size.Value()).Should(BeEquivalentTo(1000))
+		Expect(usage.Clusters[1].Name).Should(Equal("cluster-2"))
+		Expect(usage.Clusters[1].Size.Value()).Should(BeEquivalentTo(700))
+		Expect(usage.Clusters[1].BackupCount).Should(BeEquivalentTo(3))
+		Expect(usage.BackupPolicies).Should(HaveLen(3))
+		Expect(usage.BackupPolicies[2].Name).Should(Equal("policy-3"))
+		Expect(usage.BackupPolicies[2].Size.Value()).Should(BeEquivalentTo(400))
+	})
+
+	It("should check whether the backup repo exceeds its quota", func() {
+		repo := &dpv1alpha1.BackupRepo{}
+		Expect(repo.QuotaExceeded()).Should(BeFalse())
+		usage, _ := computeRepoUsage(repo, nil, map[string]int64{"/": 2048})
+		repo.Status.Usage = usage
+		Expect(repo.QuotaExceeded()).Should(BeFalse())
+		quota := usage.TotalSize.DeepCopy()
+		repo.Spec.Quota = &quota
+		Expect(repo.QuotaExceeded()).Should(BeTrue())
+	})
+})
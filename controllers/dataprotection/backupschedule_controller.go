@@ -22,10 +22,12 @@ package dataprotection
 import (
 	"context"
 	"reflect"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -40,8 +42,12 @@ import (
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 )
 
+// scheduleWaitingInterval is the interval to check the scheduled run which is postponed.
+const scheduleWaitingInterval = time.Minute
+
 // BackupScheduleReconciler reconciles a BackupSchedule object
 type BackupScheduleReconciler struct {
 	client.Client
@@ -91,7 +97,15 @@ func (r *BackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.patchStatusFailed(reqCtx, backupSchedule, "HandleBackupScheduleFailed", err)
 	}
 
-	return r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	result, err := r.patchStatusAvailable(reqCtx, original, backupSchedule)
+	if err != nil {
+		return result, err
+	}
+	// requeue at the next schedule time if the schedules are executed by the controller.
+	if requeueAfter := getNextScheduleDuration(backupSchedule); requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+	}
+	return result, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -217,8 +231,50 @@ func (r *BackupScheduleReconciler) patchScheduleMetadata(
 	return r.Client.Patch(reqCtx.Ctx, backupSchedule, patch)
 }
 
+// getNextScheduleDuration returns the duration to the nearest next schedule time of the enabled schedules.
+// It returns zero if the schedules are not executed by the controller.
+func getNextScheduleDuration(backupSchedule *dpv1alpha1.BackupSchedule) time.Duration {
+	if !backupSchedule.IsControllerMode() {
+		return 0
+	}
+	var nextScheduleTime *metav1.Time
+	for i := range backupSchedule.Spec.Schedules {
+		schedulePolicy := &backupSchedule.Spec.Schedules[i]
+		if !boolptr.IsSetToTrue(schedulePolicy.Enabled) {
+			continue
+		}
+		status, ok := backupSchedule.Status.Schedules[schedulePolicy.GetScheduleName()]
+		if !ok || status.NextScheduleTime == nil {
+			continue
+		}
+		if nextScheduleTime == nil || status.NextScheduleTime.Before(nextScheduleTime) {
+			nextScheduleTime = status.NextScheduleTime
+		}
+	}
+	if nextScheduleTime == nil {
+		return 0
+	}
+	duration := time.Until(nextScheduleTime.Time)
+	if duration <= 0 {
+		// the scheduled run is waiting for the running backups, which will also trigger the reconciliation.
+		return scheduleWaitingInterval
+	}
+	return duration
+}
+
 func (r *BackupScheduleReconciler) parseBackup(ctx context.Context, object client.Object) []reconcile.Request {
 	backup := object.(*dpv1alpha1.Backup)
+	// the backups created by the controller, reconcile the schedule to handle the concurrency policy.
+	if backup.Labels[dptypes.SchedulePolicyLabelKey] != "" && backup.Labels[dptypes.BackupScheduleLabelKey] != "" {
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: backup.Namespace,
+					Name:      backup.Labels[dptypes.BackupScheduleLabelKey],
+				},
+			},
+		}
+	}
 	backupScheduleName := dptypes.BackupScheduleLabelKey
 	if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) &&
 		backupScheduleName != "" {
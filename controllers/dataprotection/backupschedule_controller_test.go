@@ -141,7 +141,7 @@ var _ = Describe("Backup Schedule Controller", func() {
 				By("checking cronjob, should exist one cronjob to create backup")
 				Eventually(testapps.CheckObj(&testCtx, getCronjobKey(backupSchedule, testdp.BackupMethodName, ""), func(g Gomega, fetched *batchv1.CronJob) {
 					schedulePolicy := dpbackup.GetSchedulePolicyByMethod(backupSchedule, testdp.BackupMethodName)
-					timeZone, cronExpr := dpbackup.BuildCronJobSchedule(schedulePolicy.CronExpression)
+					timeZone, cronExpr := dpbackup.BuildCronJobSchedule(schedulePolicy.CronExpression, schedulePolicy.TimeZone)
 					g.Expect(fetched.Labels[constant.AppManagedByLabelKey]).Should(Equal(dptypes.AppName))
 					g.Expect(boolptr.IsSetToTrue(schedulePolicy.Enabled)).To(BeTrue())
 					g.Expect(fetched.Spec.Schedule).To(Equal(cronExpr))
@@ -193,6 +193,40 @@ var _ = Describe("Backup Schedule Controller", func() {
 			})
 		})
 
+		Context("creates a backup schedule in controller mode", func() {
+			It("should schedule without cronjob", func() {
+				By("creating a backupSchedule in controller mode")
+				backupSchedule := testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+					schedule.Spec.ScheduleMode = dpv1alpha1.ScheduleModeController
+					schedule.Spec.Schedules[0].Enabled = boolptr.True()
+					schedule.Spec.Schedules[0].TimeZone = "Asia/Shanghai"
+				})
+				backupScheduleKey := client.ObjectKeyFromObject(backupSchedule)
+
+				By("checking backupSchedule status, should record the next schedule time")
+				Eventually(testapps.CheckObj(&testCtx, backupScheduleKey, func(g Gomega, fetched *dpv1alpha1.BackupSchedule) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupSchedulePhaseAvailable))
+					status := fetched.Status.Schedules[testdp.BackupMethodName]
+					g.Expect(status.Phase).To(Equal(dpv1alpha1.ScheduleRunning))
+					g.Expect(status.NextScheduleTime).ShouldNot(BeNil())
+				})).Should(Succeed())
+
+				By("checking cronjob, should not exist because the schedule is executed by the controller")
+				Consistently(testapps.CheckObjExists(&testCtx, getCronjobKey(backupSchedule, testdp.BackupMethodName, ""),
+					&batchv1.CronJob{}, false)).Should(Succeed())
+			})
+
+			It("should fail with invalid time zone", func() {
+				backupSchedule := testdp.NewFakeBackupSchedule(&testCtx, func(schedule *dpv1alpha1.BackupSchedule) {
+					schedule.Spec.ScheduleMode = dpv1alpha1.ScheduleModeController
+					schedule.Spec.Schedules[0].TimeZone = "invalid"
+				})
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backupSchedule), func(g Gomega, fetched *dpv1alpha1.BackupSchedule) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupSchedulePhaseFailed))
+				})).Should(Succeed())
+			})
+		})
+
 		Context("create a backup schedule with parameters", func() {
 			const (
 				scheduleName = "test"
@@ -0,0 +1,310 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+// BackupVerificationReconciler verifies the integrity of the completed backups, by checksumming the
+// backup data in the backup repository, and restoring the backups in throwaway pods periodically.
+type BackupVerificationReconciler struct {
+	client.Client
+	Scheme   *k8sruntime.Scheme
+	Recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+func NewBackupVerificationReconciler(mgr ctrl.Manager) *BackupVerificationReconciler {
+	return &BackupVerificationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-verification-controller"),
+		clock:    clock.RealClock{},
+	}
+}
+
+// SetupWithManager sets up the BackupVerificationReconciler using the supplied manager.
+func (r *BackupVerificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("backupverification").
+		For(&dpv1alpha1.Backup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// verify the completed backups.
+func (r *BackupVerificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backup verification", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !r.isBackupVerifiable(backup) {
+		return intctrlutil.Reconciled()
+	}
+
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Get(reqCtx.Ctx, client.ObjectKey{Name: backup.Spec.BackupPolicyName, Namespace: backup.Namespace}, backupPolicy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	policy := backupPolicy.Spec.Verification
+	if policy == nil {
+		return intctrlutil.Reconciled()
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to get worker service account")
+	}
+	verifier := &dpbackup.Verifier{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+
+	var requeueAfter time.Duration
+	updateRequeueAfter := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+
+	// verify the checksums
+	if boolptr.IsSetToTrue(policy.Checksum) || policy.Checksum == nil {
+		result := getVerificationResult(backup, dpbackup.VerificationTypeChecksum)
+		due, remaining := r.isVerificationDue(result, policy.ChecksumIntervalHours, true)
+		// the checksums recorded by the first verification are compared with once by default
+		if result != nil && result.Phase == dpv1alpha1.VerificationPhaseChecksumRecorded && policy.ChecksumIntervalHours <= 0 {
+			due = true
+		}
+		if due {
+			if err = r.verify(reqCtx, verifier, backup, dpbackup.VerificationTypeChecksum, nil); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+		}
+		updateRequeueAfter(remaining)
+	}
+
+	// verify by restoring the latest completed backup
+	if policy.RestoreIntervalHours > 0 {
+		actionSet, err := r.getActionSet(reqCtx, backup)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if actionSet.HasPrepareDataStage() && actionSet.Spec.Backup != nil && actionSet.Spec.Backup.Verify != nil {
+			due, remaining, err := r.isRestoreVerificationDue(reqCtx.Ctx, backup, policy.RestoreIntervalHours)
+			if err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			if due {
+				if err = r.verify(reqCtx, verifier, backup, dpbackup.VerificationTypeRestore, actionSet); err != nil {
+					return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+				}
+			}
+			updateRequeueAfter(remaining)
+		}
+	}
+
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next verification")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// isBackupVerifiable checks whether the backup data is stored in the backup repository and can be verified.
+func (r *BackupVerificationReconciler) isBackupVerifiable(backup *dpv1alpha1.Backup) bool {
+	if !backup.DeletionTimestamp.IsZero() || backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return false
+	}
+	if backup.Status.PrimaryDeletionTimestamp != nil {
+		return false
+	}
+	if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+		return false
+	}
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		return false
+	}
+	return len(backup.Status.BackupRepoName) > 0 && len(backup.Status.Path) > 0
+}
+
+// isVerificationDue checks whether the verification should be run, and returns the duration to wait otherwise.
+func (r *BackupVerificationReconciler) isVerificationDue(result *dpv1alpha1.VerificationResult,
+	intervalHours int32, verifyIfNeverRun bool) (bool, time.Duration) {
+	if result == nil {
+		return verifyIfNeverRun, 0
+	}
+	if result.Phase == dpv1alpha1.VerificationPhaseVerifying {
+		return true, 0
+	}
+	if intervalHours <= 0 || result.StartTimestamp == nil {
+		return false, 0
+	}
+	remaining := result.StartTimestamp.Add(time.Duration(intervalHours) * time.Hour).Sub(r.clock.Now())
+	if remaining <= 0 {
+		return true, 0
+	}
+	return false, remaining
+}
+
+// isRestoreVerificationDue checks whether the backup should be verified by restoring it.
+// Only the latest completed backup of each backup method is verified, and the verifications of the
+// backups of the same backup method are run at most once per interval.
+func (r *BackupVerificationReconciler) isRestoreVerificationDue(ctx context.Context,
+	backup *dpv1alpha1.Backup, intervalHours int32) (bool, time.Duration, error) {
+	result := getVerificationResult(backup, dpbackup.VerificationTypeRestore)
+	if result != nil && result.Phase == dpv1alpha1.VerificationPhaseVerifying {
+		return true, 0, nil
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return false, 0, err
+	}
+	var (
+		latest *dpv1alpha1.Backup
+		last   *dpv1alpha1.VerificationResult
+	)
+	for i, b := range backupList.Items {
+		if b.Spec.BackupMethod != backup.Spec.BackupMethod || !r.isBackupVerifiable(&b) {
+			continue
+		}
+		if latest == nil || dputils.CompareWithBackupStopTime(*latest, b) {
+			latest = &backupList.Items[i]
+		}
+		if res := getVerificationResult(&b, dpbackup.VerificationTypeRestore); res != nil && res.StartTimestamp != nil {
+			if last == nil || res.StartTimestamp.After(last.StartTimestamp.Time) {
+				last = res
+			}
+		}
+	}
+	if latest == nil || latest.Name != backup.Name {
+		return false, 0, nil
+	}
+	due, remaining := r.isVerificationDue(last, intervalHours, true)
+	return due, remaining, nil
+}
+
+// verify runs the verification and records the result in the backup status.
+func (r *BackupVerificationReconciler) verify(reqCtx intctrlutil.RequestCtx, verifier *dpbackup.Verifier,
+	backup *dpv1alpha1.Backup, verificationType dpbackup.VerificationType, actionSet *dpv1alpha1.ActionSet) error {
+	phase, verifyErr := verifier.Verify(backup, verificationType, actionSet)
+	if phase == "" {
+		return verifyErr
+	}
+
+	patch := client.MergeFrom(backup.DeepCopy())
+	now := metav1.NewTime(r.clock.Now())
+	result := getVerificationResult(backup, verificationType)
+	if result == nil || result.Phase != dpv1alpha1.VerificationPhaseVerifying {
+		result = &dpv1alpha1.VerificationResult{StartTimestamp: &now}
+	}
+	result.Phase = phase
+	if phase != dpv1alpha1.VerificationPhaseVerifying {
+		result.CompletionTimestamp = &now
+	}
+	if verifyErr != nil {
+		result.FailureReason = verifyErr.Error()
+	}
+	setVerificationResult(backup, verificationType, result)
+	if phase == dpv1alpha1.VerificationPhaseChecksumRecorded {
+		backup.Status.Verification.ChecksumRecordTimestamp = &now
+	}
+	if err := r.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return err
+	}
+
+	switch phase {
+	case dpv1alpha1.VerificationPhaseVerified:
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupVerified",
+			fmt.Sprintf("the %s verification of the backup succeeded", verificationType))
+	case dpv1alpha1.VerificationPhaseChecksumRecorded:
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupChecksumRecorded",
+			"the checksums of the backup are recorded, and will be compared with by the next checksum verification")
+	case dpv1alpha1.VerificationPhaseFailed:
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupVerificationFailed", result.FailureReason)
+	default:
+		return nil
+	}
+	return verifier.CleanupVerification(backup, verificationType)
+}
+
+func (r *BackupVerificationReconciler) getActionSet(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (*dpv1alpha1.ActionSet, error) {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod == nil || backupMethod.ActionSetName == "" {
+		return nil, nil
+	}
+	return dputils.GetActionSetByName(reqCtx, r.Client, backupMethod.ActionSetName)
+}
+
+func getVerificationResult(backup *dpv1alpha1.Backup, verificationType dpbackup.VerificationType) *dpv1alpha1.VerificationResult {
+	if backup.Status.Verification == nil {
+		return nil
+	}
+	if verificationType == dpbackup.VerificationTypeChecksum {
+		return backup.Status.Verification.Checksum
+	}
+	return backup.Status.Verification.Restore
+}
+
+func setVerificationResult(backup *dpv1alpha1.Backup, verificationType dpbackup.VerificationType, result *dpv1alpha1.VerificationResult) {
+	if backup.Status.Verification == nil {
+		backup.Status.Verification = &dpv1alpha1.BackupVerificationStatus{}
+	}
+	if verificationType == dpbackup.VerificationTypeChecksum {
+		backup.Status.Verification.Checksum = result
+	} else {
+		backup.Status.Verification.Restore = result
+	}
+}
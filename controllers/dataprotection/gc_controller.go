@@ -25,8 +25,11 @@ import (
 	"sort"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,6 +40,7 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -45,6 +49,7 @@ import (
 // GCReconciler garbage collection reconciler, which periodically deletes expired backups.
 type GCReconciler struct {
 	client.Client
+	Scheme    *k8sruntime.Scheme
 	Recorder  record.EventRecorder
 	clock     clock.WithTickerAndDelayedExecution
 	frequency time.Duration
@@ -53,6 +58,7 @@ type GCReconciler struct {
 func NewGCReconciler(mgr ctrl.Manager) *GCReconciler {
 	return &GCReconciler{
 		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
 		Recorder:  mgr.GetEventRecorderFor("gc-controller"),
 		clock:     clock.RealClock{},
 		frequency: getGCFrequency(),
@@ -71,7 +77,9 @@ func (r *GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
-// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupschedules,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // delete expired backups.
@@ -108,11 +116,17 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		return intctrlutil.Reconciled()
 	}
 
-	if deletable, err := r.isBackupDeletable(reqCtx, backup); !deletable {
-		return intctrlutil.Reconciled()
-	} else if err != nil {
-		reqCtx.Log.Error(err, "failed to check backup deletability")
-		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	if backup.Status.PrimaryDeletionTimestamp == nil {
+		if deletable, err := r.isBackupDeletable(reqCtx, backup); !deletable {
+			return intctrlutil.Reconciled()
+		} else if err != nil {
+			reqCtx.Log.Error(err, "failed to check backup deletability")
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if isRetainedByReplicas(backup, now) {
+		return r.deletePrimaryBackupFiles(reqCtx, backup)
 	}
 
 	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
@@ -125,6 +139,66 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	return intctrlutil.Reconciled()
 }
 
+// deletePrimaryBackupFiles deletes the backup files from the backup repository where the backup was created,
+// while the backup is retained until its replicas expire.
+func (r *GCReconciler) deletePrimaryBackupFiles(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if backup.Status.PrimaryDeletionTimestamp != nil {
+		reqCtx.Log.V(1).Info("backup is retained until its replicas expire, skipping")
+		return intctrlutil.Reconciled()
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to get worker service account")
+	}
+	deleter := &dpbackup.Deleter{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	status, err := deleter.DeleteBackupFiles(backup)
+	switch status {
+	case dpbackup.DeletionStatusSucceeded:
+		patch := client.MergeFrom(backup.DeepCopy())
+		backup.Status.PrimaryDeletionTimestamp = &metav1.Time{Time: r.clock.Now()}
+		if err = r.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "ExpiredBackupFilesDeleted",
+			fmt.Sprintf("the expired backup is deleted from the backup repo %s, and retained until its replicas expire",
+				backup.Status.BackupRepoName))
+		job := &batchv1.Job{}
+		if err = r.Get(reqCtx.Ctx, dpbackup.BuildDeleteBackupFilesJobKey(backup, false), job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(client.IgnoreNotFound(err), reqCtx.Log, "")
+		}
+		if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	case dpbackup.DeletionStatusFailed:
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveExpiredBackupsFailed", err.Error())
+	case dpbackup.DeletionStatusUnknown:
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the deletion job is checked by the next gc
+	return intctrlutil.Reconciled()
+}
+
+// isRetainedByReplicas returns true if any replica of the backup has not expired yet, the backup is
+// retained as the replicas are tracked in its status, while its files in the backup repository where
+// it was created are deleted. The replicas without expiration are deleted along with the backup.
+func isRetainedByReplicas(backup *dpv1alpha1.Backup, now time.Time) bool {
+	for _, replica := range backup.Status.Replicas {
+		if replica.Phase == dpv1alpha1.BackupReplicaPhaseDeleted || replica.Expiration == nil {
+			continue
+		}
+		if replica.Expiration.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
 func getGCFrequency() time.Duration {
 	gcFrequencySeconds := viper.GetInt(dptypes.CfgKeyGCFrequencySeconds)
 	if gcFrequencySeconds > 0 {
@@ -172,6 +246,11 @@ func (r *GCReconciler) isBackupDeletable(reqCtx intctrlutil.RequestCtx, backup *
 			backup.Namespace, backup.Name))
 		return false, nil
 	}
+	if retained, err := r.isRetainedByGFS(reqCtx, backup, backupPolicy); err != nil {
+		return false, err
+	} else if retained {
+		return false, nil
+	}
 	if backupPolicy.Spec.RetentionPolicy == dpv1alpha1.BackupPolicyRetentionPolicyRetainLatestBackup {
 		isLatest, err := r.isLatestCompletedBackup(reqCtx.Ctx, backup)
 		if err != nil {
@@ -187,6 +266,89 @@ func (r *GCReconciler) isBackupDeletable(reqCtx intctrlutil.RequestCtx, backup *
 	return true, nil
 }
 
+// isRetainedByGFS returns true if the backup is retained by the grandfather-father-son retention policy,
+// or any incremental backup that depends on it is still retained.
+func (r *GCReconciler) isRetainedByGFS(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup,
+	backupPolicy *dpv1alpha1.BackupPolicy) (bool, error) {
+	gfsRetention, err := r.getGFSRetentionPolicy(reqCtx.Ctx, backup, backupPolicy)
+	if err != nil {
+		return false, err
+	}
+	policyBackups, err := r.getPolicyBackups(reqCtx.Ctx, backup)
+	if err != nil {
+		return false, err
+	}
+	if gfsRetention != nil {
+		if _, ok := dputils.GetGFSRetainedBackups(filterBackupsByMethod(policyBackups, backup.Spec.BackupMethod), gfsRetention)[backup.Name]; ok {
+			reqCtx.Log.V(1).Info(fmt.Sprintf("backup %s/%s is retained by the GFS retention policy, skipping",
+				backup.Namespace, backup.Name))
+			return true, nil
+		}
+	}
+
+	// the backup can not be deleted while the incremental backups depending on it are retained
+	now := r.clock.Now()
+	for _, b := range policyBackups {
+		if b.Name == backup.Name || !b.DeletionTimestamp.IsZero() || !dependsOnBackup(b, backup.Name) {
+			continue
+		}
+		dependantGFSRetention, err := r.getGFSRetentionPolicy(reqCtx.Ctx, b, backupPolicy)
+		if err != nil {
+			return false, err
+		}
+		if gfsRetention == nil && dependantGFSRetention == nil {
+			continue
+		}
+		retained := b.Status.Expiration == nil || b.Status.Expiration.After(now)
+		if !retained && dependantGFSRetention != nil {
+			_, retained = dputils.GetGFSRetainedBackups(filterBackupsByMethod(policyBackups, b.Spec.BackupMethod), dependantGFSRetention)[b.Name]
+		}
+		if retained {
+			reqCtx.Log.V(1).Info(fmt.Sprintf("backup %s/%s is depended on by the retained backup %s, skipping",
+				backup.Namespace, backup.Name, b.Name))
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getGFSRetentionPolicy returns the GFS retention policy of the backup, the policy of the backup schedule
+// which creates the backup takes precedence over the one of the backup policy.
+func (r *GCReconciler) getGFSRetentionPolicy(ctx context.Context, backup *dpv1alpha1.Backup,
+	backupPolicy *dpv1alpha1.BackupPolicy) (*dpv1alpha1.GFSRetentionPolicy, error) {
+	if scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]; len(scheduleName) > 0 {
+		backupSchedule := &dpv1alpha1.BackupSchedule{}
+		err := r.Get(ctx, client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			schedulePolicy := dpbackup.GetSchedulePolicyByMethod(backupSchedule, backup.Spec.BackupMethod)
+			if schedulePolicy != nil && schedulePolicy.GFSRetention != nil {
+				return schedulePolicy.GFSRetention, nil
+			}
+		}
+	}
+	return backupPolicy.Spec.GFSRetention, nil
+}
+
+// dependsOnBackup returns true if the backup is an incremental backup based on the given backup.
+func dependsOnBackup(backup *dpv1alpha1.Backup, name string) bool {
+	return backup.Status.BaseBackupName == name ||
+		backup.Status.ParentBackupName == name ||
+		backup.Spec.ParentBackupName == name
+}
+
+func filterBackupsByMethod(backups []*dpv1alpha1.Backup, method string) []*dpv1alpha1.Backup {
+	var filtered []*dpv1alpha1.Backup
+	for i, b := range backups {
+		if len(b.Spec.BackupMethod) != 0 && b.Spec.BackupMethod == method {
+			filtered = append(filtered, backups[i])
+		}
+	}
+	return filtered
+}
+
 // isLatestCompletedBackup returns true if the backup is the latest completed backup.
 func (r *GCReconciler) isLatestCompletedBackup(ctx context.Context, backup *dpv1alpha1.Backup) (bool, error) {
 	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
@@ -222,6 +384,15 @@ func (r *GCReconciler) isLatestCompletedBackup(ctx context.Context, backup *dpv1
 
 // getRelatedBackups returns the related backups of the given backup.
 func (r *GCReconciler) getRelatedBackups(ctx context.Context, backup *dpv1alpha1.Backup) ([]*dpv1alpha1.Backup, error) {
+	policyBackups, err := r.getPolicyBackups(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+	return filterBackupsByMethod(policyBackups, backup.Spec.BackupMethod), nil
+}
+
+// getPolicyBackups returns the backups of the same cluster and backup policy as the given backup.
+func (r *GCReconciler) getPolicyBackups(ctx context.Context, backup *dpv1alpha1.Backup) ([]*dpv1alpha1.Backup, error) {
 	clusterUID := backup.Labels[dptypes.ClusterUIDLabelKey]
 	if len(clusterUID) == 0 {
 		return nil, nil
@@ -236,11 +407,9 @@ func (r *GCReconciler) getRelatedBackups(ctx context.Context, backup *dpv1alpha1
 	} else if apierrors.IsNotFound(err) {
 		return nil, nil
 	}
-	var filtered []*dpv1alpha1.Backup
-	for i, b := range backupList.Items {
-		if len(b.Spec.BackupMethod) != 0 && b.Spec.BackupMethod == backup.Spec.BackupMethod {
-			filtered = append(filtered, &backupList.Items[i])
-		}
+	backups := make([]*dpv1alpha1.Backup, 0, len(backupList.Items))
+	for i := range backupList.Items {
+		backups = append(backups, &backupList.Items[i])
 	}
-	return filtered, nil
+	return backups, nil
 }
@@ -171,6 +171,41 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, expiredKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
 
+		It("delete the expired backup files while the replicas are retained", func() {
+			By("create an expired backup with an unexpired replica")
+			backup := createBackup(backupNamePrefix+"replicated", testdp.BackupMethodName)
+			key := client.ObjectKeyFromObject(backup)
+			testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+			checkBackupCompleted(key)
+			Expect(testCtx.Cli.Get(testCtx.Ctx, key, backup)).Should(Succeed())
+			backup.Status.Expiration = &metav1.Time{Time: fakeClock.Now().Add(-time.Hour)}
+			backup.Status.Replicas = []dpv1alpha1.BackupReplicaStatus{
+				{
+					BackupRepoName:      "replica-repo",
+					Path:                backup.Status.Path,
+					Phase:               dpv1alpha1.BackupReplicaPhaseCompleted,
+					CompletionTimestamp: &metav1.Time{Time: fakeClock.Now()},
+					Expiration:          &metav1.Time{Time: fakeClock.Now().Add(time.Hour * 24)},
+				},
+			}
+			testdp.PatchBackupStatus(&testCtx, key, backup.Status)
+
+			By("the backup files are deleted from the backup repo")
+			jobKey := dpbackup.BuildDeleteBackupFilesJobKey(backup, false)
+			Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, true)).Should(Succeed())
+			testdp.PatchK8sJobStatus(&testCtx, jobKey, batchv1.JobComplete)
+			Eventually(testapps.CheckObj(&testCtx, key, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.DeletionTimestamp).Should(BeNil())
+				g.Expect(fetched.Status.PrimaryDeletionTimestamp).ShouldNot(BeNil())
+			})).Should(Succeed())
+
+			By("the backup is deleted once the replica expires")
+			Expect(testCtx.Cli.Get(testCtx.Ctx, key, backup)).Should(Succeed())
+			backup.Status.Replicas[0].Expiration = &metav1.Time{Time: fakeClock.Now().Add(-time.Hour)}
+			testdp.PatchBackupStatus(&testCtx, key, backup.Status)
+			Eventually(testapps.CheckObjExists(&testCtx, key, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+		})
+
 		It("should not delete the latest backup", func() {
 			shouldNotDelete := func(key client.ObjectKey) {
 				Eventually(testapps.CheckObjExists(&testCtx, key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
@@ -266,5 +301,61 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, olderKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 			Eventually(testapps.CheckObjExists(&testCtx, incrementalKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
+
+		It("should retain the backups selected by the GFS retention policy", func() {
+			shouldNotDelete := func(key client.ObjectKey) {
+				Eventually(testapps.CheckObjExists(&testCtx, key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+				Eventually(testapps.CheckObj(&testCtx, key,
+					func(g Gomega, fetched *dpv1alpha1.Backup) {
+						g.Expect(fetched.DeletionTimestamp).To(BeNil())
+					})).Should(Succeed())
+			}
+
+			createExpiredBackup := func(name, methodName, parentName string, completion time.Time) client.ObjectKey {
+				backup := createBackup(name, methodName)
+				key := client.ObjectKeyFromObject(backup)
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				checkBackupCompleted(key)
+
+				backup.Status.Expiration = &metav1.Time{Time: fakeClock.Now().Add(-time.Hour)}
+				backup.Status.ParentBackupName = parentName
+				backup.Status.StartTimestamp = &metav1.Time{Time: completion.Add(-time.Minute)}
+				backup.Status.CompletionTimestamp = &metav1.Time{Time: completion}
+				backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+				backup.Status.BackupRepoName = testdp.BackupRepoName
+				testdp.PatchBackupStatus(&testCtx, key, backup.Status)
+				return key
+			}
+
+			By("setting the GFS retention policy to retain the latest backup of one day")
+			Expect(testapps.ChangeObj(&testCtx, backupPolicy, func(policy *dpv1alpha1.BackupPolicy) {
+				policy.Spec.GFSRetention = &dpv1alpha1.GFSRetentionPolicy{Daily: 1}
+			})).Should(Succeed())
+
+			By("creating the expired backups of different days")
+			_ = testdp.NewFakeIncActionSet(&testCtx)
+			olderKey := createExpiredBackup("older-full-backup", testdp.BackupMethodName, "", fakeClock.Now().Add(-time.Hour*72))
+			incrementalKey := createExpiredBackup("incremental-backup", testdp.IncBackupMethodName, olderKey.Name, fakeClock.Now().Add(-time.Hour*48))
+			latestKey := createExpiredBackup("latest-full-backup", testdp.BackupMethodName, "", fakeClock.Now().Add(-time.Hour*24))
+
+			By("the latest full backup and incremental backup are selected, the older full backup is depended on")
+			time.Sleep(2 * gcFrequency)
+			Eventually(testapps.List(&testCtx, generics.BackupSignature,
+				client.MatchingLabels(autoBackupLabel),
+				client.InNamespace(backupPolicy.Namespace))).Should(HaveLen(3))
+			shouldNotDelete(latestKey)
+			shouldNotDelete(incrementalKey)
+			shouldNotDelete(olderKey)
+
+			By("reset the GFS retention policy")
+			Expect(testapps.ChangeObj(&testCtx, backupPolicy, func(policy *dpv1alpha1.BackupPolicy) {
+				policy.Spec.GFSRetention = nil
+			})).Should(Succeed())
+
+			By("verify all backups are deleted")
+			Eventually(testapps.List(&testCtx, generics.BackupSignature,
+				client.MatchingLabels(autoBackupLabel),
+				client.InNamespace(backupPolicy.Namespace))).Should(HaveLen(0))
+		})
 	})
 })
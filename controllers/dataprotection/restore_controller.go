@@ -164,6 +164,9 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 		}
 		return "", err
 	}
+	if err := dprestore.UseBackupReplica(backup, restore.Spec.Backup.BackupRepoName); err != nil {
+		return "", err
+	}
 	if backup.Status.BackupRepoName == "" {
 		// The backup doesn't use backup repo.
 		return "", nil
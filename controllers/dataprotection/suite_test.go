@@ -185,6 +185,13 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&BackupReplicationPolicyReconciler{
+		Client:   k8sClient,
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("backup-replication-policy-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&BackupPolicyReconciler{
 		Client:   k8sClient,
 		Scheme:   k8sManager.GetScheme(),
@@ -253,6 +260,7 @@ func mockGCReconciler(mgr ctrl.Manager) *GCReconciler {
 	fakeClock = testclocks.NewFakeClock(time.Now())
 	return &GCReconciler{
 		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
 		Recorder:  mgr.GetEventRecorderFor("gc-controller"),
 		clock:     fakeClock,
 		frequency: gcFrequency,
@@ -35,6 +35,7 @@ const (
 	dataProtectionBackupRepoKey          = "dataprotection.kubeblocks.io/backup-repo-name"
 	dataProtectionWaitRepoPreparationKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
 	dataProtectionIsToolConfigKey        = "dataprotection.kubeblocks.io/is-tool-config"
+	dataProtectionBackupRepoUsageKey     = "dataprotection.kubeblocks.io/backup-repo-usage"
 
 	// annotation keys
 	dataProtectionBackupRepoDigestAnnotationKey     = "dataprotection.kubeblocks.io/backup-repo-digest"
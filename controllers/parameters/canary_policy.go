@@ -0,0 +1,331 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	canaryCheckInterval = time.Second * 5
+)
+
+// canaryCapablePolicies lists the policies that apply the parameters instance by instance.
+var canaryCapablePolicies = []parametersv1alpha1.ReloadPolicy{
+	parametersv1alpha1.SyncDynamicReloadPolicy,
+	parametersv1alpha1.RollingPolicy,
+	parametersv1alpha1.RestartContainerPolicy,
+}
+
+func withCanary(canary *parametersv1alpha1.CanaryStatus) options {
+	return func(result *intctrlutil.Result) {
+		result.Canary = canary
+	}
+}
+
+// resolveCanaryPolicy resolves the canary policy from the applied version of the config template item.
+func resolveCanaryPolicy(cm *corev1.ConfigMap) *parametersv1alpha1.CanaryPolicy {
+	appliedVersion, ok := cm.Annotations[constant.ConfigAppliedVersionAnnotationKey]
+	if !ok {
+		return nil
+	}
+	item := parametersv1alpha1.ConfigTemplateItemDetail{}
+	if err := json.Unmarshal([]byte(appliedVersion), &item); err != nil {
+		return nil
+	}
+	return item.CanaryPolicy
+}
+
+// resolveCanaryStatus resolves the canary status of the current revision.
+func resolveCanaryStatus(cm *corev1.ConfigMap) *parametersv1alpha1.CanaryStatus {
+	revision := cm.Annotations[constant.ConfigurationRevision]
+	for _, r := range RetrieveRevision(cm.Annotations) {
+		if r.StrRevision == revision && r.Result.Canary != nil {
+			return r.Result.Canary.DeepCopy()
+		}
+	}
+	return nil
+}
+
+func isCanaryCapable(tasks []ReloadAction) bool {
+	for _, task := range tasks {
+		if !slices.Contains(canaryCapablePolicies, parametersv1alpha1.ReloadPolicy(task.ReloadType())) {
+			return false
+		}
+	}
+	return len(tasks) != 0
+}
+
+func withCanaryInstances(tasks []ReloadAction, instances []string) []ReloadAction {
+	canaryTasks := make([]ReloadAction, 0, len(tasks))
+	for _, task := range tasks {
+		if t, ok := task.(reconfigureTask); ok {
+			t.taskCtx.CanaryInstances = instances
+			task = t
+		}
+		canaryTasks = append(canaryTasks, task)
+	}
+	return canaryTasks
+}
+
+// selectCanaryInstances selects the canary instances from the tail of the rolling update order, in which the pods are
+// sorted by the role priority with the highest-priority role (e.g. the leader) first.
+//
+// The instances of the highest-priority role are never selected unless the role is specified explicitly,
+// an empty result means that there is no other instance to be the canary.
+// It returns a fatal error if no instance matches the specified role, and a retryable error
+// if the roles of the instances have not been probed yet.
+func selectCanaryInstances(tasks []ReloadAction, policy *parametersv1alpha1.CanaryPolicy) ([]string, error) {
+	task, ok := tasks[0].(reconfigureTask)
+	if !ok {
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "not support canary for reload action[%s]", tasks[0].ReloadType())
+	}
+	pods, err := GetInstanceSetRollingUpgradeFuncs().GetPodsFunc(task.taskCtx)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, core.MakeError("not found the instances of the component")
+	}
+
+	var highestPriorityRole string
+	if synthesizedComp := task.taskCtx.SynthesizedComponent; synthesizedComp != nil && len(synthesizedComp.Roles) != 0 {
+		highestPriorityRole = slices.MaxFunc(synthesizedComp.Roles, func(a, b workloads.ReplicaRole) int {
+			return a.UpdatePriority - b.UpdatePriority
+		}).Name
+	}
+
+	var (
+		unlabeled bool
+		instances []string
+		replicas  = max(int(policy.Replicas), 1)
+	)
+	for i := len(pods) - 1; i >= 0 && len(instances) < replicas; i-- {
+		role := pods[i].Labels[constant.RoleLabelKey]
+		switch {
+		case role == "" && (policy.Role != "" || highestPriorityRole != ""):
+			unlabeled = true
+		case policy.Role != "" && !strings.EqualFold(role, policy.Role):
+		case policy.Role == "" && strings.EqualFold(role, highestPriorityRole):
+		default:
+			instances = append(instances, pods[i].Name)
+		}
+	}
+
+	switch {
+	case len(instances) != 0:
+		return instances, nil
+	case unlabeled:
+		return nil, core.MakeError("the roles of some instances have not been probed yet")
+	case policy.Role != "":
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "not found the canary instance with role[%s]", policy.Role)
+	default:
+		return nil, nil
+	}
+}
+
+func (r *ReconfigureReconciler) performCanaryUpgrade(rctx *ReconcileContext,
+	tasks []ReloadAction,
+	configPatch *core.ConfigPatchInfo,
+	policy *parametersv1alpha1.CanaryPolicy) (ctrl.Result, error) {
+	reloadType := tasks[len(tasks)-1].ReloadType()
+	canary := resolveCanaryStatus(rctx.ConfigMap)
+	if canary == nil {
+		canary = &parametersv1alpha1.CanaryStatus{Stage: parametersv1alpha1.CanaryApplyingStage}
+		if !isCanaryCapable(tasks) {
+			canary.Stage = parametersv1alpha1.CanarySkippedStage
+			canary.Message = fmt.Sprintf("the reload action[%s] can't be applied instance by instance, apply to all instances", reloadType)
+		} else {
+			instances, err := selectCanaryInstances(tasks, policy)
+			switch {
+			case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+				return r.failCanary(rctx, canary, reloadType, err)
+			case err != nil:
+				return intctrlutil.RequeueAfter(canaryCheckInterval, rctx.Log, "failed to select the canary instances, retry", "error", err)
+			case len(instances) == 0:
+				canary.Stage = parametersv1alpha1.CanarySkippedStage
+				canary.Message = "there is no instance other than the highest-priority role to be the canary, apply to all instances"
+			default:
+				canary.Instances = instances
+			}
+		}
+	}
+
+	switch canary.Stage {
+	case parametersv1alpha1.CanaryApplyingStage:
+		return r.applyCanary(rctx, tasks, canary)
+	case parametersv1alpha1.CanaryBakingStage:
+		return r.bakeCanary(rctx, tasks, configPatch, policy, canary)
+	case parametersv1alpha1.CanaryFailedStage:
+		return intctrlutil.Reconciled()
+	default:
+		return r.performUpgrade(rctx, tasks, withCanary(canary))
+	}
+}
+
+// applyCanary applies the parameters to the canary instances, and starts baking once all the canary instances are updated.
+func (r *ReconfigureReconciler) applyCanary(rctx *ReconcileContext, tasks []ReloadAction, canary *parametersv1alpha1.CanaryStatus) (ctrl.Result, error) {
+	var (
+		err            error
+		reloadType     string
+		returnedStatus ReturnedStatus
+	)
+	for _, task := range withCanaryInstances(tasks, canary.Instances) {
+		reloadType = task.ReloadType()
+		returnedStatus, err = task.ExecReload()
+		if err != nil || returnedStatus.Status != ESNone {
+			return r.status(rctx, returnedStatus, reloadType, err, withCanary(canary))
+		}
+	}
+
+	canary.Stage = parametersv1alpha1.CanaryBakingStage
+	canary.BakeStartTime = &metav1.Time{Time: time.Now()}
+	rctx.Recorder.Eventf(rctx.ConfigMap, corev1.EventTypeNormal, "CanaryBaking",
+		"the parameters have been applied to the canary instances %v, start baking", canary.Instances)
+	result := reconciled(makeReturnedStatus(ESRetry, withSucceed(returnedStatus.SucceedCount), withExpected(returnedStatus.ExpectedCount)),
+		reloadType, parametersv1alpha1.CUpgradingPhase, withCanary(canary))
+	return updateConfigPhaseWithResult(rctx.Client, rctx.RequestCtx, rctx.ConfigMap, result)
+}
+
+// bakeCanary checks the canary instances during the bake time, and promotes the parameters to all instances after the bake time.
+func (r *ReconfigureReconciler) bakeCanary(rctx *ReconcileContext,
+	tasks []ReloadAction,
+	configPatch *core.ConfigPatchInfo,
+	policy *parametersv1alpha1.CanaryPolicy,
+	canary *parametersv1alpha1.CanaryStatus) (ctrl.Result, error) {
+	reloadType := tasks[len(tasks)-1].ReloadType()
+	if err := checkCanaryInstances(rctx, configPatch, policy, canary); err != nil {
+		// only the health or verification failures of the canary instances fail the canary,
+		// the errors of the API server or kbagent are retried.
+		if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return intctrlutil.RequeueAfter(canaryCheckInterval, rctx.Log, "failed to check the canary instances, retry", "error", err)
+		}
+		return r.failCanary(rctx, canary, reloadType, err)
+	}
+
+	if remaining := time.Until(canary.BakeStartTime.Add(policy.BakeTime.Duration)); remaining > 0 {
+		return intctrlutil.RequeueAfter(min(remaining, canaryCheckInterval), rctx.Log, "canary is baking")
+	}
+
+	canary.Stage = parametersv1alpha1.CanaryPromotedStage
+	rctx.Recorder.Eventf(rctx.ConfigMap, corev1.EventTypeNormal, "CanaryPromoted",
+		"the canary instances %v are healthy after %s, apply the parameters to all instances", canary.Instances, policy.BakeTime.Duration)
+	return r.performUpgrade(rctx, tasks, withCanary(canary))
+}
+
+// failCanary pauses the reconfiguration, and marks the current configuration as applied,
+// so that the parameters rolled back by the Parameter are re-applied to the canary instances.
+func (r *ReconfigureReconciler) failCanary(rctx *ReconcileContext, canary *parametersv1alpha1.CanaryStatus, reloadType string, err error) (ctrl.Result, error) {
+	canary.Stage = parametersv1alpha1.CanaryFailedStage
+	canary.Message = err.Error()
+	rctx.Recorder.Eventf(rctx.ConfigMap, corev1.EventTypeWarning, "CanaryFailed",
+		"the canary instances %v failed: %v", canary.Instances, err)
+
+	result := reconciled(makeReturnedStatus(ESFailed), reloadType, parametersv1alpha1.CFailedAndPausePhase,
+		withFailed(err, false), withCanary(canary))
+	return r.updateConfigCMStatus(rctx.RequestCtx, rctx.ConfigMap, reloadType, &result)
+}
+
+// checkCanaryInstances checks whether the canary instances are healthy, and the updated parameters have taken effect.
+// The health or verification failures are returned as fatal errors.
+func checkCanaryInstances(rctx *ReconcileContext,
+	configPatch *core.ConfigPatchInfo,
+	policy *parametersv1alpha1.CanaryPolicy,
+	canary *parametersv1alpha1.CanaryStatus) error {
+	var pods []corev1.Pod
+	for i := range rctx.InstanceSetList {
+		podList, err := intctrlutil.GetPodListByInstanceSet(rctx.Ctx, rctx.Client, &rctx.InstanceSetList[i])
+		if err != nil {
+			return err
+		}
+		for _, pod := range podList {
+			if slices.Contains(canary.Instances, pod.Name) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	if len(pods) != len(canary.Instances) {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "some canary instances of %v are not found", canary.Instances)
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if !intctrlutil.IsPodReady(pod) {
+			return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "canary instance[%s] is not ready", pod.Name)
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.LastTerminationState.Terminated
+			if terminated != nil && terminated.FinishedAt.After(canary.BakeStartTime.Time) {
+				return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "container[%s] of canary instance[%s] restarted: %s", status.Name, pod.Name, terminated.Reason)
+			}
+		}
+	}
+	if policy.VerifyParameters && configPatch != nil {
+		return verifyCanaryParameters(rctx, configPatch, pods)
+	}
+	return nil
+}
+
+// verifyCanaryParameters verifies the live values of the updated parameters on the canary instances.
+func verifyCanaryParameters(rctx *ReconcileContext, configPatch *core.ConfigPatchInfo, pods []corev1.Pod) error {
+	if rctx.ConfigRender == nil {
+		return nil
+	}
+	for _, params := range core.GenerateVisualizedParamsList(configPatch, rctx.ConfigRender.Spec.Configs) {
+		paramsDef := rctx.ParametersDefs[params.Key]
+		if params.UpdateType == core.DeletedType || paramsDef == nil || paramsDef.Spec.QueryParameters == nil {
+			continue
+		}
+		expected := make(map[string]string)
+		for _, p := range params.Parameters {
+			if p.Value != nil {
+				expected[p.Key] = *p.Value
+			}
+		}
+		for i := range pods {
+			output, err := queryPodParameters(rctx, &pods[i], paramsDef)
+			if err != nil {
+				return err
+			}
+			live, err := parseLiveParameters(output)
+			if err != nil {
+				return intctrlutil.NewError(intctrlutil.ErrorTypeFatal, err.Error())
+			}
+			if drifts := compareParameters(&paramsDef.Spec, params.Key, expected, live); len(drifts) != 0 {
+				return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "the parameters of canary instance[%s] have not taken effect: %s", pods[i].Name, formatParameterDrifts(drifts))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,540 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
+	"github.com/apecloud/kubeblocks/pkg/controller/render"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestResolveCanaryPolicy(t *testing.T) {
+	policy := &parametersv1alpha1.CanaryPolicy{
+		Role:     "secondary",
+		Replicas: 1,
+		BakeTime: metav1.Duration{Duration: time.Minute},
+	}
+	item, _ := json.Marshal(parametersv1alpha1.ConfigTemplateItemDetail{Name: "mysql-config", CanaryPolicy: policy})
+
+	cm := &corev1.ConfigMap{}
+	assert.Nil(t, resolveCanaryPolicy(cm))
+
+	cm.Annotations = map[string]string{constant.ConfigAppliedVersionAnnotationKey: "invalid"}
+	assert.Nil(t, resolveCanaryPolicy(cm))
+
+	cm.Annotations[constant.ConfigAppliedVersionAnnotationKey] = string(item)
+	assert.Equal(t, policy, resolveCanaryPolicy(cm))
+}
+
+func TestResolveCanaryStatus(t *testing.T) {
+	canary := &parametersv1alpha1.CanaryStatus{
+		Stage:     parametersv1alpha1.CanaryBakingStage,
+		Instances: []string{"mysql-1"},
+	}
+	baking, _ := json.Marshal(intctrlutil.Result{Phase: parametersv1alpha1.CUpgradingPhase, Revision: "2", Canary: canary})
+	finished, _ := json.Marshal(intctrlutil.Result{Phase: parametersv1alpha1.CFinishedPhase, Revision: "1"})
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				constant.ConfigurationRevision:     "1",
+				core.GenerateRevisionPhaseKey("1"): string(finished),
+				core.GenerateRevisionPhaseKey("2"): string(baking),
+			},
+		},
+	}
+	assert.Nil(t, resolveCanaryStatus(cm))
+
+	cm.Annotations[constant.ConfigurationRevision] = "2"
+	assert.Equal(t, canary, resolveCanaryStatus(cm))
+
+	cm.Annotations[constant.ConfigurationRevision] = "3"
+	assert.Nil(t, resolveCanaryStatus(cm))
+}
+
+func TestCanaryTasks(t *testing.T) {
+	rolling := reconfigureTask{ReloadPolicy: parametersv1alpha1.RollingPolicy}
+	sync := reconfigureTask{ReloadPolicy: parametersv1alpha1.SyncDynamicReloadPolicy}
+	restart := reconfigureTask{ReloadPolicy: parametersv1alpha1.RestartPolicy}
+
+	assert.False(t, isCanaryCapable(nil))
+	assert.True(t, isCanaryCapable([]ReloadAction{sync, rolling}))
+	assert.False(t, isCanaryCapable([]ReloadAction{sync, restart}))
+
+	tasks := withCanaryInstances([]ReloadAction{sync, rolling}, []string{"mysql-1"})
+	for _, task := range tasks {
+		assert.Equal(t, []string{"mysql-1"}, task.(reconfigureTask).taskCtx.CanaryInstances)
+	}
+	assert.Empty(t, sync.taskCtx.CanaryInstances)
+	taskCtx := tasks[0].(reconfigureTask).taskCtx
+	assert.Equal(t, 1, taskCtx.getTargetReplicas())
+}
+
+const canaryTestNamespace = "default"
+
+type mockCanaryTask struct {
+	status    ReturnedStatus
+	execCount int
+}
+
+func (t *mockCanaryTask) ExecReload() (ReturnedStatus, error) {
+	t.execCount++
+	return t.status, nil
+}
+
+func (t *mockCanaryTask) ReloadType() string {
+	return string(parametersv1alpha1.RollingPolicy)
+}
+
+func newCanaryTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = workloads.AddToScheme(scheme)
+	_ = parametersv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newCanaryTestInstanceSet() *workloads.InstanceSet {
+	return &workloads.InstanceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: canaryTestNamespace},
+		Spec: workloads.InstanceSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+		},
+	}
+}
+
+func newCanaryTestPod(name, role string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: canaryTestNamespace,
+			Labels:    map[string]string{"app": "mysql"},
+		},
+	}
+	if role != "" {
+		pod.Labels[constant.RoleLabelKey] = role
+	}
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+	return pod
+}
+
+func newCanaryTestConfigMap(canary *parametersv1alpha1.CanaryStatus) *corev1.ConfigMap {
+	result, _ := json.Marshal(intctrlutil.Result{Phase: parametersv1alpha1.CUpgradingPhase, Revision: "2", Canary: canary})
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysql-config",
+			Namespace: canaryTestNamespace,
+			Labels:    map[string]string{constant.CMConfigurationTypeLabelKey: constant.ConfigInstanceType},
+			Annotations: map[string]string{
+				constant.ConfigurationRevision:     "2",
+				core.GenerateRevisionPhaseKey("2"): string(result),
+			},
+		},
+		Data: map[string]string{"my.cnf": "[mysqld]\nmax_connections=200"},
+	}
+}
+
+func resolveCanaryResult(t *testing.T, cm *corev1.ConfigMap) intctrlutil.Result {
+	result := intctrlutil.Result{}
+	assert.Nil(t, json.Unmarshal([]byte(cm.Annotations[core.GenerateRevisionPhaseKey("2")]), &result))
+	return result
+}
+
+func hasCanaryEvent(recorder *record.FakeRecorder, reason string) bool {
+	for {
+		select {
+		case event := <-recorder.Events:
+			if strings.Contains(event, " "+reason+" ") {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestSelectCanaryInstances(t *testing.T) {
+	roles := []workloads.ReplicaRole{
+		{Name: "leader", UpdatePriority: 3},
+		{Name: "follower", UpdatePriority: 2},
+		{Name: "learner", UpdatePriority: 1},
+	}
+	newTasks := func(pods ...*corev1.Pod) []ReloadAction {
+		its := newCanaryTestInstanceSet()
+		builder := fake.NewClientBuilder().WithScheme(newCanaryTestScheme())
+		for _, pod := range pods {
+			builder.WithObjects(pod)
+		}
+		return []ReloadAction{reconfigureTask{
+			ReloadPolicy: parametersv1alpha1.RollingPolicy,
+			taskCtx: reconfigureContext{
+				RequestCtx:           intctrlutil.RequestCtx{Ctx: context.Background()},
+				Client:               builder.Build(),
+				SynthesizedComponent: &component.SynthesizedComponent{Roles: roles},
+				InstanceSetUnits:     []workloads.InstanceSet{*its},
+			},
+		}}
+	}
+	pods := []*corev1.Pod{
+		newCanaryTestPod("mysql-0", "leader", true),
+		newCanaryTestPod("mysql-1", "follower", true),
+		newCanaryTestPod("mysql-2", "follower", true),
+		newCanaryTestPod("mysql-3", "learner", true),
+	}
+
+	tests := []struct {
+		name      string
+		pods      []*corev1.Pod
+		policy    parametersv1alpha1.CanaryPolicy
+		instances []string
+		fatal     bool
+		retry     bool
+	}{{
+		name:      "select from the tail of the rolling update order",
+		pods:      pods,
+		policy:    parametersv1alpha1.CanaryPolicy{Replicas: 1},
+		instances: []string{"mysql-3"},
+	}, {
+		name:      "select multiple instances",
+		pods:      pods,
+		policy:    parametersv1alpha1.CanaryPolicy{Replicas: 2},
+		instances: []string{"mysql-3", "mysql-2"},
+	}, {
+		name:      "never select the highest-priority role if the role is not specified",
+		pods:      pods,
+		policy:    parametersv1alpha1.CanaryPolicy{Replicas: 10},
+		instances: []string{"mysql-3", "mysql-2", "mysql-1"},
+	}, {
+		name:      "select the specified role",
+		pods:      pods,
+		policy:    parametersv1alpha1.CanaryPolicy{Role: "follower", Replicas: 1},
+		instances: []string{"mysql-2"},
+	}, {
+		name:      "select the highest-priority role explicitly",
+		pods:      pods,
+		policy:    parametersv1alpha1.CanaryPolicy{Role: "leader", Replicas: 1},
+		instances: []string{"mysql-0"},
+	}, {
+		name:   "no instance with the specified role",
+		pods:   pods,
+		policy: parametersv1alpha1.CanaryPolicy{Role: "witness", Replicas: 1},
+		fatal:  true,
+	}, {
+		name:   "no instance other than the highest-priority role",
+		pods:   pods[:1],
+		policy: parametersv1alpha1.CanaryPolicy{Replicas: 1},
+	}, {
+		name: "the roles have not been probed",
+		pods: []*corev1.Pod{
+			newCanaryTestPod("mysql-0", "leader", true),
+			newCanaryTestPod("mysql-1", "", true),
+		},
+		policy: parametersv1alpha1.CanaryPolicy{Role: "follower", Replicas: 1},
+		retry:  true,
+	}, {
+		name:   "no instance",
+		policy: parametersv1alpha1.CanaryPolicy{Replicas: 1},
+		retry:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances, err := selectCanaryInstances(newTasks(tt.pods...), &tt.policy)
+			assert.Equal(t, tt.instances, instances)
+			assert.Equal(t, tt.fatal || tt.retry, err != nil)
+			assert.Equal(t, tt.fatal, intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal))
+		})
+	}
+}
+
+func TestCanaryStateMachine(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		its      = newCanaryTestInstanceSet()
+		listErr  error
+		queryErr error
+		live     = `{"max_connections": "200"}`
+	)
+
+	originalQueryPodParameters := queryPodParameters
+	defer func() {
+		queryPodParameters = originalQueryPodParameters
+	}()
+	queryPodParameters = func(_ *ReconcileContext, _ *corev1.Pod, _ *parametersv1alpha1.ParametersDefinition) ([]byte, error) {
+		return []byte(live), queryErr
+	}
+
+	newReconciler := func(canary *parametersv1alpha1.CanaryStatus, pods ...*corev1.Pod) (*ReconfigureReconciler, *ReconcileContext, *record.FakeRecorder) {
+		cm := newCanaryTestConfigMap(canary)
+		builder := fake.NewClientBuilder().
+			WithScheme(newCanaryTestScheme()).
+			WithObjects(cm, its).
+			WithInterceptorFuncs(interceptor.Funcs{
+				List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if listErr != nil {
+						return listErr
+					}
+					return cli.List(ctx, list, opts...)
+				},
+			})
+		for _, pod := range pods {
+			builder.WithObjects(pod)
+		}
+		cli := builder.Build()
+		recorder := record.NewFakeRecorder(100)
+		rctx := &ReconcileContext{
+			RequestCtx: intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard(), Recorder: recorder},
+			ResourceFetcher: configctrl.ResourceFetcher[ReconcileContext]{
+				ResourceCtx: &render.ResourceCtx{Context: ctx, Client: cli, Namespace: canaryTestNamespace},
+			},
+			ConfigMap:       cm,
+			InstanceSetList: []workloads.InstanceSet{*its},
+			ConfigRender: &parametersv1alpha1.ParamConfigRenderer{
+				Spec: parametersv1alpha1.ParamConfigRendererSpec{
+					Configs: []parametersv1alpha1.ComponentConfigDescription{{
+						Name: "my.cnf",
+						FileFormatConfig: &parametersv1alpha1.FileFormatConfig{
+							Format: parametersv1alpha1.Ini,
+							FormatterAction: parametersv1alpha1.FormatterAction{
+								IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+							},
+						},
+					}},
+				},
+			},
+			ParametersDefs: map[string]*parametersv1alpha1.ParametersDefinition{
+				"my.cnf": {Spec: parametersv1alpha1.ParametersDefinitionSpec{QueryParameters: &appsv1.Action{}}},
+			},
+		}
+		return &ReconfigureReconciler{Client: cli, Recorder: recorder}, rctx, recorder
+	}
+
+	policy := &parametersv1alpha1.CanaryPolicy{
+		Replicas:         1,
+		BakeTime:         metav1.Duration{Duration: time.Hour},
+		VerifyParameters: true,
+	}
+	configPatch := &core.ConfigPatchInfo{
+		IsModify:     true,
+		UpdateConfig: map[string][]byte{"my.cnf": []byte(`{"mysqld": {"max_connections": "200"}}`)},
+	}
+	task := &mockCanaryTask{status: ReturnedStatus{Status: ESNone}}
+	tasks := []ReloadAction{task}
+
+	t.Run("apply, bake and promote", func(t *testing.T) {
+		r, rctx, recorder := newReconciler(&parametersv1alpha1.CanaryStatus{
+			Stage:     parametersv1alpha1.CanaryApplyingStage,
+			Instances: []string{"mysql-2"},
+		}, newCanaryTestPod("mysql-2", "follower", true))
+
+		// apply the parameters to the canary instances
+		_, err := r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, task.execCount)
+		canary := resolveCanaryStatus(rctx.ConfigMap)
+		assert.Equal(t, parametersv1alpha1.CanaryBakingStage, canary.Stage)
+		assert.NotNil(t, canary.BakeStartTime)
+		assert.True(t, hasCanaryEvent(recorder, "CanaryBaking"))
+
+		// bake the healthy canary instances
+		res, err := r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		assert.Nil(t, err)
+		assert.True(t, res.RequeueAfter > 0)
+		assert.Equal(t, parametersv1alpha1.CanaryBakingStage, resolveCanaryStatus(rctx.ConfigMap).Stage)
+
+		// retry on the transient errors of the API server
+		listErr = fmt.Errorf("the server is currently unable to handle the request")
+		res, err = r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		listErr = nil
+		assert.Nil(t, err)
+		assert.True(t, res.RequeueAfter > 0)
+		assert.Equal(t, parametersv1alpha1.CanaryBakingStage, resolveCanaryStatus(rctx.ConfigMap).Stage)
+
+		// retry on the errors of kbagent
+		queryErr = fmt.Errorf("kbagent is not available")
+		res, err = r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		queryErr = nil
+		assert.Nil(t, err)
+		assert.True(t, res.RequeueAfter > 0)
+		assert.Equal(t, parametersv1alpha1.CanaryBakingStage, resolveCanaryStatus(rctx.ConfigMap).Stage)
+		assert.False(t, hasCanaryEvent(recorder, "CanaryFailed"))
+
+		// promote the parameters to all instances after the bake time
+		promotePolicy := policy.DeepCopy()
+		promotePolicy.BakeTime = metav1.Duration{}
+		_, err = r.performCanaryUpgrade(rctx, tasks, configPatch, promotePolicy)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, task.execCount)
+		result := resolveCanaryResult(t, rctx.ConfigMap)
+		assert.Equal(t, parametersv1alpha1.CFinishedPhase, result.Phase)
+		assert.Equal(t, parametersv1alpha1.CanaryPromotedStage, result.Canary.Stage)
+		assert.True(t, hasCanaryEvent(recorder, "CanaryPromoted"))
+	})
+
+	t.Run("fail if the canary instance is unhealthy", func(t *testing.T) {
+		task.execCount = 0
+		r, rctx, recorder := newReconciler(&parametersv1alpha1.CanaryStatus{
+			Stage:         parametersv1alpha1.CanaryBakingStage,
+			Instances:     []string{"mysql-2"},
+			BakeStartTime: &metav1.Time{Time: time.Now()},
+		}, newCanaryTestPod("mysql-2", "follower", false))
+
+		_, err := r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		assert.Nil(t, err)
+		result := resolveCanaryResult(t, rctx.ConfigMap)
+		assert.Equal(t, parametersv1alpha1.CFailedAndPausePhase, result.Phase)
+		assert.Equal(t, parametersv1alpha1.CanaryFailedStage, result.Canary.Stage)
+		assert.Contains(t, result.Canary.Message, "canary instance[mysql-2] is not ready")
+		assert.True(t, hasCanaryEvent(recorder, "CanaryFailed"))
+
+		// the failed canary is not retried
+		_, err = r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, task.execCount)
+		assert.Equal(t, parametersv1alpha1.CanaryFailedStage, resolveCanaryStatus(rctx.ConfigMap).Stage)
+	})
+
+	t.Run("fail if the parameters have not taken effect", func(t *testing.T) {
+		r, rctx, _ := newReconciler(&parametersv1alpha1.CanaryStatus{
+			Stage:         parametersv1alpha1.CanaryBakingStage,
+			Instances:     []string{"mysql-2"},
+			BakeStartTime: &metav1.Time{Time: time.Now()},
+		}, newCanaryTestPod("mysql-2", "follower", true))
+
+		live = `{"max_connections": "100"}`
+		_, err := r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+		assert.Nil(t, err)
+		canary := resolveCanaryStatus(rctx.ConfigMap)
+		assert.Equal(t, parametersv1alpha1.CanaryFailedStage, canary.Stage)
+		assert.Contains(t, canary.Message, "max_connections: 200 -> 100")
+	})
+}
+
+func TestRollbackFailedCanary(t *testing.T) {
+	ctx := context.Background()
+	revisionParams, _ := json.Marshal(RevisionParameters{
+		Parameters: map[string]parametersv1alpha1.ParametersInFile{
+			"my.cnf": {Parameters: map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}},
+		},
+	})
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysql-config",
+			Namespace: canaryTestNamespace,
+			Annotations: map[string]string{
+				core.GenerateRevisionParamsKey("2"): string(revisionParams),
+			},
+		},
+	}
+	compParam := &parametersv1alpha1.ComponentParameter{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: canaryTestNamespace},
+		Spec: parametersv1alpha1.ComponentParameterSpec{
+			ComponentName: "mysql",
+			ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{{
+				Name: "mysql-config",
+				ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+					"my.cnf": {Parameters: map[string]*string{
+						"innodb_buffer_pool_size": pointer.String("2G"),
+						"max_connections":         pointer.String("200"),
+					}},
+				},
+				CanaryPolicy: &parametersv1alpha1.CanaryPolicy{Replicas: 1},
+			}},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newCanaryTestScheme()).WithObjects(compParam).Build()
+	rctx := &ReconcileContext{
+		RequestCtx: intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard()},
+		ResourceFetcher: configctrl.ResourceFetcher[ReconcileContext]{
+			ResourceCtx:           &render.ResourceCtx{Context: ctx, Client: cli, Namespace: canaryTestNamespace, ComponentName: "mysql"},
+			ComponentParameterObj: compParam,
+		},
+	}
+
+	parameter := &parametersv1alpha1.Parameter{}
+	status := &parametersv1alpha1.ComponentReconfiguringStatus{
+		ComponentName: "mysql",
+		Phase:         parametersv1alpha1.CFailedAndPausePhase,
+		ParameterStatus: []parametersv1alpha1.ReconfiguringStatus{{
+			ConfigTemplateItemDetailStatus: parametersv1alpha1.ConfigTemplateItemDetailStatus{
+				Name:           "mysql-config",
+				UpdateRevision: "3",
+				ReconcileDetail: &parametersv1alpha1.ReconcileDetail{
+					Canary: &parametersv1alpha1.CanaryStatus{
+						Stage:     parametersv1alpha1.CanaryFailedStage,
+						Instances: []string{"mysql-2"},
+						Message:   "canary instance[mysql-2] is not ready",
+					},
+				},
+			},
+		}},
+	}
+	configmaps := map[string]*corev1.ConfigMap{"mysql-config": cm}
+
+	assert.Nil(t, rollbackFailedCanary(rctx, parameter, status, configmaps))
+	assert.Equal(t, "2", status.RolledBackRevision)
+	assert.Contains(t, parameter.Status.Message, "rolled back to revision[2]")
+
+	updated := &parametersv1alpha1.ComponentParameter{}
+	assert.Nil(t, cli.Get(ctx, client.ObjectKeyFromObject(compParam), updated))
+	item := intctrlutil.GetConfigTemplateItem(&updated.Spec, "mysql-config")
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}},
+	}, item.ConfigFileParams)
+	assert.Nil(t, item.CanaryPolicy)
+
+	// the rendered config file keeps the template defined max_connections rather than removing it
+	rendered := renderTestMyCnf(t, item.ConfigFileParams)
+	assert.Contains(t, rendered, "max_connections=500")
+	assert.Contains(t, rendered, "innodb_buffer_pool_size=1G")
+
+	// the parameters are rolled back only once
+	parameter.Status.Message = ""
+	assert.Nil(t, rollbackFailedCanary(rctx, parameter, status, configmaps))
+	assert.Empty(t, parameter.Status.Message)
+}
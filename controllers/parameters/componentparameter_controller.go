@@ -146,7 +146,9 @@ func (r *ComponentParameterReconciler) runTasks(taskCtx *TaskContext, tasks []Ta
 		compParameter = taskCtx.componentParameter
 	)
 
-	patch := client.MergeFrom(compParameter.DeepCopy())
+	// the drift of the parameters is recorded in the same status by the ParameterDrift controller,
+	// patch it with the optimistic lock to avoid overwriting the drift detected concurrently.
+	patch := client.MergeFromWithOptions(compParameter.DeepCopy(), client.MergeFromWithOptimisticLock{})
 	revision := strconv.FormatInt(compParameter.GetGeneration(), 10)
 	for _, task := range tasks {
 		if err := task.Do(resource, taskCtx, revision); err != nil {
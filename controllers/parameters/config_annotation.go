@@ -45,6 +45,8 @@ func reconciled(status ReturnedStatus, policy string, phase parametersv1alpha1.P
 		SucceedCount:  status.SucceedCount,
 		ExpectedCount: status.ExpectedCount,
 		Retry:         true,
+
+		ParameterStatuses: status.ParameterStatuses,
 	}
 	for _, option := range options {
 		option(&result)
@@ -60,7 +60,7 @@ func mockSchemaData() string {
 	return string(cue)
 }
 
-func mockConfigResource() (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefinition) {
+func mockConfigResource(paramsDefOptions ...func(*parametersv1alpha1.ParametersDefinition)) (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefinition) {
 	By("Create a config template obj")
 	configmap := testparameters.NewComponentTemplateFactory(configSpecName, testCtx.DefaultNamespace).
 		AddLabels(
@@ -81,11 +81,13 @@ func mockConfigResource() (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefi
 		GetObject()
 
 	By("Create a parameters definition obj")
-	paramsdef := testparameters.NewParametersDefinitionFactory(paramsDefName).
+	paramsDefFactory := testparameters.NewParametersDefinitionFactory(paramsDefName).
 		SetReloadAction(testparameters.WithNoneAction()).
-		Schema(mockSchemaData()).
-		Create(&testCtx).
-		GetObject()
+		Schema(mockSchemaData())
+	for _, option := range paramsDefOptions {
+		paramsDefFactory.Apply(option)
+	}
+	paramsdef := paramsDefFactory.Create(&testCtx).GetObject()
 
 	Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(paramsdef), func(g Gomega, def *parametersv1alpha1.ParametersDefinition) {
 		g.Expect(def.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.PDAvailablePhase))
@@ -93,8 +95,8 @@ func mockConfigResource() (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefi
 	return configmap, paramsdef
 }
 
-func mockReconcileResource() (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefinition, *appsv1.Cluster, *appsv1.Component, *component.SynthesizedComponent) {
-	configmap, paramsDef := mockConfigResource()
+func mockReconcileResource(paramsDefOptions ...func(*parametersv1alpha1.ParametersDefinition)) (*corev1.ConfigMap, *parametersv1alpha1.ParametersDefinition, *appsv1.Cluster, *appsv1.Component, *component.SynthesizedComponent) {
+	configmap, paramsDef := mockConfigResource(paramsDefOptions...)
 
 	By("Create a component definition obj and mock to available")
 	compDefObj := testapps.NewComponentDefinitionFactory(compDefName).
@@ -179,6 +181,7 @@ func cleanEnv() {
 	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ConfigMapSignature, true, inNS)
 	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.SecretSignature, true, inNS)
 	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.InstanceSetSignature, true, inNS, ml)
+	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
 	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentParameterSignature, true, inNS)
 	testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ParameterSignature, true, inNS, ml)
 }
@@ -0,0 +1,278 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/openapi"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+)
+
+const (
+	defaultDriftDetectionInterval = 5 * time.Minute
+
+	reasonParametersDrifted = "ParametersDrifted"
+	reasonNoDrift           = "NoDrift"
+)
+
+func driftDetectionInterval(paramsDef *parametersv1alpha1.ParametersDefinitionSpec) time.Duration {
+	policy := paramsDef.DriftDetectionPolicy
+	if policy == nil || policy.Interval == nil || policy.Interval.Duration <= 0 {
+		return defaultDriftDetectionInterval
+	}
+	return policy.Interval.Duration
+}
+
+func driftRemediation(paramsDef *parametersv1alpha1.ParametersDefinitionSpec) parametersv1alpha1.DriftRemediation {
+	if paramsDef.DriftDetectionPolicy == nil || paramsDef.DriftDetectionPolicy.Remediation == "" {
+		return parametersv1alpha1.AlertDriftRemediation
+	}
+	return paramsDef.DriftDetectionPolicy.Remediation
+}
+
+// parseLiveParameters parses the output of the queryParameters action, which is a JSON object of the live parameters.
+func parseLiveParameters(output []byte) (map[string]string, error) {
+	var values map[string]any
+	if err := json.Unmarshal(output, &values); err != nil {
+		return nil, core.WrapError(err, "failed to parse the output of the queryParameters action")
+	}
+	params := make(map[string]string, len(values))
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			params[name] = v
+		default:
+			b, _ := json.Marshal(v)
+			params[name] = string(b)
+		}
+	}
+	return params, nil
+}
+
+// compareParameters returns the parameters whose live values differ from the rendered values.
+// Only the parameters present in both sides are compared, and the names are matched case-insensitively.
+// The values are compared by the types of the parameters declared in the schema of the ParametersDefinition.
+func compareParameters(paramsDef *parametersv1alpha1.ParametersDefinitionSpec,
+	fileName string, expected, live map[string]string) []parametersv1alpha1.ParameterDrift {
+	liveParams := make(map[string]string, len(live))
+	for name, value := range live {
+		liveParams[strings.ToLower(name)] = value
+	}
+
+	schemas := parameterSchemas(paramsDef)
+	var drifts []parametersv1alpha1.ParameterDrift
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		liveValue, ok := liveParams[strings.ToLower(name)]
+		if !ok || equalParameterValues(schemas[name].Type, expected[name], liveValue) {
+			continue
+		}
+		drifts = append(drifts, parametersv1alpha1.ParameterDrift{
+			Name:     name,
+			FileName: fileName,
+			Expected: expected[name],
+			Actual:   liveValue,
+		})
+	}
+	return drifts
+}
+
+func parameterSchemas(paramsDef *parametersv1alpha1.ParametersDefinitionSpec) map[string]apiextv1.JSONSchemaProps {
+	if paramsDef == nil || paramsDef.ParametersSchema == nil || paramsDef.ParametersSchema.SchemaInJSON == nil {
+		return nil
+	}
+	schema, ok := paramsDef.ParametersSchema.SchemaInJSON.Properties[openapi.DefaultSchemaName]
+	if !ok {
+		return nil
+	}
+	return openapi.FlattenSchema(schema).Properties
+}
+
+// equalParameterValues checks whether the live value equals to the rendered value of the parameter type:
+//   - the integers are compared by the values with the size units resolved, e.g. 1G equals to 1073741824;
+//   - the numbers are compared by the values;
+//   - the booleans are compared by the truth values, e.g. ON equals to true;
+//   - the others are compared as strings.
+//
+// The live value may be presented by the engine in a form different from the rendered one, the values that can't
+// be converted to the type of the parameter are not compared, and the values of an undeclared parameter are compared
+// as the type they can be converted to both.
+func equalParameterValues(typ, expected, live string) bool {
+	expected, live = normalizeParameterValue(expected), normalizeParameterValue(live)
+	if expected == live {
+		return true
+	}
+	switch typ {
+	case "integer":
+		equal, ok := equalQuantities(expected, live)
+		return !ok || equal
+	case "number":
+		equal, ok := equalNumbers(expected, live)
+		return !ok || equal
+	case "boolean":
+		equal, ok := equalBooleans(expected, live)
+		return !ok || equal
+	case "":
+		if equal, ok := equalQuantities(expected, live); ok {
+			return equal
+		}
+		if equal, ok := equalNumbers(expected, live); ok {
+			return equal
+		}
+		if equal, ok := equalBooleans(expected, live); ok {
+			return equal
+		}
+	}
+	return false
+}
+
+func normalizeParameterValue(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `'"`)
+}
+
+func equalQuantities(expected, live string) (bool, bool) {
+	x, err1 := validate.ParseQuantity(expected)
+	y, err2 := validate.ParseQuantity(live)
+	if err1 != nil || err2 != nil {
+		return false, false
+	}
+	return x == y, true
+}
+
+func equalNumbers(expected, live string) (bool, bool) {
+	x, err1 := strconv.ParseFloat(expected, 64)
+	y, err2 := strconv.ParseFloat(live, 64)
+	if err1 != nil || err2 != nil {
+		return false, false
+	}
+	return x == y, true
+}
+
+func equalBooleans(expected, live string) (bool, bool) {
+	x, ok1 := parseBoolean(expected)
+	y, ok2 := parseBoolean(live)
+	if !ok1 || !ok2 {
+		return false, false
+	}
+	return x == y, true
+}
+
+func parseBoolean(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "on", "yes", "1":
+		return true, true
+	case "false", "off", "no", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// updateDriftedInstances updates the drifted instances of the config template with the detected drifts,
+// and returns the pods which are newly drifted.
+//
+// The drifts contain all the pods that have been queried successfully, the pods that failed to be queried keep
+// their previous state, and the pods that no longer exist are removed.
+func updateDriftedInstances(status *parametersv1alpha1.ConfigTemplateItemDetailStatus,
+	drifts map[string][]parametersv1alpha1.ParameterDrift, pods sets.Set[string], now metav1.Time) []string {
+	var (
+		newlyDrifted []string
+		instances    []parametersv1alpha1.InstanceParametersDrift
+		previous     = make(map[string]parametersv1alpha1.InstanceParametersDrift)
+	)
+
+	for _, instance := range status.DriftedInstances {
+		previous[instance.PodName] = instance
+		if _, ok := drifts[instance.PodName]; !ok && pods.Has(instance.PodName) {
+			instances = append(instances, instance)
+		}
+	}
+	for podName, params := range drifts {
+		if len(params) == 0 {
+			continue
+		}
+		detectedTime := now.DeepCopy()
+		if instance, ok := previous[podName]; ok && instance.DetectedTime != nil {
+			detectedTime = instance.DetectedTime
+		} else {
+			newlyDrifted = append(newlyDrifted, podName)
+		}
+		instances = append(instances, parametersv1alpha1.InstanceParametersDrift{
+			PodName:      podName,
+			Parameters:   params,
+			DetectedTime: detectedTime,
+		})
+	}
+
+	slices.SortFunc(instances, func(a, b parametersv1alpha1.InstanceParametersDrift) int {
+		return strings.Compare(a.PodName, b.PodName)
+	})
+	slices.Sort(newlyDrifted)
+	status.DriftedInstances = instances
+	return newlyDrifted
+}
+
+// setDriftCondition sets the ConfigurationDrifted condition according to the drifted instances of all config templates.
+func setDriftCondition(status *parametersv1alpha1.ComponentParameterStatus, generation int64) {
+	var drifted []string
+	for _, item := range status.ConfigurationItemStatus {
+		for _, instance := range item.DriftedInstances {
+			drifted = append(drifted, fmt.Sprintf("%s/%s", item.Name, instance.PodName))
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               parametersv1alpha1.ConfigurationDriftedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reasonNoDrift,
+		Message:            "the live parameters of all instances are consistent with the rendered configuration",
+	}
+	if len(drifted) != 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonParametersDrifted
+		condition.Message = fmt.Sprintf("the live parameters drift from the rendered configuration: [%s]", strings.Join(drifted, ","))
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+func formatParameterDrifts(drifts []parametersv1alpha1.ParameterDrift) string {
+	items := make([]string, 0, len(drifts))
+	for _, drift := range drifts {
+		items = append(items, fmt.Sprintf("%s: %s -> %s", drift.Name, drift.Expected, drift.Actual))
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/openapi"
+)
+
+func TestParseLiveParameters(t *testing.T) {
+	params, err := parseLiveParameters([]byte(`{"max_connections": 1000, "sql_mode": "STRICT", "read_only": false, "unset": null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"max_connections": "1000",
+		"sql_mode":        "STRICT",
+		"read_only":       "false",
+	}, params)
+
+	_, err = parseLiveParameters([]byte("max_connections=1000"))
+	assert.NotNil(t, err)
+}
+
+func TestCompareParameters(t *testing.T) {
+	expected := map[string]string{
+		"max_connections": "1000",
+		"sql_mode":        "'STRICT'",
+		"innodb_log_size": "1G",
+		"not_queried":     "x",
+	}
+	live := map[string]string{
+		"MAX_CONNECTIONS": "500",
+		"sql_mode":        "STRICT",
+		"innodb_log_size": "1G",
+		"not_rendered":    "y",
+	}
+	drifts := compareParameters(nil, "my.cnf", expected, live)
+	assert.Equal(t, []parametersv1alpha1.ParameterDrift{{
+		Name:     "max_connections",
+		FileName: "my.cnf",
+		Expected: "1000",
+		Actual:   "500",
+	}}, drifts)
+
+	assert.Empty(t, compareParameters(nil, "my.cnf", expected, nil))
+}
+
+func TestCompareParametersByType(t *testing.T) {
+	paramsDef := &parametersv1alpha1.ParametersDefinitionSpec{
+		ParametersSchema: &parametersv1alpha1.ParametersSchema{
+			SchemaInJSON: &apiextv1.JSONSchemaProps{
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					openapi.DefaultSchemaName: {
+						Properties: map[string]apiextv1.JSONSchemaProps{
+							"innodb_buffer_pool_size": {Type: "integer"},
+							"long_query_time":         {Type: "number"},
+							"read_only":               {Type: "boolean"},
+							"sql_mode":                {Type: "string"},
+							"max_connections":         {Type: "integer"},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := map[string]string{
+		"innodb_buffer_pool_size": "1G",
+		"long_query_time":         "10",
+		"read_only":               "ON",
+		"sql_mode":                "STRICT",
+		"max_connections":         "1000",
+		"undeclared":              "64M",
+	}
+	live := map[string]string{
+		"innodb_buffer_pool_size": "1073741824",
+		"long_query_time":         "10.000000",
+		"read_only":               "1",
+		"sql_mode":                "strict",
+		"max_connections":         "unlimited",
+		"undeclared":              "67108864",
+	}
+	drifts := compareParameters(paramsDef, "my.cnf", expected, live)
+	assert.Equal(t, []parametersv1alpha1.ParameterDrift{{
+		Name:     "sql_mode",
+		FileName: "my.cnf",
+		Expected: "STRICT",
+		Actual:   "strict",
+	}}, drifts)
+
+	live["innodb_buffer_pool_size"] = "2G"
+	live["read_only"] = "OFF"
+	drifts = compareParameters(paramsDef, "my.cnf", expected, live)
+	assert.Len(t, drifts, 3)
+	assert.Equal(t, "innodb_buffer_pool_size", drifts[0].Name)
+	assert.Equal(t, "read_only", drifts[1].Name)
+}
+
+func TestUpdateDriftedInstances(t *testing.T) {
+	detected := metav1.NewTime(time.Now().Add(-time.Hour))
+	now := metav1.Now()
+	drift := parametersv1alpha1.ParameterDrift{Name: "max_connections", Expected: "1000", Actual: "500"}
+	status := &parametersv1alpha1.ConfigTemplateItemDetailStatus{
+		Name: "mysql-config",
+		DriftedInstances: []parametersv1alpha1.InstanceParametersDrift{
+			{PodName: "pod-0", Parameters: []parametersv1alpha1.ParameterDrift{drift}, DetectedTime: &detected},
+			{PodName: "pod-1", Parameters: []parametersv1alpha1.ParameterDrift{drift}, DetectedTime: &detected},
+			{PodName: "pod-2", Parameters: []parametersv1alpha1.ParameterDrift{drift}, DetectedTime: &detected},
+			{PodName: "pod-deleted", Parameters: []parametersv1alpha1.ParameterDrift{drift}, DetectedTime: &detected},
+		},
+	}
+	drifts := map[string][]parametersv1alpha1.ParameterDrift{
+		// still drifted
+		"pod-0": {drift},
+		// drift resolved
+		"pod-1": nil,
+		// newly drifted
+		"pod-3": {drift},
+	}
+	// pod-2 failed to be queried
+	pods := sets.New("pod-0", "pod-1", "pod-2", "pod-3")
+
+	newlyDrifted := updateDriftedInstances(status, drifts, pods, now)
+	assert.Equal(t, []string{"pod-3"}, newlyDrifted)
+
+	var podNames []string
+	for _, instance := range status.DriftedInstances {
+		podNames = append(podNames, instance.PodName)
+	}
+	assert.Equal(t, []string{"pod-0", "pod-2", "pod-3"}, podNames)
+	assert.Equal(t, detected, *status.DriftedInstances[0].DetectedTime)
+	assert.Equal(t, now, *status.DriftedInstances[2].DetectedTime)
+}
+
+func TestSetDriftCondition(t *testing.T) {
+	status := &parametersv1alpha1.ComponentParameterStatus{
+		ConfigurationItemStatus: []parametersv1alpha1.ConfigTemplateItemDetailStatus{{
+			Name: "mysql-config",
+			DriftedInstances: []parametersv1alpha1.InstanceParametersDrift{{
+				PodName:    "pod-0",
+				Parameters: []parametersv1alpha1.ParameterDrift{{Name: "max_connections"}},
+			}},
+		}},
+	}
+	setDriftCondition(status, 2)
+	condition := meta.FindStatusCondition(status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, reasonParametersDrifted, condition.Reason)
+	assert.Contains(t, condition.Message, "mysql-config/pod-0")
+
+	status.ConfigurationItemStatus[0].DriftedInstances = nil
+	setDriftCondition(status, 2)
+	condition = meta.FindStatusCondition(status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonNoDrift, condition.Reason)
+}
+
+func TestDriftDetectionPolicy(t *testing.T) {
+	spec := &parametersv1alpha1.ParametersDefinitionSpec{}
+	assert.Equal(t, defaultDriftDetectionInterval, driftDetectionInterval(spec))
+	assert.Equal(t, parametersv1alpha1.AlertDriftRemediation, driftRemediation(spec))
+
+	spec.DriftDetectionPolicy = &parametersv1alpha1.DriftDetectionPolicy{
+		Interval:    &metav1.Duration{Duration: time.Minute},
+		Remediation: parametersv1alpha1.ReapplyDriftRemediation,
+	}
+	assert.Equal(t, time.Minute, driftDetectionInterval(spec))
+	assert.Equal(t, parametersv1alpha1.ReapplyDriftRemediation, driftRemediation(spec))
+}
@@ -22,9 +22,12 @@ package parameters
 import (
 	"context"
 	"errors"
+	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -89,19 +92,30 @@ func (r *ParameterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func (r *ParameterReconciler) handleComponent(rctx *ReconcileContext, updatedParameters parametersv1alpha1.ComponentParameters, parameter *parametersv1alpha1.Parameter) error {
+func (r *ParameterReconciler) handleComponent(rctx *ReconcileContext, compParameter parametersv1alpha1.ComponentParametersSpec, parameter *parametersv1alpha1.Parameter) error {
 	configmaps, err := resolveComponentRefConfigMap(rctx)
 	if err != nil {
 		return err
 	}
 
-	handles := []reconfigureReconcileHandle{
-		prepareResources,
-		syncComponentParameterStatus,
-		classifyParameters(updatedParameters, configmaps),
-		updateCustomTemplates,
-		updateParameters,
-		updateComponentParameterStatus(configmaps),
+	var handles []reconfigureReconcileHandle
+	if compParameter.RevertToRevision != nil {
+		handles = []reconfigureReconcileHandle{
+			prepareResources,
+			syncComponentParameterStatus,
+			revertToRevision(*compParameter.RevertToRevision, configmaps),
+			updateParameters(true, nil),
+			updateComponentParameterStatus(configmaps),
+		}
+	} else {
+		handles = []reconfigureReconcileHandle{
+			prepareResources,
+			syncComponentParameterStatus,
+			classifyParameters(compParameter.Parameters, configmaps),
+			updateCustomTemplates,
+			updateParameters(false, compParameter.CanaryPolicy),
+			updateComponentParameterStatus(configmaps),
+		}
 	}
 
 	for _, handle := range handles {
@@ -131,7 +145,7 @@ func (r *ParameterReconciler) reconcile(reqCtx intctrlutil.RequestCtx, parameter
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 	if intctrlutil.ParametersTerminalPhases(parameter.Status, parameter.Generation) {
-		return intctrlutil.Reconciled()
+		return r.checkRollback(reqCtx, parameter, &cluster)
 	}
 
 	if err := r.validate(parameter, reqCtx.Ctx); err != nil {
@@ -149,22 +163,79 @@ func (r *ParameterReconciler) reconcile(reqCtx intctrlutil.RequestCtx, parameter
 		}
 	}
 	finished := syncParameterStatus(&parameter.Status)
-	return updateParameterStatus(reqCtx, r.Client, parameter, patch, finished)
+	if finished && parameter.Status.Phase == parametersv1alpha1.CFinishedPhase {
+		parameter.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	}
+	return updateParameterStatus(reqCtx, r.Client, parameter, patch, finished && !hasRollbackPolicy(parameter))
+}
+
+// checkRollback rolls back the parameters of the components which become unavailable within the rollback window.
+func (r *ParameterReconciler) checkRollback(reqCtx intctrlutil.RequestCtx, parameter *parametersv1alpha1.Parameter, cluster *appsv1.Cluster) (ctrl.Result, error) {
+	if parameter.Status.Phase != parametersv1alpha1.CFinishedPhase || parameter.Status.CompletionTime == nil {
+		return intctrlutil.Reconciled()
+	}
+
+	var requeueAfter time.Duration
+	patch := parameter.DeepCopy()
+	for _, compParameter := range parameter.Spec.ComponentParameters {
+		if compParameter.RollbackPolicy == nil {
+			continue
+		}
+		remaining := time.Until(parameter.Status.CompletionTime.Add(compParameter.RollbackPolicy.Window.Duration))
+		if remaining <= 0 {
+			continue
+		}
+		comps, err := resolveComponents(reqCtx.Ctx, r.Client, cluster, compParameter.ComponentName)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		for _, compName := range comps {
+			rctx := newParameterReconcileContext(reqCtx,
+				&render.ResourceCtx{
+					Context:       reqCtx.Ctx,
+					Client:        r.Client,
+					Namespace:     parameter.Namespace,
+					ClusterName:   parameter.Spec.ClusterName,
+					ComponentName: compName,
+				}, nil, cluster, "", nil)
+			rolledBack, err := rollbackUnavailableComponent(rctx, parameter)
+			if err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			if rolledBack {
+				r.Recorder.Eventf(parameter, corev1.EventTypeWarning, "RolledBack",
+					"component[%s] is unavailable after reconfiguring, and the parameters have been rolled back", compName)
+			}
+		}
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	if !reflect.DeepEqual(patch.Status, parameter.Status) {
+		if err := r.Client.Status().Patch(reqCtx.Ctx, parameter, client.MergeFrom(patch)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if parameter.Status.Phase != parametersv1alpha1.CFinishedPhase || requeueAfter == 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(min(requeueAfter, ConfigReconcileInterval), reqCtx.Log, "")
 }
 
 func (r *ParameterReconciler) generateParameterTaskContext(
 	reqCtx intctrlutil.RequestCtx,
 	parameter *parametersv1alpha1.Parameter,
-	cluster *appsv1.Cluster) ([]*ReconcileContext, []parametersv1alpha1.ComponentParameters, error) {
+	cluster *appsv1.Cluster) ([]*ReconcileContext, []parametersv1alpha1.ComponentParametersSpec, error) {
 	var rctxs []*ReconcileContext
-	var params []parametersv1alpha1.ComponentParameters
+	var params []parametersv1alpha1.ComponentParametersSpec
 	for _, compParameter := range parameter.Spec.ComponentParameters {
 		comps, err := resolveComponents(reqCtx.Ctx, r.Client, cluster, compParameter.ComponentName)
 		if err != nil {
 			return nil, nil, err
 		}
 		for _, compName := range comps {
-			params = append(params, compParameter.Parameters)
+			params = append(params, compParameter)
 			rctxs = append(rctxs, newParameterReconcileContext(reqCtx,
 				&render.ResourceCtx{
 					Context:       reqCtx.Ctx,
@@ -184,6 +255,12 @@ func (r *ParameterReconciler) validate(parameter *parametersv1alpha1.Parameter,
 	}
 
 	for _, compParameter := range parameter.Spec.ComponentParameters {
+		if compParameter.RevertToRevision != nil {
+			if len(compParameter.Parameters) != 0 || len(compParameter.CustomTemplates) != 0 {
+				return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "revertToRevision is mutually exclusive with parameters and custom templates for component[%s]", compParameter.ComponentName)
+			}
+			continue
+		}
 		if len(compParameter.Parameters) == 0 && len(compParameter.CustomTemplates) == 0 {
 			return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "required parameters or custom templates for component[%s]", compParameter.ComponentName)
 		}
@@ -20,11 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package parameters
 
 import (
+	"strconv"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -250,4 +254,161 @@ var _ = Describe("Parameter Controller", func() {
 		})
 	})
 
+	Context("parameter revision", func() {
+		var configKey types.NamespacedName
+
+		prepareRevisionTestEnv := func() {
+			prepareTestEnv()
+			configKey = types.NamespacedName{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      configcore.GetComponentCfgName(comp.ClusterName, comp.Name, configSpecName),
+			}
+		}
+
+		newParameterFactory := func() *testparameters.MockParameterFactory {
+			key := testapps.GetRandomizedKey(comp.Namespace, comp.FullCompName)
+			return testparameters.NewParameterFactory(key.Name, key.Namespace, comp.ClusterName, comp.Name)
+		}
+
+		submitParameter := func(factory *testparameters.MockParameterFactory) *parametersv1alpha1.Parameter {
+			parameterObj := factory.Create(&testCtx).GetObject()
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFinishedPhase))
+				g.Expect(parameter.Status.CompletionTime).ShouldNot(BeNil())
+			})).Should(Succeed())
+			return parameterObj
+		}
+
+		currentRevision := func() string {
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(testCtx.Ctx, configKey, cm)).Should(Succeed())
+			revision := GetCurrentRevision(cm.Annotations)
+			Expect(revision).ShouldNot(BeEmpty())
+			Expect(cm.Annotations).Should(HaveKey(configcore.GenerateRevisionParamsKey(revision)))
+			return revision
+		}
+
+		checkMaxConnections := func(value string) {
+			Eventually(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+				item := intctrlutil.GetConfigTemplateItem(&compParameter.Spec, configSpecName)
+				g.Expect(item).ShouldNot(BeNil())
+				g.Expect(item.ConfigFileParams[testparameters.MysqlConfigFile].Parameters).Should(HaveKeyWithValue("max_connections", pointer.String(value)))
+			})).Should(Succeed())
+		}
+
+		mockComponentUnavailable := func() {
+			compKey := types.NamespacedName{Namespace: comp.Namespace, Name: comp.FullCompName}
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, compKey, func(comp *appsv1.Component) {
+				meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+					Type:    appsv1.ConditionTypeAvailable,
+					Status:  metav1.ConditionFalse,
+					Reason:  "MockUnavailable",
+					Message: "mock the component unavailable",
+				})
+			})()).Should(Succeed())
+		}
+
+		It("revert to the specified revision", func() {
+			prepareRevisionTestEnv()
+
+			By("update the parameters twice")
+			submitParameter(newParameterFactory().AddParameters("max_connections", "100"))
+			revision := currentRevision()
+			submitParameter(newParameterFactory().AddParameters("max_connections", "200"))
+			checkMaxConnections("200")
+
+			By("revert to the revision of the first update")
+			rev, err := strconv.ParseInt(revision, 10, 64)
+			Expect(err).ShouldNot(HaveOccurred())
+			submitParameter(newParameterFactory().SetRevertToRevision(rev))
+			checkMaxConnections("100")
+
+			By("revert to a revision which has not been recorded")
+			parameterObj := newParameterFactory().SetRevertToRevision(0).Create(&testCtx).GetObject()
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CMergeFailedPhase))
+			})).Should(Succeed())
+			checkMaxConnections("100")
+		})
+
+		It("roll back the parameters if the component becomes unavailable within the window", func() {
+			prepareRevisionTestEnv()
+
+			By("update the parameters with the rollback policy")
+			submitParameter(newParameterFactory().AddParameters("max_connections", "100"))
+			revision := currentRevision()
+			parameterObj := submitParameter(newParameterFactory().
+				AddParameters("max_connections", "200").
+				SetRollbackPolicy(time.Hour))
+			checkMaxConnections("200")
+
+			By("mock the component unavailable")
+			mockComponentUnavailable()
+
+			By("check the parameters rolled back")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFailedAndPausePhase))
+				compStatus := intctrlutil.GetParameterStatus(&parameter.Status, comp.Name)
+				g.Expect(compStatus).ShouldNot(BeNil())
+				g.Expect(compStatus.RolledBackRevision).Should(Equal(revision))
+			})).Should(Succeed())
+			checkMaxConnections("100")
+		})
+
+		It("not roll back the parameters after the window", func() {
+			prepareRevisionTestEnv()
+
+			By("update the parameters with the rollback policy")
+			submitParameter(newParameterFactory().AddParameters("max_connections", "100"))
+			window := time.Second
+			parameterObj := submitParameter(newParameterFactory().
+				AddParameters("max_connections", "200").
+				SetRollbackPolicy(window))
+
+			By("wait for the window to pass")
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKeyFromObject(parameterObj), parameterObj)).Should(Succeed())
+			time.Sleep(time.Until(parameterObj.Status.CompletionTime.Add(window)))
+
+			By("mock the component unavailable")
+			mockComponentUnavailable()
+
+			By("check the parameters not rolled back")
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFinishedPhase))
+			}), time.Second*3).Should(Succeed())
+			checkMaxConnections("200")
+		})
+
+		It("not roll back the parameters if the previous revision has no recorded parameters", func() {
+			prepareRevisionTestEnv()
+
+			By("update the parameters with the rollback policy")
+			parameterObj := submitParameter(newParameterFactory().
+				AddParameters("max_connections", "200").
+				SetRollbackPolicy(time.Hour))
+			revision := currentRevision()
+
+			By("remove the recorded parameters of the previous revisions")
+			Expect(testapps.GetAndChangeObj(&testCtx, configKey, func(cm *corev1.ConfigMap) {
+				for _, params := range RetrieveRevisionParameters(cm.Annotations) {
+					if rev := strconv.FormatInt(params.Revision, 10); rev != revision {
+						delete(cm.Annotations, configcore.GenerateRevisionParamsKey(rev))
+					}
+				}
+			})()).Should(Succeed())
+
+			By("mock the component unavailable")
+			mockComponentUnavailable()
+
+			By("check the parameters not rolled back")
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFinishedPhase))
+				compStatus := intctrlutil.GetParameterStatus(&parameter.Status, comp.Name)
+				g.Expect(compStatus).ShouldNot(BeNil())
+				g.Expect(compStatus.RolledBackRevision).Should(BeEmpty())
+			}), time.Second*3).Should(Succeed())
+			checkMaxConnections("200")
+		})
+	})
+
 })
@@ -22,15 +22,22 @@ package parameters
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/imdario/mergo"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -44,11 +51,43 @@ func updateComponentParameterStatus(configmaps map[string]*corev1.ConfigMap) fun
 		status := safeResolveComponentStatus(&parameter.Status, rctx.ComponentName)
 		if !intctrlutil.IsParameterFinished(status.Phase) {
 			syncReconfiguringPhase(rctx, status, configmaps)
+			return rollbackFailedCanary(rctx, parameter, status, configmaps)
 		}
 		return nil
 	}
 }
 
+// rollbackFailedCanary rolls back the parameters of the component if the canary instances fail during the bake time.
+func rollbackFailedCanary(rctx *ReconcileContext,
+	parameter *parametersv1alpha1.Parameter,
+	status *parametersv1alpha1.ComponentReconfiguringStatus,
+	configmaps map[string]*corev1.ConfigMap) error {
+	if status.Phase != parametersv1alpha1.CFailedAndPausePhase || status.RolledBackRevision != "" {
+		return nil
+	}
+
+	var canary *parametersv1alpha1.CanaryStatus
+	for _, parameterStatus := range status.ParameterStatus {
+		detail := parameterStatus.ReconcileDetail
+		if detail != nil && detail.Canary != nil && detail.Canary.Stage == parametersv1alpha1.CanaryFailedStage {
+			canary = detail.Canary
+			break
+		}
+	}
+	if canary == nil {
+		return nil
+	}
+
+	rollbackRevision, err := rollbackComponentParameters(rctx, status, configmaps)
+	if err != nil || rollbackRevision < 0 {
+		return err
+	}
+	status.RolledBackRevision = strconv.FormatInt(rollbackRevision, 10)
+	parameter.Status.Message = fmt.Sprintf("the canary instances %v of component[%s] failed: %s, and the parameters have been rolled back to revision[%d]",
+		canary.Instances, rctx.ComponentName, canary.Message, rollbackRevision)
+	return nil
+}
+
 func syncReconfiguringPhase(rctx *ReconcileContext, status *parametersv1alpha1.ComponentReconfiguringStatus, configmaps map[string]*corev1.ConfigMap) {
 	var finished = true
 
@@ -107,36 +146,149 @@ func mergeWithOverride(item *parametersv1alpha1.ConfigTemplateItemDetail, update
 	return nil
 }
 
-func updateParameters(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) error {
-	var updated bool
+// updateParameters updates the parameters of the ComponentParameter, if replace is true,
+// the parameters are replaced by the updated parameters rather than merged.
+func updateParameters(replace bool, canaryPolicy *parametersv1alpha1.CanaryPolicy) reconfigureReconcileHandle {
+	return func(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) error {
+		var updated bool
 
-	compStatus := intctrlutil.GetParameterStatus(&parameter.Status, rctx.ComponentName)
-	if compStatus == nil || intctrlutil.IsParameterFinished(compStatus.Phase) {
+		compStatus := intctrlutil.GetParameterStatus(&parameter.Status, rctx.ComponentName)
+		if compStatus == nil || intctrlutil.IsParameterFinished(compStatus.Phase) {
+			return nil
+		}
+
+		patch := rctx.ComponentParameterObj.DeepCopy()
+		var item *parametersv1alpha1.ConfigTemplateItemDetail
+		for _, status := range compStatus.ParameterStatus {
+			if item = intctrlutil.GetConfigTemplateItem(&rctx.ComponentParameterObj.Spec, status.Name); item == nil {
+				status.Phase = parametersv1alpha1.CMergeFailedPhase
+				continue
+			}
+			if replace {
+				item.ConfigFileParams = status.UpdatedParameters
+			} else if err := mergeWithOverride(item, status.UpdatedParameters); err != nil {
+				status.Phase = parametersv1alpha1.CMergeFailedPhase
+				return err
+			}
+			if status.CustomTemplate != nil {
+				item.CustomTemplates = status.CustomTemplate
+			}
+			item.CanaryPolicy = canaryPolicy
+			updated = true
+			status.Phase = parametersv1alpha1.CMergedPhase
+		}
+
+		if updated && !reflect.DeepEqual(patch, rctx.ComponentParameterObj) {
+			return rctx.Client.Patch(rctx.Ctx, rctx.ComponentParameterObj, client.MergeFrom(patch))
+		}
 		return nil
 	}
+}
 
+// revertToRevision resolves the parameters of each config template at the given revision.
+func revertToRevision(revision int64, configmaps map[string]*corev1.ConfigMap) reconfigureReconcileHandle {
+	return func(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) error {
+		compStatus := intctrlutil.GetParameterStatus(&parameter.Status, rctx.ComponentName)
+		if compStatus != nil && len(compStatus.ParameterStatus) != 0 {
+			return nil
+		}
+
+		var reverted bool
+		for tpl, cm := range configmaps {
+			revisionParams, ok := GetRevisionParameters(cm.Annotations, revision)
+			if !ok {
+				continue
+			}
+			item := intctrlutil.GetConfigTemplateItem(&rctx.ComponentParameterObj.Spec, tpl)
+			if item == nil {
+				continue
+			}
+			status := safeResolveComponentParameterStatus(&parameter.Status, rctx.ComponentName, tpl)
+			// the parameters are merged into the rendered template from scratch, so the parameters of the target
+			// revision are restored as they are, and the template defaults are kept for the others.
+			status.UpdatedParameters = revisionParams.Parameters
+			reverted = true
+		}
+		if !reverted {
+			return intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal, "not found revision[%d] for component[%s]", revision, rctx.ComponentName)
+		}
+		return nil
+	}
+}
+
+// rollbackUnavailableComponent rolls back the parameters of the component to the revision before the reconfiguration
+// if the component fails its availability check.
+func rollbackUnavailableComponent(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) (bool, error) {
+	compStatus := intctrlutil.GetParameterStatus(&parameter.Status, rctx.ComponentName)
+	if compStatus == nil || compStatus.RolledBackRevision != "" {
+		return false, nil
+	}
+	if err := rctx.ComponentAndComponentDef().ComponentParameter().Complete(); err != nil {
+		return false, err
+	}
+	if isComponentAvailable(rctx.ComponentObj) {
+		return false, nil
+	}
+
+	configmaps, err := resolveComponentRefConfigMap(rctx)
+	if err != nil {
+		return false, err
+	}
+	rollbackRevision, err := rollbackComponentParameters(rctx, compStatus, configmaps)
+	if err != nil || rollbackRevision < 0 {
+		return false, err
+	}
+
+	compStatus.RolledBackRevision = strconv.FormatInt(rollbackRevision, 10)
+	compStatus.Phase = parametersv1alpha1.CFailedAndPausePhase
+	parameter.Status.Phase = parametersv1alpha1.CFailedAndPausePhase
+	parameter.Status.Message = fmt.Sprintf("component[%s] is unavailable after reconfiguring, and the parameters have been rolled back to revision[%d]",
+		rctx.ComponentName, rollbackRevision)
+	return true, nil
+}
+
+// rollbackComponentParameters reverts the parameters of each config template to the revision before the update revision,
+// and returns the revision rolled back to, or -1 if there is no revision to roll back.
+func rollbackComponentParameters(rctx *ReconcileContext,
+	compStatus *parametersv1alpha1.ComponentReconfiguringStatus,
+	configmaps map[string]*corev1.ConfigMap) (int64, error) {
+	var rollbackRevision int64 = -1
 	patch := rctx.ComponentParameterObj.DeepCopy()
-	var item *parametersv1alpha1.ConfigTemplateItemDetail
 	for _, status := range compStatus.ParameterStatus {
-		if item = intctrlutil.GetConfigTemplateItem(&rctx.ComponentParameterObj.Spec, status.Name); item == nil {
-			status.Phase = parametersv1alpha1.CMergeFailedPhase
+		cm := configmaps[status.Name]
+		item := intctrlutil.GetConfigTemplateItem(&rctx.ComponentParameterObj.Spec, status.Name)
+		updateRevision, err := strconv.ParseInt(status.UpdateRevision, 10, 64)
+		if cm == nil || item == nil || err != nil {
 			continue
 		}
-		if err := mergeWithOverride(item, status.UpdatedParameters); err != nil {
-			status.Phase = parametersv1alpha1.CMergeFailedPhase
-			return err
-		}
-		if status.CustomTemplate != nil {
-			item.CustomTemplates = status.CustomTemplate
+		revisionParams, ok := GetRevisionParameters(cm.Annotations, updateRevision-1)
+		if !ok {
+			continue
 		}
-		updated = true
-		status.Phase = parametersv1alpha1.CMergedPhase
+		item.ConfigFileParams = revisionParams.Parameters
+		// the rolled back parameters are applied to all instances
+		item.CanaryPolicy = nil
+		rollbackRevision = max(rollbackRevision, revisionParams.Revision)
+	}
+	if rollbackRevision < 0 {
+		rctx.Log.Info("not found the revision to roll back", "component", rctx.ComponentName)
+		return rollbackRevision, nil
 	}
+	return rollbackRevision, rctx.Client.Patch(rctx.Ctx, rctx.ComponentParameterObj, client.MergeFrom(patch))
+}
 
-	if updated && !reflect.DeepEqual(patch, rctx.ComponentParameterObj) {
-		return rctx.Client.Patch(rctx.Ctx, rctx.ComponentParameterObj, client.MergeFrom(patch))
+func isComponentAvailable(comp *appsv1.Component) bool {
+	cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeAvailable)
+	return cond == nil || cond.Status != metav1.ConditionFalse
+}
+
+func hasRollbackPolicy(parameter *parametersv1alpha1.Parameter) bool {
+	for _, compParameter := range parameter.Spec.ComponentParameters {
+		if compParameter.RollbackPolicy != nil {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
 func updateCustomTemplates(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) error {
@@ -174,6 +326,9 @@ func classifyParameters(updatedParameters parametersv1alpha1.ComponentParameters
 			if err := validateComponentParameter(toArray(rctx.ParametersDefs), configDescs, m); err != nil {
 				return intctrlutil.NewFatalError(err.Error())
 			}
+			if err := validateParameterRules(rctx, configmaps[tpl], configDescs, m); err != nil {
+				return intctrlutil.NewFatalError(err.Error())
+			}
 			safeUpdateComponentParameterStatus(&parameter.Status, rctx.ComponentName, tpl, m)
 		}
 		return nil
@@ -188,6 +343,53 @@ func validateComponentParameter(parametersDefs []*parametersv1alpha1.ParametersD
 	return err
 }
 
+// validateParameterRules evaluates the validation rules of the parameters definitions against
+// the parameters merged with the current config files.
+func validateParameterRules(rctx *ReconcileContext,
+	cm *corev1.ConfigMap,
+	descs []parametersv1alpha1.ComponentConfigDescription,
+	parameters map[string]*parametersv1alpha1.ParametersInFile) error {
+	var fileNames []string
+	for fileName := range parameters {
+		if paramsDef := rctx.ParametersDefs[fileName]; paramsDef != nil && len(paramsDef.Spec.ValidationRules) != 0 {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	if len(fileNames) == 0 {
+		return nil
+	}
+	slices.Sort(fileNames)
+
+	baseData := resolveBaseData(parameters)
+	if cm != nil {
+		baseData = core.MergeUpdatedConfig(baseData, cm.Data)
+	}
+	mergedData, err := configctrl.DoMerge(baseData, configctrl.DerefMapValues(parameters), toArray(rctx.ParametersDefs), descs)
+	if err != nil {
+		return err
+	}
+
+	ruleCtx := &validate.RuleContext{}
+	if rctx.BuiltinComponent != nil {
+		ruleCtx.Resources = rctx.BuiltinComponent.Resources
+		ruleCtx.Replicas = rctx.BuiltinComponent.Replicas
+	}
+	var errs []error
+	for _, fileName := range fileNames {
+		fc := core.ResolveConfigFormat(descs, fileName)
+		if fc == nil {
+			continue
+		}
+		if ruleCtx.Parameters, err = core.GetParametersFromConfigFile(mergedData[fileName], fc); err != nil {
+			return err
+		}
+		if err = validate.ValidateRules(rctx.ParametersDefs[fileName].Spec.ValidationRules, ruleCtx); err != nil {
+			errs = append(errs, core.WrapError(err, "failed to validate the parameters of file[%s]", fileName))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func resolveBaseData(updatedParameters map[string]*parametersv1alpha1.ParametersInFile) map[string]string {
 	baseData := make(map[string]string)
 	for key := range updatedParameters {
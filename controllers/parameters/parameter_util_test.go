@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+func TestValidateParameterRules(t *testing.T) {
+	const fileName = "my.cnf"
+
+	paramsDef := &parametersv1alpha1.ParametersDefinition{
+		Spec: parametersv1alpha1.ParametersDefinitionSpec{
+			FileName: fileName,
+			ValidationRules: []parametersv1alpha1.ParametersValidationRule{{
+				Name:       "buffer-pool-size",
+				Expression: "quantity(parameters.innodb_buffer_pool_size) <= resources.limits.memory * 3 / 4",
+				Message:    "innodb_buffer_pool_size must be at most 75% of the memory limit",
+			}, {
+				Name:       "connections",
+				Expression: "int(parameters.max_connections) <= 1000 * replicas",
+			}},
+		},
+	}
+	rctx := &ReconcileContext{
+		ParametersDefs: map[string]*parametersv1alpha1.ParametersDefinition{fileName: paramsDef},
+		BuiltinComponent: &component.SynthesizedComponent{
+			Replicas: 1,
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+		},
+	}
+	descs := []parametersv1alpha1.ComponentConfigDescription{{
+		Name:         fileName,
+		TemplateName: "mysql-config",
+		FileFormatConfig: &parametersv1alpha1.FileFormatConfig{
+			Format: parametersv1alpha1.Ini,
+			FormatterAction: parametersv1alpha1.FormatterAction{
+				IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+			},
+		},
+	}}
+	cm := builder.NewConfigMapBuilder("default", "mysql-config").
+		SetData(map[string]string{fileName: "[mysqld]\ninnodb_buffer_pool_size=2G\nmax_connections=500\n"}).
+		GetObject()
+	updated := func(name, value string) map[string]*parametersv1alpha1.ParametersInFile {
+		return map[string]*parametersv1alpha1.ParametersInFile{
+			fileName: {Parameters: map[string]*string{name: pointer.String(value)}},
+		}
+	}
+
+	// the merged parameters satisfy the rules
+	assert.NoError(t, validateParameterRules(rctx, cm, descs, updated("innodb_buffer_pool_size", "3G")))
+
+	// the updated parameter violates the rule against the resources
+	err := validateParameterRules(rctx, cm, descs, updated("innodb_buffer_pool_size", "4G"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule[buffer-pool-size] is violated: innodb_buffer_pool_size must be at most 75% of the memory limit")
+
+	// the rule references the parameter in the current config file
+	err = validateParameterRules(rctx, cm, descs, updated("max_connections", "2000"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule[connections] is violated")
+	assert.NotContains(t, err.Error(), "rule[buffer-pool-size]")
+
+	// no rules defined
+	paramsDef.Spec.ValidationRules = nil
+	assert.NoError(t, validateParameterRules(rctx, cm, descs, updated("max_connections", "2000")))
+}
@@ -0,0 +1,379 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/render"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// ParameterDriftReconciler periodically compares the live parameters of the running instances with
+// the rendered configuration, and reports or re-applies the drifted parameters.
+type ParameterDriftReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// queryPodParameters queries the live parameters of the pod by the queryParameters action, support ut mock
+var queryPodParameters = func(rctx *ReconcileContext, pod *corev1.Pod, paramsDef *parametersv1alpha1.ParametersDefinition) ([]byte, error) {
+	var templateVars map[string]any
+	if rctx.BuiltinComponent != nil {
+		templateVars = rctx.BuiltinComponent.TemplateVars
+	}
+	lfa, err := lifecycle.New(rctx.Namespace, rctx.ClusterName, rctx.ComponentName,
+		&appsv1.ComponentLifecycleActions{}, templateVars, pod)
+	if err != nil {
+		return nil, err
+	}
+	return lfa.QueryParameters(rctx.Ctx, rctx.Client, nil,
+		component.UDFQueryParametersActionName(paramsDef.Name), paramsDef.Spec.QueryParameters)
+}
+
+// reapplyPodParameters re-applies the parameters to the pod online, and returns the errors of the parameters
+// failed to be re-applied, support ut mock
+var reapplyPodParameters = func(rctx *ReconcileContext,
+	pod *corev1.Pod,
+	paramsDef *parametersv1alpha1.ParametersDefinition,
+	fileName string,
+	params map[string]string) map[string]error {
+	failed := make(map[string]error)
+	if cfgcm.IsControllerReload(paramsDef.Spec.ReloadAction) {
+		reCtx := reconfigureContext{
+			RequestCtx:            rctx.RequestCtx,
+			Client:                rctx.Client,
+			SynthesizedComponent:  rctx.BuiltinComponent,
+			ParametersDef:         &paramsDef.Spec,
+			ParamsReloaderFactory: GetParamsReloaderFactory(),
+		}
+		paramErrors, err := reloadPodParameters(reCtx, pod, params)
+		if err != nil {
+			for name := range params {
+				failed[name] = err
+			}
+		}
+		maps.Copy(failed, paramErrors)
+	} else if err := commonOnlineUpdateWithPod(pod, rctx.Ctx, GetClientFactory(), rctx.Name, fileName, params); err != nil {
+		for name := range params {
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters/status,verbs=get;update;patch
+
+func (r *ParameterDriftReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Recorder: r.Recorder,
+		Log: log.FromContext(ctx).
+			WithName("ParameterDriftReconciler").
+			WithValues("Namespace", req.Namespace, "ComponentParameter", req.Name),
+	}
+
+	compParam := &parametersv1alpha1.ComponentParameter{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, compParam); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if model.IsObjectDeleting(compParam) {
+		return intctrlutil.Reconciled()
+	}
+	// the drift is only meaningful after the rendered configuration has been applied to all instances.
+	if compParam.Status.Phase != parametersv1alpha1.CFinishedPhase {
+		return intctrlutil.Reconciled()
+	}
+	return r.reconcile(reqCtx, compParam)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ParameterDriftReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the detection is driven by the periodic requeue, ignore the status updates made by the drift detection itself.
+	driftPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj, ok1 := e.ObjectOld.(*parametersv1alpha1.ComponentParameter)
+			newObj, ok2 := e.ObjectNew.(*parametersv1alpha1.ComponentParameter)
+			if !ok1 || !ok2 {
+				return false
+			}
+			return oldObj.Generation != newObj.Generation || oldObj.Status.Phase != newObj.Status.Phase
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("parameterdrift").
+		For(&parametersv1alpha1.ComponentParameter{}, builder.WithPredicates(driftPredicate)).
+		Complete(r)
+}
+
+func (r *ParameterDriftReconciler) reconcile(reqCtx intctrlutil.RequestCtx, compParam *parametersv1alpha1.ComponentParameter) (ctrl.Result, error) {
+	var (
+		requeueAfter time.Duration
+		// the status is shared with the ComponentParameter controller, patch it with the optimistic lock to
+		// avoid overwriting the configuration status updated concurrently.
+		patch    = client.MergeFromWithOptions(compParam.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		original = compParam.Status.DeepCopy()
+	)
+
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		status := intctrlutil.GetItemStatus(&compParam.Status, item.Name)
+		if status == nil {
+			continue
+		}
+		interval, err := r.detectTemplateDrift(reqCtx, compParam, status)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to detect the configuration drift")
+		}
+		if interval > 0 && (requeueAfter == 0 || interval < requeueAfter) {
+			requeueAfter = interval
+		}
+	}
+
+	// no template declares the queryParameters action, and the drift has never been detected.
+	if requeueAfter == 0 && meta.FindStatusCondition(compParam.Status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition) == nil {
+		return intctrlutil.Reconciled()
+	}
+	setDriftCondition(&compParam.Status, compParam.Generation)
+	if !reflect.DeepEqual(original, &compParam.Status) {
+		if err := r.Client.Status().Patch(reqCtx.Ctx, compParam, patch); err != nil {
+			if apierrors.IsConflict(err) {
+				return intctrlutil.Requeue(reqCtx.Log, err.Error())
+			}
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to update the drift status")
+		}
+	}
+	if requeueAfter == 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "")
+}
+
+// detectTemplateDrift detects the drift of the config template, and returns the interval of the next detection.
+func (r *ParameterDriftReconciler) detectTemplateDrift(reqCtx intctrlutil.RequestCtx,
+	compParam *parametersv1alpha1.ComponentParameter,
+	status *parametersv1alpha1.ConfigTemplateItemDetailStatus) (time.Duration, error) {
+	var (
+		clusterName = compParam.Spec.ClusterName
+		compName    = compParam.Spec.ComponentName
+	)
+
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: compParam.Namespace, Name: core.GetComponentCfgName(clusterName, compName, status.Name)}
+	if err := r.Client.Get(reqCtx.Ctx, cmKey, cm); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+
+	rctx := newParameterReconcileContext(reqCtx,
+		&render.ResourceCtx{
+			Context:       reqCtx.Ctx,
+			Client:        r.Client,
+			Namespace:     compParam.Namespace,
+			ClusterName:   clusterName,
+			ComponentName: compName,
+		},
+		cm, nil, status.Name, constant.GetCompLabels(clusterName, compName))
+	if err := rctx.GetRelatedObjects(); err != nil {
+		return 0, err
+	}
+	if rctx.ConfigRender == nil {
+		status.DriftedInstances = nil
+		return 0, nil
+	}
+
+	pods, err := r.listReadyPods(rctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		interval time.Duration
+		drifts   = make(map[string][]parametersv1alpha1.ParameterDrift)
+		failed   = sets.New[string]()
+	)
+	for _, fileName := range slices.Sorted(maps.Keys(cm.Data)) {
+		paramsDef := rctx.ParametersDefs[fileName]
+		if paramsDef == nil || paramsDef.Spec.QueryParameters == nil {
+			continue
+		}
+		configDesc := intctrlutil.GetComponentConfigDescription(&rctx.ConfigRender.Spec, fileName)
+		if configDesc == nil || configDesc.FileFormatConfig == nil {
+			continue
+		}
+		if d := driftDetectionInterval(&paramsDef.Spec); interval == 0 || d < interval {
+			interval = d
+		}
+		expected, err := core.GetParametersFromConfigFile(cm.Data[fileName], configDesc.FileFormatConfig)
+		if err != nil {
+			return 0, err
+		}
+		for i := range pods {
+			pod := &pods[i]
+			podDrifts, err := r.detectPodDrift(rctx, compParam, pod, paramsDef, fileName, expected)
+			if err != nil {
+				reqCtx.Log.Error(err, "failed to query the live parameters", "pod", pod.Name)
+				r.Recorder.Eventf(compParam, corev1.EventTypeWarning, "QueryParametersFailed",
+					"failed to query the live parameters of pod[%s]: %v", pod.Name, err)
+				failed.Insert(pod.Name)
+				continue
+			}
+			drifts[pod.Name] = append(drifts[pod.Name], podDrifts...)
+		}
+	}
+	if interval == 0 {
+		status.DriftedInstances = nil
+		return 0, nil
+	}
+
+	// keep the previous state of the pods failed to be queried.
+	for podName := range failed {
+		delete(drifts, podName)
+	}
+	podNames := sets.New[string]()
+	for _, pod := range pods {
+		podNames.Insert(pod.Name)
+	}
+	for _, podName := range updateDriftedInstances(status, drifts, podNames, metav1.Now()) {
+		r.Recorder.Eventf(compParam, corev1.EventTypeWarning, parametersv1alpha1.ConfigurationDriftedCondition,
+			"the live parameters of pod[%s] drift from the config template[%s]: %s",
+			podName, status.Name, formatParameterDrifts(drifts[podName]))
+	}
+	return interval, nil
+}
+
+func (r *ParameterDriftReconciler) listReadyPods(rctx *ReconcileContext) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	for i := range rctx.InstanceSetList {
+		podList, err := intctrlutil.GetPodListByInstanceSet(rctx.Ctx, r.Client, &rctx.InstanceSetList[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range podList {
+			if intctrlutil.IsPodReady(&pod) && !model.IsObjectDeleting(&pod) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
+func (r *ParameterDriftReconciler) detectPodDrift(rctx *ReconcileContext,
+	compParam *parametersv1alpha1.ComponentParameter,
+	pod *corev1.Pod,
+	paramsDef *parametersv1alpha1.ParametersDefinition,
+	fileName string,
+	expected map[string]string) ([]parametersv1alpha1.ParameterDrift, error) {
+	output, err := queryPodParameters(rctx, pod, paramsDef)
+	if err != nil {
+		return nil, err
+	}
+	live, err := parseLiveParameters(output)
+	if err != nil {
+		return nil, err
+	}
+	drifts := compareParameters(&paramsDef.Spec, fileName, expected, live)
+	if len(drifts) == 0 || driftRemediation(&paramsDef.Spec) != parametersv1alpha1.ReapplyDriftRemediation {
+		return drifts, nil
+	}
+	return r.reapplyParameters(rctx, compParam, pod, paramsDef, fileName, drifts), nil
+}
+
+// reapplyParameters re-applies the rendered values of the drifted dynamic parameters to the pod,
+// and returns the parameters that are still drifted.
+func (r *ParameterDriftReconciler) reapplyParameters(rctx *ReconcileContext,
+	compParam *parametersv1alpha1.ComponentParameter,
+	pod *corev1.Pod,
+	paramsDef *parametersv1alpha1.ParametersDefinition,
+	fileName string,
+	drifts []parametersv1alpha1.ParameterDrift) []parametersv1alpha1.ParameterDrift {
+	if !enableSyncTrigger(paramsDef.Spec.ReloadAction) {
+		return drifts
+	}
+
+	params := make(map[string]string)
+	for _, drift := range drifts {
+		if core.IsDynamicParameter(drift.Name, &paramsDef.Spec) {
+			params[drift.Name] = drift.Expected
+		}
+	}
+	if len(params) == 0 {
+		return drifts
+	}
+
+	failed := reapplyPodParameters(rctx, pod, paramsDef, fileName, params)
+
+	var (
+		remaining []parametersv1alpha1.ParameterDrift
+		reapplied []string
+	)
+	for _, drift := range drifts {
+		_, updated := params[drift.Name]
+		if err, ok := failed[drift.Name]; !updated || ok {
+			if ok {
+				rctx.Log.Error(err, "failed to re-apply the drifted parameter", "pod", pod.Name, "parameter", drift.Name)
+			}
+			remaining = append(remaining, drift)
+			continue
+		}
+		reapplied = append(reapplied, drift.Name)
+	}
+	if len(reapplied) != 0 {
+		r.Recorder.Eventf(compParam, corev1.EventTypeNormal, "DriftReapplied",
+			"re-applied the drifted parameters [%s] to pod[%s]", strings.Join(reapplied, ","), pod.Name)
+	}
+	if len(failed) != 0 {
+		r.Recorder.Eventf(compParam, corev1.EventTypeWarning, "DriftReapplyFailed",
+			"failed to re-apply the drifted parameters to pod[%s]: %s", pod.Name, fmt.Sprint(slices.Sorted(maps.Keys(failed))))
+	}
+	return remaining
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	configcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testparameters "github.com/apecloud/kubeblocks/pkg/testutil/parameters"
+)
+
+var _ = Describe("ParameterDrift Controller", func() {
+
+	var (
+		compParamKey types.NamespacedName
+		comp         *component.SynthesizedComponent
+		podName      string
+
+		liveParameters      atomic.Value
+		reappliedParameters atomic.Value
+
+		originalQueryPodParameters   = queryPodParameters
+		originalReapplyPodParameters = reapplyPodParameters
+	)
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		liveParameters.Store(`{"gtid_mode": "OFF"}`)
+		reappliedParameters.Store(map[string]string(nil))
+		queryPodParameters = func(_ *ReconcileContext, pod *corev1.Pod, _ *parametersv1alpha1.ParametersDefinition) ([]byte, error) {
+			if pod.Name != podName {
+				return nil, fmt.Errorf("unexpected pod: %s", pod.Name)
+			}
+			return []byte(liveParameters.Load().(string)), nil
+		}
+		reapplyPodParameters = func(_ *ReconcileContext, _ *corev1.Pod, _ *parametersv1alpha1.ParametersDefinition, _ string, params map[string]string) map[string]error {
+			reappliedParameters.Store(params)
+			// the drifted parameters have been re-applied to the instance.
+			liveParameters.Store(`{"gtid_mode": "OFF"}`)
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+
+		queryPodParameters = originalQueryPodParameters
+		reapplyPodParameters = originalReapplyPodParameters
+	})
+
+	prepareTestEnv := func(remediation parametersv1alpha1.DriftRemediation, reloadAction *parametersv1alpha1.ReloadAction) {
+		var clusterObj *appsv1.Cluster
+		_, _, clusterObj, _, comp = mockReconcileResource(func(paramsDef *parametersv1alpha1.ParametersDefinition) {
+			paramsDef.Spec.ReloadAction = reloadAction
+			paramsDef.Spec.DynamicParameters = []string{"gtid_mode"}
+			paramsDef.Spec.QueryParameters = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"query-parameters"},
+				},
+			}
+			paramsDef.Spec.DriftDetectionPolicy = &parametersv1alpha1.DriftDetectionPolicy{
+				Interval:    &metav1.Duration{Duration: time.Second},
+				Remediation: remediation,
+			}
+		})
+		compParamKey = types.NamespacedName{
+			Namespace: testCtx.DefaultNamespace,
+			Name:      configcore.GenerateComponentConfigurationName(comp.ClusterName, comp.Name),
+		}
+
+		By("mock the ready pod of the component")
+		its := &workloads.InstanceSet{}
+		Expect(k8sClient.Get(testCtx.Ctx, types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: defaultITSName}, its)).Should(Succeed())
+		podName = fmt.Sprintf("%s-0", its.Name)
+		testapps.MockInstanceSetPod(&testCtx, its, clusterObj.Name, comp.Name, podName, "")
+
+		Eventually(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+			g.Expect(compParameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFinishedPhase))
+			g.Expect(meta.IsStatusConditionFalse(compParameter.Status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)).Should(BeTrue())
+		})).Should(Succeed())
+	}
+
+	checkDriftEvent := func(reason string) {
+		Eventually(func(g Gomega) {
+			events := &corev1.EventList{}
+			g.Expect(k8sClient.List(testCtx.Ctx, events, client.InNamespace(testCtx.DefaultNamespace))).Should(Succeed())
+			var found bool
+			for _, event := range events.Items {
+				if event.InvolvedObject.Name == compParamKey.Name && event.Reason == reason {
+					found = true
+				}
+			}
+			g.Expect(found).Should(BeTrue())
+		}).Should(Succeed())
+	}
+
+	Context("drift detection", func() {
+		It("alert the drifted parameters", func() {
+			prepareTestEnv(parametersv1alpha1.AlertDriftRemediation, testparameters.WithNoneAction())
+
+			By("mock the parameter changed manually")
+			liveParameters.Store(`{"gtid_mode": "ON"}`)
+
+			By("check the drift condition and event")
+			Eventually(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+				cond := meta.FindStatusCondition(compParameter.Status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Status).Should(BeEquivalentTo(metav1.ConditionTrue))
+				g.Expect(cond.Reason).Should(Equal(reasonParametersDrifted))
+				g.Expect(cond.Message).Should(ContainSubstring(fmt.Sprintf("%s/%s", configSpecName, podName)))
+
+				status := intctrlutil.GetItemStatus(&compParameter.Status, configSpecName)
+				g.Expect(status).ShouldNot(BeNil())
+				g.Expect(status.DriftedInstances).Should(HaveLen(1))
+				g.Expect(status.DriftedInstances[0].PodName).Should(Equal(podName))
+				g.Expect(status.DriftedInstances[0].Parameters).Should(ConsistOf(parametersv1alpha1.ParameterDrift{
+					Name:     "gtid_mode",
+					FileName: testparameters.MysqlConfigFile,
+					Expected: "OFF",
+					Actual:   "ON",
+				}))
+			})).Should(Succeed())
+			checkDriftEvent(parametersv1alpha1.ConfigurationDriftedCondition)
+			Expect(reappliedParameters.Load()).Should(BeNil())
+
+			By("mock the parameter restored manually")
+			liveParameters.Store(`{"gtid_mode": "OFF"}`)
+
+			Eventually(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+				cond := meta.FindStatusCondition(compParameter.Status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Status).Should(BeEquivalentTo(metav1.ConditionFalse))
+				g.Expect(cond.Reason).Should(Equal(reasonNoDrift))
+				g.Expect(intctrlutil.GetItemStatus(&compParameter.Status, configSpecName).DriftedInstances).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+
+		It("re-apply the drifted parameters", func() {
+			prepareTestEnv(parametersv1alpha1.ReapplyDriftRemediation, &parametersv1alpha1.ReloadAction{
+				ShellTrigger: &parametersv1alpha1.ShellTrigger{
+					Command: []string{"reload"},
+					Sync:    pointer.Bool(true),
+				},
+			})
+
+			By("mock the parameter changed manually")
+			liveParameters.Store(`{"gtid_mode": "ON"}`)
+
+			By("check the drifted parameters re-applied")
+			Eventually(reappliedParameters.Load).Should(Equal(map[string]string{"gtid_mode": "OFF"}))
+			checkDriftEvent("DriftReapplied")
+
+			Consistently(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+				g.Expect(meta.IsStatusConditionFalse(compParameter.Status.Conditions, parametersv1alpha1.ConfigurationDriftedCondition)).Should(BeTrue())
+				g.Expect(intctrlutil.GetItemStatus(&compParameter.Status, configSpecName).DriftedInstances).Should(BeEmpty())
+			}), time.Second*3).Should(Succeed())
+		})
+	})
+})
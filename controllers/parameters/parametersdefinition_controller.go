@@ -153,6 +153,11 @@ func checkParametersSchema(ctx intctrlutil.RequestCtx, parametersDef *parameters
 			"configMapName", fmt.Sprintf("%v", parametersDef.Spec.ParametersSchema))
 		return ok, err
 	}
+	// validate the cel rules
+	if err := validate.CompileValidationRules(parametersDef.Spec.ValidationRules); err != nil {
+		ctx.Log.Error(err, "failed to validate the validation rules")
+		return false, err
+	}
 	return true, nil
 }
 
@@ -24,9 +24,11 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"slices"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -94,6 +96,11 @@ func getPodsForOnlineUpdate(params reconfigureContext) ([]corev1.Pod, error) {
 			true,
 		)
 	}
+	if len(params.CanaryInstances) != 0 {
+		pods = slices.DeleteFunc(pods, func(pod corev1.Pod) bool {
+			return !slices.Contains(params.CanaryInstances, pod.Name)
+		})
+	}
 	return pods, nil
 }
 
@@ -170,6 +177,85 @@ func resolveReloadServerGrpcURL(pod *corev1.Pod) (string, error) {
 	return generateGrpcURL(pod, podPort)
 }
 
+func resolveReloadTarget(rctx reconfigureContext, pod *corev1.Pod) (cfgcm.ReloadTarget, error) {
+	var (
+		port        intstr.IntOrString
+		accountName string
+		target      cfgcm.ReloadTarget
+
+		reloadAction = rctx.ParametersDef.ReloadAction
+	)
+
+	switch {
+	case reloadAction.SQLTrigger != nil:
+		port, accountName = reloadAction.SQLTrigger.Port, reloadAction.SQLTrigger.AccountName
+	case reloadAction.HTTPTrigger != nil:
+		port, accountName = reloadAction.HTTPTrigger.Port, reloadAction.HTTPTrigger.AccountName
+	default:
+		return target, core.MakeError("not supported reload type[%s]", cfgcm.FromReloadTypeConfig(reloadAction))
+	}
+
+	ip, err := ipAddressFromPod(pod.Status)
+	if err != nil {
+		return target, err
+	}
+	if target.Port, err = resolveContainerPort(pod, port); err != nil {
+		return target, err
+	}
+	target.Host = ip.String()
+	if accountName != "" {
+		if target.Username, target.Password, err = getAccountCredential(rctx, accountName); err != nil {
+			return target, err
+		}
+	}
+	if reloadAction.HTTPTrigger != nil && reloadAction.HTTPTrigger.CASecretKeyRef != nil {
+		target.CACert, err = getCACert(rctx, reloadAction.HTTPTrigger.CASecretKeyRef)
+	}
+	return target, err
+}
+
+func resolveContainerPort(pod *corev1.Pod, port intstr.IntOrString) (int32, error) {
+	if port.Type == intstr.Int {
+		return port.IntVal, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return containerPort.ContainerPort, nil
+			}
+		}
+	}
+	return 0, core.MakeError("port[%s] not found in pod[%s]", port.StrVal, pod.Name)
+}
+
+func getAccountCredential(rctx reconfigureContext, accountName string) (string, string, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: rctx.SynthesizedComponent.Namespace,
+		Name:      constant.GenerateAccountSecretName(rctx.SynthesizedComponent.ClusterName, rctx.SynthesizedComponent.Name, accountName),
+	}
+	if err := rctx.Client.Get(rctx.Ctx, secretKey, secret); err != nil {
+		return "", "", core.WrapError(err, "failed to get the secret of account[%s]", accountName)
+	}
+	return string(secret.Data[constant.AccountNameForSecret]), string(secret.Data[constant.AccountPasswdForSecret]), nil
+}
+
+func getCACert(rctx reconfigureContext, secretRef *corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{
+		Namespace: rctx.SynthesizedComponent.Namespace,
+		Name:      secretRef.Name,
+	}
+	if err := rctx.Client.Get(rctx.Ctx, secretKey, secret); err != nil {
+		return nil, core.WrapError(err, "failed to get the secret of CA[%s]", secretRef.Name)
+	}
+	caCert, ok := secret.Data[secretRef.Key]
+	if !ok {
+		return nil, core.MakeError("the key[%s] of CA not found in secret[%s]", secretRef.Key, secretRef.Name)
+	}
+	return caCert, nil
+}
+
 func generateGrpcURL(pod *corev1.Pod, portPort int) (string, error) {
 	ip, err := ipAddressFromPod(pod.Status)
 	if err != nil {
@@ -350,6 +436,7 @@ func buildReloadActionTask(reloadPolicy parametersv1alpha1.ReloadPolicy, templat
 		ClusterComponent:         rctx.ClusterComObj,
 		SynthesizedComponent:     rctx.BuiltinComponent,
 		ReconfigureClientFactory: GetClientFactory(),
+		ParamsReloaderFactory:    GetParamsReloaderFactory(),
 	}
 
 	if reloadPolicy == parametersv1alpha1.SyncDynamicReloadPolicy {
@@ -111,6 +111,12 @@ func withGRPCClient(clientFactory createReconfigureClient) ParamsOps {
 	}
 }
 
+func withParamsReloader(reloaderFactory createParamsReloader) ParamsOps {
+	return func(params *reconfigureContext) {
+		params.ParamsReloaderFactory = reloaderFactory
+	}
+}
+
 func withConfigSpec(configSpecName string, data map[string]string) ParamsOps {
 	return func(params *reconfigureContext) {
 		params.ConfigMap = &corev1.ConfigMap{
@@ -272,6 +272,9 @@ func updateConfigLabels(obj *corev1.ConfigMap,
 	if obj.Annotations == nil {
 		obj.Annotations = make(map[string]string)
 	}
+	if err := recordRevisionParameters(obj, item, revision); err != nil {
+		return err
+	}
 	b, err := json.Marshal(&item)
 	if err != nil {
 		return err
@@ -321,6 +324,9 @@ func updateRevision(revision ConfigurationRevision, status *parametersv1alpha1.C
 			ExpectedCount:   revision.Result.ExpectedCount,
 			ExecResult:      revision.Result.ExecResult,
 			ErrMessage:      revision.Result.Message,
+
+			ParameterStatuses: revision.Result.ParameterStatuses,
+			Canary:            revision.Result.Canary,
 		}
 	}
 }
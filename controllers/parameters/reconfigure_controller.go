@@ -219,6 +219,9 @@ func (r *ReconfigureReconciler) sync(reqCtx intctrlutil.RequestCtx, configMap *c
 	if err != nil {
 		return intctrlutil.RequeueWithErrorAndRecordEvent(configMap, r.Recorder, err, reqCtx.Log)
 	}
+	if policy := resolveCanaryPolicy(configMap); policy != nil {
+		return r.performCanaryUpgrade(rctx, tasks, configPatch, policy)
+	}
 	return r.performUpgrade(rctx, tasks)
 }
 
@@ -235,7 +238,7 @@ func (r *ReconfigureReconciler) updateConfigCMStatus(reqCtx intctrlutil.RequestC
 	return intctrlutil.Reconciled()
 }
 
-func (r *ReconfigureReconciler) performUpgrade(rctx *ReconcileContext, reloadTasks []ReloadAction) (ctrl.Result, error) {
+func (r *ReconfigureReconciler) performUpgrade(rctx *ReconcileContext, reloadTasks []ReloadAction, opts ...options) (ctrl.Result, error) {
 	var err error
 	var returnedStatus ReturnedStatus
 	var reloadType string
@@ -244,15 +247,15 @@ func (r *ReconfigureReconciler) performUpgrade(rctx *ReconcileContext, reloadTas
 		reloadType = task.ReloadType()
 		returnedStatus, err = task.ExecReload()
 		if err != nil || returnedStatus.Status != ESNone {
-			return r.status(rctx, returnedStatus, reloadType, err)
+			return r.status(rctx, returnedStatus, reloadType, err, opts...)
 		}
 	}
-	return r.succeed(rctx, reloadType, returnedStatus)
+	return r.succeed(rctx, reloadType, returnedStatus, opts...)
 }
 
-func (r *ReconfigureReconciler) status(rctx *ReconcileContext, returnedStatus ReturnedStatus, policy string, err error) (ctrl.Result, error) {
+func (r *ReconfigureReconciler) status(rctx *ReconcileContext, returnedStatus ReturnedStatus, policy string, err error, opts ...options) (ctrl.Result, error) {
 	updatePhase := func(phase parametersv1alpha1.ParameterPhase, options ...options) (ctrl.Result, error) {
-		return updateConfigPhaseWithResult(rctx.Client, rctx.RequestCtx, rctx.ConfigMap, reconciled(returnedStatus, policy, phase, options...))
+		return updateConfigPhaseWithResult(rctx.Client, rctx.RequestCtx, rctx.ConfigMap, reconciled(returnedStatus, policy, phase, append(opts, options...)...))
 	}
 
 	switch returnedStatus.Status {
@@ -263,19 +266,19 @@ func (r *ReconfigureReconciler) status(rctx *ReconcileContext, returnedStatus Re
 	case ESFailed:
 		return updatePhase(parametersv1alpha1.CFailedAndPausePhase, withFailed(err, false))
 	case ESNone:
-		return r.succeed(rctx, policy, returnedStatus)
+		return r.succeed(rctx, policy, returnedStatus, opts...)
 	default:
 		return updatePhase(parametersv1alpha1.CFailedAndPausePhase, withFailed(core.MakeError("unknown status"), false))
 	}
 }
 
-func (r *ReconfigureReconciler) succeed(rctx *ReconcileContext, reloadType string, returnedStatus ReturnedStatus) (ctrl.Result, error) {
+func (r *ReconfigureReconciler) succeed(rctx *ReconcileContext, reloadType string, returnedStatus ReturnedStatus, opts ...options) (ctrl.Result, error) {
 	rctx.Recorder.Eventf(rctx.ConfigMap,
 		corev1.EventTypeNormal,
 		appsv1alpha1.ReasonReconfigureSucceed,
 		"the reconfigure[%s] has been processed successfully",
 		reloadType)
 
-	result := reconciled(returnedStatus, reloadType, parametersv1alpha1.CFinishedPhase)
+	result := reconciled(returnedStatus, reloadType, parametersv1alpha1.CFinishedPhase, opts...)
 	return r.updateConfigCMStatus(rctx.RequestCtx, rctx.ConfigMap, reloadType, &result)
 }
@@ -32,6 +32,7 @@ import (
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	cfgproto "github.com/apecloud/kubeblocks/pkg/configuration/proto"
 	"github.com/apecloud/kubeblocks/pkg/configuration/util"
@@ -62,6 +63,9 @@ type ReturnedStatus struct {
 	Status        ExecStatus
 	SucceedCount  int32
 	ExpectedCount int32
+
+	// The results of the parameters applied by the sql or http reload action.
+	ParameterStatuses []parametersv1alpha1.ParameterReloadStatus
 }
 
 type reconfigurePolicy interface {
@@ -99,12 +103,18 @@ type reconfigureContext struct {
 	// For grpc factory
 	ReconfigureClientFactory createReconfigureClient
 
+	// For sql or http reloader factory
+	ParamsReloaderFactory createParamsReloader
+
 	// List of containers using this config volume.
 	ContainerNames []string
 
 	ConfigDescription *parametersv1alpha1.ComponentConfigDescription
 	ParametersDef     *parametersv1alpha1.ParametersDefinitionSpec
 	UpdatedParameters map[string]string
+
+	// The names of the canary instances, if not empty, the parameters are only applied to the canary instances.
+	CanaryInstances []string
 }
 
 var (
@@ -130,6 +140,11 @@ func GetClientFactory() createReconfigureClient {
 	return newGRPCClient
 }
 
+// GetParamsReloaderFactory support ut mock
+func GetParamsReloaderFactory() createParamsReloader {
+	return cfgcm.NewParamsReloader
+}
+
 func (param *reconfigureContext) generateConfigIdentifier() string {
 	key := param.ConfigTemplate.Name
 	if param.ConfigDescription != nil && param.ConfigDescription.Name != "" {
@@ -189,6 +204,9 @@ func (param *reconfigureContext) maxRollingReplicas() int32 {
 }
 
 func (param *reconfigureContext) getTargetReplicas() int {
+	if len(param.CanaryInstances) != 0 {
+		return len(param.CanaryInstances)
+	}
 	return int(param.ClusterComponent.Replicas)
 }
 
@@ -222,7 +240,9 @@ func enableSyncTrigger(reloadAction *parametersv1alpha1.ReloadAction) bool {
 	if reloadAction.ShellTrigger != nil {
 		return !core.IsWatchModuleForShellTrigger(reloadAction.ShellTrigger)
 	}
-	return false
+
+	// the sql and http reload actions are always performed by the controller.
+	return cfgcm.IsControllerReload(reloadAction)
 }
 
 func withSucceed(succeedCount int32) func(status *ReturnedStatus) {
@@ -237,6 +257,12 @@ func withExpected(expectedCount int32) func(status *ReturnedStatus) {
 	}
 }
 
+func withParameterStatuses(statuses []parametersv1alpha1.ParameterReloadStatus) func(status *ReturnedStatus) {
+	return func(status *ReturnedStatus) {
+		status.ParameterStatuses = statuses
+	}
+}
+
 func makeReturnedStatus(status ExecStatus, ops ...func(status *ReturnedStatus)) ReturnedStatus {
 	ret := ReturnedStatus{
 		Status:        status,
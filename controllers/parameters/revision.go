@@ -21,6 +21,8 @@ package parameters
 
 import (
 	"encoding/json"
+	"math"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -126,3 +128,206 @@ func GetCurrentRevision(annotations map[string]string) string {
 	}
 	return annotations[constant.ConfigurationRevision]
 }
+
+// RevisionParameters records the parameters of a configuration revision.
+//
+// To keep the annotations of the configmap small, only the oldest revision kept records all the parameters as
+// a snapshot, and each later revision records the changes from its previous revision. The parameters of a revision
+// are rebuilt by replaying the changes on the snapshot.
+type RevisionParameters struct {
+	Revision int64 `json:"-"`
+
+	// Parameters holds all the parameters of the revision, it is nil if the revision only records the changes.
+	Parameters map[string]parametersv1alpha1.ParametersInFile `json:"parameters"`
+	// UpdatedParameters holds the parameters added or changed from the previous revision.
+	UpdatedParameters map[string]parametersv1alpha1.ParametersInFile `json:"updatedParameters,omitempty"`
+	// RemovedParameters holds the parameters removed from the previous revision, keyed by the file name,
+	// an empty list means that the whole file has been removed.
+	RemovedParameters map[string][]string `json:"removedParameters,omitempty"`
+}
+
+func (r *RevisionParameters) isSnapshot() bool {
+	return r.Parameters != nil
+}
+
+// apply replays the changes of the revision on the parameters of its previous revision.
+func (r *RevisionParameters) apply(params map[string]parametersv1alpha1.ParametersInFile) {
+	for file, keys := range r.RemovedParameters {
+		if len(keys) == 0 {
+			delete(params, file)
+			continue
+		}
+		fileParams := params[file]
+		for _, key := range keys {
+			delete(fileParams.Parameters, key)
+		}
+		params[file] = fileParams
+	}
+	for file, updated := range r.UpdatedParameters {
+		fileParams := params[file]
+		if updated.Content != nil {
+			fileParams.Content = updated.Content
+		}
+		for key, value := range updated.Parameters {
+			if fileParams.Parameters == nil {
+				fileParams.Parameters = make(map[string]*string)
+			}
+			fileParams.Parameters[key] = value
+		}
+		params[file] = fileParams
+	}
+}
+
+func RetrieveRevisionParameters(annotations map[string]string) []RevisionParameters {
+	var revisions []RevisionParameters
+	var revisionPrefix = constant.ConfigurationRevisionParams + "-"
+
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, revisionPrefix) {
+			continue
+		}
+		revision, err := strconv.ParseInt(strings.TrimPrefix(key, revisionPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		params := RevisionParameters{}
+		if err = json.Unmarshal([]byte(value), &params); err == nil {
+			params.Revision = revision
+			revisions = append(revisions, params)
+		}
+	}
+
+	// for sort
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions
+}
+
+// GetRevisionParameters returns the parameters of the latest revision not later than the given revision,
+// the returned Parameters holds all the parameters of the revision.
+func GetRevisionParameters(annotations map[string]string, revision int64) (RevisionParameters, bool) {
+	return rebuildRevisionParameters(RetrieveRevisionParameters(annotations), revision)
+}
+
+func rebuildRevisionParameters(revisions []RevisionParameters, revision int64) (RevisionParameters, bool) {
+	last := -1
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Revision <= revision {
+			last = i
+			break
+		}
+	}
+	base := last
+	for base >= 0 && !revisions[base].isSnapshot() {
+		base--
+	}
+	if base < 0 {
+		return RevisionParameters{}, false
+	}
+
+	params := make(map[string]parametersv1alpha1.ParametersInFile, len(revisions[base].Parameters))
+	for file, fileParams := range revisions[base].Parameters {
+		params[file] = *fileParams.DeepCopy()
+	}
+	for i := base + 1; i <= last; i++ {
+		revisions[i].apply(params)
+	}
+	rebuilt := revisions[last]
+	rebuilt.Parameters = params
+	return rebuilt, true
+}
+
+func gcRevisionParameters(configObj *corev1.ConfigMap) error {
+	revisions := RetrieveRevisionParameters(configObj.Annotations)
+	if len(revisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	// the oldest revision kept becomes the snapshot of the later revisions
+	oldest := revisions[len(revisions)-revisionHistoryLimit]
+	if !oldest.isSnapshot() {
+		if snapshot, ok := rebuildRevisionParameters(revisions, oldest.Revision); ok {
+			b, err := json.Marshal(RevisionParameters{Parameters: snapshot.Parameters})
+			if err != nil {
+				return err
+			}
+			configObj.Annotations[core.GenerateRevisionParamsKey(strconv.FormatInt(oldest.Revision, 10))] = string(b)
+		}
+	}
+	for _, v := range revisions[0 : len(revisions)-revisionHistoryLimit] {
+		delete(configObj.Annotations, core.GenerateRevisionParamsKey(strconv.FormatInt(v.Revision, 10)))
+	}
+	return nil
+}
+
+// recordRevisionParameters records the parameters of the revision into the annotations of the configmap.
+func recordRevisionParameters(configObj *corev1.ConfigMap, item parametersv1alpha1.ConfigTemplateItemDetail, revision string) error {
+	key := core.GenerateRevisionParamsKey(revision)
+	if _, ok := configObj.Annotations[key]; ok || revision == "" {
+		return nil
+	}
+
+	revisionParams := RevisionParameters{}
+	revisions := RetrieveRevisionParameters(configObj.Annotations)
+	if last, ok := rebuildRevisionParameters(revisions, math.MaxInt64); ok {
+		revisionParams.UpdatedParameters, revisionParams.RemovedParameters = diffParameters(last.Parameters, item.ConfigFileParams)
+	} else {
+		revisionParams.Parameters = make(map[string]parametersv1alpha1.ParametersInFile, len(item.ConfigFileParams))
+		for file, fileParams := range item.ConfigFileParams {
+			revisionParams.Parameters[file] = fileParams
+		}
+	}
+	b, err := json.Marshal(revisionParams)
+	if err != nil {
+		return err
+	}
+	configObj.Annotations[key] = string(b)
+	return gcRevisionParameters(configObj)
+}
+
+// diffParameters returns the parameters added or changed and the parameters removed from the base to the updated.
+func diffParameters(base, updated map[string]parametersv1alpha1.ParametersInFile) (map[string]parametersv1alpha1.ParametersInFile, map[string][]string) {
+	changed := make(map[string]parametersv1alpha1.ParametersInFile)
+	removed := make(map[string][]string)
+	for file, baseParams := range base {
+		params, ok := updated[file]
+		if !ok || (baseParams.Content != nil && params.Content == nil) {
+			// the whole file is removed, and the remaining parameters are recorded as added
+			removed[file] = []string{}
+			if ok {
+				changed[file] = *params.DeepCopy()
+			}
+			continue
+		}
+		for key := range baseParams.Parameters {
+			if _, ok := params.Parameters[key]; !ok {
+				removed[file] = append(removed[file], key)
+			}
+		}
+		sort.Strings(removed[file])
+	}
+	for file, params := range updated {
+		if _, ok := changed[file]; ok {
+			continue
+		}
+		baseParams, ok := base[file]
+		fileChanged := parametersv1alpha1.ParametersInFile{}
+		if params.Content != nil && !reflect.DeepEqual(baseParams.Content, params.Content) {
+			fileChanged.Content = params.Content
+		}
+		for key, value := range params.Parameters {
+			if baseValue, ok := baseParams.Parameters[key]; ok && reflect.DeepEqual(baseValue, value) {
+				continue
+			}
+			if fileChanged.Parameters == nil {
+				fileChanged.Parameters = make(map[string]*string)
+			}
+			fileChanged.Parameters[key] = value
+		}
+		if !ok || fileChanged.Content != nil || len(fileChanged.Parameters) != 0 {
+			changed[file] = fileChanged
+		}
+	}
+	return changed, removed
+}
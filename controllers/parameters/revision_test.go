@@ -20,16 +20,28 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package parameters
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
+	"github.com/apecloud/kubeblocks/pkg/controller/render"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -206,3 +218,229 @@ func TestGetLastRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordRevisionParameters(t *testing.T) {
+	newItem := func(params map[string]*string) parametersv1alpha1.ConfigTemplateItemDetail {
+		return parametersv1alpha1.ConfigTemplateItemDetail{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Parameters: params},
+			},
+		}
+	}
+	applyItem := func(cm *corev1.ConfigMap, item parametersv1alpha1.ConfigTemplateItemDetail, revision string) {
+		assert.NoError(t, recordRevisionParameters(cm, item, revision))
+		b, _ := json.Marshal(&item)
+		cm.Annotations[constant.ConfigAppliedVersionAnnotationKey] = string(b)
+	}
+
+	cm := builder.NewConfigMapBuilder("default", "test").
+		AddAnnotations("test", "test").
+		GetObject()
+	applyItem(cm, newItem(map[string]*string{"max_connections": pointer.String("100")}), "1")
+	applyItem(cm, newItem(map[string]*string{"max_connections": pointer.String("200"), "innodb_buffer_pool_size": pointer.String("1G")}), "2")
+	applyItem(cm, newItem(map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}), "3")
+
+	// the first revision is the snapshot, and the later revisions only record the changes
+	revisions := RetrieveRevisionParameters(cm.Annotations)
+	assert.Equal(t, 3, len(revisions))
+	assert.Equal(t, int64(1), revisions[0].Revision)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("100")}},
+	}, revisions[0].Parameters)
+	assert.Nil(t, revisions[1].Parameters)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("200"), "innodb_buffer_pool_size": pointer.String("1G")}},
+	}, revisions[1].UpdatedParameters)
+	assert.Nil(t, revisions[2].Parameters)
+	assert.Equal(t, 0, len(revisions[2].UpdatedParameters))
+	assert.Equal(t, map[string][]string{"my.cnf": {"max_connections"}}, revisions[2].RemovedParameters)
+
+	// the parameters of a revision are rebuilt from the snapshot
+	revision, ok := GetRevisionParameters(cm.Annotations, 2)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("200"), "innodb_buffer_pool_size": pointer.String("1G")}},
+	}, revision.Parameters)
+
+	// the recorded revision is not overwritten
+	applyItem(cm, newItem(nil), "3")
+	revision, ok = GetRevisionParameters(cm.Annotations, 3)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), revision.Revision)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}},
+	}, revision.Parameters)
+
+	// returns the latest revision not later than the given revision
+	revision, ok = GetRevisionParameters(cm.Annotations, 10)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), revision.Revision)
+	_, ok = GetRevisionParameters(cm.Annotations, 0)
+	assert.False(t, ok)
+
+	for i := 4; i <= 15; i++ {
+		applyItem(cm, newItem(map[string]*string{"max_connections": pointer.String(strconv.Itoa(i))}), strconv.Itoa(i))
+	}
+	revisions = RetrieveRevisionParameters(cm.Annotations)
+	assert.Equal(t, revisionHistoryLimit, len(revisions))
+	assert.Equal(t, int64(6), revisions[0].Revision)
+
+	// the oldest revision kept becomes the snapshot
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("6")}},
+	}, revisions[0].Parameters)
+	for _, rev := range revisions[1:] {
+		assert.Nil(t, rev.Parameters)
+	}
+	revision, ok = GetRevisionParameters(cm.Annotations, 15)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("15")}},
+	}, revision.Parameters)
+	_, ok = GetRevisionParameters(cm.Annotations, 5)
+	assert.False(t, ok)
+}
+
+func TestRecordRevisionParametersWithContent(t *testing.T) {
+	content := strings.Repeat("[mysqld]\nmax_connections=100\n", 1024)
+	newItem := func(params map[string]*string) parametersv1alpha1.ConfigTemplateItemDetail {
+		return parametersv1alpha1.ConfigTemplateItemDetail{
+			Name: "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+				"my.cnf": {Content: pointer.String(content), Parameters: params},
+			},
+		}
+	}
+
+	cm := builder.NewConfigMapBuilder("default", "test").GetObject()
+	cm.Annotations = map[string]string{}
+	for i := 1; i <= 15; i++ {
+		assert.NoError(t, recordRevisionParameters(cm, newItem(map[string]*string{"max_connections": pointer.String(strconv.Itoa(i))}), strconv.Itoa(i)))
+	}
+
+	// the content is recorded only in the snapshot
+	size := 0
+	for _, v := range cm.Annotations {
+		size += len(v)
+	}
+	assert.Less(t, size, 2*len(content))
+
+	revision, ok := GetRevisionParameters(cm.Annotations, 15)
+	assert.True(t, ok)
+	assert.Equal(t, newItem(map[string]*string{"max_connections": pointer.String("15")}).ConfigFileParams, revision.Parameters)
+}
+
+func TestDiffParameters(t *testing.T) {
+	current := map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{
+			"max_connections":         pointer.String("200"),
+			"innodb_buffer_pool_size": pointer.String("1G"),
+			"skip_name_resolve":       nil,
+		}},
+		"extra.cnf": {Parameters: map[string]*string{"key": pointer.String("value")}},
+	}
+	target := map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("100")}},
+	}
+
+	updated, removed := diffParameters(target, current)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{
+			"max_connections":         pointer.String("200"),
+			"innodb_buffer_pool_size": pointer.String("1G"),
+			"skip_name_resolve":       nil,
+		}},
+		"extra.cnf": {Parameters: map[string]*string{"key": pointer.String("value")}},
+	}, updated)
+	assert.Equal(t, 0, len(removed))
+
+	updated, removed = diffParameters(current, target)
+	assert.Equal(t, map[string]parametersv1alpha1.ParametersInFile{
+		"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("100")}},
+	}, updated)
+	assert.Equal(t, map[string][]string{
+		"my.cnf":    {"innodb_buffer_pool_size", "skip_name_resolve"},
+		"extra.cnf": {},
+	}, removed)
+
+	// replaying the changes restores the parameters, including the parameters with nil value
+	revision := RevisionParameters{UpdatedParameters: updated, RemovedParameters: removed}
+	params := map[string]parametersv1alpha1.ParametersInFile{}
+	for file, fileParams := range current {
+		params[file] = *fileParams.DeepCopy()
+	}
+	revision.apply(params)
+	assert.Equal(t, target, params)
+
+	updated, removed = diffParameters(current, current)
+	assert.Equal(t, 0, len(updated))
+	assert.Equal(t, 0, len(removed))
+}
+
+func TestRevertToRevisionKeepsTemplateParameters(t *testing.T) {
+	ctx := context.Background()
+	const fileName = "my.cnf"
+
+	cm := builder.NewConfigMapBuilder("default", "mysql-config").GetObject()
+	cm.Annotations = map[string]string{}
+	newItem := func(params map[string]*string) parametersv1alpha1.ConfigTemplateItemDetail {
+		return parametersv1alpha1.ConfigTemplateItemDetail{
+			Name:             "mysql-config",
+			ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{fileName: {Parameters: params}},
+		}
+	}
+	// max_connections is not overridden in the revision 1
+	assert.NoError(t, recordRevisionParameters(cm, newItem(map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}), "1"))
+	current := newItem(map[string]*string{"innodb_buffer_pool_size": pointer.String("2G"), "max_connections": pointer.String("200")})
+	assert.NoError(t, recordRevisionParameters(cm, current, "2"))
+
+	compParam := &parametersv1alpha1.ComponentParameter{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-config", Namespace: "default"},
+		Spec: parametersv1alpha1.ComponentParameterSpec{
+			ComponentName:     "mysql",
+			ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{current},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newCanaryTestScheme()).WithObjects(compParam).Build()
+	rctx := &ReconcileContext{
+		RequestCtx: intctrlutil.RequestCtx{Ctx: ctx, Log: logr.Discard()},
+		ResourceFetcher: configctrl.ResourceFetcher[ReconcileContext]{
+			ResourceCtx:           &render.ResourceCtx{Context: ctx, Client: cli, Namespace: "default", ComponentName: "mysql"},
+			ComponentParameterObj: compParam,
+		},
+	}
+
+	parameter := &parametersv1alpha1.Parameter{}
+	configmaps := map[string]*corev1.ConfigMap{"mysql-config": cm}
+	assert.NoError(t, revertToRevision(1, configmaps)(rctx, parameter))
+	assert.NoError(t, updateParameters(true, nil)(rctx, parameter))
+
+	updated := &parametersv1alpha1.ComponentParameter{}
+	assert.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(compParam), updated))
+	item := intctrlutil.GetConfigTemplateItem(&updated.Spec, "mysql-config")
+	assert.Equal(t, newItem(map[string]*string{"innodb_buffer_pool_size": pointer.String("1G")}).ConfigFileParams, item.ConfigFileParams)
+
+	// the template defined max_connections is kept in the rendered config file
+	rendered := renderTestMyCnf(t, item.ConfigFileParams)
+	assert.Contains(t, rendered, "max_connections=500")
+	assert.Contains(t, rendered, "innodb_buffer_pool_size=1G")
+}
+
+// renderTestMyCnf renders the parameters into a my.cnf template which defines max_connections and innodb_buffer_pool_size.
+func renderTestMyCnf(t *testing.T, params map[string]parametersv1alpha1.ParametersInFile) string {
+	descs := []parametersv1alpha1.ComponentConfigDescription{{
+		Name:         "my.cnf",
+		TemplateName: "mysql-config",
+		FileFormatConfig: &parametersv1alpha1.FileFormatConfig{
+			Format: parametersv1alpha1.Ini,
+			FormatterAction: parametersv1alpha1.FormatterAction{
+				IniConfig: &parametersv1alpha1.IniConfig{SectionName: "mysqld"},
+			},
+		},
+	}}
+	base := map[string]string{"my.cnf": "[mysqld]\ninnodb_buffer_pool_size=512M\nmax_connections=500\n"}
+	rendered, err := configctrl.DoMerge(base, params, nil, descs)
+	assert.NoError(t, err)
+	return rendered["my.cnf"]
+}
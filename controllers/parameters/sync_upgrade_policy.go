@@ -21,12 +21,16 @@ package parameters
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -85,6 +89,9 @@ func sync(rctx reconfigureContext, updatedParameters map[string]string, pods []c
 		versionHash = rctx.getTargetVersionHash()
 		selector    = intctrlutil.GetPodSelector(rctx.ParametersDef)
 		fileName    string
+
+		controllerReload = cfgcm.IsControllerReload(rctx.ParametersDef.ReloadAction)
+		failedParams     = make(map[string][]string)
 	)
 
 	if selector != nil {
@@ -111,7 +118,18 @@ func sync(rctx reconfigureContext, updatedParameters map[string]string, pods []c
 		if !intctrlutil.IsPodReady(&pod) {
 			continue
 		}
-		if err = funcs.OnlineUpdatePodFunc(&pod, ctx, rctx.ReconfigureClientFactory, rctx.ConfigTemplate.Name, fileName, updatedParameters); err != nil {
+		if controllerReload {
+			var paramErrors map[string]error
+			if paramErrors, err = reloadPodParameters(rctx, &pod, updatedParameters); err != nil {
+				return makeReturnedStatus(ESFailedAndRetry), err
+			}
+			for name, paramErr := range paramErrors {
+				failedParams[name] = append(failedParams[name], fmt.Sprintf("%s: %v", pod.Name, paramErr))
+			}
+			if len(paramErrors) != 0 {
+				continue
+			}
+		} else if err = funcs.OnlineUpdatePodFunc(&pod, ctx, rctx.ReconfigureClientFactory, rctx.ConfigTemplate.Name, fileName, updatedParameters); err != nil {
 			return makeReturnedStatus(ESFailedAndRetry), err
 		}
 		if err = updatePodLabelsWithConfigVersion(&pod, configKey, versionHash, rctx.Client, ctx); err != nil {
@@ -120,8 +138,47 @@ func sync(rctx reconfigureContext, updatedParameters map[string]string, pods []c
 		progress++
 	}
 
+	ops := []func(status *ReturnedStatus){withExpected(requireUpdatedCount), withSucceed(progress)}
+	if controllerReload {
+		ops = append(ops, withParameterStatuses(buildParameterStatuses(updatedParameters, failedParams)))
+	}
+	if len(failedParams) != 0 {
+		return makeReturnedStatus(ESFailedAndRetry, ops...),
+			core.MakeError("failed to apply parameters: %s", strings.Join(slices.Sorted(maps.Keys(failedParams)), ","))
+	}
 	if requireUpdatedCount != progress || replicas != total {
 		r = ESRetry
 	}
-	return makeReturnedStatus(r, withExpected(requireUpdatedCount), withSucceed(progress)), nil
+	return makeReturnedStatus(r, ops...), nil
+}
+
+// reloadPodParameters applies the updated parameters to the pod by the sql or http reload action,
+// and returns the errors of the failed parameters.
+func reloadPodParameters(rctx reconfigureContext, pod *corev1.Pod, updatedParameters map[string]string) (map[string]error, error) {
+	target, err := resolveReloadTarget(rctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	reloader, err := rctx.ParamsReloaderFactory(rctx.Ctx, rctx.ParametersDef.ReloadAction, target)
+	if err != nil {
+		return nil, err
+	}
+	defer reloader.Close()
+	return reloader.Reload(rctx.Ctx, updatedParameters), nil
+}
+
+func buildParameterStatuses(updatedParameters map[string]string, failedParams map[string][]string) []parametersv1alpha1.ParameterReloadStatus {
+	var statuses []parametersv1alpha1.ParameterReloadStatus
+	for _, name := range slices.Sorted(maps.Keys(updatedParameters)) {
+		status := parametersv1alpha1.ParameterReloadStatus{
+			Name:  name,
+			Phase: parametersv1alpha1.ParameterReloadSucceed,
+		}
+		if messages, ok := failedParams[name]; ok {
+			status.Phase = parametersv1alpha1.ParameterReloadFailed
+			status.Message = strings.Join(messages, "; ")
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
 }
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package parameters
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -27,10 +29,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	cfgproto "github.com/apecloud/kubeblocks/pkg/configuration/proto"
 	mockproto "github.com/apecloud/kubeblocks/pkg/configuration/proto/mocks"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 )
 
@@ -163,4 +170,101 @@ var _ = Describe("Reconfigure OperatorSyncPolicy", func() {
 		})
 	})
 
+	Context("sync reconfigure policy with sql trigger test", func() {
+		It("Should report the status of each parameter", func() {
+			By("prepare reconfigure policy params")
+			reloader := &mockParamsReloader{failed: map[string]error{"b": core.MakeError("unknown variable")}}
+			mockParam := newMockReconfigureParams("operatorSyncPolicy", k8sMockClient.Client(),
+				withParamsReloader(func(_ context.Context, _ *parametersv1alpha1.ReloadAction, target cfgcm.ReloadTarget) (cfgcm.ParamsReloader, error) {
+					reloader.target = target
+					return reloader, nil
+				}),
+				withMockInstanceSet(3, nil),
+				withConfigSpec("for_test", map[string]string{"a": "c b e f"}),
+				withConfigDescription(&parametersv1alpha1.FileFormatConfig{Format: parametersv1alpha1.RedisCfg}),
+				withUpdatedParameters(map[string]string{
+					"a": "100",
+					"b": "200",
+				}),
+				withClusterComponent(3))
+			mockParam.SynthesizedComponent.ClusterName = "test"
+			mockParam.SynthesizedComponent.Name = "mysql"
+			mockParam.ParametersDef = &parametersv1alpha1.ParametersDefinitionSpec{
+				ReloadAction: &parametersv1alpha1.ReloadAction{
+					SQLTrigger: &parametersv1alpha1.SQLTrigger{
+						Engine:      parametersv1alpha1.MySQLEngine,
+						Port:        intstr.FromInt32(3306),
+						AccountName: "root",
+						Statement:   "SET GLOBAL {{ .Name }} = {{ .Value }}",
+					},
+				},
+			}
+
+			By("mock client get pod and secret caller")
+			k8sMockClient.MockListMethod(testutil.WithListReturned(
+				testutil.WithConstructListReturnedResult(
+					fromPodObjectList(newMockPodsWithInstanceSet(&mockParam.InstanceSetUnits[0], 3,
+						withReadyPod(0, 3)))),
+				testutil.WithAnyTimes()))
+			k8sMockClient.MockGetMethod(testutil.WithGetReturned(testutil.WithConstructSimpleGetResult([]client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: constant.GenerateAccountSecretName("test", "mysql", "root"),
+					},
+					Data: map[string][]byte{
+						constant.AccountNameForSecret:   []byte("root"),
+						constant.AccountPasswdForSecret: []byte("passwd"),
+					},
+				},
+			}), testutil.WithAnyTimes()))
+			k8sMockClient.MockPatchMethod(testutil.WithSucceed(testutil.WithTimes(3)))
+
+			By("failed to apply one of the parameters")
+			status, err := operatorSyncPolicy.Upgrade(mockParam)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("failed to apply parameters: b"))
+			Expect(status.Status).Should(BeEquivalentTo(ESFailedAndRetry))
+			Expect(status.SucceedCount).Should(BeEquivalentTo(0))
+			Expect(status.ParameterStatuses).Should(HaveLen(2))
+			Expect(status.ParameterStatuses[0].Phase).Should(BeEquivalentTo(parametersv1alpha1.ParameterReloadSucceed))
+			Expect(status.ParameterStatuses[1].Phase).Should(BeEquivalentTo(parametersv1alpha1.ParameterReloadFailed))
+			Expect(status.ParameterStatuses[1].Message).Should(ContainSubstring("unknown variable"))
+			Expect(reloader.target).Should(BeEquivalentTo(cfgcm.ReloadTarget{
+				Host:     "1.1.1.1",
+				Port:     3306,
+				Username: "root",
+				Password: "passwd",
+			}))
+
+			By("succeed to apply all parameters")
+			reloader.failed = nil
+			status, err = operatorSyncPolicy.Upgrade(mockParam)
+			Expect(err).Should(Succeed())
+			Expect(status.Status).Should(BeEquivalentTo(ESNone))
+			Expect(status.SucceedCount).Should(BeEquivalentTo(3))
+			Expect(status.ParameterStatuses).Should(HaveLen(2))
+			for _, paramStatus := range status.ParameterStatuses {
+				Expect(paramStatus.Phase).Should(BeEquivalentTo(parametersv1alpha1.ParameterReloadSucceed))
+			}
+		})
+	})
+
 })
+
+type mockParamsReloader struct {
+	target cfgcm.ReloadTarget
+	failed map[string]error
+}
+
+func (m *mockParamsReloader) Reload(_ context.Context, params map[string]string) map[string]error {
+	errs := make(map[string]error)
+	for name := range params {
+		if err, ok := m.failed[name]; ok {
+			errs[name] = err
+		}
+	}
+	return errs
+}
+
+func (m *mockParamsReloader) Close() {
+}
@@ -25,11 +25,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
 	cfgproto "github.com/apecloud/kubeblocks/pkg/configuration/proto"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 type createReconfigureClient func(addr string) (cfgproto.ReconfigureClient, error)
+type createParamsReloader func(ctx context.Context, reloadAction *parametersv1alpha1.ReloadAction, target cfgcm.ReloadTarget) (cfgcm.ParamsReloader, error)
 
 type GetPodsFunc func(params reconfigureContext) ([]corev1.Pod, error)
 type RestartComponent func(client client.Client, ctx intctrlutil.RequestCtx, key string, version string, objs []client.Object, recordEvent func(obj client.Object)) (client.Object, error)
@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package workloads
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	drainingNodeRequeueDuration = 10 * time.Second
+)
+
+// NodeReconciler switches over the leaders of InstanceSets away from the nodes being drained,
+// before they are evicted by the drain.
+type NodeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("node", req.Name)
+
+	node := &corev1.Node{}
+	if err := r.Client.Get(ctx, req.NamespacedName, node); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, logger, "")
+	}
+	if !node.Spec.Unschedulable {
+		return intctrlutil.Reconciled()
+	}
+
+	// the node is cordoned, which is the first step of draining, the leaders are switched over to the candidates
+	// on the other schedulable nodes, and the ones without a candidate are left as they are
+	leaders, err := instanceset.SwitchoverLeadersOnNode(ctx, r.Client, logger, node)
+	if err != nil {
+		return intctrlutil.RequeueAfter(drainingNodeRequeueDuration, logger, "switchover leaders on node failed", "error", err.Error())
+	}
+	if leaders > 0 {
+		// requeue until no leader that can be switched over remains on the node
+		return intctrlutil.RequeueAfter(drainingNodeRequeueDuration, logger, "wait for the leaders to be switched over", "leaders", leaders)
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			node, ok := obj.(*corev1.Node)
+			return ok && node.Spec.Unschedulable
+		}))).
+		Complete(r)
+}
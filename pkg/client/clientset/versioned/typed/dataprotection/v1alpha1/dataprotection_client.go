@@ -32,6 +32,7 @@ type DataprotectionV1alpha1Interface interface {
 	BackupsGetter
 	BackupPoliciesGetter
 	BackupPolicyTemplatesGetter
+	BackupReplicationPoliciesGetter
 	BackupReposGetter
 	BackupSchedulesGetter
 	RestoresGetter
@@ -59,6 +60,10 @@ func (c *DataprotectionV1alpha1Client) BackupPolicyTemplates() BackupPolicyTempl
 	return newBackupPolicyTemplates(c)
 }
 
+func (c *DataprotectionV1alpha1Client) BackupReplicationPolicies(namespace string) BackupReplicationPolicyInterface {
+	return newBackupReplicationPolicies(c, namespace)
+}
+
 func (c *DataprotectionV1alpha1Client) BackupRepos() BackupRepoInterface {
 	return newBackupRepos(c)
 }
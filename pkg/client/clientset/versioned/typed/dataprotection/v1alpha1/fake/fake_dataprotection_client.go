@@ -44,6 +44,10 @@ func (c *FakeDataprotectionV1alpha1) BackupPolicyTemplates() v1alpha1.BackupPoli
 	return &FakeBackupPolicyTemplates{c}
 }
 
+func (c *FakeDataprotectionV1alpha1) BackupReplicationPolicies(namespace string) v1alpha1.BackupReplicationPolicyInterface {
+	return &FakeBackupReplicationPolicies{c, namespace}
+}
+
 func (c *FakeDataprotectionV1alpha1) BackupRepos() v1alpha1.BackupRepoInterface {
 	return &FakeBackupRepos{c}
 }
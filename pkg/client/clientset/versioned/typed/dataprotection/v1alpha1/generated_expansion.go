@@ -26,6 +26,8 @@ type BackupPolicyExpansion interface{}
 
 type BackupPolicyTemplateExpansion interface{}
 
+type BackupReplicationPolicyExpansion interface{}
+
 type BackupRepoExpansion interface{}
 
 type BackupScheduleExpansion interface{}
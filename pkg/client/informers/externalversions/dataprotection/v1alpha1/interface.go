@@ -32,6 +32,8 @@ type Interface interface {
 	BackupPolicies() BackupPolicyInformer
 	// BackupPolicyTemplates returns a BackupPolicyTemplateInformer.
 	BackupPolicyTemplates() BackupPolicyTemplateInformer
+	// BackupReplicationPolicies returns a BackupReplicationPolicyInformer.
+	BackupReplicationPolicies() BackupReplicationPolicyInformer
 	// BackupRepos returns a BackupRepoInformer.
 	BackupRepos() BackupRepoInformer
 	// BackupSchedules returns a BackupScheduleInformer.
@@ -73,6 +75,11 @@ func (v *version) BackupPolicyTemplates() BackupPolicyTemplateInformer {
 	return &backupPolicyTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// BackupReplicationPolicies returns a BackupReplicationPolicyInformer.
+func (v *version) BackupReplicationPolicies() BackupReplicationPolicyInformer {
+	return &backupReplicationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BackupRepos returns a BackupRepoInformer.
 func (v *version) BackupRepos() BackupRepoInformer {
 	return &backupRepoInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -109,6 +109,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupPolicies().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backuppolicytemplates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupPolicyTemplates().Informer()}, nil
+	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backupreplicationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupReplicationPolicies().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backuprepos"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupRepos().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backupschedules"):
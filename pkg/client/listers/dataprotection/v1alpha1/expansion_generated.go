@@ -42,6 +42,14 @@ type BackupPolicyNamespaceListerExpansion interface{}
 // BackupPolicyTemplateLister.
 type BackupPolicyTemplateListerExpansion interface{}
 
+// BackupReplicationPolicyListerExpansion allows custom methods to be added to
+// BackupReplicationPolicyLister.
+type BackupReplicationPolicyListerExpansion interface{}
+
+// BackupReplicationPolicyNamespaceListerExpansion allows custom methods to be added to
+// BackupReplicationPolicyNamespaceLister.
+type BackupReplicationPolicyNamespaceListerExpansion interface{}
+
 // BackupRepoListerExpansion allows custom methods to be added to
 // BackupRepoLister.
 type BackupRepoListerExpansion interface{}
@@ -85,13 +85,21 @@ func isValidReloadPolicy(reload parametersv1alpha1.ReloadAction) bool {
 	return reload.AutoTrigger != nil ||
 		reload.ShellTrigger != nil ||
 		reload.TPLScriptTrigger != nil ||
-		reload.UnixSignalTrigger != nil
+		reload.UnixSignalTrigger != nil ||
+		reload.SQLTrigger != nil ||
+		reload.HTTPTrigger != nil
 }
 
 func IsAutoReload(reload *parametersv1alpha1.ReloadAction) bool {
 	return reload != nil && reload.AutoTrigger != nil
 }
 
+// IsControllerReload returns true if the reload action is performed by the controller directly,
+// without the config-manager sidecar.
+func IsControllerReload(reload *parametersv1alpha1.ReloadAction) bool {
+	return reload != nil && (reload.SQLTrigger != nil || reload.HTTPTrigger != nil)
+}
+
 func FromReloadTypeConfig(reloadAction *parametersv1alpha1.ReloadAction) parametersv1alpha1.DynamicReloadType {
 	switch {
 	case reloadAction.UnixSignalTrigger != nil:
@@ -102,6 +110,10 @@ func FromReloadTypeConfig(reloadAction *parametersv1alpha1.ReloadAction) paramet
 		return parametersv1alpha1.TPLScriptType
 	case reloadAction.AutoTrigger != nil:
 		return parametersv1alpha1.AutoType
+	case reloadAction.SQLTrigger != nil:
+		return parametersv1alpha1.SQLType
+	case reloadAction.HTTPTrigger != nil:
+		return parametersv1alpha1.HTTPType
 	}
 	return ""
 }
@@ -116,6 +128,10 @@ func ValidateReloadOptions(reloadAction *parametersv1alpha1.ReloadAction, cli cl
 		return checkTPLScriptTrigger(reloadAction.TPLScriptTrigger, cli, ctx)
 	case reloadAction.AutoTrigger != nil:
 		return nil
+	case reloadAction.SQLTrigger != nil:
+		return checkSQLTrigger(reloadAction.SQLTrigger)
+	case reloadAction.HTTPTrigger != nil:
+		return checkHTTPTrigger(reloadAction.HTTPTrigger)
 	}
 	return core.MakeError("require special reload type!")
 }
@@ -135,6 +151,31 @@ func checkShellTrigger(options *parametersv1alpha1.ShellTrigger) error {
 	return nil
 }
 
+func checkSQLTrigger(options *parametersv1alpha1.SQLTrigger) error {
+	switch options.Engine {
+	case parametersv1alpha1.MySQLEngine, parametersv1alpha1.PostgreSQLEngine:
+	default:
+		return core.MakeError("this sql engine [%s] is not supported now.", options.Engine)
+	}
+	if options.AccountName == "" {
+		return core.MakeError("required account name for sql trigger")
+	}
+	if options.Statement == "" {
+		return core.MakeError("required statement for sql trigger")
+	}
+	return checkTPLScript("sql-trigger", options.Statement)
+}
+
+func checkHTTPTrigger(options *parametersv1alpha1.HTTPTrigger) error {
+	if options.Path == "" {
+		return core.MakeError("required path for http trigger")
+	}
+	if err := checkTPLScript("http-trigger-path", options.Path); err != nil {
+		return err
+	}
+	return checkTPLScript("http-trigger-body", options.Body)
+}
+
 func checkSignalTrigger(options *parametersv1alpha1.UnixSignalTrigger) error {
 	signal := options.Signal
 	if !IsValidUnixSignal(signal) {
@@ -232,7 +273,7 @@ func GetSupportReloadConfigSpecs(configSpecs []appsv1.ComponentFileTemplate,
 			continue
 		}
 		action := paramsDef.Spec.ReloadAction
-		if !IsSupportReload(action) || IsAutoReload(action) {
+		if !IsSupportReload(action) || IsAutoReload(action) || IsControllerReload(action) {
 			continue
 		}
 		reloadConfigSpecMeta = append(reloadConfigSpecMeta, ConfigSpecMeta{
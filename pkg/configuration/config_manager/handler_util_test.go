@@ -357,6 +357,21 @@ var _ = Describe("Handler Util Test", func() {
 				}})))
 		})
 
+		It("TestSQLTrigger", func() {
+			Expect(parametersv1alpha1.SQLType).Should(BeEquivalentTo(FromReloadTypeConfig(&parametersv1alpha1.ReloadAction{
+				SQLTrigger: &parametersv1alpha1.SQLTrigger{
+					Engine:    parametersv1alpha1.MySQLEngine,
+					Statement: "SET GLOBAL {{ .Name }} = {{ .Value }}",
+				}})))
+		})
+
+		It("TestHTTPTrigger", func() {
+			Expect(parametersv1alpha1.HTTPType).Should(BeEquivalentTo(FromReloadTypeConfig(&parametersv1alpha1.ReloadAction{
+				HTTPTrigger: &parametersv1alpha1.HTTPTrigger{
+					Path: "/config",
+				}})))
+		})
+
 		It("TestInvalidTrigger", func() {
 			Expect("").Should(BeEquivalentTo(FromReloadTypeConfig(&parametersv1alpha1.ReloadAction{})))
 		})
@@ -422,6 +437,37 @@ var _ = Describe("Handler Util Test", func() {
 			).ShouldNot(Succeed())
 		})
 
+		It("TestSQLTrigger", func() {
+			sqlTrigger := parametersv1alpha1.SQLTrigger{
+				Engine:      parametersv1alpha1.PostgreSQLEngine,
+				AccountName: "postgres",
+				Statement:   "ALTER SYSTEM SET {{ .Name }} = '{{ .Value }}'",
+			}
+			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{SQLTrigger: &sqlTrigger}, nil, nil)).Should(Succeed())
+
+			By("Test invalid engine")
+			invalidTrigger := sqlTrigger
+			invalidTrigger.Engine = "oracle"
+			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{SQLTrigger: &invalidTrigger}, nil, nil)).ShouldNot(Succeed())
+
+			By("Test invalid statement")
+			invalidTrigger = sqlTrigger
+			invalidTrigger.Statement = "SET {{ .Name "
+			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{SQLTrigger: &invalidTrigger}, nil, nil)).ShouldNot(Succeed())
+		})
+
+		It("TestHTTPTrigger", func() {
+			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{
+				HTTPTrigger: &parametersv1alpha1.HTTPTrigger{
+					Path: "/admin/config/{{ .Name }}",
+					Body: "{{ .Value }}",
+				}}, nil, nil),
+			).Should(Succeed())
+			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{
+				HTTPTrigger: &parametersv1alpha1.HTTPTrigger{}}, nil, nil),
+			).ShouldNot(Succeed())
+		})
+
 		It("TestInvalidTrigger", func() {
 			Expect(ValidateReloadOptions(&parametersv1alpha1.ReloadAction{}, nil, nil)).ShouldNot(Succeed())
 		})
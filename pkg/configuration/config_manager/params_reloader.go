@@ -0,0 +1,406 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/gotemplate"
+)
+
+const (
+	reloadParamName  = "Name"
+	reloadParamValue = "Value"
+
+	postgresDriver = "postgres"
+)
+
+// ReloadTarget describes the process to which the updated parameters are applied.
+type ReloadTarget struct {
+	Host     string
+	Port     int32
+	Username string
+	Password string
+	// CACert is the PEM-encoded CA certificates used to verify the certificate of the HTTPS endpoint.
+	CACert []byte
+}
+
+// ParamsReloader applies the updated parameters to the process directly from the controller,
+// without the config-manager sidecar.
+type ParamsReloader interface {
+	// Reload applies the updated parameters one by one, and returns the errors of the failed parameters.
+	Reload(ctx context.Context, params map[string]string) map[string]error
+	Close()
+}
+
+var (
+	openSQLDB = sql.Open
+
+	// the same as the kubelet does for HTTPS probes, the certificate of the endpoint is not verified if no CA is specified.
+	reloadHTTPClient = &http.Client{
+		Timeout: connectTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+)
+
+func NewParamsReloader(ctx context.Context, reloadAction *parametersv1alpha1.ReloadAction, target ReloadTarget) (ParamsReloader, error) {
+	switch {
+	case reloadAction == nil:
+		return nil, cfgcore.MakeError("require reload action!")
+	case reloadAction.SQLTrigger != nil:
+		return newSQLParamsReloader(ctx, reloadAction.SQLTrigger, target)
+	case reloadAction.HTTPTrigger != nil:
+		return newHTTPParamsReloader(reloadAction.HTTPTrigger, target)
+	}
+	return nil, cfgcore.MakeError("not supported reload type[%s]", FromReloadTypeConfig(reloadAction))
+}
+
+func renderReloadTemplate(tpl string, name, value string, funcs *gotemplate.BuiltInObjectsFunc) (string, error) {
+	values := gotemplate.TplValues{
+		reloadParamName:  name,
+		reloadParamValue: value,
+	}
+	engine := gotemplate.NewTplEngine(&values, funcs, "render-reload-parameter", nil, nil)
+	rendered, err := engine.Render(tpl)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(rendered), nil
+}
+
+// sqlTemplateFuncs returns the functions to quote the name and the value of the parameter in the statement:
+//   - sqlQuote: quotes a string literal.
+//   - sqlIdent: quotes an identifier.
+func sqlTemplateFuncs(engine parametersv1alpha1.SQLEngine) *gotemplate.BuiltInObjectsFunc {
+	return &gotemplate.BuiltInObjectsFunc{
+		"sqlQuote": func(s string) string {
+			if engine == parametersv1alpha1.MySQLEngine {
+				s = strings.ReplaceAll(s, `\`, `\\`)
+			}
+			return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+		},
+		"sqlIdent": func(s string) string {
+			if engine == parametersv1alpha1.MySQLEngine {
+				return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+			}
+			return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+		},
+	}
+}
+
+// checkSingleSQLStatement checks that the rendered statement is exactly one statement without comments,
+// so that a value which is not quoted can't inject other statements.
+// For MySQL, the statement is checked both with and without the backslash escapes in quotes,
+// since the escapes are disabled by the NO_BACKSLASH_ESCAPES mode.
+func checkSingleSQLStatement(engine parametersv1alpha1.SQLEngine, statement string) error {
+	stmt := []rune(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+	check := func(backslashEscapes bool) error {
+		var quote rune
+		for i := 0; i < len(stmt); i++ {
+			c := stmt[i]
+			var next rune
+			if i+1 < len(stmt) {
+				next = stmt[i+1]
+			}
+			if quote != 0 {
+				switch {
+				case c == '\\' && backslashEscapes && quote != '`':
+					i++
+				case c == quote && next == quote:
+					i++
+				case c == quote:
+					quote = 0
+				}
+				continue
+			}
+			switch {
+			case c == '\'' || c == '"' || (c == '`' && engine == parametersv1alpha1.MySQLEngine):
+				quote = c
+			case c == ';':
+				return cfgcore.MakeError("the statement must be a single statement: %s", statement)
+			case c == '-' && next == '-', c == '/' && next == '*', c == '#' && engine == parametersv1alpha1.MySQLEngine:
+				return cfgcore.MakeError("the statement must not contain comments: %s", statement)
+			}
+		}
+		if quote != 0 {
+			return cfgcore.MakeError("the statement has an unterminated quote: %s", statement)
+		}
+		return nil
+	}
+	if err := check(false); err != nil {
+		return err
+	}
+	if engine == parametersv1alpha1.MySQLEngine {
+		return check(true)
+	}
+	return nil
+}
+
+type sqlParamsReloader struct {
+	updater         DynamicParamUpdater
+	engine          parametersv1alpha1.SQLEngine
+	statement       string
+	reloadStatement string
+}
+
+func newSQLParamsReloader(ctx context.Context, trigger *parametersv1alpha1.SQLTrigger, target ReloadTarget) (ParamsReloader, error) {
+	driverName, dsn, err := buildDataSourceName(trigger, target)
+	if err != nil {
+		return nil, err
+	}
+	db, err := openSQLDB(driverName, dsn)
+	if err != nil {
+		return nil, cfgcore.WrapError(err, "failed to open connection to %s.", trigger.Engine)
+	}
+	updater, err := newDynamicParamUpdater(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqlParamsReloader{
+		updater:         updater,
+		engine:          trigger.Engine,
+		statement:       trigger.Statement,
+		reloadStatement: trigger.ReloadStatement,
+	}, nil
+}
+
+func buildDataSourceName(trigger *parametersv1alpha1.SQLTrigger, target ReloadTarget) (string, string, error) {
+	address := net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port)))
+	sslMode := trigger.SSLMode
+	if sslMode == "" {
+		sslMode = parametersv1alpha1.SQLSSLModeRequire
+	}
+	if sslMode != parametersv1alpha1.SQLSSLModeDisable && sslMode != parametersv1alpha1.SQLSSLModeRequire {
+		return "", "", cfgcore.MakeError("not supported ssl mode[%s]", sslMode)
+	}
+	switch trigger.Engine {
+	case parametersv1alpha1.MySQLEngine:
+		config := mysqldriver.NewConfig()
+		config.User = target.Username
+		config.Passwd = target.Password
+		config.Net = "tcp"
+		config.Addr = address
+		config.DBName = trigger.Database
+		config.Timeout = connectTimeout
+		if sslMode == parametersv1alpha1.SQLSSLModeRequire {
+			// the same as the sslmode "require" of PostgreSQL, the certificate is not verified
+			config.TLSConfig = "skip-verify"
+		}
+		return mysql, config.FormatDSN(), nil
+	case parametersv1alpha1.PostgreSQLEngine:
+		dsn := url.URL{
+			Scheme:   postgresDriver,
+			User:     url.UserPassword(target.Username, target.Password),
+			Host:     address,
+			Path:     "/" + trigger.Database,
+			RawQuery: fmt.Sprintf("sslmode=%s&connect_timeout=%d", sslMode, int(connectTimeout.Seconds())),
+		}
+		return postgresDriver, dsn.String(), nil
+	}
+	return "", "", cfgcore.MakeError("not supported sql engine[%s]", trigger.Engine)
+}
+
+func (s *sqlParamsReloader) Reload(ctx context.Context, params map[string]string) map[string]error {
+	errs := make(map[string]error)
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		statement, err := renderReloadTemplate(s.statement, name, params[name], sqlTemplateFuncs(s.engine))
+		if err == nil {
+			err = checkSingleSQLStatement(s.engine, statement)
+		}
+		if err == nil {
+			logger.V(1).Info(fmt.Sprintf("exec sql statement: %s", statement))
+			_, err = s.updater.ExecCommand(ctx, statement)
+		}
+		if err != nil {
+			errs[name] = err
+		}
+	}
+	if s.reloadStatement == "" || len(errs) == len(params) {
+		return errs
+	}
+	if _, err := s.updater.ExecCommand(ctx, s.reloadStatement); err != nil {
+		for name := range params {
+			if _, ok := errs[name]; !ok {
+				errs[name] = err
+			}
+		}
+	}
+	return errs
+}
+
+func (s *sqlParamsReloader) Close() {
+	s.updater.Close()
+}
+
+type httpParamsReloader struct {
+	trigger *parametersv1alpha1.HTTPTrigger
+	target  ReloadTarget
+	client  *http.Client
+}
+
+func newHTTPParamsReloader(trigger *parametersv1alpha1.HTTPTrigger, target ReloadTarget) (ParamsReloader, error) {
+	reloader := &httpParamsReloader{trigger: trigger, target: target, client: reloadHTTPClient}
+	if len(target.CACert) == 0 {
+		return reloader, nil
+	}
+	tlsConfig, err := buildCAVerifiedTLSConfig(target.CACert)
+	if err != nil {
+		return nil, err
+	}
+	reloader.client = &http.Client{
+		Timeout:   connectTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	return reloader, nil
+}
+
+// buildCAVerifiedTLSConfig builds the TLS config which verifies the certificate chain of the endpoint against the CA.
+// The host name is not verified, since the endpoint is addressed by the pod IP which is not in the certificate generally.
+func buildCAVerifiedTLSConfig(caCert []byte) (*tls.Config, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCert) {
+		return nil, cfgcore.MakeError("no valid CA certificate found")
+	}
+	return &tls.Config{
+		// the default verification is replaced by VerifyConnection, which skips the host name only
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return cfgcore.MakeError("no certificate presented by the endpoint")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range state.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := state.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}, nil
+}
+
+func (h *httpParamsReloader) Reload(ctx context.Context, params map[string]string) map[string]error {
+	errs := make(map[string]error)
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if err := h.reloadParameter(ctx, name, params[name]); err != nil {
+			errs[name] = err
+		}
+	}
+	return errs
+}
+
+func (h *httpParamsReloader) reloadParameter(ctx context.Context, name, value string) error {
+	req, err := h.buildRequest(ctx, name, value)
+	if err != nil {
+		return err
+	}
+	logger.V(1).Info(fmt.Sprintf("send reload request: %s %s", req.Method, req.URL.Path))
+	rsp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode < http.StatusOK || rsp.StatusCode >= http.StatusMultipleChoices {
+		return cfgcore.MakeError("unexpected response status: %s, body: %s", rsp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (h *httpParamsReloader) buildRequest(ctx context.Context, name, value string) (*http.Request, error) {
+	// the name and the value are escaped as path segments, so that they can't change the structure of the path
+	path, err := renderReloadTemplate(h.trigger.Path, url.PathEscape(name), url.PathEscape(value), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := renderReloadTemplate(h.trigger.Body, name, value, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := h.trigger.Scheme
+	if scheme == "" {
+		scheme = corev1.URISchemeHTTP
+	}
+	method := h.trigger.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	reqURL := fmt.Sprintf("%s://%s%s", strings.ToLower(string(scheme)),
+		net.JoinHostPort(h.target.Host, strconv.Itoa(int(h.target.Port))), path)
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, header := range h.trigger.Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+	if h.target.Username != "" {
+		// never send the credentials in plain text, or to an endpoint not verified
+		if scheme != corev1.URISchemeHTTPS {
+			return nil, cfgcore.MakeError("the basic authentication requires the HTTPS scheme")
+		}
+		if len(h.target.CACert) == 0 {
+			return nil, cfgcore.MakeError("the basic authentication requires the CA to verify the endpoint")
+		}
+		req.SetBasicAuth(h.target.Username, h.target.Password)
+	}
+	return req, nil
+}
+
+func (h *httpParamsReloader) Close() {
+	if h.client != reloadHTTPClient {
+		h.client.CloseIdleConnections()
+	}
+}
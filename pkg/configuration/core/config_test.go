@@ -288,6 +288,27 @@ func TestGenerateVisualizedParamsList(t *testing.T) {
 	}
 }
 
+func TestGetParametersFromConfigFile(t *testing.T) {
+	params, err := GetParametersFromConfigFile(iniConfig, &parametersv1alpha1.FileFormatConfig{
+		Format: parametersv1alpha1.Ini,
+		FormatterAction: parametersv1alpha1.FormatterAction{
+			IniConfig: &parametersv1alpha1.IniConfig{
+				SectionName: "mysqld",
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "512M", params["innodb-buffer-pool-size"])
+	require.Equal(t, "OFF", params["gtid_mode"])
+	require.NotContains(t, params, "mysqld.gtid_mode")
+
+	params, err = GetParametersFromConfigFile("maxmemory 1gb\nappendonly yes", &parametersv1alpha1.FileFormatConfig{
+		Format: parametersv1alpha1.RedisCfg,
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"maxmemory": "1gb", "appendonly": "yes"}, params)
+}
+
 func resolveKey(patch *ConfigPatchInfo) []string {
 	var keys []string
 	if len(patch.AddConfig) != 0 {
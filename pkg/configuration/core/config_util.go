@@ -116,6 +116,29 @@ func ApplyConfigPatch(baseCfg []byte, updatedParameters map[string]*string, form
 	return mergedConfig.Marshal()
 }
 
+// GetParametersFromConfigFile returns the flattened parameters of the config file.
+func GetParametersFromConfigFile(content string, formatConfig *parametersv1alpha1.FileFormatConfig) (map[string]string, error) {
+	configLoaderOption := CfgOption{
+		Type:    CfgRawType,
+		Log:     log.FromContext(context.TODO()),
+		CfgType: formatConfig.Format,
+		RawData: []byte(content),
+	}
+	configWrapper, err := NewConfigLoader(configLoaderOption)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	configObject := configWrapper.getConfigObject(NewCfgOptions(""))
+	for _, param := range checkAndFlattenMap(configObject.GetAllParameters(), NestedPrefixField(formatConfig)) {
+		if param.Value != nil {
+			params[param.Key] = *param.Value
+		}
+	}
+	return params, nil
+}
+
 func IsWatchModuleForShellTrigger(trigger *parametersv1alpha1.ShellTrigger) bool {
 	if trigger == nil || trigger.Sync == nil {
 		return true
@@ -183,3 +183,7 @@ func GenerateEnvFromName(originName string) string {
 func GenerateRevisionPhaseKey(revision string) string {
 	return strings.Join([]string{constant.LastConfigurationRevisionPhase, revision}, "-")
 }
+
+func GenerateRevisionParamsKey(revision string) string {
+	return strings.Join([]string{constant.ConfigurationRevisionParams, revision}, "-")
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package validate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+)
+
+const (
+	parametersVariable = "parameters"
+	resourcesVariable  = "resources"
+	replicasVariable   = "replicas"
+)
+
+var (
+	quantityPattern = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]*)$`)
+
+	// the size units used by the databases, which are powers of 1024.
+	quantityUnits = map[string]float64{
+		"":  1,
+		"b": 1,
+		"k": 1 << 10, "kb": 1 << 10, "ki": 1 << 10, "kib": 1 << 10,
+		"m": 1 << 20, "mb": 1 << 20, "mi": 1 << 20, "mib": 1 << 20,
+		"g": 1 << 30, "gb": 1 << 30, "gi": 1 << 30, "gib": 1 << 30,
+		"t": 1 << 40, "tb": 1 << 40, "ti": 1 << 40, "tib": 1 << 40,
+	}
+)
+
+// RuleContext holds the variables to evaluate the validation rules.
+type RuleContext struct {
+	// merged parameters of the config file
+	Parameters map[string]string
+	Resources  corev1.ResourceRequirements
+	Replicas   int32
+}
+
+func newRuleEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(parametersVariable, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(resourcesVariable, cel.MapType(cel.StringType, cel.MapType(cel.StringType, cel.IntType))),
+		cel.Variable(replicasVariable, cel.IntType),
+		cel.Function("quantity",
+			cel.Overload("quantity_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					str, ok := value.Value().(string)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(value)
+					}
+					v, err := ParseQuantity(str)
+					if err != nil {
+						return types.NewErr("%s", err.Error())
+					}
+					return types.Int(v)
+				}))),
+	)
+}
+
+// ParseQuantity converts a value with a size unit to an integer,
+// the units of databases such as K/M/G and KB/MB/GB are treated as powers of 1024,
+// and the other values are parsed as the K8s quantity.
+func ParseQuantity(value string) (int64, error) {
+	value = strings.Trim(strings.TrimSpace(value), `'"`)
+	if matches := quantityPattern.FindStringSubmatch(value); matches != nil {
+		if unit, ok := quantityUnits[strings.ToLower(matches[2])]; ok {
+			number, err := strconv.ParseFloat(matches[1], 64)
+			if err != nil {
+				return 0, err
+			}
+			return int64(number * unit), nil
+		}
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, core.MakeError("invalid quantity: %s", value)
+	}
+	return quantity.Value(), nil
+}
+
+func compileRule(env *cel.Env, rule parametersv1alpha1.ParametersValidationRule) (cel.Program, error) {
+	ast, issues := env.Compile(rule.Expression)
+	if issues.Err() != nil {
+		return nil, core.WrapError(issues.Err(), "failed to compile the expression of rule[%s]", rule.Name)
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, core.MakeError("the expression of rule[%s] must evaluate to a boolean, but got %s", rule.Name, ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// CompileValidationRules checks whether the validation rules are valid CEL expressions.
+func CompileValidationRules(rules []parametersv1alpha1.ParametersValidationRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	env, err := newRuleEnv()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, rule := range rules {
+		if _, err := compileRule(env, rule); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateRules evaluates the validation rules against the rule context,
+// and returns an error that contains all the violated rules.
+func ValidateRules(rules []parametersv1alpha1.ParametersValidationRule, ruleCtx *RuleContext) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	env, err := newRuleEnv()
+	if err != nil {
+		return err
+	}
+
+	parameters := ruleCtx.Parameters
+	if parameters == nil {
+		parameters = map[string]string{}
+	}
+	vars := map[string]any{
+		parametersVariable: parameters,
+		resourcesVariable: map[string]map[string]int64{
+			"limits":   fromResourceList(ruleCtx.Resources.Limits),
+			"requests": fromResourceList(ruleCtx.Resources.Requests),
+		},
+		replicasVariable: int64(ruleCtx.Replicas),
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		if err := evalRule(env, rule, vars); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func evalRule(env *cel.Env, rule parametersv1alpha1.ParametersValidationRule, vars map[string]any) error {
+	prg, err := compileRule(env, rule)
+	if err != nil {
+		return err
+	}
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return core.WrapError(err, "failed to evaluate rule[%s]", rule.Name)
+	}
+	passed, ok := out.Value().(bool)
+	if !ok {
+		return core.MakeError("the expression of rule[%s] did not evaluate to a boolean", rule.Name)
+	}
+	if passed {
+		return nil
+	}
+	if rule.Message != "" {
+		return core.MakeError("rule[%s] is violated: %s", rule.Name, rule.Message)
+	}
+	return core.MakeError("rule[%s] is violated: %s", rule.Name, rule.Expression)
+}
+
+func fromResourceList(resources corev1.ResourceList) map[string]int64 {
+	values := make(map[string]int64, len(resources))
+	for name, quantity := range resources {
+		switch name {
+		case corev1.ResourceCPU:
+			values[string(name)] = quantity.MilliValue()
+		default:
+			values[string(name)] = quantity.Value()
+		}
+	}
+	return values
+}
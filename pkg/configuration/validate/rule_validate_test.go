@@ -0,0 +1,122 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1024", want: 1024},
+		{value: "128M", want: 128 << 20},
+		{value: "128m", want: 128 << 20},
+		{value: "1GB", want: 1 << 30},
+		{value: "64kB", want: 64 << 10},
+		{value: "512Mi", want: 512 << 20},
+		{value: "1.5G", want: 3 << 29},
+		{value: "'2G'", want: 2 << 30},
+		{value: "1e3", want: 1000},
+		{value: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseQuantity(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	rules := []parametersv1alpha1.ParametersValidationRule{{
+		Name:       "buffer-pool-size",
+		Expression: "quantity(parameters.innodb_buffer_pool_size) <= resources.limits.memory * 3 / 4",
+		Message:    "innodb_buffer_pool_size must be at most 75% of the memory limit",
+	}, {
+		Name:       "wal-size",
+		Expression: "!has(parameters.min_wal_size) || quantity(parameters.min_wal_size) <= quantity(parameters.max_wal_size)",
+	}, {
+		Name:       "replicas",
+		Expression: "replicas >= 1 && resources.requests.cpu >= 500",
+	}}
+	ruleCtx := &RuleContext{
+		Parameters: map[string]string{
+			"innodb_buffer_pool_size": "3G",
+			"max_wal_size":            "1GB",
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+		},
+		Replicas: 3,
+	}
+	assert.NoError(t, ValidateRules(rules, ruleCtx))
+
+	ruleCtx.Parameters["innodb_buffer_pool_size"] = "4G"
+	ruleCtx.Parameters["min_wal_size"] = "2GB"
+	err := ValidateRules(rules, ruleCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule[buffer-pool-size] is violated: innodb_buffer_pool_size must be at most 75% of the memory limit")
+	assert.Contains(t, err.Error(), "rule[wal-size] is violated")
+	assert.NotContains(t, err.Error(), "rule[replicas]")
+
+	// the missing parameter fails the evaluation
+	delete(ruleCtx.Parameters, "innodb_buffer_pool_size")
+	err = ValidateRules(rules[:1], ruleCtx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to evaluate rule[buffer-pool-size]")
+
+	assert.NoError(t, ValidateRules(nil, ruleCtx))
+}
+
+func TestCompileValidationRules(t *testing.T) {
+	assert.NoError(t, CompileValidationRules([]parametersv1alpha1.ParametersValidationRule{{
+		Name:       "valid",
+		Expression: "int(parameters.max_connections) <= 1000",
+	}}))
+	assert.Error(t, CompileValidationRules([]parametersv1alpha1.ParametersValidationRule{{
+		Name:       "syntax",
+		Expression: "parameters.max_connections <=",
+	}}))
+	assert.Error(t, CompileValidationRules([]parametersv1alpha1.ParametersValidationRule{{
+		Name:       "not-bool",
+		Expression: "quantity(parameters.max_connections)",
+	}}))
+}
@@ -51,6 +51,12 @@ const (
 	NodeSelectorOnceAnnotationKey = "workloads.kubeblocks.io/node-selector-once"
 
 	PVCNamePrefixAnnotationKey = "apps.kubeblocks.io/pvc-name-prefix"
+
+	// ReplicaReadonlyAnnotationKey records who has switched the replica pod into the read-only state.
+	ReplicaReadonlyAnnotationKey = "apps.kubeblocks.io/replica-readonly"
+
+	// LifecycleActionStatusAnnotationKey records the status of the latest executions of the multi-step lifecycle actions called for the pod.
+	LifecycleActionStatusAnnotationKey = "apps.kubeblocks.io/lifecycle-action-status"
 )
 
 const (
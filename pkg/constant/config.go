@@ -30,6 +30,7 @@ const (
 	// TODO support multi version
 	ConfigurationRevision          = "config.kubeblocks.io/configuration-revision"
 	LastConfigurationRevisionPhase = "config.kubeblocks.io/revision-reconcile-phase"
+	ConfigurationRevisionParams    = "config.kubeblocks.io/revision-parameters"
 
 	// Deprecated: only compatible with version 0.6, will be removed in 0.8
 	// CMInsEnableRerenderTemplateKey is used to enable rerender template
@@ -23,6 +23,9 @@ const (
 	EnableRBACManager = "EnableRBACManager"
 
 	ManagedNamespacesFlag = "managed-namespaces"
+
+	// ParametersFlag is the flag to enable the parameters API group and its controllers.
+	ParametersFlag = "parameters"
 )
 
 const (
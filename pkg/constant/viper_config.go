@@ -56,6 +56,14 @@ const (
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
 
+	// kb-agent config keys
+	CfgKeyKBAgentAuthMode      = "KBAGENT_AUTH_MODE"
+	CfgKeyKBAgentAuthTokenFile = "KBAGENT_AUTH_TOKEN_FILE"
+
+	// CfgRolloutAnalysisPrometheusAddresses specifies the comma-separated addresses of the Prometheus-compatible servers
+	// allowed to be queried by the analysis of rollouts, any HTTP or HTTPS address is allowed if not specified.
+	CfgRolloutAnalysisPrometheusAddresses = "ROLLOUT_ANALYSIS_PROMETHEUS_ADDRESSES"
+
 	CfgRegistries     = "registries"
 	I18nResourcesName = "I18N_RESOURCES_NAME"
 )
@@ -184,6 +184,11 @@ func (builder *ComponentBuilder) SetOfflineInstances(offlineInstances []string)
 	return builder
 }
 
+func (builder *ComponentBuilder) SetAutoHealing(autoHealing *appsv1.InstanceAutoHealing) *ComponentBuilder {
+	builder.get().Spec.AutoHealing = autoHealing
+	return builder
+}
+
 func (builder *ComponentBuilder) SetRuntimeClassName(runtimeClassName *string) *ComponentBuilder {
 	if runtimeClassName != nil {
 		className := *runtimeClassName
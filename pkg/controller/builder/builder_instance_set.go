@@ -121,11 +121,16 @@ func (builder *InstanceSetBuilder) SetMemberUpdateStrategy(strategy *workloads.M
 }
 
 func (builder *InstanceSetBuilder) SetLifecycleActions(lifecycleActions *kbappsv1.ComponentLifecycleActions) *InstanceSetBuilder {
-	if lifecycleActions != nil && lifecycleActions.Switchover != nil {
+	if lifecycleActions == nil {
+		return builder
+	}
+	if lifecycleActions.Switchover != nil || lifecycleActions.MemberJoin != nil || lifecycleActions.MemberLeave != nil {
 		if builder.get().Spec.MembershipReconfiguration == nil {
 			builder.get().Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{}
 		}
 		builder.get().Spec.MembershipReconfiguration.Switchover = lifecycleActions.Switchover
+		builder.get().Spec.MembershipReconfiguration.MemberJoin = lifecycleActions.MemberJoin
+		builder.get().Spec.MembershipReconfiguration.MemberLeave = lifecycleActions.MemberLeave
 	}
 	return builder
 }
@@ -44,6 +44,24 @@ func (c *ParameterBuilder) SetComponentParameters(component string, parameters p
 	return c
 }
 
+func (c *ParameterBuilder) SetRevertToRevision(component string, revision int64) *ParameterBuilder {
+	componentSpec := safeGetComponentSpec(&c.get().Spec, component)
+	componentSpec.RevertToRevision = &revision
+	return c
+}
+
+func (c *ParameterBuilder) SetRollbackPolicy(component string, policy parametersv1alpha1.RollbackPolicy) *ParameterBuilder {
+	componentSpec := safeGetComponentSpec(&c.get().Spec, component)
+	componentSpec.RollbackPolicy = &policy
+	return c
+}
+
+func (c *ParameterBuilder) SetCanaryPolicy(component string, policy parametersv1alpha1.CanaryPolicy) *ParameterBuilder {
+	componentSpec := safeGetComponentSpec(&c.get().Spec, component)
+	componentSpec.CanaryPolicy = &policy
+	return c
+}
+
 func (c *ParameterBuilder) AddCustomTemplate(component string, tpl string, customTemplates parametersv1alpha1.ConfigTemplateExtension) *ParameterBuilder {
 	componentSpec := safeGetComponentSpec(&c.get().Spec, component)
 	if componentSpec.CustomTemplates == nil {
@@ -0,0 +1,56 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type PodDisruptionBudgetBuilder struct {
+	BaseBuilder[policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudget, PodDisruptionBudgetBuilder]
+}
+
+func NewPodDisruptionBudgetBuilder(namespace, name string) *PodDisruptionBudgetBuilder {
+	builder := &PodDisruptionBudgetBuilder{}
+	builder.init(namespace, name, &policyv1.PodDisruptionBudget{}, builder)
+	return builder
+}
+
+func (b *PodDisruptionBudgetBuilder) SetSelector(selector *metav1.LabelSelector) *PodDisruptionBudgetBuilder {
+	b.get().Spec.Selector = selector
+	return b
+}
+
+func (b *PodDisruptionBudgetBuilder) SetMinAvailable(minAvailable intstr.IntOrString) *PodDisruptionBudgetBuilder {
+	b.get().Spec.MinAvailable = &minAvailable
+	return b
+}
+
+func (b *PodDisruptionBudgetBuilder) SetMaxUnavailable(maxUnavailable intstr.IntOrString) *PodDisruptionBudgetBuilder {
+	b.get().Spec.MaxUnavailable = &maxUnavailable
+	return b
+}
+
+func (b *PodDisruptionBudgetBuilder) SetUnhealthyPodEvictionPolicy(policy policyv1.UnhealthyPodEvictionPolicyType) *PodDisruptionBudgetBuilder {
+	b.get().Spec.UnhealthyPodEvictionPolicy = &policy
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("pod disruption budget builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		selector := &metav1.LabelSelector{
+			MatchLabels: map[string]string{"foo": "bar"},
+		}
+		minAvailable := intstr.FromInt32(2)
+		maxUnavailable := intstr.FromInt32(1)
+		policy := policyv1.AlwaysAllow
+		pdb := NewPodDisruptionBudgetBuilder(ns, name).
+			SetSelector(selector).
+			SetMinAvailable(minAvailable).
+			SetMaxUnavailable(maxUnavailable).
+			SetUnhealthyPodEvictionPolicy(policy).
+			GetObject()
+
+		Expect(pdb.Name).Should(Equal(name))
+		Expect(pdb.Namespace).Should(Equal(ns))
+		Expect(pdb.Spec.Selector).Should(Equal(selector))
+		Expect(*pdb.Spec.MinAvailable).Should(Equal(minAvailable))
+		Expect(*pdb.Spec.MaxUnavailable).Should(Equal(maxUnavailable))
+		Expect(*pdb.Spec.UnhealthyPodEvictionPolicy).Should(Equal(policy))
+	})
+})
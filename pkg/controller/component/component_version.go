@@ -282,9 +282,7 @@ func actionsToResolveImage(compDef *appsv1.ComponentDefinition) map[string]*apps
 	actions := map[string]*appsv1.Action{
 		normalize("postProvision"):    compDef.Spec.LifecycleActions.PostProvision,
 		normalize("preTerminate"):     compDef.Spec.LifecycleActions.PreTerminate,
-		normalize("switchover"):       compDef.Spec.LifecycleActions.Switchover,
 		normalize("memberJoin"):       compDef.Spec.LifecycleActions.MemberJoin,
-		normalize("memberLeave"):      compDef.Spec.LifecycleActions.MemberLeave,
 		normalize("readonly"):         compDef.Spec.LifecycleActions.Readonly,
 		normalize("readwrite"):        compDef.Spec.LifecycleActions.Readwrite,
 		normalize("dataDump"):         compDef.Spec.LifecycleActions.DataDump,
@@ -292,6 +290,12 @@ func actionsToResolveImage(compDef *appsv1.ComponentDefinition) map[string]*apps
 		normalize("reconfigure"):      compDef.Spec.LifecycleActions.Reconfigure,
 		normalize("accountProvision"): compDef.Spec.LifecycleActions.AccountProvision,
 	}
+	if compDef.Spec.LifecycleActions.Switchover != nil {
+		actions[normalize("switchover")] = &compDef.Spec.LifecycleActions.Switchover.Action
+	}
+	if compDef.Spec.LifecycleActions.MemberLeave != nil {
+		actions[normalize("memberLeave")] = &compDef.Spec.LifecycleActions.MemberLeave.Action
+	}
 	if compDef.Spec.LifecycleActions.RoleProbe != nil {
 		actions[normalize("roleProbe")] = &compDef.Spec.LifecycleActions.RoleProbe.Action
 	}
@@ -22,11 +22,15 @@ package component
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -35,6 +39,7 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	kbagentutil "github.com/apecloud/kubeblocks/pkg/kbagent/util"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -147,13 +152,14 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 		return b.GetObject(), nil
 	}
 
+	ports, err := getAvailablePorts(synthesizedComp.PodSpec.Containers,
+		[]int32{int32(kbagent.DefaultHTTPPort), int32(kbagent.DefaultStreamingPort)})
+	if err != nil {
+		return err
+	}
+	httpPort, streamingPort := int(ports[0]), int(ports[1])
+
 	container, err := newContainer(kbagent.ContainerName, func(b *builder.ContainerBuilder) error {
-		ports, err1 := getAvailablePorts(synthesizedComp.PodSpec.Containers,
-			[]int32{int32(kbagent.DefaultHTTPPort), int32(kbagent.DefaultStreamingPort)})
-		if err1 != nil {
-			return err1
-		}
-		httpPort, streamingPort := int(ports[0]), int(ports[1])
 		b.AddArgs("--port", strconv.Itoa(httpPort)).
 			AddArgs("--streaming-port", strconv.Itoa(streamingPort)).
 			AddPorts(
@@ -179,16 +185,37 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 
 	workerContainer, err := newContainer(kbagent.ContainerName4Worker, func(b *builder.ContainerBuilder) error {
 		b.AddArgs("--server=false") // run as a worker
+		if hasTaskDefined(synthesizedComp) {
+			// the worker serves the task query API on the same port before the server starts
+			b.AddArgs("--port", strconv.Itoa(httpPort))
+		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
 
+	buildKBAgentTaskJournal(synthesizedComp, container, workerContainer)
+
+	buildKBAgentAuth(synthesizedComp, container, workerContainer)
+
 	if err = handleCustomImageNContainerDefined(synthesizedComp, container, workerContainer); err != nil {
 		return err
 	}
 
+	// mount the volumes to be protected to the kb-agent container to check the usage
+	if _, mounts := buildVolumeProtection4KBAgent(synthesizedComp); len(mounts) > 0 {
+		mountPaths := sets.New[string]()
+		for _, mount := range container.VolumeMounts {
+			mountPaths.Insert(mount.MountPath)
+		}
+		for _, mount := range mounts {
+			if !mountPaths.Has(mount.MountPath) {
+				container.VolumeMounts = append(container.VolumeMounts, mount)
+			}
+		}
+	}
+
 	// set kb-agent container ports to host network
 	if synthesizedComp.HostNetwork != nil {
 		if synthesizedComp.HostNetwork.ContainerPorts == nil {
@@ -214,6 +241,126 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 	return nil
 }
 
+// buildKBAgentAuth configures the authentication of the kb-agent server according to the auth mode of the operator.
+func buildKBAgentAuth(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	switch kbagent.AuthMode() {
+	case kbagent.AuthModeMTLS:
+		buildKBAgentMTLS(synthesizedComp, containers...)
+	case kbagent.AuthModeToken:
+		buildKBAgentToken(synthesizedComp, containers...)
+	}
+}
+
+func buildKBAgentMTLS(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	if !slices.ContainsFunc(synthesizedComp.PodSpec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == kbagent.TLSVolumeName
+	}) {
+		synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+			Name: kbagent.TLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: kbagent.TLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+					// the client certificate is used by the worker to connect to the streaming server of other replicas
+					Items: []corev1.KeyToPath{
+						{Key: kbagent.TLSCAFile, Path: kbagent.TLSCAFile},
+						{Key: kbagent.TLSCertFile, Path: kbagent.TLSCertFile},
+						{Key: kbagent.TLSKeyFile, Path: kbagent.TLSKeyFile},
+						{Key: kbagent.TLSClientCertFile, Path: kbagent.TLSClientCertFile},
+						{Key: kbagent.TLSClientKeyFile, Path: kbagent.TLSClientKeyFile},
+					},
+					DefaultMode: ptr.To(int32(0600)),
+				},
+			},
+		})
+	}
+	for _, c := range containers {
+		c.Args = append(c.Args,
+			"--tls-cert-file", filepath.Join(kbagent.TLSMountPath, kbagent.TLSCertFile),
+			"--tls-key-file", filepath.Join(kbagent.TLSMountPath, kbagent.TLSKeyFile),
+			"--tls-ca-file", filepath.Join(kbagent.TLSMountPath, kbagent.TLSCAFile),
+			"--tls-client-cert-file", filepath.Join(kbagent.TLSMountPath, kbagent.TLSClientCertFile),
+			"--tls-client-key-file", filepath.Join(kbagent.TLSMountPath, kbagent.TLSClientKeyFile))
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      kbagent.TLSVolumeName,
+			MountPath: kbagent.TLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// buildKBAgentToken enables the token authentication, the callers are the operator and the kb-agents of other
+// replicas, which share the same service account of the pod and present the projected token to each other.
+func buildKBAgentToken(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	args := []string{"--auth-token-audience", kbagent.TokenAudience}
+	namespace, sa := viper.GetString(constant.CfgKeyCtrlrMgrNS), viper.GetString(constant.KBServiceAccountName)
+	if len(namespace) > 0 && len(sa) > 0 {
+		args = append(args,
+			"--auth-token-users", fmt.Sprintf("system:serviceaccount:%s:%s", namespace, sa),
+			"--auth-token-users", kbagentutil.ServiceAccountUser())
+	}
+	args = append(args, "--auth-token-file", kbagent.DefaultTokenFile)
+
+	if !slices.ContainsFunc(synthesizedComp.PodSpec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == kbagent.TokenVolumeName
+	}) {
+		synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+			Name: kbagent.TokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          kbagent.TokenAudience,
+								ExpirationSeconds: ptr.To(int64(kbagent.TokenExpirationSeconds)),
+								Path:              filepath.Base(kbagent.DefaultTokenFile),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	for _, c := range containers {
+		c.Env = append(c.Env, kbagentutil.ServiceAccountEnvVar())
+		c.Args = append(c.Args, args...)
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      kbagent.TokenVolumeName,
+			MountPath: filepath.Dir(kbagent.DefaultTokenFile),
+			ReadOnly:  true,
+		})
+	}
+}
+
+// buildKBAgentTaskJournal shares a volume between the kb-agent worker and server to persist the task journal,
+// so that the task status can survive the restart of kb-agent.
+func buildKBAgentTaskJournal(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	if !hasTaskDefined(synthesizedComp) {
+		return
+	}
+	for _, vol := range synthesizedComp.PodSpec.Volumes {
+		if vol.Name == kbagent.TaskJournalVolumeName {
+			return
+		}
+	}
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbagent.TaskJournalVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	for _, c := range containers {
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      kbagent.TaskJournalVolumeName,
+			MountPath: kbagent.TaskJournalMountPath,
+		})
+	}
+}
+
+// hasTaskDefined checks whether the kb-agent worker may run tasks, only the new replica task is supported now.
+func hasTaskDefined(synthesizedComp *SynthesizedComponent) bool {
+	return synthesizedComp.LifecycleActions != nil && lifecycle.IsActionDefined(synthesizedComp.LifecycleActions.DataLoad)
+}
+
 func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 	envSet := sets.New[string]()
@@ -230,19 +377,7 @@ func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.Env
 	}
 
 	if synthesizedComp.LifecycleActions != nil {
-		for _, action := range []*appsv1.Action{
-			synthesizedComp.LifecycleActions.PostProvision,
-			synthesizedComp.LifecycleActions.PreTerminate,
-			synthesizedComp.LifecycleActions.Switchover,
-			synthesizedComp.LifecycleActions.MemberJoin,
-			synthesizedComp.LifecycleActions.MemberLeave,
-			synthesizedComp.LifecycleActions.Readonly,
-			synthesizedComp.LifecycleActions.Readwrite,
-			synthesizedComp.LifecycleActions.DataDump,
-			synthesizedComp.LifecycleActions.DataLoad,
-			synthesizedComp.LifecycleActions.Reconfigure,
-			synthesizedComp.LifecycleActions.AccountProvision,
-		} {
+		for _, action := range lifecycleActionHandlers(synthesizedComp.LifecycleActions) {
 			checkedAppend(action)
 		}
 		if synthesizedComp.LifecycleActions.RoleProbe != nil {
@@ -270,15 +405,11 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.PreTerminate, "preTerminate"); a != nil {
 			actions = append(actions, *a)
 		}
-		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Switchover, "switchover"); a != nil {
-			actions = append(actions, *a)
-		}
+		actions = append(actions, buildActions4KBAgent(synthesizedComp.LifecycleActions.Switchover, "switchover")...)
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.MemberJoin, "memberJoin"); a != nil {
 			actions = append(actions, *a)
 		}
-		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.MemberLeave, "memberLeave"); a != nil {
-			actions = append(actions, *a)
-		}
+		actions = append(actions, buildActions4KBAgent(synthesizedComp.LifecycleActions.MemberLeave, "memberLeave")...)
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Readonly, "readonly"); a != nil {
 			actions = append(actions, *a)
 		}
@@ -318,7 +449,61 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		}
 	})
 
-	return kbagent.BuildEnv4Server(actions, probes, streaming)
+	volumeProtection, _ := buildVolumeProtection4KBAgent(synthesizedComp)
+
+	return kbagent.BuildEnv4Server(actions, probes, streaming, volumeProtection)
+}
+
+// buildVolumeProtection4KBAgent builds the volume protection config for the volumes with high watermark defined,
+// and the volume mounts that the kbagent container needs to check the usage of these volumes.
+func buildVolumeProtection4KBAgent(synthesizedComp *SynthesizedComponent) (*proto.VolumeProtection, []corev1.VolumeMount) {
+	if synthesizedComp.LifecycleActions == nil ||
+		!lifecycle.IsActionDefined(synthesizedComp.LifecycleActions.Readonly) ||
+		!lifecycle.IsActionDefined(synthesizedComp.LifecycleActions.Readwrite) {
+		return nil, nil
+	}
+
+	lookupVolumeMount := func(name string) *corev1.VolumeMount {
+		for _, c := range synthesizedComp.PodSpec.Containers {
+			for i, mount := range c.VolumeMounts {
+				if mount.Name == name {
+					return &c.VolumeMounts[i]
+				}
+			}
+		}
+		return nil
+	}
+
+	var (
+		volumes []proto.VolumeWatermark
+		mounts  []corev1.VolumeMount
+	)
+	for _, vol := range synthesizedComp.Volumes {
+		if vol.HighWatermark <= 0 || vol.HighWatermark > 100 {
+			continue
+		}
+		mount := lookupVolumeMount(vol.Name)
+		if mount == nil {
+			continue
+		}
+		volumes = append(volumes, proto.VolumeWatermark{
+			Name:          vol.Name,
+			MountPath:     mount.MountPath,
+			HighWatermark: int32(vol.HighWatermark),
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      mount.Name,
+			MountPath: mount.MountPath,
+			ReadOnly:  true,
+		})
+	}
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	return &proto.VolumeProtection{
+		Instance: synthesizedComp.FullCompName,
+		Volumes:  volumes,
+	}, mounts
 }
 
 func probeReportPeriodSeconds(periodSeconds int32) int32 {
@@ -331,17 +516,109 @@ func probeReportPeriodSeconds(periodSeconds int32) int32 {
 	return periodSeconds
 }
 
+// buildActions4KBAgent builds the kb-agent actions for the action, each step of a multi-step action and
+// its compensation handler are built as separate kb-agent actions, which are called in order by the controller.
+func buildActions4KBAgent(action *appsv1.StepAction, name string) []proto.Action {
+	if action == nil {
+		return nil
+	}
+	if len(action.Steps) == 0 {
+		if a := buildAction4KBAgent(&action.Action, name); a != nil {
+			return []proto.Action{*a}
+		}
+		return nil
+	}
+	actions := make([]proto.Action, 0)
+	for i := range action.Steps {
+		step := &action.Steps[i]
+		if a := buildAction4KBAgent(lifecycle.StepHandlerAction(&step.ActionStepHandler), lifecycle.StepActionName(name, step.Name)); a != nil {
+			actions = append(actions, *a)
+		}
+		if step.OnFailure != nil {
+			if a := buildAction4KBAgent(lifecycle.StepHandlerAction(step.OnFailure), lifecycle.StepRollbackActionName(name, step.Name)); a != nil {
+				actions = append(actions, *a)
+			}
+		}
+	}
+	return actions
+}
+
+// actionHandlers returns the handlers of the action, which are the handlers of the steps for a multi-step action.
+func actionHandlers(action *appsv1.StepAction) []*appsv1.Action {
+	if action == nil {
+		return nil
+	}
+	if len(action.Steps) == 0 {
+		return []*appsv1.Action{&action.Action}
+	}
+	handlers := make([]*appsv1.Action, 0)
+	for i := range action.Steps {
+		handlers = append(handlers, lifecycle.StepHandlerAction(&action.Steps[i].ActionStepHandler))
+		if action.Steps[i].OnFailure != nil {
+			handlers = append(handlers, lifecycle.StepHandlerAction(action.Steps[i].OnFailure))
+		}
+	}
+	return handlers
+}
+
+// lifecycleActionHandlers returns the handlers of the lifecycle actions except the probes, in the order of the actions.
+func lifecycleActionHandlers(lifecycleActions *appsv1.ComponentLifecycleActions) []*appsv1.Action {
+	handlers := []*appsv1.Action{
+		lifecycleActions.PostProvision,
+		lifecycleActions.PreTerminate,
+	}
+	handlers = append(handlers, actionHandlers(lifecycleActions.Switchover)...)
+	handlers = append(handlers, lifecycleActions.MemberJoin)
+	handlers = append(handlers, actionHandlers(lifecycleActions.MemberLeave)...)
+	return append(handlers,
+		lifecycleActions.Readonly,
+		lifecycleActions.Readwrite,
+		lifecycleActions.DataDump,
+		lifecycleActions.DataLoad,
+		lifecycleActions.Reconfigure,
+		lifecycleActions.AccountProvision,
+	)
+}
+
 func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
-	if action == nil || action.Exec == nil {
+	if !lifecycle.IsActionDefined(action) {
 		return nil
 	}
 	a := &proto.Action{
-		Name: name,
-		Exec: &proto.ExecAction{
+		Name:            name,
+		TimeoutSeconds:  action.TimeoutSeconds,
+		CacheTTLSeconds: action.CacheTTLSeconds,
+	}
+	switch {
+	case action.Exec != nil:
+		a.Exec = &proto.ExecAction{
 			Commands: action.Exec.Command,
 			Args:     action.Exec.Args,
-		},
-		TimeoutSeconds: action.TimeoutSeconds,
+		}
+	case action.HTTP != nil:
+		a.HTTP = &proto.HTTPAction{
+			Host:                action.HTTP.Host,
+			Port:                action.HTTP.Port,
+			Scheme:              string(action.HTTP.Scheme),
+			Method:              action.HTTP.Method,
+			Path:                action.HTTP.Path,
+			Body:                action.HTTP.Body,
+			ExpectedStatusCodes: action.HTTP.ExpectedStatusCodes,
+		}
+		for _, header := range action.HTTP.Headers {
+			a.HTTP.Headers = append(a.HTTP.Headers, proto.HTTPHeader{
+				Name:  header.Name,
+				Value: header.Value,
+			})
+		}
+	case action.GRPC != nil:
+		a.GRPC = &proto.GRPCAction{
+			Host:    action.GRPC.Host,
+			Port:    action.GRPC.Port,
+			Service: action.GRPC.Service,
+			Method:  action.GRPC.Method,
+			Request: action.GRPC.Request,
+		}
 	}
 	if action.RetryPolicy != nil {
 		a.RetryPolicy = &proto.RetryPolicy{
@@ -353,7 +630,7 @@ func buildAction4KBAgent(action *appsv1.Action, name string) *proto.Action {
 }
 
 func buildProbe4KBAgent(probe *appsv1.Probe, name, instance string) (*proto.Action, *proto.Probe) {
-	if probe == nil || probe.Exec == nil {
+	if probe == nil || !lifecycle.IsActionDefined(&probe.Action) {
 		return nil, nil
 	}
 	a := buildAction4KBAgent(&probe.Action, name)
@@ -408,19 +685,7 @@ func customExecActionImageNContainer(synthesizedComp *SynthesizedComponent) (str
 
 	actions := make([]*appsv1.Action, 0)
 	if synthesizedComp.LifecycleActions != nil {
-		actions = append(actions, []*appsv1.Action{
-			synthesizedComp.LifecycleActions.PostProvision,
-			synthesizedComp.LifecycleActions.PreTerminate,
-			synthesizedComp.LifecycleActions.Switchover,
-			synthesizedComp.LifecycleActions.MemberJoin,
-			synthesizedComp.LifecycleActions.MemberLeave,
-			synthesizedComp.LifecycleActions.Readonly,
-			synthesizedComp.LifecycleActions.Readwrite,
-			synthesizedComp.LifecycleActions.DataDump,
-			synthesizedComp.LifecycleActions.DataLoad,
-			synthesizedComp.LifecycleActions.Reconfigure,
-			synthesizedComp.LifecycleActions.AccountProvision,
-		}...)
+		actions = append(actions, lifecycleActionHandlers(synthesizedComp.LifecycleActions)...)
 		if synthesizedComp.LifecycleActions.RoleProbe != nil && synthesizedComp.LifecycleActions.RoleProbe.Exec != nil {
 			actions = append(actions, &synthesizedComp.LifecycleActions.RoleProbe.Action)
 		}
@@ -520,7 +785,7 @@ func hasActionDefined(synthesizedComp *SynthesizedComponent) bool {
 			return true
 		}
 	}
-	return false
+	return len(synthesizedComp.QueryParametersActions) > 0
 }
 
 func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(name string, action *appsv1.Action)) {
@@ -531,4 +796,8 @@ func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(na
 			f(name, synthesizedComp.FileTemplates[i].Reconfigure)
 		}
 	}
+	for _, paramsDef := range slices.Sorted(maps.Keys(synthesizedComp.QueryParametersActions)) {
+		name := lifecycle.UDFActionName(UDFQueryParametersActionName(paramsDef))
+		f(name, synthesizedComp.QueryParametersActions[paramsDef])
+	}
 }
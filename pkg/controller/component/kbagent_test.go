@@ -349,6 +349,96 @@ var _ = Describe("kb-agent", func() {
 
 		// TODO: host-network
 
+		It("volume protection", func() {
+			synthesizedComp.FullCompName = "test-cluster-test-comp"
+			synthesizedComp.LifecycleActions.Readonly = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"echo", "readonly"},
+				},
+			}
+			synthesizedComp.LifecycleActions.Readwrite = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"echo", "readwrite"},
+				},
+			}
+			synthesizedComp.Volumes = []appsv1.ComponentVolume{
+				{
+					Name:          "data",
+					HighWatermark: 90,
+				},
+				{
+					Name: "log",
+				},
+			}
+			synthesizedComp.PodSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+				},
+				{
+					Name:      "log",
+					MountPath: "/log",
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.VolumeMounts).Should(Equal([]corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+					ReadOnly:  true,
+				},
+			}))
+
+			var val string
+			for _, e := range c.Env {
+				if e.Name == "KB_AGENT_VOLUME_PROTECTION" {
+					val = e.Value
+				}
+			}
+			Expect(val).ShouldNot(BeEmpty())
+
+			volumeProtection := &proto.VolumeProtection{}
+			Expect(json.Unmarshal([]byte(val), volumeProtection)).Should(BeNil())
+			Expect(volumeProtection.Instance).Should(Equal(synthesizedComp.FullCompName))
+			Expect(volumeProtection.Volumes).Should(Equal([]proto.VolumeWatermark{
+				{
+					Name:          "data",
+					MountPath:     "/data",
+					HighWatermark: 90,
+				},
+			}))
+		})
+
+		It("volume protection - w/o readonly & readwrite actions", func() {
+			synthesizedComp.Volumes = []appsv1.ComponentVolume{
+				{
+					Name:          "data",
+					HighWatermark: 90,
+				},
+			}
+			synthesizedComp.PodSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+				{
+					Name:      "data",
+					MountPath: "/data",
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.VolumeMounts).Should(BeEmpty())
+			for _, e := range c.Env {
+				Expect(e.Name).ShouldNot(Equal("KB_AGENT_VOLUME_PROTECTION"))
+			}
+		})
+
 		It("user-defined actions", func() {
 			synthesizedComp.LifecycleActions.Reconfigure = &appsv1.Action{
 				Exec: &appsv1.ExecAction{
@@ -442,5 +532,83 @@ var _ = Describe("kb-agent", func() {
 				Value: "/var/run/server.conf",
 			}))
 		})
+
+		It("multi-step actions", func() {
+			synthesizedComp.LifecycleActions.MemberLeave = &appsv1.StepAction{
+				Steps: []appsv1.ActionStep{
+					{
+						Name: "add",
+						ActionStepHandler: appsv1.ActionStepHandler{
+							Exec: &appsv1.ExecAction{
+								Env: []corev1.EnvVar{
+									{
+										Name:  "STEP_ENV",
+										Value: "add",
+									},
+								},
+								Command: []string{"echo", "add"},
+							},
+							TimeoutSeconds: 10,
+						},
+						OnFailure: &appsv1.ActionStepHandler{
+							Exec: &appsv1.ExecAction{
+								Command: []string{"echo", "remove"},
+							},
+						},
+					},
+					{
+						Name: "sync",
+						ActionStepHandler: appsv1.ActionStepHandler{
+							Exec: &appsv1.ExecAction{
+								Command: []string{"echo", "sync"},
+							},
+						},
+					},
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			var val string
+			for _, e := range c.Env {
+				if e.Name == "KB_AGENT_ACTION" {
+					val = e.Value
+				}
+			}
+			Expect(val).ShouldNot(BeEmpty())
+
+			actions := make([]proto.Action, 0)
+			Expect(json.Unmarshal([]byte(val), &actions)).Should(BeNil())
+
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "memberLeave.add",
+				Exec: &proto.ExecAction{
+					Commands: []string{"echo", "add"},
+				},
+				TimeoutSeconds: 10,
+			}))
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "memberLeave.add.onFailure",
+				Exec: &proto.ExecAction{
+					Commands: []string{"echo", "remove"},
+				},
+			}))
+			Expect(actions).Should(ContainElement(proto.Action{
+				Name: "memberLeave.sync",
+				Exec: &proto.ExecAction{
+					Commands: []string{"echo", "sync"},
+				},
+			}))
+			for _, a := range actions {
+				Expect(a.Name).ShouldNot(Equal("memberLeave"))
+			}
+
+			Expect(c.Env).Should(ContainElement(corev1.EnvVar{
+				Name:  "STEP_ENV",
+				Value: "add",
+			}))
+		})
 	})
 })
@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+)
+
+const (
+	// ReplicaReadonlyByVolumeProtection indicates that the replica is switched into the read-only state
+	// since the usage of its volumes exceeds the high watermark.
+	ReplicaReadonlyByVolumeProtection = "VolumeProtection"
+
+	// ReplicaReadonlyByOpsRequest indicates that the replica is switched into the read-only state by an OpsRequest.
+	ReplicaReadonlyByOpsRequest = "OpsRequest"
+)
+
+// GetReplicaReadonlySource returns who has switched the replica into the read-only state, empty if it's read-write.
+func GetReplicaReadonlySource(pod *corev1.Pod) string {
+	if pod.Annotations == nil {
+		return ""
+	}
+	return pod.Annotations[constant.ReplicaReadonlyAnnotationKey]
+}
+
+// SetReplicaReadonly calls the readonly action on the replica, and records the source in the annotations of the pod.
+func SetReplicaReadonly(ctx context.Context, cli client.Client, synthesizedComp *SynthesizedComponent, pod *corev1.Pod, source string) error {
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod)
+	if err != nil {
+		return err
+	}
+	if err = lfa.Readonly(ctx, cli, nil); err != nil {
+		return err
+	}
+	return patchReplicaReadonlySource(ctx, cli, pod, source)
+}
+
+// SetReplicaReadwrite calls the readwrite action on the replica, and removes the read-only source from the pod.
+func SetReplicaReadwrite(ctx context.Context, cli client.Client, synthesizedComp *SynthesizedComponent, pod *corev1.Pod) error {
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod)
+	if err != nil {
+		return err
+	}
+	if err = lfa.Readwrite(ctx, cli, nil); err != nil {
+		return err
+	}
+	return patchReplicaReadonlySource(ctx, cli, pod, "")
+}
+
+func patchReplicaReadonlySource(ctx context.Context, cli client.Client, pod *corev1.Pod, source string) error {
+	if GetReplicaReadonlySource(pod) == source {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if len(source) == 0 {
+		delete(pod.Annotations, constant.ReplicaReadonlyAnnotationKey)
+	} else {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[constant.ReplicaReadonlyAnnotationKey] = source
+	}
+	return cli.Patch(ctx, pod, patch)
+}
@@ -35,6 +35,7 @@ import (
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
@@ -46,6 +47,7 @@ const (
 	// new replicas task & event
 	newReplicaTask                           = "newReplica"
 	defaultNewReplicaTaskReportPeriodSeconds = 60
+	newReplicaTaskQueryTimeout               = 5 * time.Second
 )
 
 type ReplicasStatus struct {
@@ -61,6 +63,7 @@ type ReplicaStatus struct {
 	Message           string     `json:"message,omitempty"`
 	Provisioned       bool       `json:"provisioned,omitempty"`
 	DataLoaded        *bool      `json:"dataLoaded,omitempty"`
+	DataLoadFailed    bool       `json:"dataLoadFailed,omitempty"`
 	MemberJoined      *bool      `json:"memberJoined,omitempty"`
 	Reconfigured      *string    `json:"reconfigured,omitempty"` // TODO: component status
 }
@@ -356,30 +359,109 @@ func handleNewReplicaTaskEvent4Finished(ctx context.Context, cli client.Client,
 		return err
 	}
 	return updateReplicaStatusFunc(ctx, cli, its, event.Replica, func(status *ReplicaStatus) error {
-		status.Message = ""
-		status.Provisioned = true
-		status.DataLoaded = ptr.To(true)
+		applyNewReplicaTaskEvent(status, event)
 		return nil
 	})
 }
 
 func handleNewReplicaTaskEvent4Unfinished(ctx context.Context, cli client.Client, its *workloads.InstanceSet, event proto.TaskEvent) error {
 	return updateReplicaStatusFunc(ctx, cli, its, event.Replica, func(status *ReplicaStatus) error {
-		status.Message = event.Message
-		status.Provisioned = true
-		status.DataLoaded = ptr.To(false)
+		applyNewReplicaTaskEvent(status, event)
 		return nil
 	})
 }
 
 func handleNewReplicaTaskEvent4Failed(ctx context.Context, cli client.Client, its *workloads.InstanceSet, event proto.TaskEvent) error {
 	return updateReplicaStatusFunc(ctx, cli, its, event.Replica, func(status *ReplicaStatus) error {
-		status.Message = event.Message
-		status.Provisioned = true
+		applyNewReplicaTaskEvent(status, event)
+		return nil
+	})
+}
+
+// applyNewReplicaTaskEvent updates the status of the replica by the event of the new replica task,
+// no matter the event is reported by the kb-agent or polled from it.
+func applyNewReplicaTaskEvent(status *ReplicaStatus, event proto.TaskEvent) {
+	finished := !event.EndTime.IsZero()
+	status.Message = event.Message
+	status.Provisioned = true
+	switch {
+	case finished && event.Code == 0:
+		status.DataLoaded = ptr.To(true)
+		status.DataLoadFailed = false
+	case finished:
+		// the task may be re-run after the kb-agent restarts, and it will be reset by the event of the re-run
+		status.DataLoadFailed = true
+	default:
+		status.DataLoaded = ptr.To(false)
+		status.DataLoadFailed = false
+	}
+}
+
+// PollNewReplicaTasks polls the latest events of the new replica task from the kb-agent of the replicas whose data
+// have not been loaded, and applies them to the status of the replicas in the ITS. It makes the progress of the task
+// tracked without depending on the task events only, and the failure of the task is observed as soon as possible.
+// The replicas that can't be polled are left to the task events.
+func PollNewReplicaTasks(ctx context.Context, cli client.Reader, its *workloads.InstanceSet,
+	clusterName, compName string, pods []*corev1.Pod) error {
+	replicas, err := GetReplicasStatusFunc(its, func(s ReplicaStatus) bool {
+		return s.DataLoaded != nil && !*s.DataLoaded
+	})
+	if err != nil || len(replicas) == 0 {
+		return err
+	}
+	uid, err := getNewReplicaTaskUID(ctx, cli, its)
+	if err != nil || len(uid) == 0 {
+		return err
+	}
+
+	events := map[string]proto.TaskEvent{}
+	for _, pod := range pods {
+		if !slices.Contains(replicas, pod.Name) {
+			continue
+		}
+		queryCtx, cancel := context.WithTimeout(ctx, newReplicaTaskQueryTimeout)
+		event, err := lifecycle.QueryTask(queryCtx, cli, its.Namespace, clusterName, compName, pod, uid)
+		cancel()
+		if err != nil || event.Replica != pod.Name {
+			continue // fallback to the task events
+		}
+		events[pod.Name] = *event
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return UpdateReplicasStatusFunc(its, func(status *ReplicasStatus) error {
+		for i := range status.Status {
+			if event, ok := events[status.Status[i].Name]; ok {
+				applyNewReplicaTaskEvent(&status.Status[i], event)
+			}
+		}
 		return nil
 	})
 }
 
+// getNewReplicaTaskUID returns the UID of the new replica task in the env of the kb-agent.
+func getNewReplicaTaskUID(ctx context.Context, cli client.Reader, its *workloads.InstanceSet) (string, error) {
+	envKey := types.NamespacedName{
+		Namespace: its.Namespace,
+		Name:      constant.GetCompEnvCMName(its.Name),
+	}
+	obj := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, envKey, obj, inDataContext()); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	uid := ""
+	if _, err := updateKBAgentTaskEnv(obj.Data, func(task proto.Task) *proto.Task {
+		if task.Task == newReplicaTask {
+			uid = task.UID
+		}
+		return &task
+	}); err != nil {
+		return "", err
+	}
+	return uid, nil
+}
+
 func updateReplicaStatusFunc(ctx context.Context, cli client.Client,
 	its *workloads.InstanceSet, replicaName string, f func(*ReplicaStatus) error) error {
 	if err := UpdateReplicasStatusFunc(its, func(status *ReplicasStatus) error {
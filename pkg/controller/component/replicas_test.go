@@ -21,6 +21,7 @@ package component
 
 import (
 	"slices"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -30,6 +31,7 @@ import (
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("replicas", func() {
@@ -181,6 +183,28 @@ var _ = Describe("replicas", func() {
 			})).Should(Succeed())
 		})
 
+		It("apply task event of new replicas", func() {
+			status := &ReplicaStatus{Name: "test-cluster-its-3"}
+
+			applyNewReplicaTaskEvent(status, proto.TaskEvent{Replica: status.Name, Message: "in progress"})
+			Expect(status.Provisioned).Should(BeTrue())
+			Expect(status.DataLoaded).ShouldNot(BeNil())
+			Expect(*status.DataLoaded).Should(BeFalse())
+			Expect(status.DataLoadFailed).Should(BeFalse())
+			Expect(status.Message).Should(Equal("in progress"))
+
+			applyNewReplicaTaskEvent(status, proto.TaskEvent{Replica: status.Name, EndTime: time.Now(), Code: -1, Message: "failed"})
+			Expect(*status.DataLoaded).Should(BeFalse())
+			Expect(status.DataLoadFailed).Should(BeTrue())
+			Expect(status.Message).Should(Equal("failed"))
+
+			// re-run of the task
+			applyNewReplicaTaskEvent(status, proto.TaskEvent{Replica: status.Name, EndTime: time.Now(), Code: 0})
+			Expect(*status.DataLoaded).Should(BeTrue())
+			Expect(status.DataLoadFailed).Should(BeFalse())
+			Expect(status.Message).Should(BeEmpty())
+		})
+
 		// It("task event for new replicas - succeed", func() {
 		//	Expect(StatusReplicasStatus(its, replicas, true, true)).Should(Succeed())
 		//
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -155,6 +156,8 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 		return nil, err
 	}
 
+	buildQueryParametersActions(ctx, cli, synthesizeComp, compDef)
+
 	if err = buildKBAgentContainer(synthesizeComp); err != nil {
 		return nil, errors.Wrap(err, "build kb-agent container failed")
 	}
@@ -472,6 +475,49 @@ func buildFileTemplates(synthesizedComp *SynthesizedComponent, compDef *appsv1.C
 	synthesizedComp.FileTemplates = templates
 }
 
+// buildQueryParametersActions builds the actions to query the live parameters, which are defined in the ParametersDefinitions.
+//
+// The lookup is best-effort: the parameters API may be disabled, and the ParamConfigRenderer or ParametersDefinitions
+// may be unavailable temporarily while they are being updated. The actions are only consumed by the drift detection,
+// so any error or unavailable object is skipped rather than failing the synthesis.
+func buildQueryParametersActions(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent, compDef *appsv1.ComponentDefinition) {
+	if cli == nil || !viper.GetBool(constant.ParametersFlag) || len(ConfigTemplates(synthesizedComp)) == 0 {
+		return
+	}
+
+	renderers := &parametersv1alpha1.ParamConfigRendererList{}
+	if err := cli.List(ctx, renderers); err != nil {
+		return
+	}
+	var renderer *parametersv1alpha1.ParamConfigRenderer
+	for i, item := range renderers.Items {
+		if item.Spec.ComponentDef != compDef.Name {
+			continue
+		}
+		if item.Spec.ServiceVersion == "" || item.Spec.ServiceVersion == compDef.Spec.ServiceVersion {
+			renderer = &renderers.Items[i]
+			break
+		}
+	}
+	if renderer == nil || renderer.Status.Phase != parametersv1alpha1.PDAvailablePhase {
+		return
+	}
+
+	for _, defName := range renderer.Spec.ParametersDefs {
+		paramsDef := &parametersv1alpha1.ParametersDefinition{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: defName}, paramsDef); err != nil {
+			continue
+		}
+		if paramsDef.Status.Phase != parametersv1alpha1.PDAvailablePhase || paramsDef.Spec.QueryParameters == nil {
+			continue
+		}
+		if synthesizedComp.QueryParametersActions == nil {
+			synthesizedComp.QueryParametersActions = make(map[string]*appsv1.Action)
+		}
+		synthesizedComp.QueryParametersActions[paramsDef.Name] = paramsDef.Spec.QueryParameters
+	}
+}
+
 func synthesizeFileTemplate(comp *appsv1.Component, tpl appsv1.ComponentFileTemplate, config bool) SynthesizedFileTemplate {
 	merge := func(tpl SynthesizedFileTemplate, utpl appsv1.ClusterComponentConfig) SynthesizedFileTemplate {
 		tpl.Variables = utpl.Variables
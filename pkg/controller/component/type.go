@@ -72,6 +72,7 @@ type SynthesizedComponent struct {
 	InstanceUpdateStrategy           *kbappsv1.InstanceUpdateStrategy    `json:"instanceUpdateStrategy,omitempty"`
 	PolicyRules                      []rbacv1.PolicyRule                 `json:"policyRules,omitempty"`
 	LifecycleActions                 *kbappsv1.ComponentLifecycleActions `json:"lifecycleActions,omitempty"`
+	QueryParametersActions           map[string]*kbappsv1.Action         // actions to query the live parameters, keyed by the ParametersDefinition name
 	SystemAccounts                   []kbappsv1.SystemAccount            `json:"systemAccounts,omitempty"`
 	Volumes                          []kbappsv1.ComponentVolume          `json:"volumes,omitempty"`
 	HostNetwork                      *kbappsv1.HostNetwork               `json:"hostNetwork,omitempty"`
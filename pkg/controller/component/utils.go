@@ -190,6 +190,10 @@ func UDFReconfigureActionName(tpl SynthesizedFileTemplate) string {
 	return fmt.Sprintf("reconfigure-%s", tpl.Name)
 }
 
+func UDFQueryParametersActionName(paramsDefName string) string {
+	return fmt.Sprintf("query-parameters-%s", paramsDefName)
+}
+
 func ConfigTemplates(synthesizedComp *SynthesizedComponent) []appsv1.ComponentFileTemplate {
 	if synthesizedComp.FileTemplates == nil {
 		return nil
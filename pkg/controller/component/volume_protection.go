@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// VolumeProtectionEventHandler handles the volume usage events reported by kbagent, it switches the replica
+// into the read-only state when the high watermark of any volume is exceeded, and back to the read-write state
+// when the usage of all volumes falls below the high watermark.
+type VolumeProtectionEventHandler struct{}
+
+func (h *VolumeProtectionEventHandler) Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error {
+	if !h.isVolumeProtectionEvent(event) {
+		return nil
+	}
+
+	ppEvent := &proto.ProbeEvent{}
+	if err := json.Unmarshal([]byte(event.Message), ppEvent); err != nil {
+		return err
+	}
+	status := &proto.VolumeProtectionStatus{}
+	if err := json.Unmarshal(ppEvent.Output, status); err != nil {
+		return err
+	}
+
+	comp := &appsv1.Component{}
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: event.InvolvedObject.Namespace, Name: ppEvent.Instance}, comp); err != nil {
+		return err
+	}
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}, pod); err != nil {
+		return err
+	}
+	if pod.UID != event.InvolvedObject.UID {
+		return nil // the event is reported by a stale pod
+	}
+
+	source := GetReplicaReadonlySource(pod)
+	if status.HighWatermarkExceeded && len(source) > 0 {
+		return nil // already in the read-only state
+	}
+	if !status.HighWatermarkExceeded && source != ReplicaReadonlyByVolumeProtection {
+		return nil // not switched to read-only by the volume protection
+	}
+
+	synthesizedComp, err := h.buildSynthesizedComp(reqCtx, cli, comp)
+	if err != nil {
+		return err
+	}
+	if status.HighWatermarkExceeded {
+		if err = SetReplicaReadonly(reqCtx.Ctx, cli, synthesizedComp, pod, ReplicaReadonlyByVolumeProtection); err != nil {
+			return err
+		}
+		recorder.Event(comp, corev1.EventTypeWarning, "HighWatermarkExceeded",
+			fmt.Sprintf("the volume space usage exceeds the high watermark, switch replica %s to read-only: %s",
+				pod.Name, h.exceededVolumes(status)))
+		return nil
+	}
+	if err = SetReplicaReadwrite(reqCtx.Ctx, cli, synthesizedComp, pod); err != nil {
+		return err
+	}
+	recorder.Event(comp, corev1.EventTypeNormal, "HighWatermarkRecovered",
+		fmt.Sprintf("the volume space usage falls below the high watermark, switch replica %s back to read-write", pod.Name))
+	return nil
+}
+
+func (h *VolumeProtectionEventHandler) isVolumeProtectionEvent(event *corev1.Event) bool {
+	return event.ReportingController == proto.ProbeEventReportingController &&
+		event.Reason == proto.VolumeProtectionProbe && event.InvolvedObject.FieldPath == proto.ProbeEventFieldPath
+}
+
+func (h *VolumeProtectionEventHandler) buildSynthesizedComp(reqCtx intctrlutil.RequestCtx, cli client.Client, comp *appsv1.Component) (*SynthesizedComponent, error) {
+	compDef, err := GetCompDefByName(reqCtx.Ctx, cli, comp.Spec.CompDef)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp, err := BuildSynthesizedComponent(reqCtx.Ctx, cli, compDef, comp)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp.TemplateVars, _, err = ResolveTemplateNEnvVars(reqCtx.Ctx, cli, synthesizedComp, compDef.Spec.Vars)
+	if err != nil {
+		return nil, err
+	}
+	return synthesizedComp, nil
+}
+
+func (h *VolumeProtectionEventHandler) exceededVolumes(status *proto.VolumeProtectionStatus) string {
+	volumes := make([]string, 0)
+	for _, v := range status.Volumes {
+		if v.Exceeded {
+			volumes = append(volumes, fmt.Sprintf("%s(%d%%>=%d%%)", v.Name, v.Usage, v.HighWatermark))
+		}
+	}
+	return strings.Join(volumes, ",")
+}
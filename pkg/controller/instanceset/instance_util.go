@@ -31,6 +31,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -560,6 +561,17 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return oldCm
 	}
 
+	copyAndMergePDB := func(oldPDB, newPDB *policyv1.PodDisruptionBudget) client.Object {
+		intctrlutil.MergeList(&newPDB.OwnerReferences, &oldPDB.OwnerReferences, func(reference metav1.OwnerReference) func(metav1.OwnerReference) bool {
+			return func(item metav1.OwnerReference) bool {
+				return reference.UID == item.UID
+			}
+		})
+		mergeMap(&newPDB.Labels, &oldPDB.Labels)
+		oldPDB.Spec = newPDB.Spec
+		return oldPDB
+	}
+
 	copyAndMergePod := func(oldPod, newPod *corev1.Pod) client.Object {
 		mergeInPlaceFields(newPod, oldPod)
 		return oldPod
@@ -596,6 +608,8 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		return copyAndMergeSvc(targetObj.(*corev1.Service), o)
 	case *corev1.ConfigMap:
 		return copyAndMergeCm(targetObj.(*corev1.ConfigMap), o)
+	case *policyv1.PodDisruptionBudget:
+		return copyAndMergePDB(targetObj.(*policyv1.PodDisruptionBudget), o)
 	case *corev1.Pod:
 		return copyAndMergePod(targetObj.(*corev1.Pod), o)
 	case *corev1.PersistentVolumeClaim:
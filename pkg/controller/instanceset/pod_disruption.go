@@ -0,0 +1,329 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// drainSwitchoverTimeout is the time to wait for the role of the leader to be changed after the switchover is sent.
+const drainSwitchoverTimeout = 2 * time.Minute
+
+// buildPodDisruptionBudgets builds the PDBs to protect the instances from the voluntary disruptions, e.g. node drains.
+//
+// The PDBs are derived from the roles, and the instances selected by them are disjoint,
+// since the eviction API does not support a pod selected by multiple PDBs:
+//   - without roles: at most one instance is unavailable.
+//   - the leader: not evicted before it is switched over, if the switchover action is defined and there is
+//     a candidate to switch over to, otherwise it is not protected to not block the drain forever.
+//   - the roles participate in quorum, and the instances without role: at most one of them is unavailable,
+//     and the quorum is never lost.
+//   - the roles not participate in quorum: at most one instance is unavailable.
+//
+// The surge instances are not counted since they are temporary, but they are selected as the instances they replace.
+func buildPodDisruptionBudgets(its *workloads.InstanceSet, pods []*corev1.Pod) []*policyv1.PodDisruptionBudget {
+	labels := getMatchLabels(its.Name)
+	newPDB := func(name string, expr *metav1.LabelSelectorRequirement, minAvailable int) *policyv1.PodDisruptionBudget {
+		selector := &metav1.LabelSelector{MatchLabels: labels}
+		if expr != nil {
+			selector.MatchExpressions = []metav1.LabelSelectorRequirement{*expr}
+		}
+		return builder.NewPodDisruptionBudgetBuilder(its.Namespace, name).
+			AddLabelsInMap(labels).
+			SetSelector(selector).
+			SetMinAvailable(intstr.FromInt32(int32(max(minAvailable, 0)))).
+			SetUnhealthyPodEvictionPolicy(policyv1.AlwaysAllow).
+			GetObject()
+	}
+	pods = slices.DeleteFunc(slices.Clone(pods), func(pod *corev1.Pod) bool {
+		return isSurgeInstance(pod)
+	})
+	countPods := func(roles []string) int {
+		cnt := 0
+		for _, pod := range pods {
+			if slices.Contains(roles, getRoleName(pod)) {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	if len(its.Spec.Roles) == 0 {
+		return []*policyv1.PodDisruptionBudget{newPDB(its.Name, nil, int(ptr.Deref(its.Spec.Replicas, 0))-1)}
+	}
+
+	var pdbs []*policyv1.PodDisruptionBudget
+	leader := pdbLeaderRole(its)
+	if len(leader) > 0 {
+		protected := 0
+		for _, pod := range pods {
+			if getRoleName(pod) == leader && len(switchoverCandidates(its, pod, pods, pod.Spec.NodeName)) > 0 {
+				protected++
+			}
+		}
+		expr := &metav1.LabelSelectorRequirement{
+			Key:      constant.RoleLabelKey,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{leader},
+		}
+		pdbs = append(pdbs, newPDB(getLeaderPDBName(its.Name), expr, protected))
+	}
+
+	var voters, nonVoters []string
+	for _, role := range its.Spec.Roles {
+		if role.ParticipatesInQuorum {
+			voters = append(voters, strings.ToLower(role.Name))
+		} else if strings.ToLower(role.Name) != leader {
+			nonVoters = append(nonVoters, strings.ToLower(role.Name))
+		}
+	}
+	var excluded []string // the roles protected by the other PDBs
+	if len(leader) > 0 {
+		excluded = append(excluded, leader)
+	}
+	// NotIn selects the instances without role as well
+	notIn := func(roles []string) *metav1.LabelSelectorRequirement {
+		if len(roles) == 0 {
+			return nil
+		}
+		return &metav1.LabelSelectorRequirement{
+			Key:      constant.RoleLabelKey,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   roles,
+		}
+	}
+
+	if len(voters) == 0 {
+		return append(pdbs, newPDB(its.Name, notIn(excluded), max(0, len(pods)-countPods(excluded)-1)))
+	}
+
+	// the instances without role are protected along with the voters, since they may be voters not probed yet,
+	// at most one of them is unavailable, as long as the quorum is kept
+	total := countPods(voters)
+	unavailable := min(1, total-(total/2+1))
+	excluded = append(excluded, nonVoters...)
+	pdbs = append(pdbs, newPDB(its.Name, notIn(excluded), len(pods)-countPods(excluded)-unavailable))
+
+	if len(nonVoters) > 0 {
+		expr := &metav1.LabelSelectorRequirement{
+			Key:      constant.RoleLabelKey,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   nonVoters,
+		}
+		pdbs = append(pdbs, newPDB(getNonVoterPDBName(its.Name), expr, max(0, countPods(nonVoters)-1)))
+	}
+	return pdbs
+}
+
+// pdbLeaderRole returns the role which should never be evicted before a switchover,
+// that is the only role with the highest update priority, if the switchover action is defined.
+func pdbLeaderRole(its *workloads.InstanceSet) string {
+	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.Switchover == nil {
+		return ""
+	}
+	if ptr.Deref(its.Spec.Replicas, 0) <= 1 || len(its.Spec.Roles) <= 1 {
+		return "" // no candidate to switch over to
+	}
+	var leader *workloads.ReplicaRole
+	unique := false
+	for i, role := range its.Spec.Roles {
+		switch {
+		case leader == nil || role.UpdatePriority > leader.UpdatePriority:
+			leader, unique = &its.Spec.Roles[i], true
+		case role.UpdatePriority == leader.UpdatePriority:
+			unique = false
+		}
+	}
+	if !unique {
+		return ""
+	}
+	return strings.ToLower(leader.Name)
+}
+
+func getLeaderPDBName(itsName string) string {
+	return strings.Join([]string{itsName, "leader"}, "-")
+}
+
+func getNonVoterPDBName(itsName string) string {
+	return strings.Join([]string{itsName, "nonvoter"}, "-")
+}
+
+// switchoverCandidates returns the instances that the leader can be switched over to, which are ready and not on
+// the excluded nodes, ordered by preference: the voters first, and then the roles with higher update priority.
+func switchoverCandidates(its *workloads.InstanceSet, leader *corev1.Pod, pods []*corev1.Pod, excludedNodes ...string) []*corev1.Pod {
+	roles := make(map[string]workloads.ReplicaRole)
+	for _, role := range its.Spec.Roles {
+		roles[strings.ToLower(role.Name)] = role
+	}
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if _, ok := roles[getRoleName(pod)]; !ok || getRoleName(pod) == getRoleName(leader) {
+			continue
+		}
+		if pod.DeletionTimestamp != nil || isSurgeInstance(pod) || !intctrlutil.IsPodReady(pod) {
+			continue
+		}
+		if len(pod.Spec.NodeName) == 0 || slices.Contains(excludedNodes, pod.Spec.NodeName) {
+			continue
+		}
+		candidates = append(candidates, pod)
+	}
+	slices.SortStableFunc(candidates, func(a, b *corev1.Pod) int {
+		ra, rb := roles[getRoleName(a)], roles[getRoleName(b)]
+		switch {
+		case ra.ParticipatesInQuorum != rb.ParticipatesInQuorum:
+			if ra.ParticipatesInQuorum {
+				return -1
+			}
+			return 1
+		case ra.UpdatePriority != rb.UpdatePriority:
+			return cmp.Compare(rb.UpdatePriority, ra.UpdatePriority)
+		default:
+			return strings.Compare(a.Name, b.Name)
+		}
+	})
+	return candidates
+}
+
+// SwitchoverLeadersOnNode switches over the leaders on the node which is being drained, before they are evicted.
+// The leader is switched over to a candidate on another schedulable node, and is skipped if there is no such candidate,
+// its PDB does not protect it either in that case.
+// The switchover in flight is not sent again until it times out.
+// It returns the number of the leaders which are still on the node and being switched over.
+func SwitchoverLeadersOnNode(ctx context.Context, cli client.Client, logger logr.Logger, node *corev1.Node) (int, error) {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName},
+		client.HasLabels{WorkloadsInstanceLabelKey, constant.RoleLabelKey}, inDataContextUnspecified()); err != nil {
+		return 0, err
+	}
+
+	unschedulable := map[string]bool{node.Name: true}
+	excludedNodes := func(candidates []*corev1.Pod) ([]string, error) {
+		for _, candidate := range candidates {
+			nodeName := candidate.Spec.NodeName
+			if _, ok := unschedulable[nodeName]; ok {
+				continue
+			}
+			n := &corev1.Node{}
+			if err := cli.Get(ctx, types.NamespacedName{Name: nodeName}, n); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				unschedulable[nodeName] = true
+				continue
+			}
+			unschedulable[nodeName] = n.Spec.Unschedulable
+		}
+		var nodes []string
+		for name, ok := range unschedulable {
+			if ok {
+				nodes = append(nodes, name)
+			}
+		}
+		return nodes, nil
+	}
+
+	leaders := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != node.Name || pod.DeletionTimestamp != nil {
+			continue
+		}
+		its := &workloads.InstanceSet{}
+		itsKey := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[WorkloadsInstanceLabelKey]}
+		if err := cli.Get(ctx, itsKey, its, inDataContextUnspecified()); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return leaders, err
+		}
+		leader := pdbLeaderRole(its)
+		if len(leader) == 0 || getRoleName(pod) != leader {
+			continue
+		}
+
+		var itsPods []*corev1.Pod
+		for j := range pods.Items {
+			if pods.Items[j].Namespace == its.Namespace && pods.Items[j].Labels[WorkloadsInstanceLabelKey] == its.Name {
+				itsPods = append(itsPods, &pods.Items[j])
+			}
+		}
+		nodes, err := excludedNodes(switchoverCandidates(its, pod, itsPods, node.Name))
+		if err != nil {
+			return leaders, err
+		}
+		candidates := switchoverCandidates(its, pod, itsPods, nodes...)
+		if len(candidates) == 0 {
+			logger.Info("skip the switchover since there is no candidate on the other schedulable nodes", "pod", pod.Name)
+			continue
+		}
+
+		leaders++
+		if switchoverInFlight(its, pod, time.Now()) {
+			logger.V(1).Info("the switchover of the leader is in flight", "pod", pod.Name)
+			continue
+		}
+		if err := switchoverTo(ctx, logger.WithValues("node", node.Name), its, pod, candidates[0].Name); err != nil {
+			return leaders, err
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[drainSwitchoverAnnotationKey] = time.Now().Format(time.RFC3339)
+		if err := cli.Patch(ctx, pod, patch); err != nil {
+			return leaders, err
+		}
+	}
+	return leaders, nil
+}
+
+// switchoverInFlight checks whether the leader has been switched over for the drain and is waiting for the role
+// to be changed, the switchover is not sent again until it times out.
+func switchoverInFlight(its *workloads.InstanceSet, pod *corev1.Pod, now time.Time) bool {
+	startTime, err := time.Parse(time.RFC3339, pod.Annotations[drainSwitchoverAnnotationKey])
+	if err != nil {
+		return false
+	}
+	timeout := drainSwitchoverTimeout
+	if action := its.Spec.MembershipReconfiguration.Switchover; action != nil {
+		timeout = max(timeout, time.Duration(action.TimeoutSeconds)*time.Second)
+	}
+	return now.Before(startTime.Add(timeout))
+}
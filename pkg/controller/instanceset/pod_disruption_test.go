@@ -0,0 +1,216 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("pod disruption budget test", func() {
+	buildPods := func(roles ...string) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i, role := range roles {
+			pod := builder.NewPodBuilder(namespace, fmt.Sprintf("%s-%d", name, i)).
+				AddLabels(constant.RoleLabelKey, role).
+				SetNodeName(types.NodeName(fmt.Sprintf("node-%d", i))).
+				GetObject()
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			pods = append(pods, pod)
+		}
+		return pods
+	}
+
+	BeforeEach(func() {
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetReplicas(3).
+			SetRoles(roles).
+			GetObject()
+		its.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+			Switchover: &kbappsv1.StepAction{},
+		}
+	})
+
+	Context("pdbLeaderRole", func() {
+		It("should work well", func() {
+			By("the role with the highest update priority")
+			Expect(pdbLeaderRole(its)).Should(Equal("leader"))
+
+			By("no switchover action")
+			its.Spec.MembershipReconfiguration = nil
+			Expect(pdbLeaderRole(its)).Should(BeEmpty())
+
+			By("single replica")
+			its.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{Switchover: &kbappsv1.StepAction{}}
+			its.Spec.Replicas = ptr.To[int32](1)
+			Expect(pdbLeaderRole(its)).Should(BeEmpty())
+
+			By("the highest update priority is not unique")
+			its.Spec.Replicas = ptr.To[int32](3)
+			its.Spec.Roles = []workloads.ReplicaRole{
+				{Name: "primary", UpdatePriority: 2},
+				{Name: "secondary", UpdatePriority: 2},
+			}
+			Expect(pdbLeaderRole(its)).Should(BeEmpty())
+		})
+	})
+
+	Context("buildPodDisruptionBudgets", func() {
+		It("builds the PDB without roles", func() {
+			its.Spec.Roles = nil
+			pdbs := buildPodDisruptionBudgets(its, buildPods("", "", ""))
+			Expect(pdbs).Should(HaveLen(1))
+			Expect(pdbs[0].Name).Should(Equal(name))
+			Expect(pdbs[0].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(2))))
+			Expect(pdbs[0].Spec.Selector.MatchExpressions).Should(BeEmpty())
+		})
+
+		It("builds the PDBs for the leader, the voters and the non-voters", func() {
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader", "follower", "follower", "learner"))
+			Expect(pdbs).Should(HaveLen(3))
+
+			Expect(pdbs[0].Name).Should(Equal(getLeaderPDBName(name)))
+			Expect(pdbs[0].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+			Expect(pdbs[0].Spec.Selector.MatchExpressions).Should(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      constant.RoleLabelKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"leader"},
+			}))
+
+			// 3 voters, at most one follower is unavailable
+			Expect(pdbs[1].Name).Should(Equal(name))
+			Expect(pdbs[1].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+			Expect(pdbs[1].Spec.Selector.MatchExpressions).Should(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      constant.RoleLabelKey,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"leader", "logger", "learner"},
+			}))
+
+			Expect(pdbs[2].Name).Should(Equal(getNonVoterPDBName(name)))
+			Expect(pdbs[2].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(0))))
+			Expect(pdbs[2].Spec.Selector.MatchExpressions).Should(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      constant.RoleLabelKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"logger", "learner"},
+			}))
+		})
+
+		It("keeps the quorum of the voters", func() {
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader", "follower"))
+			Expect(pdbs).Should(HaveLen(3))
+			// 2 voters, no voter can be unavailable
+			Expect(pdbs[1].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+		})
+
+		It("protects the instances without role along with the voters", func() {
+			pdbs := buildPodDisruptionBudgets(its, buildPods("leader", "follower", "follower", ""))
+			Expect(pdbs).Should(HaveLen(3))
+			// the instance without role is selected by NotIn, at most one of the followers and it is unavailable
+			Expect(pdbs[1].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(2))))
+			Expect(pdbs[1].Spec.Selector.MatchExpressions[0].Operator).Should(Equal(metav1.LabelSelectorOpNotIn))
+		})
+
+		It("does not protect the leader without a candidate", func() {
+			pods := buildPods("leader", "follower", "follower")
+			pods[1].Status.Conditions = nil
+			pods[2].Spec.NodeName = pods[0].Spec.NodeName
+			pdbs := buildPodDisruptionBudgets(its, pods)
+			Expect(pdbs[0].Name).Should(Equal(getLeaderPDBName(name)))
+			Expect(pdbs[0].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(0))))
+		})
+
+		It("does not count the surge instances", func() {
+			pods := buildPods("leader", "follower", "follower", "follower")
+			pods[3].Labels[surgeForLabelKey] = pods[1].Name
+			pdbs := buildPodDisruptionBudgets(its, pods)
+			Expect(pdbs[1].Name).Should(Equal(name))
+			Expect(pdbs[1].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+		})
+
+		It("builds the PDBs without voters", func() {
+			its.Spec.Roles = []workloads.ReplicaRole{
+				{Name: "primary", UpdatePriority: 2},
+				{Name: "secondary", UpdatePriority: 1},
+			}
+			pdbs := buildPodDisruptionBudgets(its, buildPods("primary", "secondary", "secondary"))
+			Expect(pdbs).Should(HaveLen(2))
+			Expect(pdbs[0].Name).Should(Equal(getLeaderPDBName(name)))
+			Expect(pdbs[1].Name).Should(Equal(name))
+			Expect(pdbs[1].Spec.MinAvailable).Should(Equal(ptr.To(intstr.FromInt32(1))))
+			Expect(pdbs[1].Spec.Selector.MatchExpressions).Should(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      constant.RoleLabelKey,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"primary"},
+			}))
+		})
+	})
+
+	Context("switchoverCandidates", func() {
+		It("should work well", func() {
+			pods := buildPods("leader", "learner", "follower", "follower", "follower", "")
+			pods[3].Spec.NodeName = pods[0].Spec.NodeName
+			pods[4].Status.Conditions = nil
+
+			candidates := switchoverCandidates(its, pods[0], pods, pods[0].Spec.NodeName)
+			Expect(candidates).Should(HaveLen(2))
+			// the voters first
+			Expect(candidates[0].Name).Should(Equal(pods[2].Name))
+			Expect(candidates[1].Name).Should(Equal(pods[1].Name))
+
+			By("excludes the other nodes")
+			candidates = switchoverCandidates(its, pods[0], pods, pods[0].Spec.NodeName, pods[2].Spec.NodeName)
+			Expect(candidates).Should(HaveLen(1))
+			Expect(candidates[0].Name).Should(Equal(pods[1].Name))
+		})
+	})
+
+	Context("switchoverInFlight", func() {
+		It("should work well", func() {
+			pod := buildPods("leader")[0]
+			now := time.Now()
+			Expect(switchoverInFlight(its, pod, now)).Should(BeFalse())
+
+			By("the switchover has been sent")
+			pod.Annotations = map[string]string{drainSwitchoverAnnotationKey: now.Add(-time.Minute).Format(time.RFC3339)}
+			Expect(switchoverInFlight(its, pod, now)).Should(BeTrue())
+
+			By("the switchover times out")
+			Expect(switchoverInFlight(its, pod, now.Add(drainSwitchoverTimeout))).Should(BeFalse())
+
+			By("the timeout of the switchover action")
+			its.Spec.MembershipReconfiguration.Switchover.TimeoutSeconds = int32((drainSwitchoverTimeout + 2*time.Minute).Seconds())
+			Expect(switchoverInFlight(its, pod, now.Add(drainSwitchoverTimeout))).Should(BeTrue())
+		})
+	})
+})
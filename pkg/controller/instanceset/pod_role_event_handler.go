@@ -72,6 +72,11 @@ const (
 var roleMessageRegex = regexp.MustCompile(`Readiness probe failed: .*({.*})`)
 
 func (h *PodRoleEventHandler) Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder, event *corev1.Event) error {
+	// the role changes are delivered by the watch stream of the pod, the events are the fallback only
+	if h.isKBAgentProbeEvent(event) && isRoleStreamed(event.InvolvedObject.UID) {
+		return nil
+	}
+
 	// HACK: to support kb-agent probe event
 	event = h.transformKBAgentProbeEvent(reqCtx.Log, event)
 
@@ -102,8 +107,12 @@ func (h *PodRoleEventHandler) Handle(cli client.Client, reqCtx intctrlutil.Reque
 	return cli.Patch(reqCtx.Ctx, event, patch, inDataContextUnspecified())
 }
 
+func (h *PodRoleEventHandler) isKBAgentProbeEvent(event *corev1.Event) bool {
+	return event.ReportingController == proto.ProbeEventReportingController && event.Reason == roleProbeName
+}
+
 func (h *PodRoleEventHandler) transformKBAgentProbeEvent(logger logr.Logger, event *corev1.Event) *corev1.Event {
-	if event.ReportingController != proto.ProbeEventReportingController || event.Reason != "roleProbe" {
+	if !h.isKBAgentProbeEvent(event) {
 		return event
 	}
 
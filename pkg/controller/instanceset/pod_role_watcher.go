@@ -0,0 +1,272 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	roleProbeName = "roleProbe"
+
+	defaultRoleWatchResyncPeriod = 30 * time.Second
+	roleWatchPollTimeout         = 30 * time.Second
+	roleWatchRetryInterval       = 5 * time.Second
+)
+
+// streamedPods records the pods whose role changes are delivered by the watch stream currently,
+// the role probe events of them are redundant and ignored.
+var streamedPods sync.Map
+
+func isRoleStreamed(uid types.UID) bool {
+	_, ok := streamedPods.Load(uid)
+	return ok
+}
+
+// PodRoleWatcher watches the role changes pushed by the kb-agent of the pods through long-polling.
+// It delivers the role transitions with sequence numbers, which is faster and more reliable than the events
+// under the rate limits of the API server. The events are kept as the fallback when the stream is unavailable.
+//
+// Each watch holds a goroutine and a long-polling connection to the pod, so at most MaxConcurrency pods are watched,
+// and the role changes of the other pods are reported through the events only.
+type PodRoleWatcher struct {
+	Client         client.Client
+	ResyncPeriod   time.Duration
+	MaxConcurrency int
+
+	mutex    sync.Mutex
+	watchers map[types.UID]*podRoleWatch
+}
+
+var _ manager.Runnable = &PodRoleWatcher{}
+var _ manager.LeaderElectionRunnable = &PodRoleWatcher{}
+
+func (w *PodRoleWatcher) NeedLeaderElection() bool {
+	return true
+}
+
+func (w *PodRoleWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("pod-role-watcher")
+	period := w.ResyncPeriod
+	if period <= 0 {
+		period = defaultRoleWatchResyncPeriod
+	}
+	w.watchers = map[types.UID]*podRoleWatch{}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		if err := w.resync(ctx, logger); err != nil {
+			logger.Error(err, "resync the watched pods failed")
+		}
+		select {
+		case <-ctx.Done():
+			w.stopAll()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// resync starts to watch the new pods with the role probe defined, and stops the watches of the pods gone.
+func (w *PodRoleWatcher) resync(ctx context.Context, logger logr.Logger) error {
+	pods := &corev1.PodList{}
+	if err := w.Client.List(ctx, pods, client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName},
+		client.HasLabels{WorkloadsInstanceLabelKey}, inDataContextUnspecified()); err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	watchable := map[types.UID]*corev1.Pod{}
+	for i := range pods.Items {
+		if isRoleWatchable(&pods.Items[i]) {
+			watchable[pods.Items[i].UID] = &pods.Items[i]
+		}
+	}
+	// stop the watches of the pods gone first, to make room for the new pods
+	for uid, watcher := range w.watchers {
+		if _, ok := watchable[uid]; !ok {
+			watcher.stop()
+			delete(w.watchers, uid)
+		}
+	}
+	skipped := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if _, ok := watchable[pod.UID]; !ok {
+			continue
+		}
+		if _, ok := w.watchers[pod.UID]; ok {
+			continue
+		}
+		if w.MaxConcurrency > 0 && len(w.watchers) >= w.MaxConcurrency {
+			skipped++
+			continue
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		watcher := &podRoleWatch{
+			cli:    w.Client,
+			logger: logger.WithValues("pod", types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}),
+			pod:    pod.DeepCopy(),
+			cancel: cancel,
+		}
+		w.watchers[pod.UID] = watcher
+		go watcher.run(watchCtx)
+	}
+	if skipped > 0 {
+		logger.Info("the number of the watched pods reaches the max concurrency, the others fallback to the events",
+			"maxConcurrency", w.MaxConcurrency, "skipped", skipped)
+	}
+	return nil
+}
+
+func (w *PodRoleWatcher) stopAll() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for uid, watcher := range w.watchers {
+		watcher.stop()
+		delete(w.watchers, uid)
+	}
+}
+
+func isRoleWatchable(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || len(pod.Status.PodIP) == 0 {
+		return false
+	}
+	return kbagt.IsProbeDefined(pod, roleProbeName)
+}
+
+// podRoleWatch long-polls the role probe events from the kb-agent of a pod.
+type podRoleWatch struct {
+	cli    client.Client
+	logger logr.Logger
+	pod    *corev1.Pod
+	cancel context.CancelFunc
+}
+
+func (w *podRoleWatch) stop() {
+	w.cancel()
+	streamedPods.Delete(w.pod.UID)
+}
+
+func (w *podRoleWatch) run(ctx context.Context) {
+	defer streamedPods.Delete(w.pod.UID)
+
+	var (
+		epoch string
+		since uint64
+	)
+	for ctx.Err() == nil {
+		rsp, err := w.poll(ctx, since)
+		if err != nil {
+			if ctx.Err() == nil {
+				// fallback to the events until the stream is available again
+				streamedPods.Delete(w.pod.UID)
+				w.logger.Info("watch the role of pod failed, retry later", "error", err.Error())
+				w.sleep(ctx, roleWatchRetryInterval)
+			}
+			continue
+		}
+		if rsp.Epoch != epoch {
+			epoch = rsp.Epoch
+			if since > 0 {
+				// the kb-agent has been restarted, watch from the beginning of the new epoch
+				since = 0
+				continue
+			}
+		}
+		streamedPods.Store(w.pod.UID, true)
+
+		for _, event := range rsp.Events {
+			if err = w.handle(ctx, event); err != nil {
+				break
+			}
+			since = event.Seq
+		}
+		if err != nil {
+			w.logger.Info("handle the role change of pod failed, retry later", "error", err.Error())
+			w.sleep(ctx, roleWatchRetryInterval)
+			continue
+		}
+		since = max(since, rsp.Seq)
+	}
+}
+
+func (w *podRoleWatch) poll(ctx context.Context, since uint64) (*proto.ProbeWatchResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, roleWatchPollTimeout+roleWatchRetryInterval)
+	defer cancel()
+	return lifecycle.WatchProbe(ctx, w.cli, w.pod.Namespace, w.pod.Labels[constant.AppInstanceLabelKey],
+		w.pod.Labels[constant.KBAppComponentLabelKey], w.pod, roleProbeName, since, roleWatchPollTimeout)
+}
+
+// handle handles the role probe event as the one reported through the events.
+func (w *podRoleWatch) handle(ctx context.Context, event proto.ProbeWatchEvent) error {
+	msg, err := json.Marshal(event.Event)
+	if err != nil {
+		return err
+	}
+	// the version of the role snapshot is the time of the role change in the kb-agent
+	k8sEvent := &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: w.pod.Namespace,
+			Name:      w.pod.Name,
+			UID:       w.pod.UID,
+			FieldPath: proto.ProbeEventFieldPath,
+		},
+		Reason:              roleProbeName,
+		Message:             string(msg),
+		EventTime:           metav1.NewMicroTime(event.Timestamp),
+		ReportingController: proto.ProbeEventReportingController,
+	}
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx: ctx,
+		Log: w.logger.WithValues("seq", event.Seq),
+	}
+	h := &PodRoleEventHandler{}
+	_, err = handleRoleChangedEvent(w.cli, reqCtx, nil, h.transformKBAgentProbeEvent(reqCtx.Log, k8sEvent))
+	return err
+}
+
+func (w *podRoleWatch) sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("pod role watcher test", func() {
+	var (
+		roleProbePod = func() *corev1.Pod {
+			probes, _ := json.Marshal([]proto.Probe{{Instance: name, Action: roleProbeName}})
+			p := builder.NewPodBuilder(namespace, getPodName(name, 0)).
+				SetUID(uid).
+				AddLabels(constant.AppInstanceLabelKey, name, WorkloadsInstanceLabelKey, name).
+				AddContainer(corev1.Container{
+					Name: kbagt.ContainerName,
+					Env: []corev1.EnvVar{
+						{
+							Name:  "KB_AGENT_PROBE",
+							Value: string(probes),
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          kbagt.DefaultHTTPPortName,
+							ContainerPort: kbagt.DefaultHTTPPort,
+						},
+					},
+				}).
+				GetObject()
+			p.Status.Phase = corev1.PodRunning
+			p.Status.PodIP = "127.0.0.1"
+			return p
+		}
+
+		expectRoleUpdated = func(role string, version string) {
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &corev1.Pod{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, p *corev1.Pod, _ ...client.GetOption) error {
+					p.Namespace = objKey.Namespace
+					p.Name = objKey.Name
+					p.UID = uid
+					p.Labels = map[string]string{
+						constant.AppInstanceLabelKey: name,
+						WorkloadsInstanceLabelKey:    name,
+					}
+					return nil
+				}).Times(1)
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &workloads.InstanceSet{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objKey client.ObjectKey, its *workloads.InstanceSet, _ ...client.GetOption) error {
+					its.Namespace = objKey.Namespace
+					its.Name = objKey.Name
+					its.Spec.Roles = roles
+					return nil
+				}).Times(1)
+			k8sMock.EXPECT().
+				Update(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, pd *corev1.Pod, _ ...client.UpdateOption) error {
+					Expect(pd.Labels[RoleLabelKey]).Should(Equal(role))
+					Expect(pd.Annotations[constant.LastRoleSnapshotVersionAnnotationKey]).Should(Equal(version))
+					return nil
+				}).Times(1)
+		}
+	)
+
+	Context("watchable", func() {
+		It("should work well", func() {
+			p := roleProbePod()
+			Expect(isRoleWatchable(p)).Should(BeTrue())
+
+			By("not running")
+			p.Status.Phase = corev1.PodPending
+			Expect(isRoleWatchable(p)).Should(BeFalse())
+
+			By("no role probe")
+			p = roleProbePod()
+			p.Spec.Containers[0].Env = nil
+			Expect(isRoleWatchable(p)).Should(BeFalse())
+		})
+	})
+
+	Context("handle", func() {
+		It("should work well", func() {
+			watcher := &podRoleWatch{
+				cli:    k8sMock,
+				logger: logger,
+				pod:    roleProbePod(),
+			}
+			timestamp := time.Now()
+			expectRoleUpdated("leader", strconv.FormatInt(timestamp.UnixMicro(), 10))
+			Expect(watcher.handle(ctx, proto.ProbeWatchEvent{
+				Seq:       1,
+				Timestamp: timestamp,
+				Event: proto.ProbeEvent{
+					Instance: name,
+					Probe:    roleProbeName,
+					Output:   []byte("leader"),
+				},
+			})).Should(Succeed())
+		})
+	})
+
+	Context("run", func() {
+		It("should work well", func() {
+			mockController := gomock.NewController(GinkgoT())
+			agentMock := kbacli.NewMockClient(mockController)
+			kbacli.SetMockClient(agentMock, nil)
+			defer kbacli.UnsetMockClient()
+
+			timestamp := time.Now()
+			first := agentMock.EXPECT().
+				WatchProbe(gomock.Any(), roleProbeName, uint64(0), gomock.Any()).
+				Return(proto.ProbeWatchResponse{
+					Epoch: "e1",
+					Seq:   1,
+					Events: []proto.ProbeWatchEvent{
+						{
+							Seq:       1,
+							Timestamp: timestamp,
+							Event:     proto.ProbeEvent{Probe: roleProbeName, Output: []byte("leader")},
+						},
+					},
+				}, nil).Times(1)
+			agentMock.EXPECT().
+				WatchProbe(gomock.Any(), roleProbeName, uint64(1), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ string, _ uint64, _ time.Duration) (proto.ProbeWatchResponse, error) {
+					<-ctx.Done()
+					return proto.ProbeWatchResponse{}, ctx.Err()
+				}).After(first).MinTimes(1)
+			expectRoleUpdated("leader", strconv.FormatInt(timestamp.UnixMicro(), 10))
+
+			p := roleProbePod()
+			watchCtx, cancel := context.WithCancel(ctx)
+			watcher := &podRoleWatch{
+				cli:    k8sMock,
+				logger: logger,
+				pod:    p,
+				cancel: cancel,
+			}
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				watcher.run(watchCtx)
+			}()
+
+			Eventually(func() bool { return isRoleStreamed(p.UID) }).Should(BeTrue())
+
+			By("the role probe events are ignored when the role is streamed")
+			event := builder.NewEventBuilder(namespace, "foo").
+				SetInvolvedObject(corev1.ObjectReference{Kind: "Pod", Namespace: p.Namespace, Name: p.Name, UID: p.UID}).
+				SetReason(roleProbeName).
+				SetMessage("{}").
+				GetObject()
+			event.ReportingController = proto.ProbeEventReportingController
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx, Log: logger}
+			Expect((&PodRoleEventHandler{}).Handle(k8sMock, reqCtx, nil, event)).Should(Succeed())
+
+			watcher.stop()
+			Eventually(done).Should(BeClosed())
+			Expect(isRoleStreamed(p.UID)).Should(BeFalse())
+		})
+	})
+
+	Context("resync", func() {
+		It("watches the pods up to the max concurrency", func() {
+			mockController := gomock.NewController(GinkgoT())
+			agentMock := kbacli.NewMockClient(mockController)
+			kbacli.SetMockClient(agentMock, nil)
+			defer kbacli.UnsetMockClient()
+			agentMock.EXPECT().
+				WatchProbe(gomock.Any(), roleProbeName, uint64(0), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ string, _ uint64, _ time.Duration) (proto.ProbeWatchResponse, error) {
+					<-ctx.Done()
+					return proto.ProbeWatchResponse{}, ctx.Err()
+				}).AnyTimes()
+
+			pod0, pod1 := roleProbePod(), roleProbePod()
+			pod1.Name = getPodName(name, 1)
+			pod1.UID = uid + "-1"
+			k8sMock.EXPECT().
+				List(gomock.Any(), &corev1.PodList{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, list *corev1.PodList, _ ...client.ListOption) error {
+					list.Items = []corev1.Pod{*pod0, *pod1}
+					return nil
+				}).Times(1)
+
+			watcher := &PodRoleWatcher{Client: k8sMock, MaxConcurrency: 1, watchers: map[types.UID]*podRoleWatch{}}
+			defer watcher.stopAll()
+			Expect(watcher.resync(ctx, logger)).Should(Succeed())
+			Expect(watcher.watchers).Should(HaveLen(1))
+			Expect(watcher.watchers).Should(HaveKey(pod0.UID))
+		})
+	})
+})
@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -28,9 +29,10 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
-// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, etc.
+// assistantObjectReconciler manages non-workload objects, such as Service, ConfigMap, PodDisruptionBudget, etc.
 type assistantObjectReconciler struct{}
 
 func NewAssistantObjectReconciler() kubebuilderx.Reconciler {
@@ -60,6 +62,15 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (ku
 		headLessSvc := buildHeadlessSvc(*its, labels, headlessSelectors)
 		objects = append(objects, headLessSvc)
 	}
+	if viper.GetBool(FeatureGatePodDisruptionBudget) {
+		var pods []*corev1.Pod
+		for _, object := range tree.List(&corev1.Pod{}) {
+			pods = append(pods, object.(*corev1.Pod))
+		}
+		for _, pdb := range buildPodDisruptionBudgets(its, pods) {
+			objects = append(objects, pdb)
+		}
+	}
 	for _, object := range objects {
 		if err := intctrlutil.SetOwnership(its, object, model.GetScheme(), finalizer); err != nil {
 			return kubebuilderx.Continue, err
@@ -76,12 +87,13 @@ func (a *assistantObjectReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (ku
 	}
 	oldSnapshot := make(map[model.GVKNObjKey]client.Object)
 	svcList := tree.List(&corev1.Service{})
+	pdbList := tree.List(&policyv1.PodDisruptionBudget{})
 	cmList := tree.List(&corev1.ConfigMap{})
 	cmListFiltered, err := filterTemplate(cmList, its.Annotations)
 	if err != nil {
 		return kubebuilderx.Continue, err
 	}
-	for _, objectList := range [][]client.Object{svcList, cmListFiltered} {
+	for _, objectList := range [][]client.Object{svcList, pdbList, cmListFiltered} {
 		for _, object := range objectList {
 			name, err := model.GetGVKName(object)
 			if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("assistant object reconciler test", func() {
@@ -63,5 +64,27 @@ var _ = Describe("assistant object reconciler test", func() {
 			Expect(ok).Should(BeTrue())
 
 		})
+
+		It("should build the pod disruption budgets", func() {
+			viper.Set(FeatureGatePodDisruptionBudget, true)
+			defer viper.Set(FeatureGatePodDisruptionBudget, false)
+
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			reconciler = NewAssistantObjectReconciler()
+			res, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			// desired: svc: "bar-headless", pdb: "bar", "bar-nonvoter"
+			objects := tree.GetSecondaryObjects()
+			Expect(objects).Should(HaveLen(3))
+			for _, pdbName := range []string{name, getNonVoterPDBName(name)} {
+				pdb := builder.NewPodDisruptionBudgetBuilder(namespace, pdbName).GetObject()
+				gvkName, err := model.GetGVKName(pdb)
+				Expect(err).Should(BeNil())
+				_, ok := objects[*gvkName]
+				Expect(ok).Should(BeTrue())
+			}
+		})
 	})
 })
@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	"encoding/json"
+	"slices"
 	"sort"
 	"time"
 
@@ -56,9 +57,14 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	its, _ := tree.GetRoot().(*workloads.InstanceSet)
 	// 1. get all pods
 	pods := tree.List(&corev1.Pod{})
-	var podList []*corev1.Pod
+	var podList, surgePodList []*corev1.Pod
 	for _, object := range pods {
 		pod, _ := object.(*corev1.Pod)
+		// the surge instances are not counted in the replicas, but they are members during the update
+		if isSurgeInstance(pod) {
+			surgePodList = append(surgePodList, pod)
+			continue
+		}
 		podList = append(podList, pod)
 	}
 	// 2. calculate status summary
@@ -191,7 +197,7 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	}
 
 	// 4. set members status
-	setMembersStatus(its, podList)
+	setMembersStatus(its, append(slices.Clone(podList), surgePodList...))
 
 	// 5. set instance status
 	setInstanceStatus(its, podList)
@@ -20,11 +20,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -86,9 +88,14 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	oldNameSet := sets.New[string]()
 	oldInstanceMap := make(map[string]*corev1.Pod)
 	var oldPodList []*corev1.Pod
+	surgePods := make(map[string]*corev1.Pod)
 	for _, object := range tree.List(&corev1.Pod{}) {
-		oldNameSet.Insert(object.GetName())
 		pod, _ := object.(*corev1.Pod)
+		if isSurgeInstance(pod) {
+			surgePods[pod.Labels[surgeForLabelKey]] = pod
+			continue
+		}
+		oldNameSet.Insert(object.GetName())
 		oldInstanceMap[object.GetName()] = pod
 		oldPodList = append(oldPodList, pod)
 	}
@@ -109,6 +116,10 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	if err != nil {
 		return kubebuilderx.Continue, err
 	}
+	maxSurge, err := parseMaxSurge(its.Spec.InstanceUpdateStrategy, len(oldPodList))
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
 	currentUnavailable := 0
 	for _, pod := range oldPodList {
 		if !intctrlutil.IsPodAvailable(pod, its.Spec.MinReadySeconds) {
@@ -149,6 +160,26 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		}
 	}
 
+	// retire the surge instances which are no longer needed
+	for podName, surgePod := range surgePods {
+		needReplace := false
+		if pod, ok := oldInstanceMap[podName]; ok && maxSurge > 0 {
+			updatePolicy, err := getPodUpdatePolicy(its, pod)
+			if err != nil {
+				return kubebuilderx.Continue, err
+			}
+			needReplace = updatePolicy == RecreatePolicy
+		}
+		retired, err := retireSurgeInstance(tree, its, oldInstanceMap[podName], surgePod, needReplace)
+		if err != nil {
+			return kubebuilderx.Continue, err
+		}
+		if retired {
+			delete(surgePods, podName)
+		}
+	}
+	surging := len(surgePods)
+
 	canBeUpdated := func(pod *corev1.Pod) bool {
 		if !isImageMatched(pod) {
 			tree.Logger.Info(fmt.Sprintf("InstanceSet %s/%s blocks on update as the pod %s does not have the same image(s) in the status and in the spec", its.Namespace, its.Name, pod.Name))
@@ -224,6 +255,22 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 				return kubebuilderx.Continue, err
 			}
 			updatingPods++
+		} else if updatePolicy == RecreatePolicy && maxSurge > 0 {
+			surgePod := surgePods[pod.Name]
+			if surgePod == nil {
+				if surging >= maxSurge {
+					break
+				}
+				surging++
+			}
+			// the pod is counted as unavailable only after it is deleted, the surge instance takes its place before that
+			deleted, err := replaceBySurgeInstance(tree, its, pod, surgePod, nameToTemplateMap[pod.Name])
+			if err != nil {
+				return kubebuilderx.Continue, err
+			}
+			if deleted {
+				updatingPods++
+			}
 		} else if updatePolicy == RecreatePolicy {
 			if !isTerminating(pod) {
 				if err = r.switchover(tree, its, pod); err != nil {
@@ -259,11 +306,21 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 }
 
 func (r *updateReconciler) switchover(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod *corev1.Pod) error {
+	return switchover(tree.Context, tree.Logger, its, pod)
+}
+
+// switchover calls the switchover action to transfer the role of the pod to another one, if defined.
+func switchover(ctx context.Context, logger logr.Logger, its *workloads.InstanceSet, pod *corev1.Pod) error {
+	return switchoverTo(ctx, logger, its, pod, "")
+}
+
+// switchoverTo switches over the pod to the candidate, the candidate is chosen by the action if not specified.
+func switchoverTo(ctx context.Context, logger logr.Logger, its *workloads.InstanceSet, pod *corev1.Pod, candidate string) error {
 	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.Switchover == nil {
 		return nil
 	}
 
-	clusterName, err := r.clusterName(its)
+	clusterName, err := getClusterName(its)
 	if err != nil {
 		return err
 	}
@@ -285,14 +342,14 @@ func (r *updateReconciler) switchover(tree *kubebuilderx.ObjectTree, its *worklo
 		return err
 	}
 
-	err = lfa.Switchover(tree.Context, nil, nil, "")
+	err = lfa.Switchover(ctx, nil, nil, candidate)
 	if err != nil {
 		if errors.Is(err, lifecycle.ErrActionNotDefined) {
 			return nil
 		}
 		return err
 	}
-	tree.Logger.Info("successfully call switchover action for pod", "pod", pod.Name)
+	logger.Info("successfully call switchover action for pod", "pod", pod.Name, "candidate", candidate)
 	return nil
 }
 
@@ -316,7 +373,7 @@ func (r *updateReconciler) reconfigureConfig(tree *kubebuilderx.ObjectTree, its
 		return nil // skip
 	}
 
-	clusterName, err := r.clusterName(its)
+	clusterName, err := getClusterName(its)
 	if err != nil {
 		return err
 	}
@@ -417,7 +474,7 @@ func (r *updateReconciler) isConfigUpdated(its *workloads.InstanceSet, pod *core
 	return config.Generation <= 0
 }
 
-func (r *updateReconciler) clusterName(its *workloads.InstanceSet) (string, error) {
+func getClusterName(its *workloads.InstanceSet) (string, error) {
 	var clusterName string
 	if its.Labels != nil {
 		clusterName = its.Labels[constant.AppInstanceLabelKey]
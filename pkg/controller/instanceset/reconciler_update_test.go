@@ -357,6 +357,95 @@ var _ = Describe("update reconciler test", func() {
 			expectUpdatedPods(tree, []string{"bar-1"})
 		})
 
+		It("replaces pods by surge instances", func() {
+			// update order: bar-2, bar-1, bar-0
+			tree := kubebuilderx.NewObjectTree()
+			its.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			maxSurge := intstr.FromInt32(1)
+			its.Spec.InstanceUpdateStrategy = &workloads.InstanceUpdateStrategy{
+				RollingUpdate: &workloads.RollingUpdate{
+					MaxSurge: &maxSurge,
+				},
+			}
+			tree.SetRoot(its)
+
+			prepareForUpdate(tree)
+
+			makePodAvailable := func(pod *corev1.Pod) {
+				pod.Status.Phase = corev1.PodRunning
+				pod.Status.Conditions = append(pod.Status.Conditions, getPodReadyCondition())
+			}
+			getPod := func(name string) *corev1.Pod {
+				object, err := tree.Get(builder.NewPodBuilder(namespace, name).GetObject())
+				Expect(err).Should(BeNil())
+				if object == nil {
+					return nil
+				}
+				return object.(*corev1.Pod)
+			}
+			pods := tree.List(&corev1.Pod{})
+			Expect(pods).Should(HaveLen(3))
+			for _, object := range pods {
+				pod, ok := object.(*corev1.Pod)
+				Expect(ok).Should(BeTrue())
+				// mark the all pods with old revision and available
+				pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "old-revision"
+				makePodAvailable(pod)
+			}
+
+			By("create the surge instance")
+			reconciler = NewUpdateReconciler()
+			res, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+			surgePod := getPod("bar-2-surge")
+			Expect(surgePod).ShouldNot(BeNil())
+			Expect(surgePod.Labels).Should(HaveKeyWithValue(surgeForLabelKey, "bar-2"))
+			Expect(slices.ContainsFunc(tree.List(&corev1.PersistentVolumeClaim{}), func(object client.Object) bool {
+				return object.GetLabels()[constant.KBAppPodNameLabelKey] == "bar-2-surge"
+			})).Should(BeTrue())
+
+			By("wait for the surge instance to be available")
+			res, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(getSurgePhase(surgePod)).Should(BeEmpty())
+			Expect(getPod("bar-2")).ShouldNot(BeNil())
+
+			By("the surge instance joins")
+			makePodAvailable(surgePod)
+			res, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(getSurgePhase(surgePod)).Should(Equal(surgePhaseJoined))
+			Expect(getPod("bar-2")).ShouldNot(BeNil())
+
+			By("the replaced pod leaves")
+			res, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(getSurgePhase(surgePod)).Should(Equal(surgePhaseReplaced))
+			Expect(getPod("bar-2")).Should(BeNil())
+			Expect(getPod("bar-1")).ShouldNot(BeNil())
+
+			By("retire the surge instance after the pod is recreated, and surge the next one")
+			prepareForUpdate(tree)
+			pod2 := getPod("bar-2")
+			Expect(pod2).ShouldNot(BeNil())
+			makePodAvailable(pod2)
+			reconciler = NewUpdateReconciler()
+			res, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(getPod("bar-2-surge")).Should(BeNil())
+			Expect(slices.ContainsFunc(tree.List(&corev1.PersistentVolumeClaim{}), func(object client.Object) bool {
+				return object.GetLabels()[constant.KBAppPodNameLabelKey] == "bar-2-surge"
+			})).Should(BeFalse())
+			Expect(getPod("bar-1-surge")).ShouldNot(BeNil())
+			Expect(tree.List(&corev1.Pod{})).Should(HaveLen(4))
+		})
+
 		testInplacePodVerticalScaling := func(useSubResource bool) {
 			oldFeatureGate := viper.GetBool(constant.FeatureGateInPlacePodVerticalScaling)
 			defer viper.Set(constant.FeatureGateInPlacePodVerticalScaling, oldFeatureGate)
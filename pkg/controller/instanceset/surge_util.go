@@ -0,0 +1,253 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kbappsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset/instancetemplate"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// surgePhase is the phase of a surge instance, it is recorded in the annotation of the surge instance.
+type surgePhase string
+
+const (
+	// surgePhaseJoined means the surge instance has joined the membership.
+	surgePhaseJoined surgePhase = "Joined"
+	// surgePhaseReplaced means the instance replaced by the surge instance has left the membership and been deleted.
+	surgePhaseReplaced surgePhase = "Replaced"
+)
+
+// isSurgeInstance tells whether the pod is a temporary surge instance created during the rolling update.
+func isSurgeInstance(pod client.Object) bool {
+	_, ok := pod.GetLabels()[surgeForLabelKey]
+	return ok
+}
+
+func getSurgeInstanceName(podName string) string {
+	return fmt.Sprintf("%s-surge", podName)
+}
+
+func getSurgePhase(pod *corev1.Pod) surgePhase {
+	return surgePhase(pod.Annotations[surgePhaseAnnotationKey])
+}
+
+func parseMaxSurge(updateStrategy *workloads.InstanceUpdateStrategy, totalReplicas int) (int, error) {
+	if updateStrategy == nil || updateStrategy.RollingUpdate == nil || updateStrategy.RollingUpdate.MaxSurge == nil {
+		return 0, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(updateStrategy.RollingUpdate.MaxSurge, totalReplicas, true)
+}
+
+// buildSurgeInstance builds the surge instance and its PVCs for the pod, with the updated template.
+func buildSurgeInstance(pod *corev1.Pod, template *instancetemplate.InstanceTemplateExt, its *workloads.InstanceSet) (*corev1.Pod, []*corev1.PersistentVolumeClaim, error) {
+	name := getSurgeInstanceName(pod.Name)
+	surgePod, err := buildInstancePodByTemplate(name, template, its, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	surgePod.Labels[surgeForLabelKey] = pod.Name
+	pvcs, err := buildInstancePVCByTemplate(name, template, its)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, pvc := range pvcs {
+		pvc.Labels[surgeForLabelKey] = pod.Name
+	}
+	return surgePod, pvcs, nil
+}
+
+// replaceBySurgeInstance replaces the pod by a surge instance step by step, it returns true if the pod has been deleted:
+//  1. create the surge instance.
+//  2. the surge instance joins the membership after it is available.
+//  3. the pod leaves the membership and is deleted.
+func replaceBySurgeInstance(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod, surgePod *corev1.Pod,
+	template *instancetemplate.InstanceTemplateExt) (bool, error) {
+	if surgePod == nil {
+		surgePod, pvcs, err := buildSurgeInstance(pod, template, its)
+		if err != nil {
+			return false, err
+		}
+		for _, pvc := range pvcs {
+			if err = tree.Add(pvc); err != nil {
+				return false, err
+			}
+		}
+		if err = tree.Add(surgePod); err != nil {
+			return false, err
+		}
+		tree.Logger.Info("create surge instance for pod", "pod", pod.Name, "surge", surgePod.Name)
+		return false, nil
+	}
+
+	if !intctrlutil.IsPodAvailable(surgePod, its.Spec.MinReadySeconds) || !isRoleReady(surgePod, its.Spec.Roles) {
+		return false, nil
+	}
+	switch getSurgePhase(surgePod) {
+	case surgePhaseReplaced:
+		return true, nil
+	case surgePhaseJoined:
+		if !isTerminating(pod) {
+			if err := switchover(tree.Context, tree.Logger, its, pod); err != nil {
+				return false, err
+			}
+			if err := memberLeave(tree.Context, tree.Logger, its, pod); err != nil {
+				return false, err
+			}
+			if err := tree.Delete(pod); err != nil {
+				return false, err
+			}
+		}
+		return true, setSurgePhase(tree, surgePod, surgePhaseReplaced)
+	default:
+		if err := memberJoin(tree.Context, tree.Logger, its, surgePod); err != nil {
+			return false, err
+		}
+		return false, setSurgePhase(tree, surgePod, surgePhaseJoined)
+	}
+}
+
+// retireSurgeInstance deletes the surge instance if it is no longer needed, it returns true if the surge instance is retired.
+// The surge instance is retired when the replaced pod has been recreated with the updated template, is available and has joined again,
+// or when the pod is not going to be replaced anymore, e.g. the update is reverted or the max surge is turned off.
+func retireSurgeInstance(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, pod, surgePod *corev1.Pod, needReplace bool) (bool, error) {
+	if isTerminating(surgePod) {
+		return false, nil
+	}
+	switch {
+	case getSurgePhase(surgePod) == surgePhaseReplaced:
+		if pod == nil || !intctrlutil.IsPodAvailable(pod, its.Spec.MinReadySeconds) || !isRoleReady(pod, its.Spec.Roles) {
+			return false, nil
+		}
+		updated, err := IsPodUpdated(its, pod)
+		if err != nil || !updated {
+			return false, err
+		}
+		if err = memberJoin(tree.Context, tree.Logger, its, pod); err != nil {
+			return false, err
+		}
+	case needReplace:
+		return false, nil
+	}
+
+	if getSurgePhase(surgePod) != "" {
+		if err := switchover(tree.Context, tree.Logger, its, surgePod); err != nil {
+			return false, err
+		}
+		if err := memberLeave(tree.Context, tree.Logger, its, surgePod); err != nil {
+			return false, err
+		}
+	}
+	if err := tree.Delete(surgePod); err != nil {
+		return false, err
+	}
+	for _, object := range tree.List(&corev1.PersistentVolumeClaim{}) {
+		if object.GetLabels()[constant.KBAppPodNameLabelKey] == surgePod.Name {
+			if err := tree.Delete(object); err != nil {
+				return false, err
+			}
+		}
+	}
+	tree.Logger.Info("retire surge instance", "surge", surgePod.Name)
+	return true, nil
+}
+
+func setSurgePhase(tree *kubebuilderx.ObjectTree, surgePod *corev1.Pod, phase surgePhase) error {
+	if surgePod.Annotations == nil {
+		surgePod.Annotations = map[string]string{}
+	}
+	surgePod.Annotations[surgePhaseAnnotationKey] = string(phase)
+	return tree.Update(surgePod)
+}
+
+// memberJoin calls the member join action to add the pod to the membership, if defined.
+func memberJoin(ctx context.Context, logger logr.Logger, its *workloads.InstanceSet, pod *corev1.Pod) error {
+	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.MemberJoin == nil {
+		return nil
+	}
+	lfa, err := newMembershipLifecycleAction(its, pod)
+	if err != nil {
+		return err
+	}
+	if err = lfa.MemberJoin(ctx, nil, nil); err != nil {
+		if errors.Is(err, lifecycle.ErrActionNotDefined) {
+			return nil
+		}
+		return err
+	}
+	logger.Info("successfully call member join action for pod", "pod", pod.Name)
+	return nil
+}
+
+// memberLeave calls the member leave action to remove the pod from the membership, if defined.
+func memberLeave(ctx context.Context, logger logr.Logger, its *workloads.InstanceSet, pod *corev1.Pod) error {
+	if its.Spec.MembershipReconfiguration == nil || its.Spec.MembershipReconfiguration.MemberLeave == nil {
+		return nil
+	}
+	lfa, err := newMembershipLifecycleAction(its, pod)
+	if err != nil {
+		return err
+	}
+	if err = lfa.MemberLeave(ctx, nil, nil); err != nil {
+		if errors.Is(err, lifecycle.ErrActionNotDefined) {
+			return nil
+		}
+		return err
+	}
+	logger.Info("successfully call member leave action for pod", "pod", pod.Name)
+	return nil
+}
+
+func newMembershipLifecycleAction(its *workloads.InstanceSet, pod *corev1.Pod) (lifecycle.Lifecycle, error) {
+	clusterName, err := getClusterName(its)
+	if err != nil {
+		return nil, err
+	}
+	// the member actions take the FQDN of the pod as parameter, which is composed of the component name
+	compName := its.Labels[constant.KBAppComponentLabelKey]
+	if len(compName) == 0 {
+		compName = its.Name
+	}
+	lifecycleActions := &kbappsv1.ComponentLifecycleActions{
+		MemberJoin:  its.Spec.MembershipReconfiguration.MemberJoin,
+		MemberLeave: its.Spec.MembershipReconfiguration.MemberLeave,
+	}
+	var templateVars map[string]any
+	if its.Spec.TemplateVars != nil {
+		templateVars = make(map[string]any)
+		for k, v := range its.Spec.TemplateVars {
+			templateVars[k] = v
+		}
+	}
+	return lifecycle.New(its.Namespace, clusterName, compName, lifecycleActions, templateVars, pod)
+}
@@ -42,7 +42,25 @@ const (
 
 	FeatureGateIgnorePodVerticalScaling = "IGNORE_POD_VERTICAL_SCALING"
 
+	// FeatureGateRoleProbeWatch enables to watch the role changes from the kb-agent of pods directly.
+	FeatureGateRoleProbeWatch = "ROLE_PROBE_WATCH"
+	// RoleProbeWatchMaxConcurrency specifies the max number of pods watched concurrently,
+	// the role changes of the other pods are still reported through the events.
+	RoleProbeWatchMaxConcurrency = "ROLE_PROBE_WATCH_MAX_CONCURRENCY"
+
+	// FeatureGatePodDisruptionBudget enables to create the role-aware PodDisruptionBudgets for InstanceSets,
+	// and to switch over the leaders on the nodes being drained.
+	FeatureGatePodDisruptionBudget = "POD_DISRUPTION_BUDGET"
+
 	finalizer = "instanceset.workloads.kubeblocks.io/finalizer"
+
+	// surgeForLabelKey is the label of the surge instances, its value is the name of the instance to be replaced.
+	surgeForLabelKey = "workloads.kubeblocks.io/surge-for"
+	// surgePhaseAnnotationKey records the phase of the surge instances.
+	surgePhaseAnnotationKey = "workloads.kubeblocks.io/surge-phase"
+
+	// drainSwitchoverAnnotationKey records the time when the leader is switched over since its node is being drained.
+	drainSwitchoverAnnotationKey = "workloads.kubeblocks.io/drain-switchover"
 )
 
 // AnnotationScope defines scope that annotations belong to.
@@ -30,6 +30,7 @@ var (
 	ErrActionInProgress     = errors.New("action is in progress")
 	ErrActionBusy           = errors.New("action is busy")
 	ErrActionTimedOut       = errors.New("action timed-out")
+	ErrActionCanceled       = errors.New("action canceled")
 	ErrActionFailed         = errors.New("action failed")
 	ErrActionInternalError  = errors.New("action internal error")
 )
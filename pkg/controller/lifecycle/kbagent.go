@@ -90,7 +90,7 @@ func (a *kbagent) Switchover(ctx context.Context, cli client.Reader, opts *Optio
 		currentPod:   a.pod.Name,
 		candidatePod: candidate,
 	}
-	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Switchover, lfa, opts))
+	return a.ignoreOutput(a.checkedCallStepAction(ctx, cli, a.lifecycleActions.Switchover, lfa, opts))
 }
 
 func (a *kbagent) MemberJoin(ctx context.Context, cli client.Reader, opts *Options) error {
@@ -110,7 +110,27 @@ func (a *kbagent) MemberLeave(ctx context.Context, cli client.Reader, opts *Opti
 		compName:    a.compName,
 		pod:         a.pod,
 	}
-	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.MemberLeave, lfa, opts))
+	return a.ignoreOutput(a.checkedCallStepAction(ctx, cli, a.lifecycleActions.MemberLeave, lfa, opts))
+}
+
+func (a *kbagent) Readonly(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readonly{
+		namespace:   a.namespace,
+		clusterName: a.clusterName,
+		compName:    a.compName,
+		podName:     a.pod.Name,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readonly, lfa, opts))
+}
+
+func (a *kbagent) Readwrite(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readwrite{
+		namespace:   a.namespace,
+		clusterName: a.clusterName,
+		compName:    a.compName,
+		podName:     a.pod.Name,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readwrite, lfa, opts))
 }
 
 func (a *kbagent) Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error {
@@ -137,12 +157,19 @@ func (a *kbagent) UserDefined(ctx context.Context, cli client.Reader, opts *Opti
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, action, lfa, opts))
 }
 
+func (a *kbagent) QueryParameters(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action) ([]byte, error) {
+	lfa := &udf{
+		uname: name,
+	}
+	return a.checkedCallAction(ctx, cli, action, lfa, opts)
+}
+
 func (a *kbagent) ignoreOutput(_ []byte, err error) error {
 	return err
 }
 
 func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if !IsActionDefined(spec) {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	if err := a.precondition(ctx, cli, spec); err != nil {
@@ -153,12 +180,25 @@ func (a *kbagent) checkedCallAction(ctx context.Context, cli client.Reader, spec
 }
 
 func (a *kbagent) checkedCallProbe(ctx context.Context, cli client.Reader, spec *appsv1.Probe, lfa lifecycleAction, opts *Options) ([]byte, error) {
-	if spec == nil || spec.Exec == nil {
+	if spec == nil || !IsActionDefined(&spec.Action) {
 		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
 	}
 	return a.checkedCallAction(ctx, cli, &spec.Action, lfa, opts)
 }
 
+func (a *kbagent) checkedCallStepAction(ctx context.Context, cli client.Reader, spec *appsv1.StepAction, lfa lifecycleAction, opts *Options) ([]byte, error) {
+	if !IsStepActionDefined(spec) {
+		return nil, errors.Wrap(ErrActionNotDefined, lfa.name())
+	}
+	if len(spec.Steps) == 0 {
+		return a.checkedCallAction(ctx, cli, &spec.Action, lfa, opts)
+	}
+	if err := a.precondition(ctx, cli, &spec.Action); err != nil {
+		return nil, err
+	}
+	return a.callSteps(ctx, cli, spec, lfa, opts)
+}
+
 func (a *kbagent) precondition(ctx context.Context, cli client.Reader, spec *appsv1.Action) error {
 	if spec.PreCondition == nil {
 		return nil
@@ -222,7 +262,11 @@ func (a *kbagent) callAction(ctx context.Context, cli client.Reader, spec *appsv
 	if err1 != nil {
 		return nil, err1
 	}
-	return a.callActionWithSelector(ctx, spec, lfa, req)
+	var stream func(proto.ActionOutput)
+	if opts != nil {
+		stream = opts.Stream
+	}
+	return a.callActionWithSelector(ctx, cli, spec, lfa, req, stream)
 }
 
 func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa lifecycleAction, opts *Options) (*proto.ActionRequest, error) {
@@ -247,6 +291,7 @@ func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa
 				RetryInterval: opts.RetryPolicy.RetryInterval,
 			}
 		}
+		req.RequestID = opts.RequestID
 	}
 	return req, nil
 }
@@ -278,11 +323,17 @@ func (a *kbagent) templateVarsParameters() (map[string]string, error) {
 	return m, nil
 }
 
-func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Action, lfa lifecycleAction, req *proto.ActionRequest) ([]byte, error) {
+func (a *kbagent) callActionWithSelector(ctx context.Context, cli client.Reader, spec *appsv1.Action,
+	lfa lifecycleAction, req *proto.ActionRequest, stream func(proto.ActionOutput)) ([]byte, error) {
 	pods, err := a.selectTargetPods(spec)
 	if err != nil {
 		return nil, err
 	}
+	return a.callActionOnPods(ctx, cli, pods, lfa, req, stream)
+}
+
+func (a *kbagent) callActionOnPods(ctx context.Context, cli client.Reader, pods []*corev1.Pod,
+	lfa lifecycleAction, req *proto.ActionRequest, stream func(proto.ActionOutput)) ([]byte, error) {
 	if len(pods) == 0 {
 		return nil, fmt.Errorf("no available pod to execute action %s", lfa.name())
 	}
@@ -292,31 +343,21 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 	//  - timeout
 	var output []byte
 	for _, pod := range pods {
-		endpoint := func() (string, int32, error) {
-			host, port, err := a.serverEndpoint(pod)
-			if err != nil {
-				return "", 0, errors.Wrapf(err, "pod %s is unavailable to execute action %s", pod.Name, lfa.name())
-			}
-			return host, port, nil
-		}
-		var cli kbacli.Client
-		_, err := rest.InClusterConfig()
-		if err != nil {
-			// If kb is not run in a k8s cluster, using pod ip to call kb-agent would fail.
-			// So we use a client that utilizes k8s' portforward ability.
-			cli, err = kbacli.NewPortForwardClient(pod, endpoint)
-		} else {
-			cli, err = kbacli.NewClient(endpoint)
-		}
+		agentCli, err := a.agentClient(ctx, cli, pod, lfa.name())
 		if err != nil {
 			return nil, err // mock client error
 		}
-		if cli == nil {
+		if agentCli == nil {
 			continue // not kb-agent container and port defined, for test only
 		}
 
-		rsp, err := cli.Action(ctx, *req)
-		_ = cli.Close()
+		var rsp proto.ActionResponse
+		if stream != nil {
+			rsp, err = agentCli.ActionFollow(ctx, *req, stream)
+		} else {
+			rsp, err = agentCli.Action(ctx, *req)
+		}
+		_ = agentCli.Close()
 
 		if err != nil {
 			return nil, errors.Wrapf(err, "http error occurred when executing action %s at pod %s", lfa.name(), pod.Name)
@@ -332,6 +373,54 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 	return output, nil
 }
 
+func (a *kbagent) Cancel(ctx context.Context, cli client.Reader, requestID string) error {
+	if len(requestID) == 0 {
+		return fmt.Errorf("the request ID is required to cancel the action")
+	}
+	// the action may be called on any of the pods, the pods that have no such request running are ignored
+	for _, pod := range a.pods {
+		agentCli, err := a.agentClient(ctx, cli, pod, "cancel")
+		if err != nil {
+			return err
+		}
+		if agentCli == nil {
+			continue
+		}
+
+		rsp, err := agentCli.CancelAction(ctx, requestID)
+		_ = agentCli.Close()
+
+		if err != nil {
+			return errors.Wrapf(err, "http error occurred when canceling request %s at pod %s", requestID, pod.Name)
+		}
+		if err = proto.Type2Error(rsp.Error); err != nil && !errors.Is(err, proto.ErrNotFound) {
+			return errors.Wrapf(err, "cancel request %s at pod %s error: %s", requestID, pod.Name, rsp.Message)
+		}
+	}
+	return nil
+}
+
+// agentClient creates a client to access the kb-agent in the pod, it returns nil if the pod has no kb-agent defined.
+func (a *kbagent) agentClient(ctx context.Context, cli client.Reader, pod *corev1.Pod, name string) (kbacli.Client, error) {
+	endpoint := func() (string, int32, error) {
+		host, port, err := a.serverEndpoint(pod)
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "pod %s is unavailable to execute action %s", pod.Name, name)
+		}
+		return host, port, nil
+	}
+	opts, err := a.clientOptions(ctx, cli, pod)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = rest.InClusterConfig(); err != nil {
+		// If kb is not run in a k8s cluster, using pod ip to call kb-agent would fail.
+		// So we use a client that utilizes k8s' portforward ability.
+		return kbacli.NewPortForwardClient(pod, endpoint, opts...)
+	}
+	return kbacli.NewClient(endpoint, opts...)
+}
+
 func (a *kbagent) selectTargetPods(spec *appsv1.Action) ([]*corev1.Pod, error) {
 	return SelectTargetPods(a.pods, a.pod, spec)
 }
@@ -371,6 +460,8 @@ func (a *kbagent) formatError(lfa lifecycleAction, rsp proto.ActionResponse) err
 		return wrapError(ErrActionBusy)
 	case errors.Is(err, proto.ErrTimedOut):
 		return wrapError(ErrActionTimedOut)
+	case errors.Is(err, proto.ErrCanceled):
+		return wrapError(ErrActionCanceled)
 	case errors.Is(err, proto.ErrFailed):
 		return wrapError(ErrActionFailed)
 	case errors.Is(err, proto.ErrInternalError):
@@ -381,7 +472,14 @@ func (a *kbagent) formatError(lfa lifecycleAction, rsp proto.ActionResponse) err
 }
 
 func SelectTargetPods(pods []*corev1.Pod, pod *corev1.Pod, spec *appsv1.Action) ([]*corev1.Pod, error) {
-	if spec.Exec == nil || len(spec.Exec.TargetPodSelector) == 0 {
+	if spec.Exec == nil {
+		return []*corev1.Pod{pod}, nil
+	}
+	return selectPods(pods, pod, spec.Exec.TargetPodSelector, spec.Exec.MatchingKey)
+}
+
+func selectPods(pods []*corev1.Pod, pod *corev1.Pod, selector appsv1.TargetPodSelector, matchingKey string) ([]*corev1.Pod, error) {
+	if len(selector) == 0 {
 		return []*corev1.Pod{pod}, nil
 	}
 
@@ -395,7 +493,7 @@ func SelectTargetPods(pods []*corev1.Pod, pod *corev1.Pod, spec *appsv1.Action)
 	}
 
 	podsWithRole := func() []*corev1.Pod {
-		roleName := spec.Exec.MatchingKey
+		roleName := matchingKey
 		var rolePods []*corev1.Pod
 		for i, pod := range pods {
 			if len(pod.Labels) != 0 {
@@ -407,7 +505,7 @@ func SelectTargetPods(pods []*corev1.Pod, pod *corev1.Pod, spec *appsv1.Action)
 		return rolePods
 	}
 
-	switch spec.Exec.TargetPodSelector {
+	switch selector {
 	case appsv1.AnyReplica:
 		return anyPod(), nil
 	case appsv1.AllReplicas:
@@ -417,6 +515,6 @@ func SelectTargetPods(pods []*corev1.Pod, pod *corev1.Pod, spec *appsv1.Action)
 	case appsv1.OrdinalSelector:
 		return nil, fmt.Errorf("ordinal selector is not supported")
 	default:
-		return nil, fmt.Errorf("unknown pod selector: %s", spec.Exec.TargetPodSelector)
+		return nil, fmt.Errorf("unknown pod selector: %s", selector)
 	}
 }
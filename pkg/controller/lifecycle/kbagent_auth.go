@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// clientOptions returns the options to access the kb-agent in the pod according to the auth mode.
+func (a *kbagent) clientOptions(ctx context.Context, cli client.Reader, pod *corev1.Pod) ([]kbacli.Option, error) {
+	switch kbagt.AuthMode() {
+	case kbagt.AuthModeMTLS:
+		config, err := a.tlsConfig(ctx, cli, pod)
+		if err != nil {
+			return nil, err
+		}
+		return []kbacli.Option{kbacli.WithTLSConfig(config)}, nil
+	case kbagt.AuthModeToken:
+		return []kbacli.Option{kbacli.WithToken(a.token)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (a *kbagent) tlsConfig(ctx context.Context, cli client.Reader, pod *corev1.Pod) (*tls.Config, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("the client is required to load the kbagent TLS certificates")
+	}
+	secretKey := types.NamespacedName{
+		Namespace: a.namespace,
+		Name:      kbagt.TLSSecretName(a.clusterName, a.compName),
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("get the kbagent TLS secret error: %s", err.Error())
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[kbagt.TLSClientCertFile], secret.Data[kbagt.TLSClientKeyFile])
+	if err != nil {
+		return nil, fmt.Errorf("load the kbagent client certificate error: %s", err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[kbagt.TLSCAFile]) {
+		return nil, fmt.Errorf("no valid CA found in the kbagent TLS secret %s", secretKey.Name)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		// the pod is accessed by IP, verify the server certificate with the FQDN of the pod
+		ServerName: fmt.Sprintf("%s.%s.%s.svc", pod.Name,
+			constant.GenerateDefaultComponentHeadlessServiceName(a.clusterName, a.compName), a.namespace),
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// token reads the projected service account token on each request, since it is rotated by the kubelet.
+func (a *kbagent) token() (string, error) {
+	file := viper.GetString(constant.CfgKeyKBAgentAuthTokenFile)
+	if len(file) == 0 {
+		file = kbagt.DefaultTokenFile
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("read the kbagent auth token error: %s", err.Error())
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// StepActionName returns the name of the kb-agent action which executes the step of the multi-step action.
+func StepActionName(action, step string) string {
+	return fmt.Sprintf("%s.%s", action, step)
+}
+
+// StepRollbackActionName returns the name of the kb-agent action which executes the compensation of the step.
+func StepRollbackActionName(action, step string) string {
+	return fmt.Sprintf("%s.%s.onFailure", action, step)
+}
+
+// StepHandlerAction converts the handler of a step to an action.
+func StepHandlerAction(handler *appsv1.ActionStepHandler) *appsv1.Action {
+	return &appsv1.Action{
+		Exec:           handler.Exec,
+		HTTP:           handler.HTTP,
+		GRPC:           handler.GRPC,
+		TimeoutSeconds: handler.TimeoutSeconds,
+	}
+}
+
+// ActionStepStatuses returns the status of the latest executions of the multi-step actions called for the pods,
+// which are recorded in the annotations of the pods.
+func ActionStepStatuses(pods []*corev1.Pod) []appsv1.LifecycleActionStatus {
+	var statuses []appsv1.LifecycleActionStatus
+	for _, pod := range pods {
+		statuses = append(statuses, getActionStepStatuses(pod)...)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+		return statuses[i].PodName < statuses[j].PodName
+	})
+	return statuses
+}
+
+type stepAction struct {
+	lifecycleAction
+	action string
+}
+
+func (a *stepAction) name() string {
+	return a.action
+}
+
+// callSteps executes the steps of the action one by one, if a step fails, the compensation handlers of the failed step
+// and the steps succeeded before it are executed in reverse order to roll back.
+func (a *kbagent) callSteps(ctx context.Context, cli client.Reader, spec *appsv1.StepAction, lfa lifecycleAction, opts *Options) ([]byte, error) {
+	req, err := a.buildActionRequest(ctx, cli, lfa, opts)
+	if err != nil {
+		return nil, err
+	}
+	// the steps are executed in order, which can't be non-blocking
+	req.NonBlocking = nil
+
+	status := &appsv1.LifecycleActionStatus{
+		Name:      lfa.name(),
+		PodName:   a.pod.Name,
+		Phase:     appsv1.RunningActionStepPhase,
+		StartTime: ptrNow(),
+	}
+	a.recordStepStatus(ctx, cli, status)
+
+	var output []byte
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		status.Steps = append(status.Steps, appsv1.ActionStepStatus{
+			Name:      step.Name,
+			Phase:     appsv1.RunningActionStepPhase,
+			StartTime: ptrNow(),
+		})
+		a.recordStepStatus(ctx, cli, status)
+
+		output, err = a.callStep(ctx, cli, lfa, StepActionName(lfa.name(), step.Name), &step.ActionStepHandler, req)
+		stepStatus := &status.Steps[i]
+		stepStatus.CompletionTime = ptrNow()
+		if err != nil {
+			stepStatus.Phase = appsv1.FailedActionStepPhase
+			stepStatus.Message = err.Error()
+			a.rollbackSteps(ctx, cli, spec, i, lfa, req, status)
+			status.Phase = appsv1.FailedActionStepPhase
+			status.CompletionTime = ptrNow()
+			a.recordStepStatus(ctx, cli, status)
+			return nil, errors.Wrapf(err, "step %s of action %s failed", step.Name, lfa.name())
+		}
+		stepStatus.Phase = appsv1.SucceededActionStepPhase
+	}
+	status.Phase = appsv1.SucceededActionStepPhase
+	status.CompletionTime = ptrNow()
+	a.recordStepStatus(ctx, cli, status)
+	return output, nil
+}
+
+func (a *kbagent) rollbackSteps(ctx context.Context, cli client.Reader, spec *appsv1.StepAction, failed int,
+	lfa lifecycleAction, req *proto.ActionRequest, status *appsv1.LifecycleActionStatus) {
+	for i := failed; i >= 0; i-- {
+		step := &spec.Steps[i]
+		if step.OnFailure == nil {
+			continue
+		}
+		stepStatus := &status.Steps[i]
+		_, err := a.callStep(ctx, cli, lfa, StepRollbackActionName(lfa.name(), step.Name), step.OnFailure, req)
+		if err != nil {
+			stepStatus.Phase = appsv1.RollbackFailedActionStepPhase
+			stepStatus.Message = strings.TrimSpace(fmt.Sprintf("%s; rollback: %s", stepStatus.Message, err.Error()))
+		} else {
+			stepStatus.Phase = appsv1.RolledBackActionStepPhase
+		}
+		stepStatus.CompletionTime = ptrNow()
+	}
+}
+
+func (a *kbagent) callStep(ctx context.Context, cli client.Reader, lfa lifecycleAction, name string,
+	handler *appsv1.ActionStepHandler, req *proto.ActionRequest) ([]byte, error) {
+	pods, err := a.selectStepTargetPods(handler)
+	if err != nil {
+		return nil, err
+	}
+	stepReq := *req
+	stepReq.Action = name
+	return a.callActionOnPods(ctx, cli, pods, &stepAction{lifecycleAction: lfa, action: name}, &stepReq, nil)
+}
+
+// selectStepTargetPods selects the target pods of the step, by the selector of the exec handler, or the selector
+// of the step itself for the http and grpc handlers.
+func (a *kbagent) selectStepTargetPods(handler *appsv1.ActionStepHandler) ([]*corev1.Pod, error) {
+	if handler.Exec != nil {
+		return SelectTargetPods(a.pods, a.pod, StepHandlerAction(handler))
+	}
+	return selectPods(a.pods, a.pod, handler.TargetPodSelector, handler.MatchingKey)
+}
+
+// ptrNow returns the current time in the precision of seconds, which is the same as the one persisted.
+func ptrNow() *metav1.Time {
+	now := metav1.Now().Rfc3339Copy()
+	return &now
+}
+
+// recordStepStatus records the status of the multi-step action in the annotation of the pod which the action is
+// called for. The pod is patched at once if the client is able to write, to persist the in-flight status, otherwise
+// the status is kept in the pod object only.
+func (a *kbagent) recordStepStatus(ctx context.Context, cli client.Reader, status *appsv1.LifecycleActionStatus) {
+	patch := client.MergeFrom(a.pod.DeepCopy())
+	statuses := getActionStepStatuses(a.pod)
+	idx := slices.IndexFunc(statuses, func(s appsv1.LifecycleActionStatus) bool {
+		return s.Name == status.Name
+	})
+	if idx < 0 {
+		statuses = append(statuses, *status.DeepCopy())
+	} else {
+		statuses[idx] = *status.DeepCopy()
+	}
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		return
+	}
+	if a.pod.Annotations == nil {
+		a.pod.Annotations = map[string]string{}
+	}
+	a.pod.Annotations[constant.LifecycleActionStatusAnnotationKey] = string(data)
+
+	if writer, ok := cli.(client.Writer); ok {
+		// the status is best-effort, it doesn't fail the action
+		_ = writer.Patch(ctx, a.pod, patch)
+	}
+}
+
+func getActionStepStatuses(pod *corev1.Pod) []appsv1.LifecycleActionStatus {
+	data, ok := pod.Annotations[constant.LifecycleActionStatusAnnotationKey]
+	if !ok || len(data) == 0 {
+		return nil
+	}
+	var statuses []appsv1.LifecycleActionStatus
+	if err := json.Unmarshal([]byte(data), &statuses); err != nil {
+		return nil
+	}
+	for i := range statuses {
+		statuses[i].PodName = pod.Name
+	}
+	return statuses
+}
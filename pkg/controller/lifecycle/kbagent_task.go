@@ -0,0 +1,63 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// QueryTask queries the latest event of the task from the journal of the kb-agent in the pod.
+func QueryTask(ctx context.Context, cli client.Reader, namespace, clusterName, compName string,
+	pod *corev1.Pod, uid string) (*proto.TaskEvent, error) {
+	a := &kbagent{
+		namespace:   namespace,
+		clusterName: clusterName,
+		compName:    compName,
+		pods:        []*corev1.Pod{pod},
+		pod:         pod,
+	}
+	agentCli, err := a.agentClient(ctx, cli, pod, "task")
+	if err != nil {
+		return nil, err
+	}
+	if agentCli == nil {
+		return nil, fmt.Errorf("pod %s has no kb-agent defined", pod.Name)
+	}
+	defer agentCli.Close()
+
+	rsp, err := agentCli.Task(ctx, uid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "http error occurred when querying task %s at pod %s", uid, pod.Name)
+	}
+	if len(rsp.Error) > 0 {
+		return nil, errors.Wrapf(proto.Type2Error(rsp.Error), "task: %s, error: %s", uid, rsp.Message)
+	}
+	if rsp.Event == nil {
+		return nil, errors.Wrapf(proto.ErrNotFound, "task: %s", uid)
+	}
+	return rsp.Event, nil
+}
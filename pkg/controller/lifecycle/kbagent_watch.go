@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// WatchProbe waits for the events of the probe newer than the sequence number since from the kb-agent in the pod,
+// it returns when there are new events or the timeout expires.
+func WatchProbe(ctx context.Context, cli client.Reader, namespace, clusterName, compName string,
+	pod *corev1.Pod, probe string, since uint64, timeout time.Duration) (*proto.ProbeWatchResponse, error) {
+	a := &kbagent{
+		namespace:   namespace,
+		clusterName: clusterName,
+		compName:    compName,
+		pods:        []*corev1.Pod{pod},
+		pod:         pod,
+	}
+	agentCli, err := a.agentClient(ctx, cli, pod, probe)
+	if err != nil {
+		return nil, err
+	}
+	if agentCli == nil {
+		return nil, fmt.Errorf("pod %s has no kb-agent defined", pod.Name)
+	}
+	defer agentCli.Close()
+
+	rsp, err := agentCli.WatchProbe(ctx, probe, since, timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "http error occurred when watching probe %s at pod %s", probe, pod.Name)
+	}
+	if len(rsp.Error) > 0 {
+		return nil, errors.Wrapf(proto.Type2Error(rsp.Error), "probe: %s, error: %s", probe, rsp.Message)
+	}
+	return &rsp, nil
+}
@@ -23,12 +23,16 @@ import (
 	"context"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 const (
 	configFilesCreated = "KB_CONFIG_FILES_CREATED"
 	configFilesRemoved = "KB_CONFIG_FILES_REMOVED"
 	configFilesUpdated = "KB_CONFIG_FILES_UPDATED"
+	podFQDNVar         = "KB_POD_FQDN"
 )
 
 func FileTemplateChanges(created, removed, updated string) map[string]string {
@@ -57,3 +61,49 @@ func (a *reconfigure) parameters(ctx context.Context, cli client.Reader) (map[st
 	// - KB_CONFIG_FILES_UPDATED: file1:checksum1,file2:checksum2...
 	return a.args, nil
 }
+
+type readonly struct {
+	namespace   string
+	clusterName string
+	compName    string
+	podName     string
+}
+
+var _ lifecycleAction = &readonly{}
+
+func (a *readonly) name() string {
+	return "readonly"
+}
+
+func (a *readonly) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod to switch into the read-only state.
+	return accessModeParameters(a.namespace, a.clusterName, a.compName, a.podName), nil
+}
+
+type readwrite struct {
+	namespace   string
+	clusterName string
+	compName    string
+	podName     string
+}
+
+var _ lifecycleAction = &readwrite{}
+
+func (a *readwrite) name() string {
+	return "readwrite"
+}
+
+func (a *readwrite) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod to transition back to the read-write state.
+	return accessModeParameters(a.namespace, a.clusterName, a.compName, a.podName), nil
+}
+
+func accessModeParameters(namespace, clusterName, compName, podName string) map[string]string {
+	return map[string]string{
+		podFQDNVar: intctrlutil.PodFQDN(namespace, constant.GenerateClusterComponentName(clusterName, compName), podName),
+	}
+}
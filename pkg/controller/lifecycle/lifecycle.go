@@ -27,12 +27,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 type Options struct {
 	NonBlocking    *bool
 	TimeoutSeconds *int32
 	RetryPolicy    *appsv1.RetryPolicy
+	// RequestID identifies the call of the action, the running action can be canceled by it through Cancel.
+	RequestID string
+	// Stream receives the output chunks of the action as they are produced, the action is called in follow mode if it is set.
+	Stream func(proto.ActionOutput)
 }
 
 type Lifecycle interface {
@@ -48,15 +53,20 @@ type Lifecycle interface {
 
 	MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readonly(ctx context.Context, cli client.Reader, opts *Options) error
+	Readonly(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
+	Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
 
 	Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error
 
 	AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error
 
 	UserDefined(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action, args map[string]string) error
+
+	QueryParameters(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action) ([]byte, error)
+
+	// Cancel cancels the running action called with the request ID.
+	Cancel(ctx context.Context, cli client.Reader, requestID string) error
 }
 
 func New(namespace, clusterName, compName string, lifecycleActions *appsv1.ComponentLifecycleActions,
@@ -80,3 +90,13 @@ func New(namespace, clusterName, compName string, lifecycleActions *appsv1.Compo
 		pod:              pod,
 	}, nil
 }
+
+// IsActionDefined checks whether the action has a handler defined, which can be exec, http or grpc.
+func IsActionDefined(action *appsv1.Action) bool {
+	return action != nil && (action.Exec != nil || action.HTTP != nil || action.GRPC != nil)
+}
+
+// IsStepActionDefined checks whether the action has a handler or steps defined.
+func IsStepActionDefined(action *appsv1.StepAction) bool {
+	return action != nil && (IsActionDefined(&action.Action) || len(action.Steps) > 0)
+}
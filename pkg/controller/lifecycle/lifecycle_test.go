@@ -228,6 +228,46 @@ var _ = Describe("lifecycle", func() {
 			Expect(err).Should(BeNil())
 		})
 
+		It("follow", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.ActionFollow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest, stream func(proto.ActionOutput)) (proto.ActionResponse, error) {
+					Expect(req.RequestID).Should(Equal("request-id"))
+					stream(proto.ActionOutput{Stream: proto.ActionOutputStdout, Data: []byte("progress")})
+					return proto.ActionResponse{}, nil
+				}).AnyTimes()
+			})
+
+			var outputs []string
+			opts := &Options{
+				RequestID: "request-id",
+				Stream: func(output proto.ActionOutput) {
+					outputs = append(outputs, string(output.Data))
+				},
+			}
+			err = lifecycle.PostProvision(ctx, k8sClient, opts)
+			Expect(err).Should(BeNil())
+			Expect(outputs).Should(ContainElement("progress"))
+		})
+
+		It("cancel", func() {
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.CancelAction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, requestID string) (proto.ActionResponse, error) {
+					Expect(requestID).Should(Equal("request-id"))
+					return proto.ActionResponse{Error: proto.Error2Type(proto.ErrNotFound)}, nil
+				}).AnyTimes()
+			})
+
+			Expect(lifecycle.Cancel(ctx, k8sClient, "")).ShouldNot(Succeed())
+			// the pods without the request running are ignored
+			Expect(lifecycle.Cancel(ctx, k8sClient, "request-id")).Should(Succeed())
+		})
+
 		It("succeed", func() {
 			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
 			Expect(err).Should(BeNil())
@@ -621,6 +661,182 @@ var _ = Describe("lifecycle", func() {
 			Expect(err.Error()).Should(ContainSubstring("no available pod to execute action"))
 		})
 
+		It("steps - succeed", func() {
+			lifecycleActions.MemberLeave = &appsv1.StepAction{
+				Steps: []appsv1.ActionStep{
+					{
+						Name:              "step1",
+						ActionStepHandler: appsv1.ActionStepHandler{Exec: &appsv1.ExecAction{Command: []string{"step1"}}},
+					},
+					{
+						Name:              "step2",
+						ActionStepHandler: appsv1.ActionStepHandler{Exec: &appsv1.ExecAction{Command: []string{"step2"}}},
+					},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			var actions []string
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					actions = append(actions, req.Action)
+					return proto.ActionResponse{}, nil
+				}).AnyTimes()
+			})
+
+			err = lifecycle.MemberLeave(ctx, k8sClient, nil)
+			Expect(err).Should(BeNil())
+			Expect(actions).Should(Equal([]string{"memberLeave.step1", "memberLeave.step2"}))
+
+			statuses := ActionStepStatuses(pods)
+			Expect(statuses).Should(HaveLen(1))
+			Expect(statuses[0].Name).Should(Equal("memberLeave"))
+			Expect(statuses[0].Phase).Should(Equal(appsv1.SucceededActionStepPhase))
+			Expect(statuses[0].Steps).Should(HaveLen(2))
+			for _, step := range statuses[0].Steps {
+				Expect(step.Phase).Should(Equal(appsv1.SucceededActionStepPhase))
+			}
+		})
+
+		It("steps - rollback", func() {
+			handler := func(cmd string) appsv1.ActionStepHandler {
+				return appsv1.ActionStepHandler{Exec: &appsv1.ExecAction{Command: []string{cmd}}}
+			}
+			rollback := func(cmd string) *appsv1.ActionStepHandler {
+				h := handler(cmd)
+				return &h
+			}
+			lifecycleActions.MemberLeave = &appsv1.StepAction{
+				Steps: []appsv1.ActionStep{
+					{Name: "step1", ActionStepHandler: handler("step1"), OnFailure: rollback("undo1")},
+					{Name: "step2", ActionStepHandler: handler("step2")},
+					{Name: "step3", ActionStepHandler: handler("step3"), OnFailure: rollback("undo3")},
+					{Name: "step4", ActionStepHandler: handler("step4")},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			var actions []string
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					actions = append(actions, req.Action)
+					switch req.Action {
+					case "memberLeave.step3":
+						return proto.ActionResponse{Error: proto.Error2Type(proto.ErrFailed), Message: "step3 failed"}, nil
+					case "memberLeave.step1.onFailure":
+						return proto.ActionResponse{Error: proto.Error2Type(proto.ErrFailed), Message: "undo1 failed"}, nil
+					default:
+						return proto.ActionResponse{}, nil
+					}
+				}).AnyTimes()
+			})
+
+			err = lifecycle.MemberLeave(ctx, k8sClient, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, ErrActionFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("step3 failed"))
+			Expect(actions).Should(Equal([]string{
+				"memberLeave.step1",
+				"memberLeave.step2",
+				"memberLeave.step3",
+				"memberLeave.step3.onFailure",
+				"memberLeave.step1.onFailure",
+			}))
+
+			statuses := ActionStepStatuses(pods)
+			Expect(statuses).Should(HaveLen(1))
+			Expect(statuses[0].Phase).Should(Equal(appsv1.FailedActionStepPhase))
+			Expect(statuses[0].Steps).Should(HaveLen(3))
+			Expect(statuses[0].Steps[0].Phase).Should(Equal(appsv1.RollbackFailedActionStepPhase))
+			Expect(statuses[0].Steps[0].Message).Should(ContainSubstring("undo1 failed"))
+			Expect(statuses[0].Steps[1].Phase).Should(Equal(appsv1.SucceededActionStepPhase))
+			Expect(statuses[0].Steps[2].Phase).Should(Equal(appsv1.RolledBackActionStepPhase))
+		})
+
+		It("steps - status of pods", func() {
+			lifecycleActions.MemberLeave = &appsv1.StepAction{
+				Steps: []appsv1.ActionStep{
+					{
+						Name:              "step1",
+						ActionStepHandler: appsv1.ActionStepHandler{Exec: &appsv1.ExecAction{Command: []string{"step1"}}},
+					},
+				},
+			}
+			pods = []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "pod-0",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "pod-1",
+					},
+				},
+			}
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Return(proto.ActionResponse{}, nil).AnyTimes()
+			})
+
+			for _, pod := range pods {
+				lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, pod, pods...)
+				Expect(err).Should(BeNil())
+				Expect(lifecycle.MemberLeave(ctx, k8sClient, nil)).Should(Succeed())
+				Expect(pod.Annotations).Should(HaveKey(constant.LifecycleActionStatusAnnotationKey))
+			}
+			statuses := ActionStepStatuses(pods)
+			Expect(statuses).Should(HaveLen(2))
+			Expect(statuses[0].PodName).Should(Equal("pod-0"))
+			Expect(statuses[1].PodName).Should(Equal("pod-1"))
+
+			// the latest execution replaces the previous one of the pod
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, pods[0], pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle.MemberLeave(ctx, k8sClient, nil)).Should(Succeed())
+			Expect(ActionStepStatuses(pods)).Should(HaveLen(2))
+			Expect(ActionStepStatuses(pods[1:])).Should(HaveLen(1))
+		})
+
+		It("steps - http target pods", func() {
+			lifecycleActions.MemberLeave = &appsv1.StepAction{
+				Steps: []appsv1.ActionStep{
+					{
+						Name: "step1",
+						ActionStepHandler: appsv1.ActionStepHandler{
+							HTTP:              &appsv1.HTTPAction{Path: "/step1"},
+							TargetPodSelector: appsv1.RoleSelector,
+							MatchingKey:       "leader",
+						},
+					},
+				},
+			}
+			pods = []*corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "pod-0",
+						Labels: map[string]string{
+							constant.RoleLabelKey: "follower",
+						},
+					},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			err = lifecycle.MemberLeave(ctx, k8sClient, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("no available pod to execute action memberLeave.step1"))
+		})
+
 		It("non-blocking", func() {
 			// TODO: impl
 		})
@@ -629,4 +845,52 @@ var _ = Describe("lifecycle", func() {
 			// TODO: impl
 		})
 	})
+
+	Context("query task", func() {
+		It("event", func() {
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Task(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uid string) (proto.TaskResponse, error) {
+					Expect(uid).Should(Equal("1"))
+					return proto.TaskResponse{
+						Event: &proto.TaskEvent{
+							Task:    "newReplica",
+							UID:     uid,
+							Replica: "pod-0",
+							Code:    -1,
+							Message: "failed",
+						},
+					}, nil
+				}).AnyTimes()
+			})
+
+			event, err := QueryTask(ctx, k8sClient, namespace, clusterName, compName, pods[0], "1")
+			Expect(err).Should(BeNil())
+			Expect(event).ShouldNot(BeNil())
+			Expect(event.Code).Should(Equal(int32(-1)))
+			Expect(event.Message).Should(Equal("failed"))
+		})
+
+		It("not found", func() {
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Task(gomock.Any(), gomock.Any()).Return(proto.TaskResponse{}, nil).AnyTimes()
+			})
+
+			_, err := QueryTask(ctx, k8sClient, namespace, clusterName, compName, pods[0], "1")
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotFound)).Should(BeTrue())
+		})
+
+		It("error", func() {
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Task(gomock.Any(), gomock.Any()).Return(proto.TaskResponse{
+					Error:   proto.Error2Type(proto.ErrNotImplemented),
+					Message: "not implemented",
+				}, nil).AnyTimes()
+			})
+
+			_, err := QueryTask(ctx, k8sClient, namespace, clusterName, compName, pods[0], "1")
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
+		})
+	})
 })
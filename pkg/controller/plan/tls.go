@@ -31,6 +31,7 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
 )
 
 func ComposeTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
@@ -77,6 +78,52 @@ func ComposeTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
 	return secret, nil
 }
 
+// ComposeKBAgentTLSCerts issues a CA, and the server and client certificates signed by it, for the kb-agent
+// in the component. The server certificate is bound to the pod FQDNs, and the client certificate is used by
+// the controllers to call the kb-agent.
+func ComposeKBAgentTLSCerts(synthesizedComp component.SynthesizedComponent, secret *corev1.Secret) (*corev1.Secret, error) {
+	var (
+		namespace   = synthesizedComp.Namespace
+		clusterName = synthesizedComp.ClusterName
+		compName    = synthesizedComp.Name
+	)
+
+	const spliter = "___spliter___"
+	SignedCertTpl := fmt.Sprintf(`
+	{{- $ca := genCA "KubeBlocks kbagent" 36500 -}}
+	{{- $cert := genSignedCert "%s kbagent" (list "127.0.0.1" "::1") (list "localhost" "*.%s-%s-headless.%s.svc" "*.%s-%s-headless.%s.svc.cluster.local") 36500 $ca -}}
+	{{- $client := genSignedCert "kubeblocks" nil nil 36500 $ca -}}
+	{{- $ca.Cert -}}
+	{{- print "%s" -}}
+	{{- $cert.Cert -}}
+	{{- print "%s" -}}
+	{{- $cert.Key -}}
+	{{- print "%s" -}}
+	{{- $client.Cert -}}
+	{{- print "%s" -}}
+	{{- $client.Key -}}
+`, compName, clusterName, compName, namespace, clusterName, compName, namespace, spliter, spliter, spliter, spliter)
+	out, err := buildFromTemplate(SignedCertTpl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(out, spliter)
+	if len(parts) != 5 {
+		return nil, errors.Errorf("generate kbagent TLS certificates failed with cluster name %s, component name %s in namespace %s",
+			clusterName, compName, namespace)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[kbagent.TLSCAFile] = []byte(parts[0])
+	secret.Data[kbagent.TLSCertFile] = []byte(parts[1])
+	secret.Data[kbagent.TLSKeyFile] = []byte(parts[2])
+	secret.Data[kbagent.TLSClientCertFile] = []byte(parts[3])
+	secret.Data[kbagent.TLSClientKeyFile] = []byte(parts[4])
+	return secret, nil
+}
+
 func buildFromTemplate(tpl string, vars interface{}) (string, error) {
 	fmap := sprig.TxtFuncMap()
 	t := template.Must(template.New("tls").Funcs(fmap).Parse(tpl))
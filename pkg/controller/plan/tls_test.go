@@ -20,6 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package plan
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -29,6 +33,7 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/kbagent"
 )
 
 var _ = Describe("TLS test", func() {
@@ -61,4 +66,40 @@ var _ = Describe("TLS test", func() {
 		Expect(secret.Data[*compDef.Spec.TLS.CertFile]).ShouldNot(BeZero())
 		Expect(secret.Data[*compDef.Spec.TLS.KeyFile]).ShouldNot(BeZero())
 	})
+
+	It("ComposeKBAgentTLSCerts", func() {
+		synthesizedComp := component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: "foo",
+			Name:        "bar",
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      kbagent.TLSSecretName("foo", "bar"),
+			},
+		}
+		_, err := ComposeKBAgentTLSCerts(synthesizedComp, secret)
+		Expect(err).Should(BeNil())
+
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(secret.Data[kbagent.TLSCAFile])).Should(BeTrue())
+
+		verify := func(certFile, keyFile string, opts x509.VerifyOptions) {
+			cert, err := tls.X509KeyPair(secret.Data[certFile], secret.Data[keyFile])
+			Expect(err).Should(BeNil())
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			Expect(err).Should(BeNil())
+			opts.Roots = pool
+			_, err = leaf.Verify(opts)
+			Expect(err).Should(BeNil())
+		}
+		verify(kbagent.TLSCertFile, kbagent.TLSKeyFile, x509.VerifyOptions{
+			DNSName:   fmt.Sprintf("foo-bar-0.foo-bar-headless.%s.svc", testCtx.DefaultNamespace),
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		verify(kbagent.TLSClientCertFile, kbagent.TLSClientKeyFile, x509.VerifyOptions{
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+	})
 })
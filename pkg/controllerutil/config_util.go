@@ -52,6 +52,10 @@ type Result struct {
 	Retry   bool   `json:"retry"`
 	Failed  bool   `json:"failed"`
 	Message string `json:"message"`
+
+	ParameterStatuses []parametersv1alpha1.ParameterReloadStatus `json:"parameterStatuses,omitempty"`
+
+	Canary *parametersv1alpha1.CanaryStatus `json:"canary,omitempty"`
 }
 
 // MergeAndValidateConfigs merges and validates configuration files
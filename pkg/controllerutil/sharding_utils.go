@@ -171,6 +171,7 @@ func listShardingCompSpecs(ctx context.Context, cli client.Reader,
 			}
 			shardClusterCompSpec := shardTpl.DeepCopy()
 			shardClusterCompSpec.Name = compShortName
+			applyShardTemplate(sharding, comp.Name, shardClusterCompSpec)
 			compSpecList = append(compSpecList, shardClusterCompSpec)
 		}
 		return nil
@@ -191,6 +192,22 @@ func listShardingCompSpecs(ctx context.Context, cli client.Reader,
 	return compSpecList, nil
 }
 
+// applyShardTemplate overrides the service version and component definition of the shard with the shard template it matches.
+func applyShardTemplate(sharding *appsv1.ClusterSharding, compName string, spec *appsv1.ClusterComponentSpec) {
+	for _, tpl := range sharding.ShardTemplates {
+		if !slices.Contains(tpl.Shards, compName) {
+			continue
+		}
+		if len(tpl.ServiceVersion) > 0 {
+			spec.ServiceVersion = tpl.ServiceVersion
+		}
+		if len(tpl.CompDef) > 0 {
+			spec.ComponentDef = tpl.CompDef
+		}
+		return
+	}
+}
+
 func genRandomShardName(shardingName string, shardNames sets.Set[string]) (string, error) {
 	shardingNamePrefix := constant.GenerateShardingNamePrefix(shardingName)
 	for i := 0; i < GenerateNameMaxRetryTimes; i++ {
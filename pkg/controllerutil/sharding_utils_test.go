@@ -102,5 +102,46 @@ var _ = Describe("cluster shard component", func() {
 			Expect(shardingCompSpecList).ShouldNot(BeNil())
 			Expect(len(shardingCompSpecList)).Should(BeEquivalentTo(2))
 		})
+
+		It("generate sharding component spec with shard templates", func() {
+			By("create mock sharding component object")
+			mockCompObj := testapps.NewComponentFactory(testCtx.DefaultNamespace, cluster.Name+"-"+mysqlShardingCompName, "").
+				AddAnnotations(constant.KBAppClusterUIDKey, string(cluster.UID)).
+				AddLabels(constant.AppInstanceLabelKey, cluster.Name).
+				AddLabels(constant.KBAppShardingNameLabelKey, mysqlShardingName).
+				SetReplicas(1).
+				Create(&testCtx).
+				GetObject()
+			compKey := client.ObjectKeyFromObject(mockCompObj)
+			Eventually(testapps.CheckObjExists(&testCtx, compKey, &appsv1.Component{}, true)).Should(Succeed())
+
+			sharding := &appsv1.ClusterSharding{
+				Template: appsv1.ClusterComponentSpec{
+					ComponentDef:   compDefName,
+					ServiceVersion: "1.0.0",
+					Replicas:       2,
+				},
+				Name:   mysqlShardingName,
+				Shards: 2,
+				ShardTemplates: []appsv1.ShardTemplate{
+					{
+						Name:           "tpl",
+						Shards:         []string{mockCompObj.Name},
+						ServiceVersion: "1.0.1",
+					},
+				},
+			}
+			shardingCompSpecList, err := GenShardingCompSpecList(testCtx.Ctx, k8sClient, cluster, sharding)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(shardingCompSpecList).Should(HaveLen(2))
+			for _, spec := range shardingCompSpecList {
+				Expect(spec.ComponentDef).Should(Equal(compDefName))
+				if spec.Name == mysqlShardingCompName {
+					Expect(spec.ServiceVersion).Should(Equal("1.0.1"))
+				} else {
+					Expect(spec.ServiceVersion).Should(Equal("1.0.0"))
+				}
+			}
+		})
 	})
 })
@@ -163,6 +163,53 @@ func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus,
 	return DeletionStatusDeleting, d.createDeleteBackupFilesJob(jobKey, backup, backupRepo, legacyPVCName)
 }
 
+// DeleteReplicaFiles builds a job to delete the files of the backup replica in its backup repository,
+// and returns the deletion status. If the deletion job exists, it will check the job status and return
+// the corresponding deletion status.
+func (d *Deleter) DeleteReplicaFiles(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) (DeletionStatus, error) {
+	jobKey := BuildDeleteReplicaFilesJobKey(backup, replica.BackupRepoName)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(d.Ctx, d.Client, jobKey, job)
+	if err != nil {
+		return DeletionStatusUnknown, err
+	}
+
+	// if deletion job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return DeletionStatusSucceeded, nil
+		case batchv1.JobFailed:
+			return DeletionStatusFailed,
+				fmt.Errorf("deletion replica files job \"%s\" failed, you can delete it to re-delete the replica files, %s", job.Name, msg)
+		}
+		return DeletionStatusDeleting, nil
+	}
+
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = d.Client.Get(d.Ctx, client.ObjectKey{Name: replica.BackupRepoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DeletionStatusSucceeded, nil
+		}
+		return DeletionStatusUnknown, err
+	}
+
+	if replica.Path == "" || !strings.Contains(replica.Path, backup.Name) {
+		d.Log.Info("skip deleting replica files because replica file path is invalid",
+			"replicaFilePath", replica.Path, "backup", backup.Name, "backupRepo", replica.BackupRepoName)
+		return DeletionStatusSucceeded, nil
+	}
+
+	// the replica has the same layout as the backup in the source backup repository,
+	// so delete it as a backup stored in the backup repository of the replica.
+	replicaBackup := backup.DeepCopy()
+	replicaBackup.Status.BackupRepoName = replica.BackupRepoName
+	replicaBackup.Status.Path = replica.Path
+	replicaBackup.Status.KopiaRepoPath = ""
+	return DeletionStatusDeleting, d.createDeleteBackupFilesJob(jobKey, replicaBackup, backupRepo, "")
+}
+
 func (d *Deleter) buildDeleteBackupFilesScript(backupPath string) string {
 
 	// this script first deletes the directory where the backup is located (including files
@@ -389,3 +436,11 @@ func BuildDeleteBackupFilesJobKey(backup *dpv1alpha1.Backup, isPreDelete bool) c
 	}
 	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
 }
+
+func BuildDeleteReplicaFilesJobKey(backup *dpv1alpha1.Backup, backupRepoName string) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s-%s", backup.UID[:8], deleteBackupFilesJobNamePrefix, replicaHash(backupRepoName), backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
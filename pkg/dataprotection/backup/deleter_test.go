@@ -154,6 +154,16 @@ var _ = Describe("Backup Deleter Test", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(status).Should(Equal(DeletionStatusSucceeded))
 		})
+
+		It("delete backup replica without backup repo", func() {
+			replica := &dpv1alpha1.BackupReplicaStatus{
+				BackupRepoName: testdp.BackupRepoName,
+				Path:           backupPath,
+			}
+			status, err := deleter.DeleteReplicaFiles(backup, replica)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(DeletionStatusSucceeded))
+		})
 	})
 
 	Context("delete volume snapshots", func() {
@@ -0,0 +1,316 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	replicateContainerName = "replicator"
+)
+
+type Replicator struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// Replicate builds a job to copy the backup files to the backup repository of the replica, and returns
+// the phase of the replica. The job is identified by the start timestamp of the replica, if the job exists,
+// it will check the job status and return the corresponding phase, and the time range of the replicated
+// data if the job is completed. The job should be removed by CleanupReplication after the result is recorded.
+func (r *Replicator) Replicate(backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus) (dpv1alpha1.BackupReplicaPhase, *dpv1alpha1.BackupTimeRange, error) {
+	if replica.StartTimestamp == nil {
+		return "", nil, fmt.Errorf("the start timestamp of the replica is empty")
+	}
+	jobKey := BuildReplicationJobKey(backup, replica)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, jobKey, job)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// if replication job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			timeRange, err := getReplicatedTimeRange(job)
+			return dpv1alpha1.BackupReplicaPhaseCompleted, timeRange, err
+		case batchv1.JobFailed:
+			return dpv1alpha1.BackupReplicaPhaseFailed, nil,
+				fmt.Errorf("replication job \"%s\" failed, %s", job.Name, msg)
+		}
+		return dpv1alpha1.BackupReplicaPhaseReplicating, nil, nil
+	}
+
+	if backup.Status.KopiaRepoPath != "" {
+		return dpv1alpha1.BackupReplicaPhaseFailed, nil,
+			fmt.Errorf("the backup stored in the kopia repository can not be replicated")
+	}
+	sourceRepo, err := r.getBackupRepo(backup.Status.BackupRepoName)
+	if sourceRepo == nil {
+		return dpv1alpha1.BackupReplicaPhaseFailed, nil, err
+	}
+	targetRepo, err := r.getBackupRepo(replica.BackupRepoName)
+	if targetRepo == nil {
+		return dpv1alpha1.BackupReplicaPhaseFailed, nil, err
+	}
+	if targetRepo.Status.Phase != dpv1alpha1.BackupRepoReady {
+		return dpv1alpha1.BackupReplicaPhaseFailed, nil, fmt.Errorf("backup repo %s is not ready", targetRepo.Name)
+	}
+	if err = r.checkBackupRepoInNamespace(targetRepo, backup.Namespace); err != nil {
+		return "", nil, err
+	}
+
+	backupFilePath := backup.Status.Path
+	// make sure the path has a leading slash
+	if !strings.HasPrefix(backupFilePath, "/") {
+		backupFilePath = "/" + backupFilePath
+	}
+	return dpv1alpha1.BackupReplicaPhaseReplicating, nil,
+		r.createReplicationJob(jobKey, backup, replica, backupFilePath, sourceRepo, targetRepo)
+}
+
+// CleanupReplication deletes the replication job of the replica.
+func (r *Replicator) CleanupReplication(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) error {
+	if replica.StartTimestamp == nil {
+		return nil
+	}
+	job := &batchv1.Job{}
+	if err := r.Client.Get(r.Ctx, BuildReplicationJobKey(backup, replica), job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return ctrlutil.BackgroundDeleteObject(r.Client, r.Ctx, job)
+}
+
+// getBackupRepo gets the backup repo by name, it returns nil and the error message if the backup repo is not found.
+func (r *Replicator) getBackupRepo(name string) (*dpv1alpha1.BackupRepo, error) {
+	if name == "" {
+		return nil, fmt.Errorf("backup repo of the backup is not found")
+	}
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Name: name}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("backup repo %s is not found", name)
+		}
+		return nil, err
+	}
+	return backupRepo, nil
+}
+
+// checkBackupRepoInNamespace checks whether the backup repo has been prepared in the namespace,
+// the PVC or the tool config secret is created by the BackupRepo controller.
+func (r *Replicator) checkBackupRepoInNamespace(repo *dpv1alpha1.BackupRepo, namespace string) error {
+	var (
+		key client.ObjectKey
+		obj client.Object
+	)
+	switch {
+	case repo.AccessByMount():
+		key = client.ObjectKey{Namespace: namespace, Name: repo.Status.BackupPVCName}
+		obj = &corev1.PersistentVolumeClaim{}
+	case repo.AccessByTool():
+		key = client.ObjectKey{Namespace: namespace, Name: repo.Status.ToolConfigSecretName}
+		obj = &corev1.Secret{}
+	default:
+		return fmt.Errorf("unknown access method of backup repo %s", repo.Name)
+	}
+	if exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, key, obj); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("backup repo %s is not ready in the namespace %s", repo.Name, namespace)
+	}
+	return nil
+}
+
+func (r *Replicator) buildReplicationScript(backupPath string) string {
+	// this script copies the backup files which do not exist in the target repository from the source
+	// repository. The backup files are immutable once uploaded, so only the new files, such as the log
+	// files archived by the continuous backups, are copied when the replication is run again.
+	replicationScript := fmt.Sprintf(`
+set -e
+set -o pipefail
+export PATH="$PATH:$%s"
+backupPath="%s"
+
+function repo_datasafed() {
+	localBackendPath="$1"
+	configFile="$2"
+	shift 2
+	if [ -n "${localBackendPath}" ]; then
+		DATASAFED_LOCAL_BACKEND_PATH="${localBackendPath}" datasafed "$@"
+	else
+		datasafed -c "${configFile}" "$@"
+	fi
+}
+
+function source_datasafed() {
+	repo_datasafed "$%s" "$%s" "$@"
+}
+
+function target_datasafed() {
+	repo_datasafed "$%s" "$%s" "$@"
+}
+
+sourceFiles=$(mktemp)
+targetFiles=$(mktemp)
+source_datasafed list -r -f "${backupPath}" | sort > "${sourceFiles}"
+if [ ! -s "${sourceFiles}" ]; then
+	echo "no backup files found in ${backupPath}"
+	exit 1
+fi
+target_datasafed list -r -f "${backupPath}" | sort > "${targetFiles}" || true
+
+comm -23 "${sourceFiles}" "${targetFiles}" | while IFS= read -r file; do
+	echo "copying ${file}"
+	source_datasafed pull "${file}" - < /dev/null | target_datasafed push - "${file}"
+done
+echo "replicated backup files in ${backupPath}"
+	`, dptypes.DPDatasafedBinPath, backupPath,
+		dptypes.DPReplicationSourceLocalBackendPath, dptypes.DPReplicationSourceDatasafedConfig,
+		dptypes.DPReplicationTargetLocalBackendPath, dptypes.DPReplicationTargetDatasafedConfig)
+
+	return replicationScript
+}
+
+func (r *Replicator) createReplicationJob(jobKey client.ObjectKey,
+	backup *dpv1alpha1.Backup,
+	replica *dpv1alpha1.BackupReplicaStatus,
+	backupPath string,
+	sourceRepo, targetRepo *dpv1alpha1.BackupRepo) error {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            replicateContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{r.buildReplicationScript(backupPath)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	// build pod
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: r.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	utils.InjectDatasafedForReplication(&podSpec, sourceRepo, targetRepo, backup.Status.EncryptionConfig)
+
+	// build job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:         dptypes.AppName,
+				dptypes.BackupReplicationRepoLabelKey: replica.BackupRepoName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	// record the time range of the backup data before the replication, all the data
+	// within the time range have been uploaded and will be copied by the job.
+	if backup.Status.TimeRange != nil {
+		timeRange, err := json.Marshal(backup.Status.TimeRange)
+		if err != nil {
+			return err
+		}
+		job.Annotations = map[string]string{
+			dptypes.ReplicationTimeRangeAnnotationKey: string(timeRange),
+		}
+	}
+	if err := utils.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return err
+	}
+	r.Log.V(1).Info("create a job to replicate the backup", "job", job)
+	return client.IgnoreAlreadyExists(r.Client.Create(r.Ctx, job))
+}
+
+func getReplicatedTimeRange(job *batchv1.Job) (*dpv1alpha1.BackupTimeRange, error) {
+	value, ok := job.Annotations[dptypes.ReplicationTimeRangeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	timeRange := &dpv1alpha1.BackupTimeRange{}
+	if err := json.Unmarshal([]byte(value), timeRange); err != nil {
+		return nil, err
+	}
+	return timeRange, nil
+}
+
+// replicaHash returns a short hash to distinguish the jobs of the replicas.
+func replicaHash(parts ...string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.Join(parts, "/")))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// BuildReplicationJobKey builds the key of the replication job, each replication of the replica
+// is run by a new job, as the continuous backups are replicated repeatedly.
+func BuildReplicationJobKey(backup *dpv1alpha1.Backup, replica *dpv1alpha1.BackupReplicaStatus) client.ObjectKey {
+	var startTime string
+	if replica.StartTimestamp != nil {
+		startTime = replica.StartTimestamp.UTC().Format(time.RFC3339)
+	}
+	jobName := fmt.Sprintf("%s-replicate-%s-%s", backup.UID[:8], replicaHash(replica.BackupRepoName, startTime), backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
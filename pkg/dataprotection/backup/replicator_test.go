@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("Backup Replicator Test", func() {
+	const (
+		backupPath        = "/backup/test-backup"
+		backupRepoPVCName = "backup-repo-pvc"
+	)
+
+	buildReplicator := func() *Replicator {
+		return &Replicator{
+			RequestCtx: ctrlutil.RequestCtx{
+				Log:      logger,
+				Ctx:      testCtx.Ctx,
+				Recorder: recorder,
+			},
+			Scheme: testEnv.Scheme,
+			Client: testCtx.Cli,
+		}
+	}
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, testdp.KBToolImage)
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, "")
+	})
+
+	Context("replicate backup", func() {
+		var (
+			backup     *dpv1alpha1.Backup
+			replicator *Replicator
+			sourceRepo *dpv1alpha1.BackupRepo
+			replica    *dpv1alpha1.BackupReplicaStatus
+		)
+
+		createBackupRepo := func() *dpv1alpha1.BackupRepo {
+			return testapps.CreateCustomizedObj(&testCtx, "backup/backuprepo.yaml", &dpv1alpha1.BackupRepo{}, nil)
+		}
+
+		markBackupRepoReady := func(repo *dpv1alpha1.BackupRepo) {
+			Expect(testapps.ChangeObjStatus(&testCtx, repo, func() {
+				repo.Status.Phase = dpv1alpha1.BackupRepoReady
+				repo.Status.BackupPVCName = backupRepoPVCName
+			})).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			sourceRepo = createBackupRepo()
+			backup = testdp.NewFakeBackup(&testCtx, nil)
+			backup.Status.BackupRepoName = sourceRepo.Name
+			backup.Status.Path = backupPath
+			replicator = buildReplicator()
+			now := metav1.Now()
+			replica = &dpv1alpha1.BackupReplicaStatus{
+				Path:           backupPath,
+				StartTimestamp: &now,
+			}
+		})
+
+		It("should fail when start timestamp is empty", func() {
+			replica.StartTimestamp = nil
+			phase, _, err := replicator.Replicate(backup, replica)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(BeEmpty())
+		})
+
+		It("should fail when target backup repo does not exist", func() {
+			replica.BackupRepoName = "non-existent-repo"
+			phase, _, err := replicator.Replicate(backup, replica)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.BackupReplicaPhaseFailed))
+		})
+
+		It("should fail when target backup repo is not ready", func() {
+			replica.BackupRepoName = createBackupRepo().Name
+			phase, _, err := replicator.Replicate(backup, replica)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.BackupReplicaPhaseFailed))
+		})
+
+		It("should create job to replicate the backup", func() {
+			By("mock target backup repo")
+			targetRepo := createBackupRepo()
+			markBackupRepoReady(targetRepo)
+			replica.BackupRepoName = targetRepo.Name
+
+			By("replicate backup before the backup repo is prepared in the namespace")
+			_, _, err := replicator.Replicate(backup, replica)
+			Expect(err).Should(HaveOccurred())
+
+			By("mock the PVC of the backup repo")
+			testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, backupRepoPVCName,
+				testdp.ClusterName, testdp.ComponentName, testdp.DataVolumeName).
+				SetStorage("1Gi").
+				Create(&testCtx)
+
+			By("replicate backup")
+			phase, _, err := replicator.Replicate(backup, replica)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.BackupReplicaPhaseReplicating))
+
+			By("check job exist")
+			job := &batchv1.Job{}
+			key := BuildReplicationJobKey(backup, replica)
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+
+			By("replicate backup with job succeed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobComplete)
+			Eventually(func(g Gomega) {
+				phase, _, err := replicator.Replicate(backup, replica)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(phase).Should(Equal(dpv1alpha1.BackupReplicaPhaseCompleted))
+			}).Should(Succeed())
+
+			By("replicate backup with job failed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobFailed)
+			Eventually(func(g Gomega) {
+				phase, _, err := replicator.Replicate(backup, replica)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(phase).Should(Equal(dpv1alpha1.BackupReplicaPhaseFailed))
+			}).Should(Succeed())
+
+			By("cleanup the replication job")
+			Expect(replicator.CleanupReplication(backup, replica)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+		})
+	})
+})
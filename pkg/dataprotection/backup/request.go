@@ -517,10 +517,30 @@ echo "backupInfo:${backup_info}"
 
 status="{\"status\":${backup_info}}"
 kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge --patch "${status}"
-
+%s
 # save the backup CR object to the backup repo
-kubectl -n "$namespace" get backups.dataprotection.kubeblocks.io "$backup_name" -o json | datasafed push - "/kubeblocks-backup.json"
-`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name)
+kubectl -n "$namespace" get backups.dataprotection.kubeblocks.io "$backup_name" -o json | datasafed push - "/%s"
+`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name,
+		r.buildRecordChecksumsCommand(), backupObjectFileName)
+}
+
+// buildRecordChecksumsCommand builds the command to record the checksums of the uploaded backup files,
+// which are compared with by the checksum verification of the backup.
+func (r *Request) buildRecordChecksumsCommand() string {
+	verification := r.BackupPolicy.Spec.Verification
+	if verification == nil || boolptr.IsSetToFalse(verification.Checksum) {
+		return ""
+	}
+	return fmt.Sprintf(`
+# record the checksums of the uploaded backup files
+%s
+checksums=$(mktemp)
+compute_checksums > "${checksums}"
+datasafed push "${checksums}" "/%s"
+record_time=$(date -u +%%Y-%%m-%%dT%%H:%%M:%%SZ)
+kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge \
+  --patch "{\"status\":{\"verification\":{\"checksumRecordTimestamp\":\"${record_time}\"}}}"
+`, buildComputeChecksumsFunc(), checksumManifestName)
 }
 
 func (r *Request) buildContinuousSyncProgressCommand() string {
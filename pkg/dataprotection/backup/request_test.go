@@ -134,6 +134,21 @@ var _ = Describe("Request Test", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("should record the checksums of the uploaded backup files if the checksum verification is enabled", func() {
+				request.Backup = backup
+				request.BackupPolicy = backupPolicy
+				Expect(request.buildSyncProgressCommand()).ShouldNot(ContainSubstring("compute_checksums"))
+
+				backupPolicy.Spec.Verification = &dpv1alpha1.BackupVerificationPolicy{}
+				command := request.buildSyncProgressCommand()
+				Expect(command).Should(ContainSubstring("compute_checksums"))
+				Expect(command).Should(ContainSubstring(`datasafed push "${checksums}" "/` + checksumManifestName + `"`))
+				Expect(command).Should(ContainSubstring("checksumRecordTimestamp"))
+
+				backupPolicy.Spec.Verification.Checksum = boolptr.False()
+				Expect(request.buildSyncProgressCommand()).ShouldNot(ContainSubstring("compute_checksums"))
+			})
+
 			It("build create volume snapshot action", func() {
 				request.TargetPods = []*corev1.Pod{targetPod}
 				request.BackupMethod = &dpv1alpha1.BackupMethod{
@@ -25,7 +25,9 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -91,6 +93,10 @@ func (s *Scheduler) validate() error {
 			return fmt.Errorf("backup method %s is not in backup policy %s/%s",
 				sp.BackupMethod, s.BackupPolicy.Namespace, s.BackupPolicy.Name)
 		}
+		// validate cron expression and time zone
+		if _, err := ParseCronSchedule(sp.CronExpression, sp.TimeZone); err != nil {
+			return err
+		}
 		// validate schedule parameters
 		if len(method.ActionSetName) > 0 && len(sp.Parameters) > 0 {
 			actionSet, err := dputils.GetActionSetByName(s.RequestCtx, s.Client, method.ActionSetName)
@@ -130,6 +136,11 @@ func (s *Scheduler) handleSchedulePolicy(index int) error {
 		}
 	}
 
+	// the schedule is evaluated by the controller, create backup directly
+	if s.BackupSchedule.IsControllerMode() {
+		return s.reconcileScheduledBackup(schedulePolicy)
+	}
+
 	// create/delete/patch cronjob workload
 	return s.reconcileCronJob(schedulePolicy)
 }
@@ -171,7 +182,7 @@ func (s *Scheduler) buildCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, cron
 		},
 	}
 
-	timeZone, cronExpression := BuildCronJobSchedule(schedulePolicy.CronExpression)
+	timeZone, cronExpression := BuildCronJobSchedule(schedulePolicy.CronExpression, schedulePolicy.TimeZone)
 	if timeZone != nil {
 		cronjob.Spec.Schedule = schedulePolicy.CronExpression
 		cronjob.Spec.TimeZone = timeZone
@@ -239,9 +250,8 @@ EOF
 	return podSpec, nil
 }
 
-// reconcileCronJob will create/delete/patch cronjob according to cronExpression and policy changes.
-func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
-	// get cronjob from labels
+// getCronJob gets the cronjob of the schedule policy, returns an empty cronjob if not found.
+func (s *Scheduler) getCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy) (*batchv1.CronJob, error) {
 	cronJob := &batchv1.CronJob{}
 	cronJobList := &batchv1.CronJobList{}
 	if err := s.Client.List(s.Ctx, cronJobList,
@@ -251,7 +261,7 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 			dptypes.BackupMethodLabelKey:   schedulePolicy.BackupMethod,
 		},
 	); err != nil {
-		return err
+		return nil, err
 	} else if len(cronJobList.Items) > 0 {
 		// the schedulePolicy name can be empty
 		targetCronJobName := GenerateCRNameByScheduleNameAndMethod(s.BackupSchedule, schedulePolicy.BackupMethod, schedulePolicy.Name)
@@ -262,19 +272,33 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 			}
 		}
 	}
+	return cronJob, nil
+}
 
-	// schedule is disabled, delete cronjob if exists
-	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) {
-		if len(cronJob.Name) != 0 {
-			// delete the old cronjob.
-			if err := dputils.RemoveDataProtectionFinalizer(s.Ctx, s.Client, cronJob); err != nil {
-				return err
-			}
-			return s.Client.Delete(s.Ctx, cronJob)
-		}
+// deleteCronJob deletes the cronjob of the schedule policy if exists.
+func (s *Scheduler) deleteCronJob(cronJob *batchv1.CronJob) error {
+	if len(cronJob.Name) == 0 {
 		// if cronjob does not exist, return
 		return nil
 	}
+	if err := dputils.RemoveDataProtectionFinalizer(s.Ctx, s.Client, cronJob); err != nil {
+		return err
+	}
+	return client.IgnoreNotFound(s.Client.Delete(s.Ctx, cronJob))
+}
+
+// reconcileCronJob will create/delete/patch cronjob according to cronExpression and policy changes.
+func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
+	// get cronjob from labels
+	cronJob, err := s.getCronJob(schedulePolicy)
+	if err != nil {
+		return err
+	}
+
+	// schedule is disabled, delete cronjob if exists
+	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) {
+		return s.deleteCronJob(cronJob)
+	}
 
 	cronjobProto, err := s.buildCronJob(schedulePolicy, cronJob.Name)
 	if err != nil {
@@ -306,6 +330,10 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 }
 
 func (s *Scheduler) generateBackupName(schedulePolicy *dpv1alpha1.SchedulePolicy) string {
+	return s.generateBackupNamePrefix(schedulePolicy) + "-$(date -u +'%Y%m%d%H%M%S')"
+}
+
+func (s *Scheduler) generateBackupNamePrefix(schedulePolicy *dpv1alpha1.SchedulePolicy) string {
 	var backupNamePrefix string
 	targets := dputils.GetBackupTargets(s.BackupPolicy, dputils.GetBackupMethodByName(schedulePolicy.BackupMethod, s.BackupPolicy))
 	if len(targets) > 0 {
@@ -322,7 +350,235 @@ func (s *Scheduler) generateBackupName(schedulePolicy *dpv1alpha1.SchedulePolicy
 	if len(name) > 0 {
 		backupNamePrefix = fmt.Sprintf("%s-%s", backupNamePrefix, name)
 	}
-	return backupNamePrefix + "-$(date -u +'%Y%m%d%H%M%S')"
+	return backupNamePrefix
+}
+
+// reconcileScheduledBackup evaluates the cron expression of the schedule policy in the controller,
+// and creates the backup directly when the scheduled time is reached.
+func (s *Scheduler) reconcileScheduledBackup(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
+	// the schedule is executed by the controller, delete the legacy cronjob if exists.
+	cronJob, err := s.getCronJob(schedulePolicy)
+	if err != nil {
+		return err
+	}
+	if err = s.deleteCronJob(cronJob); err != nil {
+		return err
+	}
+
+	scheduleName := schedulePolicy.GetScheduleName()
+	currentStatus := s.BackupSchedule.Status.Schedules[scheduleName]
+	status := *currentStatus.DeepCopy()
+	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) {
+		// reset the next schedule time, the schedule will wait for the next run when it is enabled again.
+		status.Phase = ""
+		status.NextScheduleTime = nil
+		return s.patchScheduleStatus(scheduleName, status)
+	}
+
+	cronSchedule, err := ParseCronSchedule(schedulePolicy.CronExpression, schedulePolicy.TimeZone)
+	if err != nil {
+		return err
+	}
+	backups, err := s.listScheduledBackups(scheduleName)
+	if err != nil {
+		return err
+	}
+	status.Phase = dpv1alpha1.ScheduleRunning
+	status.FailureReason = ""
+	if t := getLastSuccessfulTime(backups); t != nil {
+		status.LastSuccessfulTime = t
+	}
+
+	now := time.Now()
+	if status.NextScheduleTime == nil || status.NextScheduleTime.After(now) {
+		// recalculate the next schedule time in case of the cron expression or time zone is changed.
+		status.NextScheduleTime = &metav1.Time{Time: cronSchedule.Next(now)}
+		return s.patchScheduleStatus(scheduleName, status)
+	}
+
+	// the missed runs are merged into the most recent one.
+	scheduledTime := getMostRecentScheduleTime(cronSchedule, status.NextScheduleTime.Time, now)
+	finished, err := s.runScheduledBackup(schedulePolicy, backups, scheduledTime, now, &status)
+	if err != nil {
+		return err
+	}
+	if finished {
+		status.NextScheduleTime = &metav1.Time{Time: cronSchedule.Next(now)}
+	}
+	return s.patchScheduleStatus(scheduleName, status)
+}
+
+// runScheduledBackup creates the backup for the scheduled time according to the starting deadline
+// and concurrency policy. It returns false if the run should be retried later.
+func (s *Scheduler) runScheduledBackup(schedulePolicy *dpv1alpha1.SchedulePolicy,
+	backups []dpv1alpha1.Backup,
+	scheduledTime, now time.Time,
+	status *dpv1alpha1.ScheduleStatus) (bool, error) {
+	scheduleName := schedulePolicy.GetScheduleName()
+	if deadline := s.BackupSchedule.Spec.StartingDeadlineMinutes; deadline != nil &&
+		scheduledTime.Add(time.Duration(*deadline)*time.Minute).Before(now) {
+		s.Recorder.Eventf(s.BackupSchedule, corev1.EventTypeWarning, "MissedSchedule",
+			"skip the run of schedule %s at %s, the starting deadline is exceeded", scheduleName, scheduledTime.UTC().Format(time.RFC3339))
+		return true, nil
+	}
+
+	var activeBackups []*dpv1alpha1.Backup
+	for i := range backups {
+		if isActiveBackup(&backups[i]) {
+			activeBackups = append(activeBackups, &backups[i])
+		}
+	}
+	switch schedulePolicy.ConcurrencyPolicy {
+	case dpv1alpha1.ScheduleConcurrencyAllow:
+		// the backups are allowed to run concurrently.
+	case dpv1alpha1.ScheduleConcurrencyReplace:
+		for _, backup := range activeBackups {
+			if err := intctrlutil.BackgroundDeleteObject(s.Client, s.Ctx, backup); err != nil {
+				return false, err
+			}
+			s.Recorder.Eventf(s.BackupSchedule, corev1.EventTypeNormal, "ReplaceBackup",
+				"delete the running backup %s of schedule %s", backup.Name, scheduleName)
+		}
+	default:
+		// forbid the concurrent backups, wait for the running backups to finish.
+		if len(activeBackups) > 0 {
+			return false, nil
+		}
+	}
+
+	hasFullBackup, err := s.hasCompletedFullBackup(schedulePolicy)
+	if err != nil {
+		return false, err
+	}
+	if !hasFullBackup {
+		s.Recorder.Eventf(s.BackupSchedule, corev1.EventTypeWarning, "SkipSchedule",
+			"skip the run of schedule %s, no completed full backup found", scheduleName)
+		return true, nil
+	}
+
+	backup := s.buildScheduledBackup(schedulePolicy, scheduledTime)
+	// the backup name is generated by the scheduled time, ignore the backup which is already created.
+	if err = intctrlutil.IgnoreIsAlreadyExists(s.Client.Create(s.Ctx, backup)); err != nil {
+		return false, err
+	}
+	status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	status.LastBackupName = backup.Name
+	return true, nil
+}
+
+// buildScheduledBackup builds the backup created by the schedule policy at the scheduled time.
+func (s *Scheduler) buildScheduledBackup(schedulePolicy *dpv1alpha1.SchedulePolicy, scheduledTime time.Time) *dpv1alpha1.Backup {
+	return &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", s.generateBackupNamePrefix(schedulePolicy), scheduledTime.UTC().Format("20060102150405")),
+			Namespace: s.BackupSchedule.Namespace,
+			Labels: map[string]string{
+				dptypes.AutoBackupLabelKey:     "true",
+				dptypes.BackupScheduleLabelKey: s.BackupSchedule.Name,
+				dptypes.SchedulePolicyLabelKey: schedulePolicy.GetScheduleName(),
+			},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: s.BackupPolicy.Name,
+			BackupMethod:     schedulePolicy.BackupMethod,
+			RetentionPeriod:  schedulePolicy.RetentionPeriod,
+			Parameters:       schedulePolicy.Parameters,
+		},
+	}
+}
+
+// listScheduledBackups lists the backups created by the schedule policy in the controller.
+func (s *Scheduler) listScheduledBackups(scheduleName string) ([]dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := s.Client.List(s.Ctx, backupList,
+		client.InNamespace(s.BackupSchedule.Namespace),
+		client.MatchingLabels{
+			dptypes.BackupScheduleLabelKey: s.BackupSchedule.Name,
+			dptypes.SchedulePolicyLabelKey: scheduleName,
+		},
+	); err != nil {
+		return nil, err
+	}
+	return backupList.Items, nil
+}
+
+// hasCompletedFullBackup checks if the completed full backup exists for the incremental backup method.
+// It always returns true for other backup types.
+func (s *Scheduler) hasCompletedFullBackup(schedulePolicy *dpv1alpha1.SchedulePolicy) (bool, error) {
+	backupMethod := dputils.GetBackupMethodByName(schedulePolicy.BackupMethod, s.BackupPolicy)
+	actionSet, err := dputils.GetActionSetByName(s.RequestCtx, s.Client, backupMethod.ActionSetName)
+	if err != nil {
+		return false, err
+	}
+	if backupType := dputils.GetBackupType(actionSet, backupMethod.SnapshotVolumes); backupType != dpv1alpha1.BackupTypeIncremental {
+		return true, nil
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err = s.Client.List(s.Ctx, backupList,
+		client.InNamespace(s.BackupSchedule.Namespace),
+		client.MatchingLabels{
+			dptypes.BackupPolicyLabelKey: s.BackupSchedule.Spec.BackupPolicyName,
+			dptypes.BackupTypeLabelKey:   string(dpv1alpha1.BackupTypeFull),
+		},
+	); err != nil {
+		return false, err
+	}
+	for _, backup := range backupList.Items {
+		if backup.Spec.BackupMethod == backupMethod.CompatibleMethod &&
+			backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// patchScheduleStatus patches the status of the schedule policy if changed.
+func (s *Scheduler) patchScheduleStatus(scheduleName string, status dpv1alpha1.ScheduleStatus) error {
+	if reflect.DeepEqual(s.BackupSchedule.Status.Schedules[scheduleName], status) {
+		return nil
+	}
+	patch := client.MergeFrom(s.BackupSchedule.DeepCopy())
+	if s.BackupSchedule.Status.Schedules == nil {
+		s.BackupSchedule.Status.Schedules = map[string]dpv1alpha1.ScheduleStatus{}
+	}
+	s.BackupSchedule.Status.Schedules[scheduleName] = status
+	return s.Client.Status().Patch(s.Ctx, s.BackupSchedule, patch)
+}
+
+// getMostRecentScheduleTime returns the most recent scheduled time which is not later than now,
+// starting from the earliest scheduled time.
+func getMostRecentScheduleTime(cronSchedule cron.Schedule, earliest, now time.Time) time.Time {
+	scheduledTime := earliest
+	for t := cronSchedule.Next(earliest); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		scheduledTime = t
+	}
+	return scheduledTime
+}
+
+// getLastSuccessfulTime returns the latest completion time of the completed backups.
+func getLastSuccessfulTime(backups []dpv1alpha1.Backup) *metav1.Time {
+	var lastSuccessfulTime *metav1.Time
+	for _, backup := range backups {
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if lastSuccessfulTime == nil || lastSuccessfulTime.Before(backup.Status.CompletionTimestamp) {
+			lastSuccessfulTime = backup.Status.CompletionTimestamp.DeepCopy()
+		}
+	}
+	return lastSuccessfulTime
+}
+
+// isActiveBackup checks if the backup is still in progress.
+func isActiveBackup(backup *dpv1alpha1.Backup) bool {
+	if !backup.DeletionTimestamp.IsZero() {
+		return false
+	}
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted, dpv1alpha1.BackupPhaseFailed, dpv1alpha1.BackupPhaseDeleting:
+		return false
+	}
+	return true
 }
 
 func (s *Scheduler) getGenerateContinuousBackup(schedulePolicy *dpv1alpha1.SchedulePolicy) (*dpv1alpha1.Backup, error) {
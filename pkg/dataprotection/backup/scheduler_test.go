@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -66,6 +68,7 @@ var _ = Describe("Scheduler Test", func() {
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, ml, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
 	}
 
@@ -183,5 +186,103 @@ var _ = Describe("Scheduler Test", func() {
 				}, client.InNamespace(testCtx.DefaultNamespace))).Should(HaveLen(1))
 			})
 		})
+
+		Context("test Schedule in controller mode", func() {
+			// the schedule will not be reached during the test
+			const scheduleCron = "0 0 1 1 *"
+
+			scheduleName := testdp.BackupMethodName
+
+			setNextScheduleTime := func(t time.Time) {
+				if backupSchedule.Status.Schedules == nil {
+					backupSchedule.Status.Schedules = map[string]dpv1alpha1.ScheduleStatus{}
+				}
+				status := backupSchedule.Status.Schedules[scheduleName]
+				status.NextScheduleTime = &v1.Time{Time: t.Truncate(time.Second)}
+				backupSchedule.Status.Schedules[scheduleName] = status
+			}
+
+			scheduledBackups := func() func(gomega Gomega) []dpv1alpha1.Backup {
+				return testapps.List(&testCtx, generics.BackupSignature, client.MatchingLabels{
+					dptypes.BackupScheduleLabelKey: backupSchedule.Name,
+					dptypes.SchedulePolicyLabelKey: scheduleName,
+				}, client.InNamespace(testCtx.DefaultNamespace))
+			}
+
+			BeforeEach(func() {
+				Expect(testapps.ChangeObj(&testCtx, backupSchedule, func(schedule *dpv1alpha1.BackupSchedule) {
+					schedule.Spec.ScheduleMode = dpv1alpha1.ScheduleModeController
+					for i := range schedule.Spec.Schedules {
+						if schedule.Spec.Schedules[i].BackupMethod == testdp.BackupMethodName {
+							schedule.Spec.Schedules[i].Enabled = pointer.Bool(true)
+							schedule.Spec.Schedules[i].CronExpression = scheduleCron
+						}
+					}
+				})).Should(Succeed())
+				scheduler.BackupPolicy = backupPolicy
+				scheduler.BackupSchedule = backupSchedule
+			})
+
+			It("should record the next schedule time", func() {
+				Expect(scheduler.Schedule()).Should(Succeed())
+				status := backupSchedule.Status.Schedules[scheduleName]
+				Expect(status.Phase).Should(Equal(dpv1alpha1.ScheduleRunning))
+				Expect(status.NextScheduleTime).ShouldNot(BeNil())
+				Expect(status.NextScheduleTime.After(time.Now())).Should(BeTrue())
+				Consistently(scheduledBackups()).Should(HaveLen(0))
+			})
+
+			It("should create backup and forbid concurrent backups", func() {
+				By("the schedule is due")
+				scheduledTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+				setNextScheduleTime(scheduledTime)
+				Expect(scheduler.Schedule()).Should(Succeed())
+				status := backupSchedule.Status.Schedules[scheduleName]
+				Expect(status.LastScheduleTime).ShouldNot(BeNil())
+				Expect(status.LastScheduleTime.Unix()).Should(Equal(scheduledTime.Unix()))
+				Expect(status.NextScheduleTime.After(time.Now())).Should(BeTrue())
+				Eventually(scheduledBackups()).Should(HaveLen(1))
+				backup := &dpv1alpha1.Backup{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: status.LastBackupName, Namespace: testCtx.DefaultNamespace}, backup)).Should(Succeed())
+				Expect(backup.Spec.BackupPolicyName).Should(Equal(backupPolicy.Name))
+				Expect(backup.Spec.BackupMethod).Should(Equal(testdp.BackupMethodName))
+
+				By("the next run is postponed because the previous backup is running")
+				setNextScheduleTime(time.Now().Add(-time.Second))
+				Expect(scheduler.Schedule()).Should(Succeed())
+				status = backupSchedule.Status.Schedules[scheduleName]
+				Expect(status.NextScheduleTime.After(time.Now())).Should(BeFalse())
+				Consistently(scheduledBackups()).Should(HaveLen(1))
+			})
+
+			It("should replace the running backup", func() {
+				Expect(testapps.ChangeObj(&testCtx, backupSchedule, func(schedule *dpv1alpha1.BackupSchedule) {
+					for i := range schedule.Spec.Schedules {
+						schedule.Spec.Schedules[i].ConcurrencyPolicy = dpv1alpha1.ScheduleConcurrencyReplace
+					}
+				})).Should(Succeed())
+				setNextScheduleTime(time.Now().Add(-2 * time.Minute))
+				Expect(scheduler.Schedule()).Should(Succeed())
+				Eventually(scheduledBackups()).Should(HaveLen(1))
+				firstBackupName := backupSchedule.Status.Schedules[scheduleName].LastBackupName
+
+				setNextScheduleTime(time.Now().Add(-time.Minute))
+				Expect(scheduler.Schedule()).Should(Succeed())
+				lastBackupName := backupSchedule.Status.Schedules[scheduleName].LastBackupName
+				Expect(lastBackupName).ShouldNot(Equal(firstBackupName))
+				Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKey{Name: firstBackupName, Namespace: testCtx.DefaultNamespace},
+					&dpv1alpha1.Backup{}, false)).Should(Succeed())
+				Eventually(scheduledBackups()).Should(HaveLen(1))
+			})
+
+			It("should skip the run if the starting deadline is exceeded", func() {
+				setNextScheduleTime(time.Now().Add(-time.Hour))
+				Expect(scheduler.Schedule()).Should(Succeed())
+				status := backupSchedule.Status.Schedules[scheduleName]
+				Expect(status.LastScheduleTime).Should(BeNil())
+				Expect(status.NextScheduleTime.After(time.Now())).Should(BeTrue())
+				Consistently(scheduledBackups()).Should(HaveLen(0))
+			})
+		})
 	})
 })
@@ -26,7 +26,9 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -313,8 +315,12 @@ func SetExpirationTime(backup *dpv1alpha1.Backup) error {
 //
 // For kubernetes version < 1.22, the CRON_TZ environment variable is not supported.
 // The kube-controller-manager interprets schedules relative to its local time zone.
-func BuildCronJobSchedule(cronExpression string) (*string, string) {
-	timeZone := "UTC"
+//
+// If timeZone is empty, UTC is used.
+func BuildCronJobSchedule(cronExpression, timeZone string) (*string, string) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
 	ver, err := intctrlutil.GetKubeVersion()
 	if err != nil {
 		return nil, cronExpression
@@ -328,6 +334,22 @@ func BuildCronJobSchedule(cronExpression string) (*string, string) {
 	return nil, fmt.Sprintf("CRON_TZ=%s %s", timeZone, cronExpression)
 }
 
+// ParseCronSchedule parses the standard cron expression which is evaluated in the
+// specified time zone. If timeZone is empty, UTC is used.
+func ParseCronSchedule(cronExpression, timeZone string) (cron.Schedule, error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, fmt.Errorf("invalid time zone %s: %v", timeZone, err)
+	}
+	schedule, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", timeZone, cronExpression))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %s: %v", cronExpression, err)
+	}
+	return schedule, nil
+}
+
 // StopStatefulSetsWhenFailed stops the sts to un-bound the pvcs.
 func StopStatefulSetsWhenFailed(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup, targetName string) error {
 	if backup.Status.Phase != dpv1alpha1.BackupPhaseFailed {
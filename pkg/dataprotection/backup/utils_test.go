@@ -85,13 +85,75 @@ func TestBuildCronJobSchedule(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			viper.Set(constant.CfgKeyServerInfo, tt.versionInfo)
-			tz, cronExp := BuildCronJobSchedule(cronExpression)
+			tz, cronExp := BuildCronJobSchedule(cronExpression, "")
 			assert.Equal(t, tt.cronExpression, cronExp)
 			assert.Equal(t, tt.timeZone, tz)
 		})
 	}
 }
 
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		name           string
+		cronExpression string
+		timeZone       string
+		from           time.Time
+		expected       time.Time
+		expectedError  bool
+	}{
+		{
+			name:           "default time zone",
+			cronExpression: "0 3 * * *",
+			from:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:       time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "explicit time zone",
+			cronExpression: "0 3 * * *",
+			timeZone:       "Asia/Shanghai",
+			from:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:       time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "invalid time zone",
+			cronExpression: "0 3 * * *",
+			timeZone:       "invalid",
+			expectedError:  true,
+		},
+		{
+			name:           "invalid cron expression",
+			cronExpression: "invalid",
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.cronExpression, tt.timeZone)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(schedule.Next(tt.from)))
+		})
+	}
+}
+
+func TestGetMostRecentScheduleTime(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 * * * *", "")
+	assert.NoError(t, err)
+	earliest := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	// no missed runs
+	now := earliest.Add(30 * time.Minute)
+	assert.True(t, earliest.Equal(getMostRecentScheduleTime(schedule, earliest, now)))
+
+	// the missed runs are merged into the most recent one
+	now = earliest.Add(3*time.Hour + 30*time.Minute)
+	assert.True(t, earliest.Add(3*time.Hour).Equal(getMostRecentScheduleTime(schedule, earliest, now)))
+}
+
 func TestSetExpirationTime(t *testing.T) {
 	now := metav1.Now()
 	tests := []struct {
@@ -0,0 +1,375 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	verifyContainerName  = "verifier"
+	restoreContainerName = "restore"
+
+	// restoreDataVolumeName is the name of the volume which the backup data is restored into to verify.
+	restoreDataVolumeName = "dp-verify-data"
+
+	// checksumManifestName is the name of the file which records the checksums of the backup data,
+	// it is stored in the backup path of each backup target in the backup repository.
+	checksumManifestName = ".kb-checksums.sha256"
+
+	// backupObjectFileName is the name of the file which the backup job saves the backup object to.
+	backupObjectFileName = "kubeblocks-backup.json"
+
+	// checksumModeAnnotationKey records whether the checksum verification job records or compares the checksums.
+	checksumModeAnnotationKey = "dataprotection.kubeblocks.io/checksum-mode"
+	checksumModeRecord        = "record"
+	checksumModeCompare       = "compare"
+)
+
+type VerificationType string
+
+const (
+	// VerificationTypeChecksum verifies the checksums of the backup data in the backup repository.
+	VerificationTypeChecksum VerificationType = "checksum"
+	// VerificationTypeRestore verifies the backup by restoring it in a throwaway pod.
+	VerificationTypeRestore VerificationType = "restore"
+)
+
+type Verifier struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// Verify builds a job to verify the backup, and returns the verification phase.
+// If the verification job exists, it will check the job status and return the corresponding phase,
+// the job should be removed by CleanupVerification after the result is recorded.
+func (v *Verifier) Verify(backup *dpv1alpha1.Backup, verificationType VerificationType,
+	actionSet *dpv1alpha1.ActionSet) (dpv1alpha1.VerificationPhase, error) {
+	jobKey := BuildVerificationJobKey(backup, verificationType)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, jobKey, job)
+	if err != nil {
+		return "", err
+	}
+
+	// if verification job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			if job.Annotations[checksumModeAnnotationKey] == checksumModeRecord {
+				return dpv1alpha1.VerificationPhaseChecksumRecorded, nil
+			}
+			return dpv1alpha1.VerificationPhaseVerified, nil
+		case batchv1.JobFailed:
+			return dpv1alpha1.VerificationPhaseFailed,
+				fmt.Errorf("%s verification job \"%s\" failed, %s", verificationType, job.Name, msg)
+		}
+		return dpv1alpha1.VerificationPhaseVerifying, nil
+	}
+
+	if backup.Status.BackupRepoName == "" {
+		return dpv1alpha1.VerificationPhaseFailed, fmt.Errorf("backup repo of the backup is not found")
+	}
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = v.Client.Get(v.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return dpv1alpha1.VerificationPhaseFailed, fmt.Errorf("backup repo %s is not found", backup.Status.BackupRepoName)
+		}
+		return "", err
+	}
+
+	backupFilePath := backup.Status.Path
+	// make sure the path has a leading slash
+	if !strings.HasPrefix(backupFilePath, "/") {
+		backupFilePath = "/" + backupFilePath
+	}
+
+	var (
+		containers  []corev1.Container
+		volumes     []corev1.Volume
+		annotations map[string]string
+	)
+	switch verificationType {
+	case VerificationTypeChecksum:
+		// the checksums are recorded by the first verification, the backup is not verified until they are
+		// compared with by the subsequent verifications.
+		mode := checksumModeRecord
+		if backup.Status.Verification != nil && backup.Status.Verification.ChecksumRecordTimestamp != nil {
+			mode = checksumModeCompare
+		}
+		containers = []corev1.Container{v.buildChecksumContainer(backupFilePath, mode)}
+		annotations = map[string]string{checksumModeAnnotationKey: mode}
+	case VerificationTypeRestore:
+		if actionSet == nil || actionSet.Spec.Backup == nil || actionSet.Spec.Backup.Verify == nil {
+			return dpv1alpha1.VerificationPhaseFailed, fmt.Errorf("the verify action is not defined")
+		}
+		if actionSet.Spec.Restore == nil || actionSet.Spec.Restore.PrepareData == nil {
+			return dpv1alpha1.VerificationPhaseFailed, fmt.Errorf("the prepareData action of the restore is not defined")
+		}
+		if containers, volumes, err = v.buildRestoreContainers(backup, backupFilePath, actionSet); err != nil {
+			return dpv1alpha1.VerificationPhaseFailed, err
+		}
+	default:
+		return "", fmt.Errorf("unknown verification type %s", verificationType)
+	}
+	return dpv1alpha1.VerificationPhaseVerifying, v.createVerificationJob(containers, volumes, jobKey, annotations, backup, backupRepo, verificationType)
+}
+
+// CleanupVerification deletes the verification job of the backup.
+func (v *Verifier) CleanupVerification(backup *dpv1alpha1.Backup, verificationType VerificationType) error {
+	job := &batchv1.Job{}
+	if err := v.Client.Get(v.Ctx, BuildVerificationJobKey(backup, verificationType), job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return ctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, job)
+}
+
+// buildComputeChecksumsFunc builds the shell function to compute the checksums of the backup files under the
+// datasafed backend base path, the checksum manifests and the backup object pushed by the backup job are excluded.
+func buildComputeChecksumsFunc() string {
+	return fmt.Sprintf(`
+compute_checksums() {
+	datasafed list -r -f / | { grep -v -e "%s$" -e "%s$" || true; } | sort | while IFS= read -r file; do
+		sum=$(datasafed pull "${file}" - < /dev/null | sha256sum | awk '{print $1}')
+		echo "${sum}  ${file}"
+	done
+}
+`, checksumManifestName, backupObjectFileName)
+}
+
+func (v *Verifier) buildChecksumScript(backupPath, mode string) string {
+	// this script computes the checksums of the backup files, and compares them with the manifest files found
+	// in the backup path. The manifests are recorded by the backup jobs for each backup target when the backup
+	// data is uploaded, and the checksums are computed relative to the directory of each manifest.
+	// If no manifest is found, the checksums are recorded into the backup path in the record mode,
+	// which is for the backups whose checksums are not recorded at upload time.
+	checksumScript := fmt.Sprintf(`
+set -e
+set -o pipefail
+export PATH="$PATH:$%s"
+targetPath="%s"
+manifestName="%s"
+mode="%s"
+checksums=$(mktemp)
+%s
+manifests=$(datasafed list -r -f "${targetPath}" | { grep "${manifestName}$" || true; })
+if [ -n "${manifests}" ]; then
+	echo "${manifests}" | while IFS= read -r manifest; do
+		echo "comparing checksums with ${manifest}"
+		(export DATASAFED_BACKEND_BASE_PATH="$(dirname "${manifest}")"; compute_checksums) > "${checksums}"
+		datasafed pull "${manifest}" - < /dev/null | diff - "${checksums}"
+	done
+elif [ "${mode}" = "%s" ]; then
+	echo "computing checksums of backup files in ${targetPath}"
+	(export DATASAFED_BACKEND_BASE_PATH="${targetPath}"; compute_checksums) > "${checksums}"
+	if [ ! -s "${checksums}" ]; then
+		echo "no backup files found in ${targetPath}"
+		exit 1
+	fi
+	echo "recording checksums to ${targetPath}/${manifestName}"
+	datasafed push "${checksums}" "${targetPath}/${manifestName}"
+else
+	echo "the recorded checksums ${manifestName} are not found in ${targetPath}"
+	exit 1
+fi
+	`, dptypes.DPDatasafedBinPath, backupPath, checksumManifestName, mode, buildComputeChecksumsFunc(), checksumModeRecord)
+
+	return checksumScript
+}
+
+func (v *Verifier) buildChecksumContainer(backupPath, mode string) corev1.Container {
+	runAsUser := int64(0)
+	return corev1.Container{
+		Name:            verifyContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{v.buildChecksumScript(backupPath, mode)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+}
+
+// buildRestoreEnv builds the envs of the restore and verify containers, which are consistent with the ones
+// provided to the restore jobs.
+func (v *Verifier) buildRestoreEnv(backup *dpv1alpha1.Backup, backupPath string, actionSet *dpv1alpha1.ActionSet) []corev1.EnvVar {
+	target := backup.Status.Target
+	if target == nil && len(backup.Status.Targets) > 0 {
+		target = &backup.Status.Targets[0]
+	}
+	targetRelativePath := ""
+	if target != nil && target.PodSelector != nil {
+		// restore the data backed up from the first target pod
+		targetPodName := ""
+		if len(target.SelectedTargetPods) > 0 {
+			targetPodName = target.SelectedTargetPods[0]
+		}
+		targetRelativePath = BuildTargetRelativePath(&target.BackupTarget, targetPodName)
+	} else if target != nil {
+		targetRelativePath = target.Name
+	}
+	envVars := []corev1.EnvVar{
+		{Name: dptypes.DPBackupName, Value: backup.Name},
+		{Name: dptypes.DPTargetRelativePath, Value: targetRelativePath},
+		{Name: dptypes.DPBackupRootPath, Value: filepath.Join(backupPath, "../")},
+		{Name: dptypes.DPBackupBasePath, Value: filepath.Join(backupPath, targetRelativePath)},
+	}
+	envVars = append(envVars, actionSet.Spec.Env...)
+	if backupMethod := backup.Status.BackupMethod; backupMethod != nil && len(backupMethod.Env) > 0 {
+		envVars = utils.MergeEnv(envVars, backupMethod.Env)
+	}
+	return envVars
+}
+
+// buildRestoreContainers builds the containers to restore the backup and verify the restored data.
+// The backup is restored by the prepareData action of the ActionSet into an emptyDir volume, which is mounted
+// at the mount paths of the target volumes of the backup method, and the verify action runs against it.
+func (v *Verifier) buildRestoreContainers(backup *dpv1alpha1.Backup, backupPath string,
+	actionSet *dpv1alpha1.ActionSet) ([]corev1.Container, []corev1.Volume, error) {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod == nil || backupMethod.TargetVolumes == nil || len(backupMethod.TargetVolumes.VolumeMounts) == 0 {
+		return nil, nil, fmt.Errorf("the target volumes of the backup method are not found")
+	}
+	volume := corev1.Volume{
+		Name:         restoreDataVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	var volumeMounts []corev1.VolumeMount
+	for _, mount := range backupMethod.TargetVolumes.VolumeMounts {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: mount.MountPath,
+			SubPath:   mount.Name,
+		})
+	}
+
+	runAsUser := int64(0)
+	envVars := v.buildRestoreEnv(backup, backupPath, actionSet)
+	buildContainer := func(name string, action *dpv1alpha1.BaseJobActionSpec) corev1.Container {
+		image := common.Expand(action.Image, common.MappingFuncFor(utils.CovertEnvToMap(envVars)))
+		return corev1.Container{
+			Name:            name,
+			Command:         action.Command,
+			Image:           ctrlutil.ReplaceImageRegistry(image),
+			Env:             slices.Clone(envVars),
+			VolumeMounts:    slices.Clone(volumeMounts),
+			ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolptr.False(),
+				RunAsUser:                &runAsUser,
+			},
+		}
+	}
+	containers := []corev1.Container{
+		buildContainer(restoreContainerName, &actionSet.Spec.Restore.PrepareData.BaseJobActionSpec),
+		buildContainer(verifyContainerName, actionSet.Spec.Backup.Verify),
+	}
+	return containers, []corev1.Volume{volume}, nil
+}
+
+// createVerificationJob creates the job to run the containers in order, the last container is the main container,
+// and the others run as the init containers after datasafed is installed.
+func (v *Verifier) createVerificationJob(containers []corev1.Container,
+	volumes []corev1.Volume,
+	jobKey client.ObjectKey,
+	annotations map[string]string,
+	backup *dpv1alpha1.Backup,
+	backupRepo *dpv1alpha1.BackupRepo,
+	verificationType VerificationType) error {
+	for i := range containers {
+		ctrlutil.InjectZeroResourcesLimitsIfEmpty(&containers[i])
+	}
+
+	// build pod
+	podSpec := corev1.PodSpec{
+		Containers:         containers,
+		Volumes:            volumes,
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: v.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	// the backup repo is injected into all the containers
+	utils.InjectDatasafed(&podSpec, backupRepo, RepoVolumeMountPath, backup.Status.EncryptionConfig, backup.Status.KopiaRepoPath)
+	last := len(podSpec.Containers) - 1
+	podSpec.InitContainers = append(podSpec.InitContainers, podSpec.Containers[:last]...)
+	podSpec.Containers = podSpec.Containers[last:]
+
+	// build job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:      dptypes.AppName,
+				dptypes.BackupVerificationLabelKey: string(verificationType),
+			},
+			Annotations: annotations,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backup, job, v.Scheme); err != nil {
+		return err
+	}
+	v.Log.V(1).Info("create a job to verify the backup", "job", job)
+	return client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, job))
+}
+
+func BuildVerificationJobKey(backup *dpv1alpha1.Backup, verificationType VerificationType) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-verify-%s-%s", backup.UID[:8], verificationType, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
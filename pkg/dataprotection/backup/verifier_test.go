@@ -0,0 +1,228 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("Backup Verifier Test", func() {
+	const (
+		backupPath = "/backup/test-backup"
+	)
+
+	buildVerifier := func() *Verifier {
+		return &Verifier{
+			RequestCtx: ctrlutil.RequestCtx{
+				Log:      logger,
+				Ctx:      testCtx.Ctx,
+				Recorder: recorder,
+			},
+			Scheme: testEnv.Scheme,
+			Client: testCtx.Cli,
+		}
+	}
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, testdp.KBToolImage)
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, "")
+	})
+
+	Context("verify backup", func() {
+		var (
+			backup   *dpv1alpha1.Backup
+			verifier *Verifier
+		)
+
+		BeforeEach(func() {
+			backup = testdp.NewFakeBackup(&testCtx, nil)
+			verifier = buildVerifier()
+		})
+
+		It("should fail when backup repo does not exist", func() {
+			phase, err := verifier.Verify(backup, VerificationTypeChecksum, nil)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseFailed))
+		})
+
+		It("should fail when the verify action is not defined", func() {
+			repo := testapps.CreateCustomizedObj(&testCtx, "backup/backuprepo.yaml", &dpv1alpha1.BackupRepo{}, nil)
+			backup.Status.BackupRepoName = repo.Name
+			backup.Status.Path = backupPath
+			phase, err := verifier.Verify(backup, VerificationTypeRestore, &dpv1alpha1.ActionSet{})
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseFailed))
+		})
+
+		It("should create job to restore and verify the backup", func() {
+			repo := testapps.CreateCustomizedObj(&testCtx, "backup/backuprepo.yaml", &dpv1alpha1.BackupRepo{}, nil)
+			backup.Status.BackupRepoName = repo.Name
+			backup.Status.Path = backupPath
+			actionSet := &dpv1alpha1.ActionSet{
+				Spec: dpv1alpha1.ActionSetSpec{
+					Backup: &dpv1alpha1.BackupActionSpec{
+						Verify: &dpv1alpha1.BaseJobActionSpec{
+							Image:   testdp.KBToolImage,
+							Command: []string{"sh", "-c", "verify"},
+						},
+					},
+					Restore: &dpv1alpha1.RestoreActionSpec{
+						PrepareData: &dpv1alpha1.JobActionSpec{
+							BaseJobActionSpec: dpv1alpha1.BaseJobActionSpec{
+								Image:   testdp.KBToolImage,
+								Command: []string{"sh", "-c", "restore"},
+							},
+						},
+					},
+				},
+			}
+
+			By("the target volumes are required to restore the data")
+			phase, err := verifier.Verify(backup, VerificationTypeRestore, actionSet)
+			Expect(err).Should(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseFailed))
+
+			By("verify backup")
+			backup.Status.BackupMethod = &dpv1alpha1.BackupMethod{
+				Name: "test",
+				TargetVolumes: &dpv1alpha1.TargetVolumeInfo{
+					Volumes:      []string{"data"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+				},
+			}
+			phase, err = verifier.Verify(backup, VerificationTypeRestore, actionSet)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseVerifying))
+
+			By("the backup is restored before verified")
+			job := &batchv1.Job{}
+			key := BuildVerificationJobKey(backup, VerificationTypeRestore)
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.InitContainers).ShouldNot(BeEmpty())
+			restoreContainer := podSpec.InitContainers[len(podSpec.InitContainers)-1]
+			Expect(restoreContainer.Name).Should(Equal(restoreContainerName))
+			Expect(restoreContainer.Command).Should(Equal(actionSet.Spec.Restore.PrepareData.Command))
+			Expect(podSpec.Containers).Should(HaveLen(1))
+			Expect(podSpec.Containers[0].Name).Should(Equal(verifyContainerName))
+			Expect(podSpec.Containers[0].Command).Should(Equal(actionSet.Spec.Backup.Verify.Command))
+			dataMount := corev1.VolumeMount{Name: restoreDataVolumeName, MountPath: "/data", SubPath: "data"}
+			for _, c := range []corev1.Container{restoreContainer, podSpec.Containers[0]} {
+				Expect(c.VolumeMounts).Should(ContainElement(dataMount))
+				Expect(c.Env).Should(ContainElement(corev1.EnvVar{Name: dptypes.DPBackupBasePath, Value: backupPath}))
+				Expect(c.Env).Should(ContainElement(HaveField("Name", dptypes.DPDatasafedBinPath)))
+			}
+			Expect(podSpec.Volumes).Should(ContainElement(HaveField("Name", restoreDataVolumeName)))
+		})
+
+		It("should create job to verify the checksums", func() {
+			By("mock backup repo")
+			repo := testapps.CreateCustomizedObj(&testCtx, "backup/backuprepo.yaml", &dpv1alpha1.BackupRepo{}, nil)
+
+			By("verify backup")
+			backup.Status.BackupRepoName = repo.Name
+			backup.Status.Path = backupPath
+			phase, err := verifier.Verify(backup, VerificationTypeChecksum, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseVerifying))
+
+			By("check job exist")
+			job := &batchv1.Job{}
+			key := BuildVerificationJobKey(backup, VerificationTypeChecksum)
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+
+			Expect(job.Annotations).Should(HaveKeyWithValue(checksumModeAnnotationKey, checksumModeRecord))
+			// the manifests recorded by the backup jobs are compared with if they exist
+			Expect(job.Spec.Template.Spec.Containers[0].Args[0]).Should(ContainSubstring(`grep "${manifestName}$"`))
+
+			By("the checksums are recorded by the first verification")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobComplete)
+			Eventually(func(g Gomega) {
+				phase, err := verifier.Verify(backup, VerificationTypeChecksum, nil)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseChecksumRecorded))
+			}).Should(Succeed())
+			Expect(verifier.CleanupVerification(backup, VerificationTypeChecksum)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+
+			By("compare with the recorded checksums")
+			backup.Status.Verification = &dpv1alpha1.BackupVerificationStatus{
+				ChecksumRecordTimestamp: &metav1.Time{Time: time.Now()},
+			}
+			phase, err = verifier.Verify(backup, VerificationTypeChecksum, nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseVerifying))
+			Eventually(testapps.CheckObj(&testCtx, key, func(g Gomega, job *batchv1.Job) {
+				g.Expect(job.Annotations).Should(HaveKeyWithValue(checksumModeAnnotationKey, checksumModeCompare))
+			})).Should(Succeed())
+
+			By("verify backup with job succeed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobComplete)
+			Eventually(func(g Gomega) {
+				phase, err := verifier.Verify(backup, VerificationTypeChecksum, nil)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseVerified))
+			}).Should(Succeed())
+
+			By("verify backup with job failed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobFailed)
+			Eventually(func(g Gomega) {
+				phase, err := verifier.Verify(backup, VerificationTypeChecksum, nil)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(phase).Should(Equal(dpv1alpha1.VerificationPhaseFailed))
+			}).Should(Succeed())
+
+			By("cleanup the verification")
+			Expect(verifier.CleanupVerification(backup, VerificationTypeChecksum)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+		})
+	})
+})
@@ -35,6 +35,8 @@ const (
 	ErrorTypeBackupPVCNameIsEmpty intctrlutil.ErrorType = "BackupPVCNameIsEmpty"
 	// ErrorTypeBackupRepoIsNotReady the backup repository is not ready
 	ErrorTypeBackupRepoIsNotReady intctrlutil.ErrorType = "BackupRepoIsNotReady"
+	// ErrorTypeBackupRepoQuotaExceeded the usage of the backup repository reaches its quota
+	ErrorTypeBackupRepoQuotaExceeded intctrlutil.ErrorType = "BackupRepoQuotaExceeded"
 	// ErrorTypeToolConfigSecretNameIsEmpty the name of  repository is not ready
 	ErrorTypeToolConfigSecretNameIsEmpty intctrlutil.ErrorType = "ToolConfigSecretNameIsEmpty"
 	// ErrorTypeBackupJobFailed backup job failed
@@ -71,6 +73,11 @@ func NewBackupRepoIsNotReady(backupRepo string) *intctrlutil.Error {
 	return intctrlutil.NewErrorf(ErrorTypeBackupRepoIsNotReady, `the backup repository %s is not ready`, backupRepo)
 }
 
+// NewBackupRepoQuotaExceeded returns a new Error with ErrorTypeBackupRepoQuotaExceeded.
+func NewBackupRepoQuotaExceeded(backupRepo, used, quota string) *intctrlutil.Error {
+	return intctrlutil.NewErrorf(ErrorTypeBackupRepoQuotaExceeded, `the backup repository %s has exceeded its quota, used %s of %s`, backupRepo, used, quota)
+}
+
 // NewToolConfigSecretNameIsEmpty returns a new Error with ErrorTypeToolConfigSecretNameIsEmpty.
 func NewToolConfigSecretNameIsEmpty(backupRepo string) *intctrlutil.Error {
 	return intctrlutil.NewErrorf(ErrorTypeToolConfigSecretNameIsEmpty, `the secret name of tool config from %s is empty`, backupRepo)
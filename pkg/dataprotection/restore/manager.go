@@ -99,6 +99,9 @@ func (r *RestoreManager) GetBackupActionSetByNamespaced(reqCtx intctrlutil.Reque
 	if backupMethod == nil {
 		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`status.backupMethod of backup "%s" is empty`, backupName))
 	}
+	if err := UseBackupReplica(backup, r.Restore.Spec.Backup.BackupRepoName); err != nil {
+		return nil, err
+	}
 	useVolumeSnapshot := backupMethod.SnapshotVolumes != nil && *backupMethod.SnapshotVolumes
 	actionSet, err := utils.GetActionSetByName(reqCtx, cli, backup.Status.BackupMethod.ActionSetName)
 	if err != nil {
@@ -239,7 +242,12 @@ func (r *RestoreManager) getBaseBackupActionSetForContinuous(reqCtx intctrlutil.
 
 	// 2. get the latest backup object
 	var latestBackup *dpv1alpha1.Backup
+	backupRepoName := r.Restore.Spec.Backup.BackupRepoName
 	for _, item := range backupItems {
+		// the base backup must be restored from the same backup repository as the continuous backup
+		if UseBackupReplica(&item, backupRepoName) != nil {
+			continue
+		}
 		backupStopTime := item.GetEndTime()
 		// latest backup rules:
 		// 1. Full or Incremental backup's stopTime must after Continuous backup's startTime.
@@ -275,6 +275,30 @@ func ValidateAndInitRestoreMGR(reqCtx intctrlutil.RequestCtx,
 	return err
 }
 
+// UseBackupReplica makes the backup to be restored from its replica in the specified backup repository,
+// by replacing the backup repository, the path and the time range of the backup with the replica's.
+// The backup object is only modified in memory.
+func UseBackupReplica(backup *dpv1alpha1.Backup, backupRepoName string) error {
+	if backupRepoName == "" || backupRepoName == backup.Status.BackupRepoName {
+		if backup.Status.PrimaryDeletionTimestamp != nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" has been deleted from backup repo "%s", restore it from its replicas`,
+				backup.Name, backup.Status.BackupRepoName))
+		}
+		return nil
+	}
+	replica := backup.GetReplica(backupRepoName)
+	if replica == nil || !replica.IsRestorable() {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" has no restorable replica in backup repo "%s"`,
+			backup.Name, backupRepoName))
+	}
+	backup.Status.BackupRepoName = replica.BackupRepoName
+	backup.Status.Path = replica.Path
+	if replica.TimeRange != nil {
+		backup.Status.TimeRange = replica.TimeRange
+	}
+	return nil
+}
+
 func cutJobName(jobName string) string {
 	l := len(jobName)
 	if l > 63 {
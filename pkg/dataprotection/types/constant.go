@@ -25,6 +25,8 @@ const AppName = "kubeblocks-dataprotection"
 const (
 	// CfgKeyGCFrequencySeconds is the key of gc frequency, its unit is second
 	CfgKeyGCFrequencySeconds = "GC_FREQUENCY_SECONDS"
+	// CfgKeyBackupRepoUsageFrequencySeconds is the key of the frequency to compute the usage of backup repos, its unit is second
+	CfgKeyBackupRepoUsageFrequencySeconds = "BACKUP_REPO_USAGE_FREQUENCY_SECONDS"
 	// CfgKeyWorkerServiceAccountName is the key of service account name for worker
 	CfgKeyWorkerServiceAccountName = "WORKER_SERVICE_ACCOUNT_NAME"
 	// CfgKeyExecWorkerServiceAccountName is the key of service account name for worker that runs "kubectl exec"
@@ -41,6 +43,8 @@ const (
 const (
 	// DefaultGCFrequencySeconds is the default gc frequency, its unit is second
 	DefaultGCFrequencySeconds = 60 * 60
+	// DefaultBackupRepoUsageFrequencySeconds is the default frequency to compute the usage of backup repos, its unit is second
+	DefaultBackupRepoUsageFrequencySeconds = 60 * 60
 )
 
 const (
@@ -66,6 +70,8 @@ const (
 	LastAppliedConfigsAnnotationKey = "dataprotection.kubeblocks.io/last-applied-configurations"
 	// SkipReconciliationAnnotationKey specifies whether to skip reconciliation.
 	SkipReconciliationAnnotationKey = "dataprotection.kubeblocks.io/skip-reconciliation"
+	// ReplicationTimeRangeAnnotationKey specifies the time range of the backup data to be replicated.
+	ReplicationTimeRangeAnnotationKey = "dataprotection.kubeblocks.io/replication-time-range"
 )
 
 // label keys
@@ -78,6 +84,8 @@ const (
 	BackupNamespaceLabelKey = "dataprotection.kubeblocks.io/backup-namespace"
 	// BackupScheduleLabelKey specifies the backup schedule label key.
 	BackupScheduleLabelKey = "dataprotection.kubeblocks.io/backup-schedule"
+	// SchedulePolicyLabelKey specifies the schedule policy name label key of the backups created by the controller.
+	SchedulePolicyLabelKey = "dataprotection.kubeblocks.io/schedule-policy"
 	// BackupPolicyLabelKey specifies the backup policy label key.
 	BackupPolicyLabelKey = "dataprotection.kubeblocks.io/backup-policy"
 	// BackupMethodLabelKey specifies the backup method label key.
@@ -88,6 +96,10 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// BackupVerificationLabelKey specifies the backup verification type label key.
+	BackupVerificationLabelKey = "dataprotection.kubeblocks.io/backup-verification"
+	// BackupReplicationRepoLabelKey specifies the target backup repo label key of the backup replication.
+	BackupReplicationRepoLabelKey = "dataprotection.kubeblocks.io/replication-repo"
 )
 
 // env names
@@ -138,6 +150,14 @@ const (
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
+	// DPReplicationSourceLocalBackendPath the local backend path of the source backup repo for replication
+	DPReplicationSourceLocalBackendPath = "DP_SOURCE_DATASAFED_LOCAL_BACKEND_PATH"
+	// DPReplicationSourceDatasafedConfig the datasafed config file of the source backup repo for replication
+	DPReplicationSourceDatasafedConfig = "DP_SOURCE_DATASAFED_CONFIG"
+	// DPReplicationTargetLocalBackendPath the local backend path of the target backup repo for replication
+	DPReplicationTargetLocalBackendPath = "DP_TARGET_DATASAFED_LOCAL_BACKEND_PATH"
+	// DPReplicationTargetDatasafedConfig the datasafed config file of the target backup repo for replication
+	DPReplicationTargetDatasafedConfig = "DP_TARGET_DATASAFED_CONFIG"
 
 	// NOTE: do not add 'DP_' prefix to the value of the following constants, they are the datasafed built-in environment.
 
@@ -21,6 +21,8 @@ package utils
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
@@ -71,3 +73,56 @@ func ValidateScheduleNames(schedules []dpv1alpha1.SchedulePolicy) error {
 	}
 	return nil
 }
+
+// GetGFSRetainedBackups returns the names of the backups retained by the grandfather-father-son retention policy.
+// For each period type, the latest completed backup of each period is retained,
+// for the most recent N periods that have completed backups.
+func GetGFSRetainedBackups(backups []*dpv1alpha1.Backup, policy *dpv1alpha1.GFSRetentionPolicy) map[string]struct{} {
+	retained := map[string]struct{}{}
+	if policy == nil {
+		return retained
+	}
+	completedBackups := make([]*dpv1alpha1.Backup, 0)
+	for _, b := range backups {
+		if b.Status.Phase == dpv1alpha1.BackupPhaseCompleted && !b.GetEndTime().IsZero() {
+			completedBackups = append(completedBackups, b)
+		}
+	}
+	// sort by stop time in descending order
+	sort.Slice(completedBackups, func(i, j int) bool {
+		i, j = j, i
+		return CompareWithBackupStopTime(*completedBackups[i], *completedBackups[j])
+	})
+
+	periods := []struct {
+		count     int32
+		periodKey func(t time.Time) string
+	}{
+		{policy.Hourly, func(t time.Time) string { return t.Format("2006-01-02T15") }},
+		{policy.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{policy.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{policy.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+		{policy.Yearly, func(t time.Time) string { return t.Format("2006") }},
+	}
+	for _, p := range periods {
+		if p.count <= 0 {
+			continue
+		}
+		seen := map[string]struct{}{}
+		for _, b := range completedBackups {
+			key := p.periodKey(b.GetEndTime().UTC())
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			if len(seen) >= int(p.count) {
+				break
+			}
+			seen[key] = struct{}{}
+			retained[b.Name] = struct{}{}
+		}
+	}
+	return retained
+}
@@ -124,6 +124,63 @@ func InjectDatasafedWithConfig(podSpec *corev1.PodSpec, configSecretName string,
 	injectDatasafedInstaller(podSpec)
 }
 
+// InjectDatasafedForReplication injects datasafed into the pod to access both the source and the target
+// backup repositories. As datasafed can access only one repository at a time, the repositories are
+// exposed by the envs instead of the datasafed built-in ones, the local backend path if the repository
+// is accessed by mount, or the datasafed config file if the repository is accessed by tool.
+func InjectDatasafedForReplication(podSpec *corev1.PodSpec, sourceRepo, targetRepo *dpv1alpha1.BackupRepo,
+	encryptionConfig *dpv1alpha1.EncryptionConfig) {
+	injectRepoForReplication(podSpec, sourceRepo, "source",
+		dptypes.DPReplicationSourceLocalBackendPath, dptypes.DPReplicationSourceDatasafedConfig)
+	injectRepoForReplication(podSpec, targetRepo, "target",
+		dptypes.DPReplicationTargetLocalBackendPath, dptypes.DPReplicationTargetDatasafedConfig)
+	injectEncryptionEnvs(podSpec, encryptionConfig)
+	injectDatasafedInstaller(podSpec)
+}
+
+func injectRepoForReplication(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, alias string,
+	localBackendPathEnv, configEnv string) {
+	var (
+		volume      corev1.Volume
+		volumeMount corev1.VolumeMount
+		env         corev1.EnvVar
+	)
+	switch {
+	case repo.AccessByMount():
+		volume = corev1.Volume{
+			Name: fmt.Sprintf("dp-%s-backup-data", alias),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: repo.Status.BackupPVCName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: fmt.Sprintf("/backupdata-%s", alias),
+		}
+		env = corev1.EnvVar{Name: localBackendPathEnv, Value: volumeMount.MountPath}
+	case repo.AccessByTool():
+		volume = corev1.Volume{
+			Name: fmt.Sprintf("dp-%s-datasafed-config", alias),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: repo.Status.ToolConfigSecretName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{
+			Name:      volume.Name,
+			ReadOnly:  true,
+			MountPath: fmt.Sprintf("%s-%s", datasafedConfigMountPath, alias),
+		}
+		env = corev1.EnvVar{Name: configEnv, Value: volumeMount.MountPath + "/datasafed.conf"}
+	default:
+		return
+	}
+	injectElements(podSpec, toSlice(volume), toSlice(volumeMount), toSlice(env))
+}
+
 func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
 	sharedVolumeName := "dp-datasafed-bin"
 	sharedVolume := corev1.Volume{
@@ -21,9 +21,12 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 )
@@ -54,3 +57,44 @@ func TestGetBackupStatusTarget(t *testing.T) {
 		assert.Error(t, errors.New("backup status target should be empty"))
 	}
 }
+
+func TestGetGFSRetainedBackups(t *testing.T) {
+	// one backup per 6 hours since 2023-12-01, for 120 days
+	start := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	var backups []*dpv1alpha1.Backup
+	for i := 0; i < 120*4; i++ {
+		backups = append(backups, &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("backup-%d", i),
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				CompletionTimestamp: &metav1.Time{Time: start.Add(time.Duration(i) * 6 * time.Hour)},
+			},
+		})
+	}
+	// the failed backup is never retained
+	backups = append(backups, &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "backup-failed",
+		},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:               dpv1alpha1.BackupPhaseFailed,
+			CompletionTimestamp: &metav1.Time{Time: start.Add(120 * 24 * time.Hour)},
+		},
+	})
+
+	retained := GetGFSRetainedBackups(backups, nil)
+	assert.Empty(t, retained)
+
+	retained = GetGFSRetainedBackups(backups, &dpv1alpha1.GFSRetentionPolicy{Daily: 2})
+	assert.Equal(t, map[string]struct{}{"backup-479": {}, "backup-475": {}}, retained)
+
+	// 2024-03-29 is the last day, the latest backups of 2024-03, 2024-02 and 2024-01 are retained
+	retained = GetGFSRetainedBackups(backups, &dpv1alpha1.GFSRetentionPolicy{Monthly: 3})
+	assert.Equal(t, map[string]struct{}{"backup-479": {}, "backup-363": {}, "backup-247": {}}, retained)
+
+	// the periods overlap
+	retained = GetGFSRetainedBackups(backups, &dpv1alpha1.GFSRetentionPolicy{Hourly: 1, Daily: 1, Weekly: 2, Yearly: 2})
+	assert.Equal(t, map[string]struct{}{"backup-479": {}, "backup-459": {}, "backup-123": {}}, retained)
+}
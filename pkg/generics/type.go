@@ -121,6 +121,8 @@ var BackupSignature = func(_ dpv1alpha1.Backup, _ *dpv1alpha1.Backup, _ dpv1alph
 }
 var BackupScheduleSignature = func(_ dpv1alpha1.BackupSchedule, _ *dpv1alpha1.BackupSchedule, _ dpv1alpha1.BackupScheduleList, _ *dpv1alpha1.BackupScheduleList) {
 }
+var BackupReplicationPolicySignature = func(_ dpv1alpha1.BackupReplicationPolicy, _ *dpv1alpha1.BackupReplicationPolicy, _ dpv1alpha1.BackupReplicationPolicyList, _ *dpv1alpha1.BackupReplicationPolicyList) {
+}
 var RestoreSignature = func(_ dpv1alpha1.Restore, _ *dpv1alpha1.Restore, _ dpv1alpha1.RestoreList, _ *dpv1alpha1.RestoreList) {
 }
 var ActionSetSignature = func(_ dpv1alpha1.ActionSet, _ *dpv1alpha1.ActionSet, _ dpv1alpha1.ActionSetList, _ *dpv1alpha1.ActionSetList) {
@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package kbagent
+
+import (
+	"fmt"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// The authentication modes of the kb-agent server.
+const (
+	// AuthModeNone accepts any caller, which is the default mode.
+	AuthModeNone = "none"
+	// AuthModeMTLS requires the callers to present a client certificate issued by the CA of the component.
+	AuthModeMTLS = "mtls"
+	// AuthModeToken requires the callers to present a bearer token, which is verified by the TokenReview API.
+	AuthModeToken = "token"
+)
+
+const (
+	TLSVolumeName = "kbagent-tls"
+	TLSMountPath  = "/etc/kbagent/tls"
+
+	TLSCAFile         = "ca.crt"
+	TLSCertFile       = "tls.crt"
+	TLSKeyFile        = "tls.key"
+	TLSClientCertFile = "client.crt"
+	TLSClientKeyFile  = "client.key"
+
+	TokenVolumeName = "kbagent-token"
+	// TokenExpirationSeconds is the expiration of the projected service account token, it is rotated by the kubelet.
+	TokenExpirationSeconds = 3600
+
+	// TokenAudience is the audience of the projected service account token presented by the callers.
+	TokenAudience = "kbagent.kubeblocks.io"
+	// DefaultTokenFile is the default path of the projected service account token in the caller's pod.
+	DefaultTokenFile = "/var/run/secrets/kubeblocks.io/kbagent/token"
+)
+
+// TLSSecretName returns the name of the secret that holds the TLS certificates of the kb-agent in a component.
+func TLSSecretName(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-kbagent-tls", clusterName, compName)
+}
+
+// AuthMode returns the auth mode of the kb-agent server configured for the operator.
+func AuthMode() string {
+	switch mode := viper.GetString(constant.CfgKeyKBAgentAuthMode); mode {
+	case AuthModeMTLS, AuthModeToken:
+		return mode
+	default:
+		return AuthModeNone
+	}
+}
@@ -21,6 +21,7 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
 	"net/http"
@@ -36,6 +37,15 @@ const (
 type Client interface {
 	io.Closer
 	Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error)
+	// ActionFollow runs the action in follow mode, the output chunks are passed to the stream callback as they are
+	// produced, and the whole stdout is returned in the response as well.
+	ActionFollow(ctx context.Context, req proto.ActionRequest, stream func(proto.ActionOutput)) (proto.ActionResponse, error)
+	// CancelAction cancels the running action by the request ID.
+	CancelAction(ctx context.Context, requestID string) (proto.ActionResponse, error)
+	Task(ctx context.Context, uid string) (proto.TaskResponse, error)
+	// WatchProbe waits for the events of the probe newer than the sequence number since, it returns
+	// when there are new events or the timeout expires.
+	WatchProbe(ctx context.Context, probe string, since uint64, timeout time.Duration) (proto.ProbeWatchResponse, error)
 }
 
 // HACK: for unit test only.
@@ -56,7 +66,24 @@ func GetMockClient() Client {
 	return mockClient
 }
 
-func NewClient(endpoint func() (string, int32, error)) (Client, error) {
+// Option configures the client to access the kb-agent server.
+type Option func(*httpClient)
+
+// WithTLSConfig enables TLS with the config to access the server.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *httpClient) {
+		c.tlsConfig = config
+	}
+}
+
+// WithToken sets the source of the bearer token presented to the server, the token is read on each request.
+func WithToken(token func() (string, error)) Option {
+	return func(c *httpClient) {
+		c.token = token
+	}
+}
+
+func NewClient(endpoint func() (string, int32, error), opts ...Option) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -73,18 +100,22 @@ func NewClient(endpoint func() (string, int32, error)) (Client, error) {
 	dialer := &net.Dialer{
 		Timeout: defaultConnectTimeout,
 	}
+	hc := &httpClient{
+		host: host,
+		port: port,
+	}
+	for _, opt := range opts {
+		opt(hc)
+	}
 	transport := &http.Transport{
 		Dial:                dialer.Dial,
 		TLSHandshakeTimeout: defaultConnectTimeout,
+		TLSClientConfig:     hc.tlsConfig,
 	}
-	cli := &http.Client{
+	hc.client = &http.Client{
 		// don't set timeout at client level
 		// Timeout:   time.Second * 30,
 		Transport: transport,
 	}
-	return &httpClient{
-		host:   host,
-		port:   port,
-		client: cli,
-	}, nil
+	return hc, nil
 }
@@ -28,6 +28,7 @@ package client
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
@@ -75,3 +76,63 @@ func (mr *MockClientMockRecorder) Action(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Action", reflect.TypeOf((*MockClient)(nil).Action), arg0, arg1)
 }
+
+// ActionFollow mocks base method.
+func (m *MockClient) ActionFollow(arg0 context.Context, arg1 proto.ActionRequest, arg2 func(proto.ActionOutput)) (proto.ActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionFollow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(proto.ActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionFollow indicates an expected call of ActionFollow.
+func (mr *MockClientMockRecorder) ActionFollow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionFollow", reflect.TypeOf((*MockClient)(nil).ActionFollow), arg0, arg1, arg2)
+}
+
+// CancelAction mocks base method.
+func (m *MockClient) CancelAction(arg0 context.Context, arg1 string) (proto.ActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAction", arg0, arg1)
+	ret0, _ := ret[0].(proto.ActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAction indicates an expected call of CancelAction.
+func (mr *MockClientMockRecorder) CancelAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAction", reflect.TypeOf((*MockClient)(nil).CancelAction), arg0, arg1)
+}
+
+// Task mocks base method.
+func (m *MockClient) Task(arg0 context.Context, arg1 string) (proto.TaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Task", arg0, arg1)
+	ret0, _ := ret[0].(proto.TaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Task indicates an expected call of Task.
+func (mr *MockClientMockRecorder) Task(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Task", reflect.TypeOf((*MockClient)(nil).Task), arg0, arg1)
+}
+
+// WatchProbe mocks base method.
+func (m *MockClient) WatchProbe(arg0 context.Context, arg1 string, arg2 uint64, arg3 time.Duration) (proto.ProbeWatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchProbe", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(proto.ProbeWatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchProbe indicates an expected call of WatchProbe.
+func (mr *MockClientMockRecorder) WatchProbe(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchProbe", reflect.TypeOf((*MockClient)(nil).WatchProbe), arg0, arg1, arg2, arg3)
+}
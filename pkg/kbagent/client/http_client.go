@@ -22,23 +22,28 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 const (
-	urlTemplate = "http://%s:%d%s"
+	urlTemplate = "%s://%s:%d%s"
 )
 
 type httpClient struct {
-	host   string
-	port   int32
-	client *http.Client
+	host      string
+	port      int32
+	client    *http.Client
+	tlsConfig *tls.Config
+	token     func() (string, error)
 }
 
 var _ Client = &httpClient{}
@@ -61,7 +66,7 @@ func (c *httpClient) Action(ctx context.Context, req proto.ActionRequest) (proto
 		return rsp, err
 	}
 
-	url := fmt.Sprintf(urlTemplate, c.host, c.port, proto.ServiceAction.URI)
+	url := c.url(proto.ServiceAction.URI)
 	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return rsp, err
@@ -71,11 +76,109 @@ func (c *httpClient) Action(ctx context.Context, req proto.ActionRequest) (proto
 	return decode(payload, &rsp)
 }
 
+func (c *httpClient) ActionFollow(ctx context.Context, req proto.ActionRequest, stream func(proto.ActionOutput)) (proto.ActionResponse, error) {
+	rsp := proto.ActionResponse{}
+
+	dryRun, ok := ctx.Value(constant.DryRunContextKey).(bool)
+	if ok && dryRun {
+		return rsp, nil
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	url := c.url(fmt.Sprintf("%s/follow", proto.ServiceAction.URI))
+	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	decoder := json.NewDecoder(payload)
+	for {
+		chunk := proto.ActionOutput{}
+		if err = decoder.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF // the last chunk is lost
+			}
+			return rsp, err
+		}
+		if chunk.Done {
+			rsp.Error = chunk.Error
+			rsp.Message = chunk.Message
+			return rsp, nil
+		}
+		if chunk.Stream == proto.ActionOutputStdout {
+			rsp.Output = append(rsp.Output, chunk.Data...)
+		}
+		if stream != nil {
+			stream(chunk)
+		}
+	}
+}
+
+func (c *httpClient) CancelAction(ctx context.Context, requestID string) (proto.ActionResponse, error) {
+	rsp := proto.ActionResponse{}
+
+	url := c.url(fmt.Sprintf("%s/%s", proto.ServiceAction.URI, requestID))
+	payload, err := c.request(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) Task(ctx context.Context, uid string) (proto.TaskResponse, error) {
+	rsp := proto.TaskResponse{}
+
+	url := c.url(fmt.Sprintf("%s/%s", proto.ServiceTask.URI, uid))
+	payload, err := c.request(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) WatchProbe(ctx context.Context, probe string, since uint64, timeout time.Duration) (proto.ProbeWatchResponse, error) {
+	rsp := proto.ProbeWatchResponse{}
+
+	url := c.url(fmt.Sprintf("%s/%s/watch?since=%d&timeoutSeconds=%d",
+		proto.ServiceProbe.URI, probe, since, int64(timeout.Seconds())))
+	payload, err := c.request(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) url(uri string) string {
+	scheme := "http"
+	if c.tlsConfig != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf(urlTemplate, scheme, c.host, c.port, uri)
+}
+
 func (c *httpClient) request(ctx context.Context, method, url string, body io.Reader) (io.ReadCloser, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
+	if c.token != nil {
+		token, err := c.token()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	rsp, err := c.client.Do(req)
 	if err != nil {
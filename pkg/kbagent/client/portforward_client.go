@@ -25,6 +25,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +44,7 @@ type portForwardClient struct {
 	port   string
 	config *rest.Config
 	logger logr.Logger
+	opts   []Option
 }
 
 var _ Client = &portForwardClient{}
@@ -55,7 +57,60 @@ func (pf *portForwardClient) Close() error {
 // Since we can't know httpClient's lifecycle, a portforward is bound to one request.
 // It's not efficient, but enough for debugging purposes.
 func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
-	emptyResp := proto.ActionResponse{}
+	rsp := proto.ActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Action(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+// ActionFollow forwards the target port to localhost, and then execute the action in follow mode.
+func (pf *portForwardClient) ActionFollow(ctx context.Context, req proto.ActionRequest, stream func(proto.ActionOutput)) (proto.ActionResponse, error) {
+	rsp := proto.ActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.ActionFollow(ctx, req, stream)
+		return err
+	})
+	return rsp, err
+}
+
+// CancelAction forwards the target port to localhost, and then cancel the action.
+func (pf *portForwardClient) CancelAction(ctx context.Context, requestID string) (proto.ActionResponse, error) {
+	rsp := proto.ActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.CancelAction(ctx, requestID)
+		return err
+	})
+	return rsp, err
+}
+
+// Task forwards the target port to localhost, and then query the task.
+func (pf *portForwardClient) Task(ctx context.Context, uid string) (proto.TaskResponse, error) {
+	rsp := proto.TaskResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Task(ctx, uid)
+		return err
+	})
+	return rsp, err
+}
+
+// WatchProbe forwards the target port to localhost, and then watch the probe.
+func (pf *portForwardClient) WatchProbe(ctx context.Context, probe string, since uint64, timeout time.Duration) (proto.ProbeWatchResponse, error) {
+	rsp := proto.ProbeWatchResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.WatchProbe(ctx, probe, since, timeout)
+		return err
+	})
+	return rsp, err
+}
+
+func (pf *portForwardClient) forward(f func(client Client) error) error {
 	stopCh := make(chan struct{})
 	defer close(stopCh) // this will stop forwarder
 	readyCh := make(chan struct{})
@@ -78,7 +133,7 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 
 	forwarder, err := pf.newPortForwarder(readyCh, stopCh, outWriter)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	go func() {
 		err := forwarder.ForwardPorts()
@@ -92,29 +147,29 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 		// do nothing
 	case err := <-errCh:
 		pf.logger.Error(err, "port forward failed")
-		return emptyResp, err
+		return err
 	}
 
 	ports, err := forwarder.GetPorts()
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	if len(ports) == 0 {
-		return emptyResp, fmt.Errorf("no port was forwarded")
+		return fmt.Errorf("no port was forwarded")
 	}
 
 	endpoint := func() (string, int32, error) {
 		return "localhost", int32(ports[0].Local), nil
 	}
-	client, err := NewClient(endpoint)
+	client, err := NewClient(endpoint, pf.opts...)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 
-	rsp, err := client.Action(ctx, req)
+	err = f(client)
 	_ = client.Close()
 
-	return rsp, err
+	return err
 }
 
 func (pf *portForwardClient) createDialer(method string, url *url.URL, config *rest.Config) (httpstream.Dialer, error) {
@@ -149,7 +204,7 @@ func (pf *portForwardClient) newPortForwarder(readyCh, stopCh chan struct{}, out
 	return fw, nil
 }
 
-func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error)) (Client, error) {
+func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error), opts ...Option) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -165,5 +220,6 @@ func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error
 		port:   fmt.Sprint(port),
 		config: config,
 		logger: ctrl.Log.WithName("portforward"),
+		opts:   opts,
 	}, nil
 }
@@ -25,12 +25,14 @@ import (
 
 var (
 	ErrNotDefined         = errors.New("notDefined")
+	ErrNotFound           = errors.New("notFound")
 	ErrNotImplemented     = errors.New("notImplemented")
 	ErrPreconditionFailed = errors.New("preconditionFailed")
 	ErrBadRequest         = errors.New("badRequest")
 	ErrInProgress         = errors.New("inProgress")
 	ErrBusy               = errors.New("busy")
 	ErrTimedOut           = errors.New("timedOut")
+	ErrCanceled           = errors.New("canceled")
 	ErrFailed             = errors.New("failed")
 	ErrInternalError      = errors.New("internalError")
 	ErrUnknown            = errors.New("unknown")
@@ -42,6 +44,8 @@ func Error2Type(err error) string {
 		return ""
 	case errors.Is(err, ErrNotDefined):
 		return "notDefined"
+	case errors.Is(err, ErrNotFound):
+		return "notFound"
 	case errors.Is(err, ErrNotImplemented):
 		return "notImplemented"
 	case errors.Is(err, ErrPreconditionFailed):
@@ -54,6 +58,8 @@ func Error2Type(err error) string {
 		return "busy"
 	case errors.Is(err, ErrTimedOut):
 		return "timedOut"
+	case errors.Is(err, ErrCanceled):
+		return "canceled"
 	case errors.Is(err, ErrFailed):
 		return "failed"
 	case errors.Is(err, ErrInternalError):
@@ -69,6 +75,8 @@ func Type2Error(errType string) error {
 		return nil
 	case "notDefined":
 		return ErrNotDefined
+	case "notFound":
+		return ErrNotFound
 	case "notImplemented":
 		return ErrNotImplemented
 	case "preconditionFailed":
@@ -81,6 +89,8 @@ func Type2Error(errType string) error {
 		return ErrBusy
 	case "timedOut":
 		return ErrTimedOut
+	case "canceled":
+		return ErrCanceled
 	case "failed":
 		return ErrFailed
 	case "internalError":
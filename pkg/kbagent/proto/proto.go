@@ -24,10 +24,13 @@ import (
 )
 
 type Action struct {
-	Name           string       `json:"name"`
-	Exec           *ExecAction  `json:"exec,omitempty"`
-	TimeoutSeconds int32        `json:"timeoutSeconds,omitempty"`
-	RetryPolicy    *RetryPolicy `json:"retryPolicy,omitempty"`
+	Name            string       `json:"name"`
+	Exec            *ExecAction  `json:"exec,omitempty"`
+	HTTP            *HTTPAction  `json:"http,omitempty"`
+	GRPC            *GRPCAction  `json:"grpc,omitempty"`
+	TimeoutSeconds  int32        `json:"timeoutSeconds,omitempty"`
+	RetryPolicy     *RetryPolicy `json:"retryPolicy,omitempty"`
+	CacheTTLSeconds int32        `json:"cacheTTLSeconds,omitempty"` // the duration to cache a successful result of the action
 }
 
 type ExecAction struct {
@@ -35,6 +38,30 @@ type ExecAction struct {
 	Args     []string `json:"args,omitempty"`
 }
 
+type HTTPAction struct {
+	Host                string       `json:"host,omitempty"`
+	Port                int32        `json:"port"`
+	Scheme              string       `json:"scheme,omitempty"`
+	Method              string       `json:"method,omitempty"`
+	Path                string       `json:"path,omitempty"`    // template
+	Headers             []HTTPHeader `json:"headers,omitempty"` // template of values
+	Body                string       `json:"body,omitempty"`    // template
+	ExpectedStatusCodes []int32      `json:"expectedStatusCodes,omitempty"`
+}
+
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type GRPCAction struct {
+	Host    string `json:"host,omitempty"`
+	Port    int32  `json:"port"`
+	Service string `json:"service"`
+	Method  string `json:"method"`
+	Request string `json:"request,omitempty"` // template of the JSON representation of the request message
+}
+
 type RetryPolicy struct {
 	MaxRetries    int           `json:"maxRetries,omitempty"`
 	RetryInterval time.Duration `json:"retryInterval,omitempty"`
@@ -46,6 +73,7 @@ type ActionRequest struct {
 	NonBlocking    *bool             `json:"nonBlocking,omitempty"`
 	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
 	RetryPolicy    *RetryPolicy      `json:"retryPolicy,omitempty"`
+	RequestID      string            `json:"requestID,omitempty"` // the unique ID of the request, which is required to cancel the action
 }
 
 type ActionResponse struct {
@@ -54,6 +82,21 @@ type ActionResponse struct {
 	Output  []byte `json:"output,omitempty"`
 }
 
+const (
+	ActionOutputStdout = "stdout"
+	ActionOutputStderr = "stderr"
+)
+
+// ActionOutput is a chunk of the output of an action running in follow mode, the last chunk is marked as done
+// and carries the result of the action.
+type ActionOutput struct {
+	Stream  string `json:"stream,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+	Done    bool   `json:"done,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
 // TODO: define the event spec for probe or async action
 
 const (
@@ -80,6 +123,67 @@ type ProbeEvent struct {
 	Message  string `json:"message,omitempty"` // message of the probe on failure
 }
 
+// ProbeResult is the result of one run of a probe, which is kept in the history of the probe.
+type ProbeResult struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Latency   time.Duration `json:"latency"`
+	Code      int32         `json:"code"`
+	Output    []byte        `json:"output,omitempty"`  // output of the probe, truncated if it is too long
+	Message   string        `json:"message,omitempty"` // message of the probe on failure
+}
+
+type ProbeResponse struct {
+	Error   string        `json:"error,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Results []ProbeResult `json:"results,omitempty"` // the recent results of the probe, ordered from oldest to newest
+}
+
+// ProbeWatchEvent is an event of the probe delivered to the watchers, the sequence number of it increases
+// monotonically within an epoch of the kb-agent.
+type ProbeWatchEvent struct {
+	Seq       uint64     `json:"seq"`
+	Timestamp time.Time  `json:"timestamp"`
+	Event     ProbeEvent `json:"event"`
+}
+
+type ProbeWatchResponse struct {
+	Error   string            `json:"error,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Epoch   string            `json:"epoch,omitempty"`  // changes when the kb-agent restarts, and the sequence numbers start over
+	Seq     uint64            `json:"seq,omitempty"`    // the latest sequence number of the probe events
+	Events  []ProbeWatchEvent `json:"events,omitempty"` // the events newer than the requested sequence number, ordered by the sequence number
+}
+
+const (
+	VolumeProtectionProbe = "volumeProtection"
+)
+
+type VolumeProtection struct {
+	Instance      string            `json:"instance"`
+	PeriodSeconds int32             `json:"periodSeconds,omitempty"`
+	Volumes       []VolumeWatermark `json:"volumes"`
+}
+
+type VolumeWatermark struct {
+	Name          string `json:"name"`
+	MountPath     string `json:"mountPath"`
+	HighWatermark int32  `json:"highWatermark"` // the percentage of the volume usage
+}
+
+type VolumeProtectionStatus struct {
+	HighWatermarkExceeded bool          `json:"highWatermarkExceeded"`
+	Volumes               []VolumeUsage `json:"volumes,omitempty"`
+}
+
+type VolumeUsage struct {
+	Name          string `json:"name"`
+	UsedBytes     uint64 `json:"usedBytes"`
+	TotalBytes    uint64 `json:"totalBytes"`
+	Usage         int32  `json:"usage"` // the percentage of the used space
+	HighWatermark int32  `json:"highWatermark"`
+	Exceeded      bool   `json:"exceeded"`
+}
+
 type Task struct {
 	Instance            string          `json:"instance"`
 	Task                string          `json:"task"`
@@ -102,6 +206,16 @@ type TaskEvent struct {
 	Message   string    `json:"message,omitempty"` // message of the task on failure
 }
 
+type TaskRequest struct {
+	UID string `json:"UID"`
+}
+
+type TaskResponse struct {
+	Error   string     `json:"error,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Event   *TaskEvent `json:"event,omitempty"` // the latest event of the task
+}
+
 type NewReplicaTask struct {
 	Remote         string            `json:"remote"` // the remote address of the data source
 	Port           int32             `json:"port"`
@@ -0,0 +1,178 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/valyala/fasthttp"
+	authv1 "k8s.io/api/authentication/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	tokenReviewTimeout   = 10 * time.Second
+	tokenCacheTTL        = time.Minute
+	tokenCacheMaxSize    = 128
+	defaultTLSMinVersion = tls.VersionTLS12
+)
+
+func (c Config) tlsEnabled() bool {
+	return len(c.TLSCertFile) > 0 && len(c.TLSKeyFile) > 0
+}
+
+func (c Config) tokenAuthEnabled() bool {
+	return len(c.TokenAudience) > 0
+}
+
+// tlsConfig builds the TLS config of the server, the client certificates are required and verified if the CA is provided.
+func tlsConfig(config Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load the TLS certificate error: %s", err.Error())
+	}
+	c := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   defaultTLSMinVersion,
+	}
+	if len(config.TLSCAFile) > 0 {
+		ca, err := os.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read the TLS CA error: %s", err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate found in the TLS CA file %s", config.TLSCAFile)
+		}
+		c.ClientCAs = pool
+		c.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return c, nil
+}
+
+type tokenReviewer func(ctx context.Context, token string, audiences []string) (*authv1.TokenReviewStatus, error)
+
+func newTokenAuthenticator(logger logr.Logger, config Config) *tokenAuthenticator {
+	return &tokenAuthenticator{
+		logger:   logger,
+		audience: config.TokenAudience,
+		users:    config.TokenUsers,
+		reviewer: util.ReviewToken,
+		cache:    map[[sha256.Size]byte]time.Time{},
+	}
+}
+
+// tokenAuthenticator authenticates the bearer token of requests by the TokenReview API, and the authenticated
+// tokens are cached for a while to avoid reviewing the same token for each request.
+type tokenAuthenticator struct {
+	logger   logr.Logger
+	audience string
+	users    []string
+	reviewer tokenReviewer
+
+	mutex sync.Mutex
+	cache map[[sha256.Size]byte]time.Time
+}
+
+func (a *tokenAuthenticator) wrap(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		if err := a.authenticate(reqCtx.Request.Header.Peek(authorizationHeader)); err != nil {
+			a.logger.Info("unauthorized request", "remote", reqCtx.RemoteAddr().String(),
+				"path", string(reqCtx.Path()), "reason", err.Error())
+			reqCtx.Response.SetStatusCode(fasthttp.StatusUnauthorized)
+			reqCtx.Response.SetBodyString(err.Error())
+			return
+		}
+		next(reqCtx)
+	}
+}
+
+func (a *tokenAuthenticator) authenticate(header []byte) error {
+	if !bytes.HasPrefix(header, []byte(bearerPrefix)) {
+		return fmt.Errorf("bearer token is required")
+	}
+	token := string(bytes.TrimSpace(header[len(bearerPrefix):]))
+	if len(token) == 0 {
+		return fmt.Errorf("bearer token is required")
+	}
+
+	key := sha256.Sum256([]byte(token))
+	if a.cached(key) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenReviewTimeout)
+	defer cancel()
+	status, err := a.reviewer(ctx, token, []string{a.audience})
+	if err != nil {
+		return fmt.Errorf("review token error: %s", err.Error())
+	}
+	if !status.Authenticated {
+		return fmt.Errorf("token is not authenticated: %s", status.Error)
+	}
+	if len(a.users) > 0 && !slices.Contains(a.users, status.User.Username) {
+		return fmt.Errorf("user %s is not allowed", status.User.Username)
+	}
+
+	a.cache4(key)
+	return nil
+}
+
+func (a *tokenAuthenticator) cached(key [sha256.Size]byte) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	expiration, ok := a.cache[key]
+	if ok && time.Now().Before(expiration) {
+		return true
+	}
+	delete(a.cache, key)
+	return false
+}
+
+func (a *tokenAuthenticator) cache4(key [sha256.Size]byte) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(a.cache) >= tokenCacheMaxSize {
+		now := time.Now()
+		for k, expiration := range a.cache {
+			if now.After(expiration) {
+				delete(a.cache, k)
+			}
+		}
+		if len(a.cache) >= tokenCacheMaxSize {
+			a.cache = map[[sha256.Size]byte]time.Time{}
+		}
+	}
+	a.cache[key] = time.Now().Add(tokenCacheTTL)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+const (
+	testTokenAudience = "kbagent.kubeblocks.io"
+	testTokenUser     = "system:serviceaccount:kb-system:kubeblocks"
+)
+
+// fakeTokenReviewer authenticates the tokens in the map as the mapped users.
+type fakeTokenReviewer struct {
+	tokens  map[string]string
+	err     error
+	reviews int
+}
+
+func (r *fakeTokenReviewer) review(_ context.Context, token string, audiences []string) (*authv1.TokenReviewStatus, error) {
+	r.reviews++
+	if r.err != nil {
+		return nil, r.err
+	}
+	user, ok := r.tokens[token]
+	if !ok || len(audiences) != 1 || audiences[0] != testTokenAudience {
+		return &authv1.TokenReviewStatus{Authenticated: false, Error: "invalid token"}, nil
+	}
+	return &authv1.TokenReviewStatus{Authenticated: true, User: authv1.UserInfo{Username: user}}, nil
+}
+
+var _ = Describe("auth", func() {
+	Context("token", func() {
+		var (
+			reviewer      *fakeTokenReviewer
+			authenticator *tokenAuthenticator
+		)
+
+		BeforeEach(func() {
+			reviewer = &fakeTokenReviewer{
+				tokens: map[string]string{
+					"token":       testTokenUser,
+					"other-token": "system:serviceaccount:default:default",
+				},
+			}
+			authenticator = newTokenAuthenticator(logr.New(nil), Config{
+				TokenAudience: testTokenAudience,
+				TokenUsers:    []string{testTokenUser},
+			})
+			authenticator.reviewer = reviewer.review
+		})
+
+		It("no token", func() {
+			Expect(authenticator.authenticate(nil)).ShouldNot(Succeed())
+			Expect(authenticator.authenticate([]byte("token"))).ShouldNot(Succeed())
+			Expect(authenticator.authenticate([]byte("Bearer  "))).ShouldNot(Succeed())
+			Expect(reviewer.reviews).Should(Equal(0))
+		})
+
+		It("ok", func() {
+			Expect(authenticator.authenticate([]byte("Bearer token"))).Should(Succeed())
+			Expect(authenticator.authenticate([]byte("Bearer token\n"))).Should(Succeed())
+			// the authenticated token is cached
+			Expect(reviewer.reviews).Should(Equal(1))
+		})
+
+		It("not authenticated", func() {
+			err := authenticator.authenticate([]byte("Bearer invalid"))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not authenticated"))
+
+			// the failed token is not cached
+			Expect(authenticator.authenticate([]byte("Bearer invalid"))).ShouldNot(Succeed())
+			Expect(reviewer.reviews).Should(Equal(2))
+		})
+
+		It("user not allowed", func() {
+			err := authenticator.authenticate([]byte("Bearer other-token"))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("is not allowed"))
+		})
+
+		It("any user", func() {
+			authenticator.users = nil
+			Expect(authenticator.authenticate([]byte("Bearer other-token"))).Should(Succeed())
+		})
+
+		It("review error", func() {
+			reviewer.err = fmt.Errorf("connection refused")
+			err := authenticator.authenticate([]byte("Bearer token"))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("connection refused"))
+		})
+	})
+
+	Context("tls", func() {
+		var (
+			certs *testCerts
+		)
+
+		BeforeEach(func() {
+			certs = newTestCerts(GinkgoT().TempDir())
+		})
+
+		It("server only", func() {
+			c, err := tlsConfig(Config{TLSCertFile: certs.serverCert, TLSKeyFile: certs.serverKey})
+			Expect(err).Should(BeNil())
+			Expect(c.Certificates).Should(HaveLen(1))
+			Expect(c.ClientCAs).Should(BeNil())
+			Expect(c.ClientAuth).Should(Equal(tls.NoClientCert))
+		})
+
+		It("require client certificates", func() {
+			c, err := tlsConfig(Config{TLSCertFile: certs.serverCert, TLSKeyFile: certs.serverKey, TLSCAFile: certs.ca})
+			Expect(err).Should(BeNil())
+			Expect(c.ClientCAs).ShouldNot(BeNil())
+			Expect(c.ClientAuth).Should(Equal(tls.RequireAndVerifyClientCert))
+		})
+
+		It("invalid certificate", func() {
+			_, err := tlsConfig(Config{TLSCertFile: certs.serverCert, TLSKeyFile: certs.clientKey})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("invalid CA", func() {
+			invalid := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+			Expect(os.WriteFile(invalid, []byte("invalid"), 0600)).Should(Succeed())
+			_, err := tlsConfig(Config{TLSCertFile: certs.serverCert, TLSKeyFile: certs.serverKey, TLSCAFile: invalid})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
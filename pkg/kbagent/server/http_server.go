@@ -20,7 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -28,21 +31,31 @@ import (
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
 const (
-	defaultMaxConcurrency = 8
-	jsonContentTypeHeader = "application/json"
+	defaultMaxConcurrency   = 8
+	jsonContentTypeHeader   = "application/json"
+	ndjsonContentTypeHeader = "application/x-ndjson"
+	queryKeyParam           = "key"
+	followURISuffix         = "follow"
+	watchURISuffix          = "watch"
+	watchSinceParam         = "since"
+	watchTimeoutParam       = "timeoutSeconds"
+	metricsURI              = "/metrics"
 )
 
 type httpServer struct {
-	logger   logr.Logger
-	config   Config
-	services []service.Service
-	servers  []*fasthttp.Server
+	logger        logr.Logger
+	config        Config
+	services      []service.Service
+	servers       []*fasthttp.Server
+	authenticator *tokenAuthenticator
 }
 
 var _ Server = &httpServer{}
@@ -66,6 +79,15 @@ func (s *httpServer) StartNonBlocking() error {
 		if err != nil {
 			s.logger.Error(err, "listen HTTP server error", "address", s.config.Address, "port", s.config.Port)
 		} else {
+			if s.config.tlsEnabled() {
+				c, err := tlsConfig(s.config)
+				if err != nil {
+					_ = l.Close()
+					return err
+				}
+				l = tls.NewListener(l, c)
+				s.logger.Info("serve the HTTP server over TLS", "client auth", c.ClientAuth.String())
+			}
 			listeners = append(listeners, l)
 		}
 	}
@@ -113,16 +135,55 @@ func (s *httpServer) Close() error {
 }
 
 func (s *httpServer) router() fasthttp.RequestHandler {
+	if s.config.tokenAuthEnabled() {
+		s.authenticator = newTokenAuthenticator(s.logger, s.config)
+		s.logger.Info("enable the token authentication", "audience", s.config.TokenAudience, "users", s.config.TokenUsers)
+	}
 	router := fasthttprouter.New()
 	for i := range s.services {
 		s.registerService(router, s.services[i])
 	}
+	// the metrics are exempted from the token authentication to be scraped
+	router.Handle(fasthttp.MethodGet, metricsURI,
+		fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(service.MetricsRegistry, promhttp.HandlerOpts{})))
 	return router.Handler
 }
 
 func (s *httpServer) registerService(router *fasthttprouter.Router, svc service.Service) {
-	router.Handle(fasthttp.MethodPost, svc.URI(), s.dispatcher(svc))
+	router.Handle(fasthttp.MethodPost, svc.URI(), s.auth(s.dispatcher(svc)))
 	s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodPost, "uri", svc.URI())
+
+	if qs, ok := svc.(service.QueryService); ok {
+		uri := fmt.Sprintf("%s/{%s}", svc.URI(), queryKeyParam)
+		router.Handle(fasthttp.MethodGet, uri, s.auth(s.queryDispatcher(qs)))
+		s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodGet, "uri", uri)
+	}
+
+	if ws, ok := svc.(service.WatchService); ok {
+		uri := fmt.Sprintf("%s/{%s}/%s", svc.URI(), queryKeyParam, watchURISuffix)
+		router.Handle(fasthttp.MethodGet, uri, s.auth(s.watchDispatcher(ws)))
+		s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodGet, "uri", uri)
+	}
+
+	if fs, ok := svc.(service.FollowService); ok {
+		uri := fmt.Sprintf("%s/%s", svc.URI(), followURISuffix)
+		router.Handle(fasthttp.MethodPost, uri, s.auth(s.followDispatcher(fs)))
+		s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodPost, "uri", uri)
+	}
+
+	if cs, ok := svc.(service.CancelService); ok {
+		uri := fmt.Sprintf("%s/{%s}", svc.URI(), queryKeyParam)
+		router.Handle(fasthttp.MethodDelete, uri, s.auth(s.cancelDispatcher(cs)))
+		s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodDelete, "uri", uri)
+	}
+}
+
+// auth authenticates the requests before dispatching them if the token authentication is enabled.
+func (s *httpServer) auth(handler fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if s.authenticator == nil {
+		return handler
+	}
+	return s.authenticator.wrap(handler)
 }
 
 func (s *httpServer) dispatcher(svc service.Service) func(*fasthttp.RequestCtx) {
@@ -148,6 +209,118 @@ func (s *httpServer) dispatcher(svc service.Service) func(*fasthttp.RequestCtx)
 	}
 }
 
+func (s *httpServer) queryDispatcher(svc service.QueryService) func(*fasthttp.RequestCtx) {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		ctx := context.Background()
+		key, _ := reqCtx.UserValue(queryKeyParam).(string)
+
+		// the errors of query are encoded in the response
+		output, _ := svc.HandleQuery(ctx, key)
+		statusCode := fasthttp.StatusOK
+		httpRespond(reqCtx, statusCode, output, nil)
+		if s.config.Logging {
+			s.logger.Info("HTTP API Called",
+				"user-agent", string(reqCtx.Request.Header.UserAgent()),
+				"method", string(reqCtx.Method()),
+				"path", string(reqCtx.Path()),
+				"status code", statusCode,
+				"cost", time.Since(reqCtx.Time()).Milliseconds(),
+			)
+		}
+	}
+}
+
+// watchDispatcher long-polls the changes of the object, the request is held until there are new changes or the timeout.
+func (s *httpServer) watchDispatcher(svc service.WatchService) func(*fasthttp.RequestCtx) {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		ctx := context.Background()
+		key, _ := reqCtx.UserValue(queryKeyParam).(string)
+		// the invalid values are treated as unspecified
+		since, _ := reqCtx.QueryArgs().GetUint(watchSinceParam)
+		timeout, _ := reqCtx.QueryArgs().GetUint(watchTimeoutParam)
+
+		output, err := svc.HandleWatch(ctx, key, uint64(max(since, 0)), time.Duration(max(timeout, 0))*time.Second)
+		statusCode := fasthttp.StatusOK
+		if err != nil {
+			statusCode = fasthttp.StatusInternalServerError
+		}
+		httpRespond(reqCtx, statusCode, output, err)
+		if s.config.Logging {
+			s.logger.Info("HTTP API Called",
+				"user-agent", string(reqCtx.Request.Header.UserAgent()),
+				"method", string(reqCtx.Method()),
+				"path", string(reqCtx.Path()),
+				"status code", statusCode,
+				"cost", time.Since(reqCtx.Time()).Milliseconds(),
+			)
+		}
+	}
+}
+
+// followDispatcher streams the output of the request as newline-delimited JSON in a chunked response.
+func (s *httpServer) followDispatcher(svc service.FollowService) func(*fasthttp.RequestCtx) {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		// the request context can't be accessed in the stream writer, copy what we need
+		body := bytes.Clone(reqCtx.PostBody())
+		userAgent := string(reqCtx.Request.Header.UserAgent())
+		path := string(reqCtx.Path())
+		start := reqCtx.Time()
+
+		reqCtx.Response.Header.SetContentType(ndjsonContentTypeHeader)
+		reqCtx.Response.SetStatusCode(fasthttp.StatusOK)
+		reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			err := svc.HandleFollow(context.Background(), body, &flushWriter{w: w})
+			if err != nil {
+				s.logger.Error(err, "stream the output error", "path", path)
+			}
+			if s.config.Logging {
+				s.logger.Info("HTTP API Called",
+					"user-agent", userAgent,
+					"method", fasthttp.MethodPost,
+					"path", path,
+					"cost", time.Since(start).Milliseconds(),
+				)
+			}
+		})
+	}
+}
+
+func (s *httpServer) cancelDispatcher(svc service.CancelService) func(*fasthttp.RequestCtx) {
+	return func(reqCtx *fasthttp.RequestCtx) {
+		ctx := context.Background()
+		key, _ := reqCtx.UserValue(queryKeyParam).(string)
+
+		output, err := svc.HandleCancel(ctx, key)
+		statusCode := fasthttp.StatusOK
+		if err != nil {
+			statusCode = fasthttp.StatusInternalServerError
+		}
+		httpRespond(reqCtx, statusCode, output, err)
+		if s.config.Logging {
+			s.logger.Info("HTTP API Called",
+				"user-agent", string(reqCtx.Request.Header.UserAgent()),
+				"method", string(reqCtx.Method()),
+				"path", string(reqCtx.Path()),
+				"status code", statusCode,
+				"cost", time.Since(reqCtx.Time()).Milliseconds(),
+			)
+		}
+	}
+}
+
+// flushWriter flushes each write to the client immediately.
+type flushWriter struct {
+	w *bufio.Writer
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, f.w.Flush()
+}
+
 func httpRespond(ctx *fasthttp.RequestCtx, code int, body []byte, err error) {
 	ctx.Response.Header.SetContentType(jsonContentTypeHeader)
 	ctx.Response.SetStatusCode(code)
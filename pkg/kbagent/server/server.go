@@ -41,6 +41,23 @@ type Config struct {
 	StreamingPort    int
 	Concurrency      int
 	Logging          bool
+
+	// TLS, the server is served over TLS if the certificate and key are provided, and the client certificates
+	// are required if the CA is provided as well.
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	// Token authentication, it is enabled if the audience is provided, and the token is required to be issued
+	// to one of the users if provided.
+	TokenAudience string
+	TokenUsers    []string
+
+	// The client credentials used to connect to the streaming server of other replicas, the client certificate
+	// is verified by the CA above, and the token is read from the file for each connection since it is rotated.
+	TLSClientCertFile string
+	TLSClientKeyFile  string
+	TokenFile         string
 }
 
 // NewHTTPServer returns a new HTTP server.
@@ -20,7 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package server
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -32,11 +34,17 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
+const (
+	streamingAuthTimeout        = 10 * time.Second
+	maxStreamingAuthPrefaceSize = 8192
+)
+
 type streamingServer struct {
-	logger   logr.Logger
-	config   Config
-	service  service.Service
-	listener net.Listener
+	logger        logr.Logger
+	config        Config
+	service       service.Service
+	listener      net.Listener
+	authenticator *tokenAuthenticator
 }
 
 var _ Server = &streamingServer{}
@@ -56,12 +64,27 @@ func (s *streamingServer) StartNonBlocking() error {
 		s.logger.Error(err1, "listen failed", "listen address", s.config.Address, "port", s.config.StreamingPort)
 		return err1
 	}
+	if s.config.tlsEnabled() {
+		c, err := tlsConfig(s.config)
+		if err != nil {
+			_ = s.listener.Close()
+			return err
+		}
+		s.listener = tls.NewListener(s.listener, c)
+	}
+	if s.config.tokenAuthEnabled() {
+		s.authenticator = newTokenAuthenticator(s.logger, s.config)
+		s.logger.Info("enable the token authentication", "audience", s.config.TokenAudience, "users", s.config.TokenUsers)
+	}
 
 	go func() {
 		var tempErr error
 		for {
 			conn, err2 := s.listener.Accept()
 			if err2 != nil {
+				if errors.Is(err2, net.ErrClosed) {
+					return // the server is closed
+				}
 				var netErr net.Error
 				if errors.As(errors.Unwrap(err2), &netErr) && netErr.Temporary() {
 					if tempErr == nil || !errors.Is(err2, tempErr) {
@@ -105,6 +128,14 @@ func (s *streamingServer) handleConn(conn net.Conn) {
 	logger := s.logger.WithValues("remote", conn.RemoteAddr())
 	logger.Info("accepted a new streaming connection")
 
+	if s.authenticator != nil {
+		var err error
+		if conn, err = s.authenticate(conn); err != nil {
+			logger.Info("unauthorized streaming connection", "reason", err.Error())
+			return
+		}
+	}
+
 	now := time.Now()
 	err := s.service.HandleConn(context.Background(), conn)
 	if err != nil {
@@ -113,3 +144,33 @@ func (s *streamingServer) handleConn(conn net.Conn) {
 		logger.Info("handle streaming connection done", "elapsed", time.Since(now))
 	}
 }
+
+// authenticate reads and authenticates the bearer token preface sent by the client before the handshake packet,
+// the preface is a single line in the form of "Bearer <token>\n".
+func (s *streamingServer) authenticate(conn net.Conn) (net.Conn, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(streamingAuthTimeout)); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReaderSize(conn, maxStreamingAuthPrefaceSize)
+	preface, err := reader.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read the bearer token error: %s", err.Error())
+	}
+	if err = s.authenticator.authenticate(preface); err != nil {
+		return nil, err
+	}
+	if err = conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	// the reader may have buffered the data after the preface
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
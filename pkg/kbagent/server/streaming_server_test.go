@@ -0,0 +1,274 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
+)
+
+type testCerts struct {
+	ca         string
+	serverCert string
+	serverKey  string
+	clientCert string
+	clientKey  string
+	// the client certificate issued by another CA
+	untrustedCert string
+	untrustedKey  string
+}
+
+func newTestCerts(dir string) *testCerts {
+	writePEM := func(name, typ string, data []byte) string {
+		file := filepath.Join(dir, name)
+		Expect(os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: data}), 0600)).Should(Succeed())
+		return file
+	}
+	issue := func(name string, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).Should(BeNil())
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		Expect(err).Should(BeNil())
+		cert, err := x509.ParseCertificate(der)
+		Expect(err).Should(BeNil())
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		Expect(err).Should(BeNil())
+		return cert, key, writePEM(name+".crt", "CERTIFICATE", der), writePEM(name+".key", "EC PRIVATE KEY", keyDER)
+	}
+	template := func(serial int64, cn string, isCA bool) *x509.Certificate {
+		t := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		if isCA {
+			t.IsCA = true
+			t.BasicConstraintsValid = true
+			t.KeyUsage |= x509.KeyUsageCertSign
+		}
+		return t
+	}
+
+	certs := &testCerts{}
+	ca, caKey, caFile, _ := issue("ca", template(1, "ca", true), nil, nil)
+	certs.ca = caFile
+	server := template(2, "kbagent", false)
+	server.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	_, _, certs.serverCert, certs.serverKey = issue("server", server, ca, caKey)
+	_, _, certs.clientCert, certs.clientKey = issue("client", template(3, "kubeblocks", false), ca, caKey)
+	other, otherKey, _, _ := issue("other-ca", template(4, "other-ca", true), nil, nil)
+	_, _, certs.untrustedCert, certs.untrustedKey = issue("untrusted", template(5, "kubeblocks", false), other, otherKey)
+	return certs
+}
+
+// echoStreamingService reads the handshake line and echoes it back.
+type echoStreamingService struct {
+	handshakes chan string
+}
+
+var _ service.Service = &echoStreamingService{}
+
+func (s *echoStreamingService) Kind() string { return "streaming" }
+
+func (s *echoStreamingService) URI() string { return "/streaming" }
+
+func (s *echoStreamingService) Start() error { return nil }
+
+func (s *echoStreamingService) HandleConn(_ context.Context, conn net.Conn) error {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	s.handshakes <- line
+	_, err = conn.Write([]byte(line))
+	return err
+}
+
+func (s *echoStreamingService) HandleRequest(context.Context, []byte) ([]byte, error) {
+	return nil, nil
+}
+
+var _ = Describe("streaming server", func() {
+	var (
+		svc *echoStreamingService
+		srv *streamingServer
+	)
+
+	start := func(config Config) string {
+		config.Address = "127.0.0.1"
+		config.StreamingPort = 0
+		srv = NewStreamingServer(logr.New(nil), config, svc).(*streamingServer)
+		Expect(srv.StartNonBlocking()).Should(Succeed())
+		return srv.listener.Addr().String()
+	}
+
+	// roundTrip sends the (optional) preface and handshake, and returns the echo from the server.
+	roundTrip := func(conn net.Conn, preface string) (string, error) {
+		defer conn.Close()
+		Expect(conn.SetDeadline(time.Now().Add(10 * time.Second))).Should(Succeed())
+		if _, err := conn.Write([]byte(preface + "handshake\n")); err != nil {
+			return "", err
+		}
+		return bufio.NewReader(conn).ReadString('\n')
+	}
+
+	BeforeEach(func() {
+		svc = &echoStreamingService{handshakes: make(chan string, 1)}
+	})
+
+	AfterEach(func() {
+		if srv != nil {
+			_ = srv.Close()
+		}
+	})
+
+	Context("mtls", func() {
+		var (
+			certs *testCerts
+			addr  string
+		)
+
+		dial := func(certFile, keyFile string) (net.Conn, error) {
+			ca, err := os.ReadFile(certs.ca)
+			Expect(err).Should(BeNil())
+			pool := x509.NewCertPool()
+			Expect(pool.AppendCertsFromPEM(ca)).Should(BeTrue())
+			config := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+			if len(certFile) > 0 {
+				cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+				Expect(err).Should(BeNil())
+				config.Certificates = []tls.Certificate{cert}
+			}
+			return tls.Dial("tcp", addr, config)
+		}
+
+		BeforeEach(func() {
+			certs = newTestCerts(GinkgoT().TempDir())
+			addr = start(Config{
+				TLSCertFile: certs.serverCert,
+				TLSKeyFile:  certs.serverKey,
+				TLSCAFile:   certs.ca,
+			})
+		})
+
+		It("accept", func() {
+			conn, err := dial(certs.clientCert, certs.clientKey)
+			Expect(err).Should(BeNil())
+			echo, err := roundTrip(conn, "")
+			Expect(err).Should(BeNil())
+			Expect(echo).Should(Equal("handshake\n"))
+			Expect(svc.handshakes).Should(Receive(Equal("handshake\n")))
+		})
+
+		It("reject plain connection", func() {
+			conn, err := net.Dial("tcp", addr)
+			Expect(err).Should(BeNil())
+			_, err = roundTrip(conn, "")
+			Expect(err).Should(HaveOccurred())
+			Consistently(svc.handshakes, time.Second).ShouldNot(Receive())
+		})
+
+		It("reject no client certificate", func() {
+			conn, err := dial("", "")
+			if err == nil {
+				_, err = roundTrip(conn, "")
+			}
+			Expect(err).Should(HaveOccurred())
+			Consistently(svc.handshakes, time.Second).ShouldNot(Receive())
+		})
+
+		It("reject untrusted client certificate", func() {
+			conn, err := dial(certs.untrustedCert, certs.untrustedKey)
+			if err == nil {
+				_, err = roundTrip(conn, "")
+			}
+			Expect(err).Should(HaveOccurred())
+			Consistently(svc.handshakes, time.Second).ShouldNot(Receive())
+		})
+	})
+
+	Context("token", func() {
+		var (
+			addr     string
+			reviewer *fakeTokenReviewer
+		)
+
+		BeforeEach(func() {
+			addr = start(Config{
+				TokenAudience: testTokenAudience,
+				TokenUsers:    []string{testTokenUser},
+			})
+			reviewer = &fakeTokenReviewer{tokens: map[string]string{"token": testTokenUser}}
+			srv.authenticator.reviewer = reviewer.review
+		})
+
+		It("accept", func() {
+			conn, err := net.Dial("tcp", addr)
+			Expect(err).Should(BeNil())
+			echo, err := roundTrip(conn, "Bearer token\n")
+			Expect(err).Should(BeNil())
+			Expect(echo).Should(Equal("handshake\n"))
+			// the handshake buffered along with the preface is passed to the service
+			Expect(svc.handshakes).Should(Receive(Equal("handshake\n")))
+		})
+
+		It("reject no token", func() {
+			conn, err := net.Dial("tcp", addr)
+			Expect(err).Should(BeNil())
+			_, err = roundTrip(conn, "")
+			Expect(err).Should(HaveOccurred())
+			Consistently(svc.handshakes, time.Second).ShouldNot(Receive())
+		})
+
+		It("reject invalid token", func() {
+			conn, err := net.Dial("tcp", addr)
+			Expect(err).Should(BeNil())
+			_, err = roundTrip(conn, fmt.Sprintf("Bearer %s\n", "invalid"))
+			Expect(err).Should(HaveOccurred())
+			Consistently(svc.handshakes, time.Second).ShouldNot(Receive())
+		})
+	})
+})
@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"sync"
@@ -40,6 +42,9 @@ func newActionService(logger logr.Logger, actions []proto.Action) (*actionServic
 		actions:        make(map[string]*proto.Action),
 		mutex:          sync.Mutex{},
 		runningActions: map[string]*runningAction{},
+		cancelMutex:    sync.Mutex{},
+		cancelFuncs:    map[string]context.CancelFunc{},
+		calls:          newActionCalls(),
 	}
 	for i, action := range actions {
 		sa.actions[action.Name] = &actions[i]
@@ -54,13 +59,22 @@ type actionService struct {
 
 	mutex          sync.Mutex
 	runningActions map[string]*runningAction
+
+	// cancel functions of the running actions, keyed by the request ID
+	cancelMutex sync.Mutex
+	cancelFuncs map[string]context.CancelFunc
+
+	// the shared executions and cached results of the blocking actions
+	calls *actionCalls
 }
 
 type runningAction struct {
 	resultChan chan *commandResult
+	done       func()
 }
 
-var _ Service = &actionService{}
+var _ FollowService = &actionService{}
+var _ CancelService = &actionService{}
 
 func (s *actionService) Kind() string {
 	return proto.ServiceAction.Kind
@@ -92,6 +106,34 @@ func (s *actionService) HandleRequest(ctx context.Context, payload []byte) ([]by
 	return s.encode(resp, err), nil
 }
 
+func (s *actionService) HandleFollow(ctx context.Context, payload []byte, writer io.Writer) error {
+	out := &outputStreamer{encoder: json.NewEncoder(writer)}
+	req, err := s.decode(payload)
+	if err != nil {
+		return out.done(err)
+	}
+	err = s.handleFollow(ctx, req, out)
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	s.logger.Info("Action Executed", "action", req.Action, "mode", "follow", "result", result)
+	return out.done(err)
+}
+
+func (s *actionService) HandleCancel(ctx context.Context, requestID string) ([]byte, error) {
+	s.cancelMutex.Lock()
+	defer s.cancelMutex.Unlock()
+
+	cancel, ok := s.cancelFuncs[requestID]
+	if !ok {
+		return s.encode(nil, errors.Wrapf(proto.ErrNotFound, "request %s is not found or has finished", requestID)), nil
+	}
+	cancel()
+	s.logger.Info("Action Canceled", "request", requestID)
+	return s.encode(nil, nil), nil
+}
+
 func (s *actionService) decode(payload []byte) (*proto.ActionRequest, error) {
 	req := &proto.ActionRequest{}
 	if err := json.Unmarshal(payload, req); err != nil {
@@ -113,39 +155,128 @@ func (s *actionService) encode(out []byte, err error) []byte {
 }
 
 func (s *actionService) handleRequest(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
+	action, err := s.precheck(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.NonBlocking == nil || !*req.NonBlocking {
+		// the request with an ID is not shared with others, so that it can be canceled individually
+		if len(req.RequestID) == 0 {
+			return s.calls.do(ctx, action, req)
+		}
+		ctx, done, err := s.cancelable(ctx, req.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+		output, err := runAction(ctx, action, req.Parameters, req.TimeoutSeconds)
+		return output, canceledError(ctx, err)
+	}
+	return s.handleActionNonBlocking(ctx, req, action)
+}
+
+func (s *actionService) handleFollow(ctx context.Context, req *proto.ActionRequest, out *outputStreamer) error {
+	action, err := s.precheck(ctx, req)
+	if err != nil {
+		return err
+	}
+	ctx, done, err := s.cancelable(ctx, req.RequestID)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	// the action is canceled if the output can't be delivered, e.g., the caller has gone
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	out.cancel = cancel
+
+	if action.Exec == nil {
+		output, err := runAction(ctx, action, req.Parameters, req.TimeoutSeconds)
+		if err == nil && len(output) > 0 {
+			_, err = out.writer(proto.ActionOutputStdout).Write(output)
+		}
+		return canceledError(ctx, err)
+	}
+
+	stderrBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
+	errChan, err := runCommandX(ctx, action.Exec, req.Parameters, req.TimeoutSeconds,
+		nil, out.writer(proto.ActionOutputStdout), io.MultiWriter(out.writer(proto.ActionOutputStderr), stderrBuf))
+	if err != nil {
+		return err
+	}
+	if err = s.wait(errChan); err != nil {
+		return commandError(err, stderrBuf.String())
+	}
+	return nil
+}
+
+func (s *actionService) precheck(ctx context.Context, req *proto.ActionRequest) (*proto.Action, error) {
 	if _, ok := s.actions[req.Action]; !ok {
 		return nil, errors.Wrapf(proto.ErrNotDefined, "%s is not defined", req.Action)
 	}
 	action := s.actions[req.Action]
-	if action.Exec == nil {
-		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec action is supported")
+	if action.Exec == nil && action.HTTP == nil && action.GRPC == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "only exec, http and grpc actions are supported")
 	}
 	// HACK: pre-check for the reconfigure action
 	if err := checkReconfigure(ctx, req); err != nil {
 		return nil, err
 	}
-	return s.handleExecAction(ctx, req, action)
+	return action, nil
 }
 
-func (s *actionService) handleExecAction(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
-	if req.NonBlocking == nil || !*req.NonBlocking {
-		return runCommand(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
+// cancelable registers the action request to be able to cancel it by the request ID, the returned done function
+// must be called to unregister it after the action is finished.
+func (s *actionService) cancelable(ctx context.Context, requestID string) (context.Context, func(), error) {
+	if len(requestID) == 0 {
+		return ctx, func() {}, nil
+	}
+
+	s.cancelMutex.Lock()
+	defer s.cancelMutex.Unlock()
+
+	if _, ok := s.cancelFuncs[requestID]; ok {
+		return nil, nil, errors.Wrapf(proto.ErrBusy, "request %s is running", requestID)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancelFuncs[requestID] = cancel
+	done := func() {
+		s.cancelMutex.Lock()
+		defer s.cancelMutex.Unlock()
+		delete(s.cancelFuncs, requestID)
+		cancel()
+	}
+	return ctx, done, nil
+}
+
+func (s *actionService) wait(errChan chan error) error {
+	err, ok := <-errChan
+	if !ok {
+		err = errors.New("runtime error: error chan closed unexpectedly")
 	}
-	return s.handleExecActionNonBlocking(ctx, req, action)
+	return err
 }
 
-func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
+func (s *actionService) handleActionNonBlocking(ctx context.Context, req *proto.ActionRequest, action *proto.Action) ([]byte, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	running, ok := s.runningActions[req.Action]
 	if !ok {
-		resultChan, err := runCommandNonBlocking(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
+		// the action outlives the request, so it is bound to a new context rather than the request's
+		ctx, done, err := s.cancelable(context.Background(), req.RequestID)
 		if err != nil {
 			return nil, err
 		}
+		resultChan, err := runActionNonBlocking(ctx, action, req.Parameters, req.TimeoutSeconds)
+		if err != nil {
+			done()
+			return nil, err
+		}
 		running = &runningAction{
 			resultChan: resultChan,
+			done:       done,
 		}
 		s.runningActions[req.Action] = running
 	}
@@ -154,8 +285,91 @@ func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *pr
 		return nil, proto.ErrInProgress
 	}
 	delete(s.runningActions, req.Action)
+	running.done()
 	if (*result).err != nil {
 		return nil, (*result).err
 	}
 	return (*result).stdout.Bytes(), nil
 }
+
+func runAction(ctx context.Context, action *proto.Action, parameters map[string]string, timeout *int32) ([]byte, error) {
+	switch {
+	case action.HTTP != nil:
+		return runHTTPAction(ctx, action.HTTP, parameters, timeout)
+	case action.GRPC != nil:
+		return runGRPCAction(ctx, action.GRPC, parameters, timeout)
+	default:
+		return runCommand(ctx, action.Exec, parameters, timeout)
+	}
+}
+
+func runActionNonBlocking(ctx context.Context, action *proto.Action, parameters map[string]string, timeout *int32) (chan *commandResult, error) {
+	if action.Exec != nil {
+		return runCommandNonBlocking(ctx, action.Exec, parameters, timeout)
+	}
+	resultChan := make(chan *commandResult, 1)
+	go func() {
+		output, err := runAction(ctx, action, parameters, timeout)
+		err = canceledError(ctx, err)
+		resultChan <- &commandResult{
+			err:    err,
+			stdout: bytes.NewBuffer(output),
+			stderr: bytes.NewBuffer(nil),
+		}
+	}()
+	return resultChan, nil
+}
+
+// canceledError converts the error caused by the cancellation of the context to proto.ErrCanceled.
+func canceledError(ctx context.Context, err error) error {
+	if err != nil && !errors.Is(err, proto.ErrCanceled) && errors.Is(ctx.Err(), context.Canceled) {
+		return errors.Wrap(proto.ErrCanceled, err.Error())
+	}
+	return err
+}
+
+// outputStreamer encodes the output of an action as a stream of proto.ActionOutput.
+type outputStreamer struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	cancel  context.CancelFunc
+}
+
+func (o *outputStreamer) writer(stream string) io.Writer {
+	return &outputWriter{streamer: o, stream: stream}
+}
+
+func (o *outputStreamer) write(chunk proto.ActionOutput) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	err := o.encoder.Encode(chunk)
+	if err != nil && o.cancel != nil {
+		o.cancel()
+	}
+	return err
+}
+
+func (o *outputStreamer) done(err error) error {
+	chunk := proto.ActionOutput{Done: true}
+	if err != nil {
+		chunk.Error = proto.Error2Type(err)
+		chunk.Message = err.Error()
+	}
+	return o.write(chunk)
+}
+
+type outputWriter struct {
+	streamer *outputStreamer
+	stream   string
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	chunk := proto.ActionOutput{
+		Stream: w.stream,
+		Data:   p,
+	}
+	if err := w.streamer.write(chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// actionCall is an execution of an action shared by the identical requests, it is kept after the execution
+// is finished to serve as the cached result if the action has a cache TTL.
+type actionCall struct {
+	done     chan struct{}
+	finished bool
+	expireAt time.Time
+	output   []byte
+	err      error
+}
+
+func (c *actionCall) expired(now time.Time) bool {
+	return c.finished && !now.Before(c.expireAt)
+}
+
+// actionCalls de-duplicates the identical requests of actions, and caches the results of them.
+type actionCalls struct {
+	mutex sync.Mutex
+	calls map[string]*actionCall
+	now   func() time.Time
+}
+
+func newActionCalls() *actionCalls {
+	return &actionCalls{
+		mutex: sync.Mutex{},
+		calls: map[string]*actionCall{},
+		now:   time.Now,
+	}
+}
+
+// do runs the action for the request, or joins the in-flight or cached execution of an identical request.
+func (c *actionCalls) do(ctx context.Context, action *proto.Action, req *proto.ActionRequest) ([]byte, error) {
+	call := c.join(action, req)
+	select {
+	case <-call.done:
+		return call.output, call.err
+	case <-ctx.Done():
+		return nil, canceledError(ctx, ctx.Err())
+	}
+}
+
+func (c *actionCalls) join(action *proto.Action, req *proto.ActionRequest) *actionCall {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := actionCallKey(req)
+	if call, ok := c.calls[key]; ok && !call.expired(c.now()) {
+		return call
+	}
+	c.evict()
+
+	call := &actionCall{done: make(chan struct{})}
+	c.calls[key] = call
+	// the execution is shared by all the identical requests, so it is not bound to the context of any of them
+	go c.run(key, call, action, req)
+	return call
+}
+
+func (c *actionCalls) run(key string, call *actionCall, action *proto.Action, req *proto.ActionRequest) {
+	output, err := runAction(context.Background(), action, req.Parameters, req.TimeoutSeconds)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	call.output, call.err = output, err
+	call.finished = true
+	if err == nil && action.CacheTTLSeconds > 0 {
+		call.expireAt = c.now().Add(time.Duration(action.CacheTTLSeconds) * time.Second)
+	} else if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	close(call.done)
+}
+
+// evict removes the expired results, it must be called with the mutex held.
+func (c *actionCalls) evict() {
+	now := c.now()
+	for key, call := range c.calls {
+		if call.expired(now) {
+			delete(c.calls, key)
+		}
+	}
+}
+
+// actionCallKey identifies the identical requests, which are requests of the same action with the same parameters and timeout.
+func actionCallKey(req *proto.ActionRequest) string {
+	// the keys of map are sorted by the json encoder
+	parameters, _ := json.Marshal(req.Parameters)
+	timeout := int32(-1)
+	if req.TimeoutSeconds != nil {
+		timeout = *req.TimeoutSeconds
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%s", req.Action, timeout, parameters)))
+	return hex.EncodeToString(sum[:])
+}
@@ -20,10 +20,302 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("action", func() {
 	Context("action", func() {
 	})
+
+	Context("de-duplicate", func() {
+		var (
+			counter string
+			actions []proto.Action
+		)
+
+		BeforeEach(func() {
+			counter = filepath.Join(GinkgoT().TempDir(), "counter")
+			actions = []proto.Action{
+				{
+					Name: "count",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", fmt.Sprintf("echo $KEY >> %s; sleep 0.5; wc -l < %s", counter, counter)},
+					},
+				},
+				{
+					Name: "cached",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", fmt.Sprintf("echo $KEY >> %s; wc -l < %s", counter, counter)},
+					},
+					CacheTTLSeconds: 60,
+				},
+				{
+					Name: "fail",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", fmt.Sprintf("echo $KEY >> %s; exit 1", counter)},
+					},
+					CacheTTLSeconds: 60,
+				},
+			}
+		})
+
+		executions := func() int {
+			data, err := os.ReadFile(counter)
+			Expect(err).Should(BeNil())
+			return strings.Count(string(data), "\n")
+		}
+
+		request := func(service *actionService, action, key string) ([]byte, error) {
+			return service.handleRequest(ctx, &proto.ActionRequest{
+				Action:     action,
+				Parameters: map[string]string{"KEY": key},
+			})
+		}
+
+		concurrently := func(n int, f func(i int)) {
+			wg := sync.WaitGroup{}
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					f(i)
+				}(i)
+			}
+			wg.Wait()
+		}
+
+		It("identical requests", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			concurrently(4, func(int) {
+				output, err := request(service, "count", "k")
+				Expect(err).Should(BeNil())
+				Expect(strings.TrimSpace(string(output))).Should(Equal("1"))
+			})
+			Expect(executions()).Should(Equal(1))
+
+			// no cache TTL, the action is executed again
+			_, err = request(service, "count", "k")
+			Expect(err).Should(BeNil())
+			Expect(executions()).Should(Equal(2))
+			Expect(service.calls.calls).Should(BeEmpty())
+		})
+
+		It("different parameters", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			concurrently(2, func(i int) {
+				_, err := request(service, "count", fmt.Sprintf("k%d", i))
+				Expect(err).Should(BeNil())
+			})
+			Expect(executions()).Should(Equal(2))
+		})
+
+		It("cached", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			now := time.Now()
+			service.calls.now = func() time.Time { return now }
+
+			for i := 0; i < 3; i++ {
+				output, err := request(service, "cached", "k")
+				Expect(err).Should(BeNil())
+				Expect(strings.TrimSpace(string(output))).Should(Equal("1"))
+			}
+			Expect(executions()).Should(Equal(1))
+
+			now = now.Add(time.Minute)
+			output, err := request(service, "cached", "k")
+			Expect(err).Should(BeNil())
+			Expect(strings.TrimSpace(string(output))).Should(Equal("2"))
+			Expect(executions()).Should(Equal(2))
+		})
+
+		It("failure is not cached", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			for i := 0; i < 2; i++ {
+				_, err := request(service, "fail", "k")
+				Expect(err).Should(MatchError(proto.ErrFailed))
+			}
+			Expect(executions()).Should(Equal(2))
+		})
+	})
+
+	Context("follow", func() {
+		var (
+			actions = []proto.Action{
+				{
+					Name: "echo",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n out; echo -n err >&2"},
+					},
+				},
+				{
+					Name: "fail",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n err >&2; exit 1"},
+					},
+				},
+			}
+		)
+
+		decode := func(data []byte) []proto.ActionOutput {
+			chunks := make([]proto.ActionOutput, 0)
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			for decoder.More() {
+				chunk := proto.ActionOutput{}
+				Expect(decoder.Decode(&chunk)).Should(Succeed())
+				chunks = append(chunks, chunk)
+			}
+			return chunks
+		}
+
+		follow := func(req proto.ActionRequest) []proto.ActionOutput {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+			payload, _ := json.Marshal(req)
+			buf := &bytes.Buffer{}
+			Expect(service.HandleFollow(ctx, payload, buf)).Should(Succeed())
+			return decode(buf.Bytes())
+		}
+
+		It("ok", func() {
+			chunks := follow(proto.ActionRequest{Action: "echo"})
+			Expect(len(chunks)).Should(BeNumerically(">=", 3))
+
+			stdout, stderr := "", ""
+			for _, chunk := range chunks[:len(chunks)-1] {
+				Expect(chunk.Done).Should(BeFalse())
+				switch chunk.Stream {
+				case proto.ActionOutputStdout:
+					stdout += string(chunk.Data)
+				case proto.ActionOutputStderr:
+					stderr += string(chunk.Data)
+				}
+			}
+			Expect(stdout).Should(Equal("out"))
+			Expect(stderr).Should(Equal("err"))
+
+			last := chunks[len(chunks)-1]
+			Expect(last.Done).Should(BeTrue())
+			Expect(last.Error).Should(BeEmpty())
+		})
+
+		It("fail", func() {
+			chunks := follow(proto.ActionRequest{Action: "fail"})
+			last := chunks[len(chunks)-1]
+			Expect(last.Done).Should(BeTrue())
+			Expect(last.Error).Should(Equal(proto.Error2Type(proto.ErrFailed)))
+			Expect(last.Message).Should(ContainSubstring("exit code: 1"))
+		})
+
+		It("not defined", func() {
+			chunks := follow(proto.ActionRequest{Action: "not-defined"})
+			Expect(chunks).Should(HaveLen(1))
+			Expect(chunks[0].Done).Should(BeTrue())
+			Expect(chunks[0].Error).Should(Equal(proto.Error2Type(proto.ErrNotDefined)))
+		})
+	})
+
+	Context("cancel", func() {
+		var (
+			actions = []proto.Action{
+				{
+					Name: "sleep",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "sleep 60 & wait"},
+					},
+				},
+			}
+		)
+
+		cancelEventually := func(service *actionService, requestID string) {
+			Eventually(func() string {
+				output, err := service.HandleCancel(ctx, requestID)
+				Expect(err).Should(BeNil())
+				rsp := proto.ActionResponse{}
+				Expect(json.Unmarshal(output, &rsp)).Should(Succeed())
+				return rsp.Error
+			}).Should(BeEmpty())
+		}
+
+		It("not found", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			output, err := service.HandleCancel(ctx, "not-exist")
+			Expect(err).Should(BeNil())
+			rsp := proto.ActionResponse{}
+			Expect(json.Unmarshal(output, &rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotFound)))
+		})
+
+		It("blocking", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			start := time.Now()
+			go func() {
+				defer GinkgoRecover()
+				cancelEventually(service, "req-1")
+			}()
+
+			_, err = service.handleRequest(ctx, &proto.ActionRequest{Action: "sleep", RequestID: "req-1"})
+			Expect(err).ShouldNot(BeNil())
+			Expect(err).Should(MatchError(proto.ErrCanceled))
+			Expect(time.Since(start)).Should(BeNumerically("<", 30*time.Second))
+			Expect(service.cancelFuncs).Should(BeEmpty())
+		})
+
+		It("follow", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			go func() {
+				defer GinkgoRecover()
+				cancelEventually(service, "req-2")
+			}()
+
+			payload, _ := json.Marshal(proto.ActionRequest{Action: "sleep", RequestID: "req-2"})
+			buf := &bytes.Buffer{}
+			Expect(service.HandleFollow(ctx, payload, buf)).Should(Succeed())
+
+			last := proto.ActionOutput{}
+			Expect(json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &last)).Should(Succeed())
+			Expect(last.Done).Should(BeTrue())
+			Expect(last.Error).Should(Equal(proto.Error2Type(proto.ErrCanceled)))
+		})
+
+		It("duplicate request", func() {
+			service, err := newActionService(logr.New(nil), actions)
+			Expect(err).Should(BeNil())
+
+			_, done, err := service.cancelable(ctx, "req-3")
+			Expect(err).Should(BeNil())
+			defer done()
+
+			_, err = service.handleRequest(ctx, &proto.ActionRequest{Action: "sleep", RequestID: "req-3"})
+			Expect(err).Should(MatchError(proto.ErrBusy))
+		})
+	})
 })
@@ -27,6 +27,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pkg/errors"
@@ -63,21 +64,47 @@ func runCommand(ctx context.Context, action *proto.ExecAction, parameters map[st
 		return nil, err
 	}
 	result := <-resultChan
-	err = result.err
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			errMsg := fmt.Sprintf("exit code: %d", exitErr.ExitCode())
-			if stderrMsg := result.stderr.String(); len(stderrMsg) > 0 {
-				errMsg += fmt.Sprintf(", stderr: %s", stderrMsg)
-			}
-			return nil, errors.Wrapf(proto.ErrFailed, errMsg)
-		}
-		return nil, err
+	if result.err != nil {
+		return nil, commandError(result.err, result.stderr.String())
 	}
 	return result.stdout.Bytes(), nil
 }
 
+// exitCodeError keeps the exit code of the failed command.
+type exitCodeError struct {
+	error
+	code int
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.error
+}
+
+func commandError(err error, stderrMsg string) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		errMsg := fmt.Sprintf("exit code: %d", exitErr.ExitCode())
+		if len(stderrMsg) > 0 {
+			errMsg += fmt.Sprintf(", stderr: %s", stderrMsg)
+		}
+		return &exitCodeError{error: errors.Wrapf(proto.ErrFailed, errMsg), code: exitErr.ExitCode()}
+	}
+	return err
+}
+
+// exitCode returns the exit code of the command from the error, -1 if the error is not caused by the exit of the command.
+func exitCode(err error) int32 {
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return int32(codeErr.code)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return int32(exitErr.ExitCode())
+	}
+	return -1
+}
+
 func runCommandNonBlocking(ctx context.Context, action *proto.ExecAction, parameters map[string]string, timeout *int32) (chan *commandResult, error) {
 	stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
 	stderrBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
@@ -146,6 +173,13 @@ func runCommandX(ctx context.Context, action *proto.ExecAction, parameters map[s
 		cmd.Env = mergedEnv
 	}
 
+	// run the command in a new process group, and kill the whole group when the action is timed-out or canceled,
+	// to avoid leaving the orphan sub-processes behind.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
 	cmd.Stdin = stdinReader
 	cmd.Stdout = stdoutWriter
 	cmd.Stderr = stderrWriter
@@ -156,9 +190,12 @@ func runCommandX(ctx context.Context, action *proto.ExecAction, parameters map[s
 		defer close(errChan)
 
 		if err := cmd.Start(); err != nil {
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			switch {
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
 				errChan <- proto.ErrTimedOut
-			} else {
+			case errors.Is(ctx.Err(), context.Canceled):
+				errChan <- proto.ErrCanceled
+			default:
 				errChan <- errors.Wrapf(proto.ErrFailed, "failed to start command: %v", err)
 			}
 			return
@@ -166,8 +203,11 @@ func runCommandX(ctx context.Context, action *proto.ExecAction, parameters map[s
 
 		execErr := cmd.Wait()
 		if execErr != nil {
-			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			switch {
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
 				execErr = proto.ErrTimedOut
+			case errors.Is(ctx.Err(), context.Canceled):
+				execErr = proto.ErrCanceled
 			}
 		}
 		errChan <- execErr
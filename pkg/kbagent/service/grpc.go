@@ -0,0 +1,201 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+func runGRPCAction(ctx context.Context, action *proto.GRPCAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	if timeout != nil && *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*timeout)*time.Second)
+		defer cancel()
+	}
+
+	request, err := renderTemplate("request", action.Request, templateVars(parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	host := action.Host
+	if len(host) == 0 {
+		host = defaultActionHost
+	}
+	conn, err := grpc.NewClient(net.JoinHostPort(host, strconv.Itoa(int(action.Port))),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "create grpc client error: %s", err.Error())
+	}
+	defer conn.Close()
+
+	method, err := resolveGRPCMethod(ctx, conn, action.Service, action.Method)
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	in := dynamicpb.NewMessage(method.Input())
+	if len(strings.TrimSpace(request)) > 0 {
+		if err = protojson.Unmarshal([]byte(request), in); err != nil {
+			return nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal the request message error: %s", err.Error())
+		}
+	}
+	out := dynamicpb.NewMessage(method.Output())
+	if err = conn.Invoke(ctx, fmt.Sprintf("/%s/%s", action.Service, action.Method), in, out); err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return protojson.Marshal(out)
+}
+
+func grpcError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return proto.ErrTimedOut
+	}
+	if errors.Is(err, proto.ErrBadRequest) || errors.Is(err, proto.ErrNotImplemented) {
+		return err
+	}
+	if s, ok := status.FromError(err); ok {
+		if s.Code() == codes.DeadlineExceeded {
+			return proto.ErrTimedOut
+		}
+		return errors.Wrapf(proto.ErrFailed, "status code: %s, message: %s", s.Code().String(), s.Message())
+	}
+	return errors.Wrapf(proto.ErrFailed, "%s", err.Error())
+}
+
+// resolveGRPCMethod resolves the descriptor of the method through the server reflection service.
+func resolveGRPCMethod(ctx context.Context, conn *grpc.ClientConn, service, method string) (protoreflect.MethodDescriptor, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	fetch := func(req *reflectionpb.ServerReflectionRequest) ([][]byte, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		rsp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if errRsp := rsp.GetErrorResponse(); errRsp != nil {
+			return nil, status.Error(codes.Code(errRsp.GetErrorCode()), errRsp.GetErrorMessage())
+		}
+		return rsp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	add := func(raws [][]byte) error {
+		for _, raw := range raws {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := gproto.Unmarshal(raw, fd); err != nil {
+				return err
+			}
+			files[fd.GetName()] = fd
+		}
+		return nil
+	}
+
+	raws, err := fetch(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = add(raws); err != nil {
+		return nil, err
+	}
+	// the server may not send all the transitive dependencies at once
+	for missing := missingGRPCDependencies(files); len(missing) > 0; missing = missingGRPCDependencies(files) {
+		for _, name := range missing {
+			raws, err = fetch(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err = add(raws); err != nil {
+				return nil, err
+			}
+			if _, ok := files[name]; !ok {
+				return nil, fmt.Errorf("dependency %s is not found", name)
+			}
+		}
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range files {
+		fileSet.File = append(fileSet.File, fd)
+	}
+	registry, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := registry.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "service %s is not found", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "method %s is not found in service %s", method, service)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, errors.Wrapf(proto.ErrNotImplemented, "streaming method %s is not supported", method)
+	}
+	return md, nil
+}
+
+func missingGRPCDependencies(files map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	for _, fd := range files {
+		for _, dep := range fd.GetDependency() {
+			if _, ok := files[dep]; !ok && !slices.Contains(missing, dep) {
+				missing = append(missing, dep)
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("grpc", func() {
+	var (
+		server *grpc.Server
+		host   string
+		port   int32
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).Should(BeNil())
+
+		healthServer := health.NewServer()
+		healthServer.SetServingStatus("serving", healthpb.HealthCheckResponse_SERVING)
+		healthServer.SetServingStatus("not-serving", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		server = grpc.NewServer()
+		healthpb.RegisterHealthServer(server, healthServer)
+		reflection.Register(server)
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		h, p, err := net.SplitHostPort(listener.Addr().String())
+		Expect(err).Should(BeNil())
+		host = h
+		pp, err := strconv.Atoi(p)
+		Expect(err).Should(BeNil())
+		port = int32(pp)
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	Context("runGRPCAction", func() {
+		It("simple", func() {
+			action := &proto.GRPCAction{
+				Host:    host,
+				Port:    port,
+				Service: "grpc.health.v1.Health",
+				Method:  "Check",
+			}
+			output, err := runGRPCAction(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(MatchJSON(`{"status": "SERVING"}`))
+		})
+
+		It("parameters", func() {
+			action := &proto.GRPCAction{
+				Host:    host,
+				Port:    port,
+				Service: "grpc.health.v1.Health",
+				Method:  "Check",
+				Request: `{"service": "{{ .SERVICE }}"}`,
+			}
+			output, err := runGRPCAction(ctx, action, map[string]string{"SERVICE": "not-serving"}, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(MatchJSON(`{"status": "NOT_SERVING"}`))
+		})
+
+		It("fail", func() {
+			action := &proto.GRPCAction{
+				Host:    host,
+				Port:    port,
+				Service: "grpc.health.v1.Health",
+				Method:  "Check",
+				Request: `{"service": "unknown"}`,
+			}
+			_, err := runGRPCAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("NotFound"))
+		})
+
+		It("method not found", func() {
+			action := &proto.GRPCAction{
+				Host:    host,
+				Port:    port,
+				Service: "grpc.health.v1.Health",
+				Method:  "NotFound",
+			}
+			_, err := runGRPCAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("streaming method", func() {
+			action := &proto.GRPCAction{
+				Host:    host,
+				Port:    port,
+				Service: "grpc.health.v1.Health",
+				Method:  "Watch",
+			}
+			_, err := runGRPCAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
+		})
+	})
+})
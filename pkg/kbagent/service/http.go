@@ -0,0 +1,184 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultActionHost = "127.0.0.1"
+)
+
+var (
+	// the same as the kubelet does for HTTPS probes, the certificate of the endpoint is not verified.
+	httpActionClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+)
+
+func runHTTPAction(ctx context.Context, action *proto.HTTPAction, parameters map[string]string, timeout *int32) ([]byte, error) {
+	if timeout != nil && *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*timeout)*time.Second)
+		defer cancel()
+	}
+
+	req, err := buildHTTPRequest(ctx, action, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := httpActionClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to send request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, proto.ErrTimedOut
+		}
+		return nil, errors.Wrapf(proto.ErrFailed, "failed to read response: %v", err)
+	}
+
+	if !expectedStatusCode(action, rsp.StatusCode) {
+		errMsg := fmt.Sprintf("status code: %d", rsp.StatusCode)
+		if len(body) > 0 {
+			errMsg += fmt.Sprintf(", body: %s", string(body))
+		}
+		return nil, errors.Wrap(proto.ErrFailed, errMsg)
+	}
+	return body, nil
+}
+
+func buildHTTPRequest(ctx context.Context, action *proto.HTTPAction, parameters map[string]string) (*http.Request, error) {
+	vars := templateVars(parameters)
+
+	path, err := renderTemplate("path", action.Path, vars)
+	if err != nil {
+		return nil, err
+	}
+	body, err := renderTemplate("body", action.Body, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := strings.ToLower(action.Scheme)
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	host := action.Host
+	if len(host) == 0 {
+		host = defaultActionHost
+	}
+	if len(path) > 0 && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u, err := url.Parse(fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(int(action.Port))), path))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid url: %s", err.Error())
+	}
+
+	method := action.Method
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBufferString(body))
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "build request error: %s", err.Error())
+	}
+	for _, header := range action.Headers {
+		value, err := renderTemplate(fmt.Sprintf("header %s", header.Name), header.Value, vars)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(header.Name, "Host") {
+			req.Host = value
+		} else {
+			req.Header.Add(header.Name, value)
+		}
+	}
+	return req, nil
+}
+
+func expectedStatusCode(action *proto.HTTPAction, statusCode int) bool {
+	if len(action.ExpectedStatusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+	return slices.Contains(action.ExpectedStatusCodes, int32(statusCode))
+}
+
+// templateVars returns the variables can be used in the templates of an action,
+// they are the same as the environment variables of an exec action.
+func templateVars(parameters map[string]string) map[string]string {
+	vars := make(map[string]string)
+	for _, e := range os.Environ() {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 2 {
+			vars[kv[0]] = kv[1]
+		}
+	}
+	for k, v := range parameters {
+		vars[k] = v
+	}
+	return vars
+}
+
+func renderTemplate(name, text string, vars map[string]string) (string, error) {
+	if len(text) == 0 || !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(proto.ErrBadRequest, "parse the template of %s error: %s", name, err.Error())
+	}
+	buf := &bytes.Buffer{}
+	if err = tpl.Execute(buf, vars); err != nil {
+		return "", errors.Wrapf(proto.ErrBadRequest, "render the template of %s error: %s", name, err.Error())
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("http", func() {
+	var (
+		server *httptest.Server
+		host   string
+		port   int32
+	)
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/echo/", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(r.Method + " " + r.URL.Path + " " + r.Header.Get("X-Param") + " " + string(body)))
+		})
+		mux.HandleFunc("/accepted", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})
+		mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("unavailable"))
+		})
+		mux.HandleFunc("/sleep", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(3 * time.Second)
+		})
+		server = httptest.NewServer(mux)
+
+		h, p, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).Should(BeNil())
+		host = h
+		pp, err := strconv.Atoi(p)
+		Expect(err).Should(BeNil())
+		port = int32(pp)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("runHTTPAction", func() {
+		It("simple", func() {
+			action := &proto.HTTPAction{
+				Host: host,
+				Port: port,
+				Path: "/echo/simple",
+			}
+			output, err := runHTTPAction(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("GET /echo/simple  "))
+		})
+
+		It("parameters", func() {
+			action := &proto.HTTPAction{
+				Host:   host,
+				Port:   port,
+				Method: http.MethodPost,
+				Path:   "/echo/{{ .PATH }}",
+				Headers: []proto.HTTPHeader{
+					{
+						Name:  "X-Param",
+						Value: "{{ .HEADER }}",
+					},
+				},
+				Body: "{\"user\": \"{{ .USER }}\"}",
+			}
+			parameters := map[string]string{
+				"PATH":   "parameters",
+				"HEADER": "header",
+				"USER":   "user",
+			}
+			output, err := runHTTPAction(ctx, action, parameters, nil)
+			Expect(err).Should(BeNil())
+			Expect(string(output)).Should(Equal("POST /echo/parameters header {\"user\": \"user\"}"))
+		})
+
+		It("parameter not defined", func() {
+			action := &proto.HTTPAction{
+				Host: host,
+				Port: port,
+				Path: "/echo/{{ .NOT_DEFINED_PARAMETER }}",
+			}
+			_, err := runHTTPAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrBadRequest)).Should(BeTrue())
+		})
+
+		It("expected status codes", func() {
+			action := &proto.HTTPAction{
+				Host: host,
+				Port: port,
+				Path: "/accepted",
+			}
+			_, err := runHTTPAction(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+
+			action.ExpectedStatusCodes = []int32{http.StatusOK}
+			_, err = runHTTPAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+		})
+
+		It("fail", func() {
+			action := &proto.HTTPAction{
+				Host: host,
+				Port: port,
+				Path: "/error",
+			}
+			_, err := runHTTPAction(ctx, action, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("status code: 503"))
+			Expect(err.Error()).Should(ContainSubstring("unavailable"))
+		})
+
+		It("timeout", func() {
+			action := &proto.HTTPAction{
+				Host: host,
+				Port: port,
+				Path: "/sleep",
+			}
+			timeout := int32(1)
+			_, err := runHTTPAction(ctx, action, nil, &timeout)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrTimedOut)).Should(BeTrue())
+		})
+	})
+
+	Context("runActionNonBlocking", func() {
+		It("http", func() {
+			action := &proto.Action{
+				Name: "http",
+				HTTP: &proto.HTTPAction{
+					Host: host,
+					Port: port,
+					Path: "/echo/non-blocking",
+				},
+			}
+			resultChan, err := runActionNonBlocking(ctx, action, nil, nil)
+			Expect(err).Should(BeNil())
+
+			result := <-resultChan
+			Expect(result.err).Should(BeNil())
+			Expect(result.stdout.String()).Should(Equal("GET /echo/non-blocking  "))
+		})
+	})
+})
@@ -40,12 +40,14 @@ const (
 	defaultProbePeriodSeconds = 60
 )
 
-func newProbeService(logger logr.Logger, actionService *actionService, probes []proto.Probe) (*probeService, error) {
+func newProbeService(logger logr.Logger, actionService *actionService, probes []proto.Probe, volumeProtection *proto.VolumeProtection) (*probeService, error) {
 	sp := &probeService{
-		logger:        logger,
-		actionService: actionService,
-		probes:        make(map[string]*proto.Probe),
-		runners:       make(map[string]*probeRunner),
+		logger:           logger,
+		actionService:    actionService,
+		probes:           make(map[string]*proto.Probe),
+		runners:          make(map[string]*probeRunner),
+		volumeProtection: volumeProtection,
+		epoch:            time.Now().UTC().Format(time.RFC3339Nano),
 	}
 	for i, p := range probes {
 		if _, ok := actionService.actions[p.Action]; !ok {
@@ -58,13 +60,17 @@ func newProbeService(logger logr.Logger, actionService *actionService, probes []
 }
 
 type probeService struct {
-	logger        logr.Logger
-	actionService *actionService
-	probes        map[string]*proto.Probe
-	runners       map[string]*probeRunner
+	logger           logr.Logger
+	actionService    *actionService
+	probes           map[string]*proto.Probe
+	runners          map[string]*probeRunner
+	volumeProtection *proto.VolumeProtection
+	// the epoch of the probe events, which identifies the incarnation of the kb-agent
+	epoch string
 }
 
-var _ Service = &probeService{}
+var _ QueryService = &probeService{}
+var _ WatchService = &probeService{}
 
 func (s *probeService) Kind() string {
 	return proto.ServiceProbe.Kind
@@ -80,10 +86,15 @@ func (s *probeService) Start() error {
 			logger:        s.logger.WithValues("probe", name),
 			actionService: s.actionService,
 			latestEvent:   make(chan proto.ProbeEvent, 1),
+			history:       newProbeHistory(defaultProbeHistorySize),
+			events:        newProbeEventLog(defaultProbeEventLogSize),
 		}
 		go runner.run(s.probes[name])
 		s.runners[name] = runner
 	}
+	if s.volumeProtection != nil && len(s.volumeProtection.Volumes) > 0 {
+		go newVolumeProtectionRunner(s.logger, s.volumeProtection).run()
+	}
 	return nil
 }
 
@@ -95,6 +106,34 @@ func (s *probeService) HandleRequest(ctx context.Context, payload []byte) ([]byt
 	return nil, errors.Wrapf(proto.ErrNotImplemented, "service %s does not support request handling", s.Kind())
 }
 
+func (s *probeService) HandleQuery(ctx context.Context, probe string) ([]byte, error) {
+	rsp := &proto.ProbeResponse{}
+	runner, ok := s.runners[probe]
+	if !ok {
+		err := errors.Wrapf(proto.ErrNotFound, "probe %s is not found", probe)
+		rsp.Error = proto.Error2Type(err)
+		rsp.Message = err.Error()
+	} else {
+		rsp.Results = runner.history.list()
+	}
+	data, _ := json.Marshal(rsp)
+	return data, nil
+}
+
+func (s *probeService) HandleWatch(ctx context.Context, probe string, since uint64, timeout time.Duration) ([]byte, error) {
+	rsp := &proto.ProbeWatchResponse{Epoch: s.epoch}
+	runner, ok := s.runners[probe]
+	if !ok {
+		err := errors.Wrapf(proto.ErrNotFound, "probe %s is not found", probe)
+		rsp.Error = proto.Error2Type(err)
+		rsp.Message = err.Error()
+	} else {
+		rsp.Events, rsp.Seq = runner.events.watch(ctx, since, probeWatchTimeout(timeout))
+	}
+	data, _ := json.Marshal(rsp)
+	return data, nil
+}
+
 type probeRunner struct {
 	logger        logr.Logger
 	actionService *actionService
@@ -103,6 +142,8 @@ type probeRunner struct {
 	failedCount   int64
 	latestOutput  []byte
 	latestEvent   chan proto.ProbeEvent
+	history       *probeHistory
+	events        *probeEventLog
 }
 
 func (r *probeRunner) run(probe *proto.Probe) {
@@ -134,6 +175,7 @@ func (r *probeRunner) runLoop(probe *proto.Probe) {
 	}
 
 	for range r.ticker.C {
+		start := time.Now()
 		output, err := runOnce()
 		if err == nil {
 			r.succeedCount++
@@ -143,6 +185,8 @@ func (r *probeRunner) runLoop(probe *proto.Probe) {
 			r.failedCount++
 		}
 
+		observeProbe(probe.Action, r.history, output, err, start, r.failedCount)
+
 		r.report(probe, output, err)
 
 		if succeed, _ := r.succeed(probe); succeed && !reflect.DeepEqual(output, r.latestOutput) {
@@ -233,6 +277,7 @@ func (r *probeRunner) buildNSendEvent(instance, probe string, code int32, output
 		Output:   output,
 		Message:  message,
 	}
+	r.events.append(*event)
 	r.sendEvent(event)
 	return event
 }
@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultProbeHistorySize      = 64
+	maxProbeHistoryOutputSize    = 1024
+	probeMetricsNamespace        = "kbagent"
+	probeMetricsSubsystem        = "probe"
+	probeMetricsLabelProbe       = "probe"
+	probeMetricsLabelResult      = "result"
+	probeMetricsResultSuccess    = "success"
+	probeMetricsResultFailure    = "failure"
+	probeMetricsHistogramBuckets = 12
+)
+
+var (
+	// MetricsRegistry is the registry of metrics exported by kbagent.
+	MetricsRegistry = prometheus.NewRegistry()
+
+	probeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: probeMetricsNamespace,
+		Subsystem: probeMetricsSubsystem,
+		Name:      "duration_seconds",
+		Help:      "The latency of the probe runs.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, probeMetricsHistogramBuckets),
+	}, []string{probeMetricsLabelProbe, probeMetricsLabelResult})
+
+	probeFlaps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: probeMetricsNamespace,
+		Subsystem: probeMetricsSubsystem,
+		Name:      "flaps_total",
+		Help:      "The number of times the result of the probe changes, from success to failure and vice versa, or the output changes.",
+	}, []string{probeMetricsLabelProbe})
+
+	probeConsecutiveFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: probeMetricsNamespace,
+		Subsystem: probeMetricsSubsystem,
+		Name:      "consecutive_failures",
+		Help:      "The number of consecutive failures of the probe.",
+	}, []string{probeMetricsLabelProbe})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(probeDuration, probeFlaps, probeConsecutiveFailures)
+}
+
+// probeHistory is a ring buffer of the recent results of a probe.
+type probeHistory struct {
+	mutex   sync.Mutex
+	results []proto.ProbeResult
+	next    int
+	full    bool
+}
+
+func newProbeHistory(size int) *probeHistory {
+	return &probeHistory{results: make([]proto.ProbeResult, size)}
+}
+
+func (h *probeHistory) add(result proto.ProbeResult) {
+	if len(result.Output) > maxProbeHistoryOutputSize {
+		result.Output = result.Output[:maxProbeHistoryOutputSize]
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.results[h.next] = result
+	h.next = (h.next + 1) % len(h.results)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the results ordered from oldest to newest.
+func (h *probeHistory) list() []proto.ProbeResult {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.full {
+		return append([]proto.ProbeResult{}, h.results[:h.next]...)
+	}
+	return append(append([]proto.ProbeResult{}, h.results[h.next:]...), h.results[:h.next]...)
+}
+
+// latest returns the newest result, nil if there is no result yet.
+func (h *probeHistory) latest() *proto.ProbeResult {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.full && h.next == 0 {
+		return nil
+	}
+	result := h.results[(h.next+len(h.results)-1)%len(h.results)]
+	return &result
+}
+
+func observeProbe(probe string, history *probeHistory, output []byte, err error, start time.Time, failedCount int64) {
+	result := proto.ProbeResult{
+		Timestamp: start,
+		Latency:   time.Since(start),
+		Output:    output,
+	}
+	label := probeMetricsResultSuccess
+	if err != nil {
+		result.Code = exitCode(err)
+		if result.Code == 0 {
+			result.Code = -1 // the probe failed without a non-zero exit code, e.g., an HTTP or gRPC error
+		}
+		result.Message = err.Error()
+		label = probeMetricsResultFailure
+	}
+
+	if prev := history.latest(); prev != nil && probeFlapped(*prev, result) {
+		probeFlaps.WithLabelValues(probe).Inc()
+	}
+	history.add(result)
+
+	probeDuration.WithLabelValues(probe, label).Observe(result.Latency.Seconds())
+	probeConsecutiveFailures.WithLabelValues(probe).Set(float64(failedCount))
+}
+
+func probeFlapped(prev, curr proto.ProbeResult) bool {
+	if prev.Code != curr.Code {
+		return true
+	}
+	// compare the outputs of succeed runs only, the (truncated) output of the previous run is used as the baseline
+	if curr.Code == 0 {
+		output := curr.Output
+		if len(output) > maxProbeHistoryOutputSize {
+			output = output[:maxProbeHistoryOutputSize]
+		}
+		return !bytes.Equal(prev.Output, output)
+	}
+	return false
+}
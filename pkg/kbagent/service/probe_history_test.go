@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("probe history", func() {
+	Context("ring buffer", func() {
+		It("empty", func() {
+			h := newProbeHistory(3)
+			Expect(h.list()).Should(BeEmpty())
+			Expect(h.latest()).Should(BeNil())
+		})
+
+		It("not full", func() {
+			h := newProbeHistory(3)
+			h.add(proto.ProbeResult{Message: "1"})
+			h.add(proto.ProbeResult{Message: "2"})
+
+			results := h.list()
+			Expect(results).Should(HaveLen(2))
+			Expect(results[0].Message).Should(Equal("1"))
+			Expect(results[1].Message).Should(Equal("2"))
+			Expect(h.latest().Message).Should(Equal("2"))
+		})
+
+		It("wrap around", func() {
+			h := newProbeHistory(3)
+			for i := 1; i <= 5; i++ {
+				h.add(proto.ProbeResult{Message: fmt.Sprintf("%d", i)})
+			}
+
+			results := h.list()
+			Expect(results).Should(HaveLen(3))
+			Expect(results[0].Message).Should(Equal("3"))
+			Expect(results[1].Message).Should(Equal("4"))
+			Expect(results[2].Message).Should(Equal("5"))
+			Expect(h.latest().Message).Should(Equal("5"))
+		})
+
+		It("truncate output", func() {
+			h := newProbeHistory(3)
+			h.add(proto.ProbeResult{Output: []byte(strings.Repeat("x", maxProbeHistoryOutputSize+1))})
+			Expect(h.latest().Output).Should(HaveLen(maxProbeHistoryOutputSize))
+		})
+	})
+
+	Context("metrics", func() {
+		It("flaps and consecutive failures", func() {
+			probe := "flapProbe"
+			h := newProbeHistory(defaultProbeHistorySize)
+
+			observeProbe(probe, h, []byte("leader"), nil, time.Now(), 0)
+			observeProbe(probe, h, []byte("leader"), nil, time.Now(), 0)
+			Expect(testutil.ToFloat64(probeFlaps.WithLabelValues(probe))).Should(Equal(float64(0)))
+
+			observeProbe(probe, h, []byte("follower"), nil, time.Now(), 0)
+			Expect(testutil.ToFloat64(probeFlaps.WithLabelValues(probe))).Should(Equal(float64(1)))
+
+			observeProbe(probe, h, nil, errors.New("fail"), time.Now(), 1)
+			observeProbe(probe, h, nil, errors.New("fail"), time.Now(), 2)
+			Expect(testutil.ToFloat64(probeFlaps.WithLabelValues(probe))).Should(Equal(float64(2)))
+			Expect(testutil.ToFloat64(probeConsecutiveFailures.WithLabelValues(probe))).Should(Equal(float64(2)))
+
+			observeProbe(probe, h, []byte("follower"), nil, time.Now(), 0)
+			Expect(testutil.ToFloat64(probeFlaps.WithLabelValues(probe))).Should(Equal(float64(3)))
+			Expect(testutil.ToFloat64(probeConsecutiveFailures.WithLabelValues(probe))).Should(Equal(float64(0)))
+
+			Expect(testutil.CollectAndCount(probeDuration)).Should(BeNumerically(">", 0))
+			Expect(h.list()).Should(HaveLen(6))
+		})
+
+		It("exit code", func() {
+			probe := "exitCodeProbe"
+			h := newProbeHistory(defaultProbeHistorySize)
+
+			_, err := runCommand(ctx, &proto.ExecAction{Commands: []string{"/bin/sh", "-c", "exit 3"}}, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(errors.Is(err, proto.ErrFailed)).Should(BeTrue())
+			observeProbe(probe, h, nil, err, time.Now(), 1)
+			Expect(h.latest().Code).Should(Equal(int32(3)))
+			Expect(h.latest().Message).Should(ContainSubstring("exit code: 3"))
+
+			observeProbe(probe, h, nil, errors.New("connection refused"), time.Now(), 2)
+			Expect(h.latest().Code).Should(Equal(int32(-1)))
+		})
+	})
+})
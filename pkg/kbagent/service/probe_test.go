@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"encoding/json"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -63,14 +64,14 @@ var _ = Describe("probe", func() {
 		})
 
 		It("new", func() {
-			service, err := newProbeService(logr.New(nil), actionSvc, probes)
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
 			Expect(err).Should(BeNil())
 			Expect(service).ShouldNot(BeNil())
 			Expect(service.Kind()).Should(Equal(proto.ServiceProbe.Kind))
 		})
 
 		It("start", func() {
-			service, err := newProbeService(logr.New(nil), actionSvc, probes)
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
 			Expect(err).Should(BeNil())
 			Expect(service).ShouldNot(BeNil())
 
@@ -79,7 +80,7 @@ var _ = Describe("probe", func() {
 		})
 
 		It("handle request", func() {
-			service, err := newProbeService(logr.New(nil), actionSvc, probes)
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
 			Expect(err).Should(BeNil())
 			Expect(service).ShouldNot(BeNil())
 
@@ -88,9 +89,59 @@ var _ = Describe("probe", func() {
 			Expect(errors.Is(err, proto.ErrNotImplemented)).Should(BeTrue())
 		})
 
+		It("handle query", func() {
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
+			Expect(err).Should(BeNil())
+			Expect(service.Start()).Should(Succeed())
+
+			Eventually(func() []proto.ProbeResult {
+				output, err := service.HandleQuery(ctx, "roleProbe")
+				Expect(err).Should(BeNil())
+				rsp := &proto.ProbeResponse{}
+				Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+				Expect(rsp.Error).Should(BeEmpty())
+				return rsp.Results
+			}).WithTimeout(5 * time.Second).ShouldNot(BeEmpty())
+
+			output, err := service.HandleQuery(ctx, "notExist")
+			Expect(err).Should(BeNil())
+			rsp := &proto.ProbeResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotFound)))
+		})
+
+		It("handle watch", func() {
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
+			Expect(err).Should(BeNil())
+			Expect(service.Start()).Should(Succeed())
+
+			output, err := service.HandleWatch(ctx, "roleProbe", 0, 5*time.Second)
+			Expect(err).Should(BeNil())
+			rsp := &proto.ProbeWatchResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Epoch).Should(Equal(service.epoch))
+			Expect(rsp.Events).Should(HaveLen(1))
+			Expect(rsp.Seq).Should(Equal(rsp.Events[0].Seq))
+			Expect(string(rsp.Events[0].Event.Output)).Should(Equal("leader"))
+
+			// the role is not changed, no more events
+			output, err = service.HandleWatch(ctx, "roleProbe", rsp.Seq, 1500*time.Millisecond)
+			Expect(err).Should(BeNil())
+			rsp = &proto.ProbeWatchResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Events).Should(BeEmpty())
+
+			output, err = service.HandleWatch(ctx, "notExist", 0, time.Second)
+			Expect(err).Should(BeNil())
+			rsp = &proto.ProbeWatchResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotFound)))
+		})
+
 		It("initial delay seconds", func() {
 			probes[0].InitialDelaySeconds = 60
-			service, err := newProbeService(logr.New(nil), actionSvc, probes)
+			service, err := newProbeService(logr.New(nil), actionSvc, probes, nil)
 			Expect(err).Should(BeNil())
 			Expect(service).ShouldNot(BeNil())
 
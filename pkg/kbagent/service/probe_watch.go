@@ -0,0 +1,115 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultProbeEventLogSize = 64
+	defaultProbeWatchTimeout = 30 * time.Second
+	maxProbeWatchTimeout     = 60 * time.Second
+)
+
+// probeEventLog keeps the recent events of a probe, which are delivered to the watchers by long-polling.
+type probeEventLog struct {
+	mutex  sync.Mutex
+	size   int
+	seq    uint64
+	events []proto.ProbeWatchEvent
+	// notify is closed and replaced when a new event is appended, to wake up the waiting watchers
+	notify chan struct{}
+}
+
+func newProbeEventLog(size int) *probeEventLog {
+	return &probeEventLog{
+		mutex:  sync.Mutex{},
+		size:   size,
+		events: make([]proto.ProbeWatchEvent, 0, size),
+		notify: make(chan struct{}),
+	}
+}
+
+func (l *probeEventLog) append(event proto.ProbeEvent) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.seq++
+	if len(l.events) == l.size {
+		l.events = append(l.events[:0], l.events[1:]...)
+	}
+	l.events = append(l.events, proto.ProbeWatchEvent{
+		Seq:       l.seq,
+		Timestamp: time.Now(),
+		Event:     event,
+	})
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// since returns the events newer than the sequence number, the latest sequence number, and a channel
+// to be notified when there are new events.
+func (l *probeEventLog) since(seq uint64) ([]proto.ProbeWatchEvent, uint64, <-chan struct{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// the watcher has seen a sequence number of a previous epoch, deliver all the events it has missed
+	if seq > l.seq {
+		seq = 0
+	}
+	var events []proto.ProbeWatchEvent
+	for _, event := range l.events {
+		if event.Seq > seq {
+			events = append(events, event)
+		}
+	}
+	return events, l.seq, l.notify
+}
+
+// watch waits for the events newer than the sequence number until the timeout.
+func (l *probeEventLog) watch(ctx context.Context, seq uint64, timeout time.Duration) ([]proto.ProbeWatchEvent, uint64) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		events, latest, notify := l.since(seq)
+		if len(events) > 0 {
+			return events, latest
+		}
+		select {
+		case <-notify:
+		case <-timer.C:
+			return nil, latest
+		case <-ctx.Done():
+			return nil, latest
+		}
+	}
+}
+
+func probeWatchTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultProbeWatchTimeout
+	}
+	return min(timeout, maxProbeWatchTimeout)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("probe watch", func() {
+	Context("event log", func() {
+		It("since", func() {
+			l := newProbeEventLog(3)
+			events, seq, _ := l.since(0)
+			Expect(events).Should(BeEmpty())
+			Expect(seq).Should(Equal(uint64(0)))
+
+			l.append(proto.ProbeEvent{Message: "1"})
+			l.append(proto.ProbeEvent{Message: "2"})
+
+			events, seq, _ = l.since(0)
+			Expect(events).Should(HaveLen(2))
+			Expect(seq).Should(Equal(uint64(2)))
+			Expect(events[0].Seq).Should(Equal(uint64(1)))
+			Expect(events[1].Event.Message).Should(Equal("2"))
+
+			events, _, _ = l.since(1)
+			Expect(events).Should(HaveLen(1))
+			Expect(events[0].Seq).Should(Equal(uint64(2)))
+
+			events, _, _ = l.since(2)
+			Expect(events).Should(BeEmpty())
+		})
+
+		It("wrap around", func() {
+			l := newProbeEventLog(3)
+			for i := 1; i <= 5; i++ {
+				l.append(proto.ProbeEvent{Message: fmt.Sprintf("%d", i)})
+			}
+			events, seq, _ := l.since(0)
+			Expect(seq).Should(Equal(uint64(5)))
+			Expect(events).Should(HaveLen(3))
+			Expect(events[0].Seq).Should(Equal(uint64(3)))
+			Expect(events[2].Event.Message).Should(Equal("5"))
+		})
+
+		It("previous epoch", func() {
+			l := newProbeEventLog(3)
+			l.append(proto.ProbeEvent{Message: "1"})
+
+			events, seq, _ := l.since(100)
+			Expect(seq).Should(Equal(uint64(1)))
+			Expect(events).Should(HaveLen(1))
+		})
+
+		It("watch timeout", func() {
+			l := newProbeEventLog(3)
+			start := time.Now()
+			events, seq := l.watch(ctx, 0, 100*time.Millisecond)
+			Expect(events).Should(BeEmpty())
+			Expect(seq).Should(Equal(uint64(0)))
+			Expect(time.Since(start)).Should(BeNumerically(">=", 100*time.Millisecond))
+		})
+
+		It("watch wake up", func() {
+			l := newProbeEventLog(3)
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				l.append(proto.ProbeEvent{Message: "1"})
+			}()
+			events, seq := l.watch(ctx, 0, 10*time.Second)
+			Expect(events).Should(HaveLen(1))
+			Expect(seq).Should(Equal(uint64(1)))
+		})
+	})
+
+	Context("timeout", func() {
+		It("normalize", func() {
+			Expect(probeWatchTimeout(0)).Should(Equal(defaultProbeWatchTimeout))
+			Expect(probeWatchTimeout(time.Second)).Should(Equal(time.Second))
+			Expect(probeWatchTimeout(time.Hour)).Should(Equal(maxProbeWatchTimeout))
+		})
+	})
+})
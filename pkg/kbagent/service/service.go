@@ -21,7 +21,9 @@ package service
 
 import (
 	"context"
+	"io"
 	"net"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -39,12 +41,46 @@ type Service interface {
 	HandleRequest(ctx context.Context, payload []byte) ([]byte, error)
 }
 
-func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string) ([]Service, error) {
+// QueryService is implemented by the services that support to query an object by its key.
+type QueryService interface {
+	Service
+
+	HandleQuery(ctx context.Context, key string) ([]byte, error)
+}
+
+// FollowService is implemented by the services that support to stream the output of a request.
+type FollowService interface {
+	Service
+
+	HandleFollow(ctx context.Context, payload []byte, writer io.Writer) error
+}
+
+// CancelService is implemented by the services that support to cancel a running request by its ID.
+type CancelService interface {
+	Service
+
+	HandleCancel(ctx context.Context, requestID string) ([]byte, error)
+}
+
+// WatchService is implemented by the services that support to watch the changes of an object by its key.
+type WatchService interface {
+	Service
+
+	// HandleWatch returns the changes newer than the sequence number since, it waits for the new changes
+	// until the timeout if there are none.
+	HandleWatch(ctx context.Context, key string, since uint64, timeout time.Duration) ([]byte, error)
+}
+
+var (
+	taskJournalDir = DefaultTaskJournalDir
+)
+
+func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string, volumeProtection *proto.VolumeProtection) ([]Service, error) {
 	sa, err := newActionService(logger, actions)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := newProbeService(logger, sa, probes)
+	sp, err := newProbeService(logger, sa, probes, volumeProtection)
 	if err != nil {
 		return nil, err
 	}
@@ -52,14 +88,17 @@ func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, strea
 	if err != nil {
 		return nil, err
 	}
-	return []Service{sa, sp, ss}, nil
+	st := newTaskQueryService(logger, taskJournalDir)
+	return []Service{sa, sp, ss, st}, nil
 }
 
-func RunTasks(logger logr.Logger, service Service, tasks []proto.Task) error {
+func RunTasks(logger logr.Logger, service Service, tasks []proto.Task, streamingClient StreamingClientConfig) error {
 	st := &taskService{
-		logger:        logger,
-		actionService: service.(*actionService),
-		tasks:         tasks,
+		logger:          logger,
+		actionService:   service.(*actionService),
+		streamingClient: streamingClient,
+		tasks:           tasks,
+		journal:         newTaskJournal(taskJournalDir),
 	}
 	return st.runTasks(context.Background())
 }
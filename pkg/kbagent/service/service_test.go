@@ -31,12 +31,13 @@ import (
 var _ = Describe("service", func() {
 	Context("new", func() {
 		It("empty", func() {
-			services, err := New(logr.New(nil), nil, nil, nil)
+			services, err := New(logr.New(nil), nil, nil, nil, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(4))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
 		})
 
 		It("action", func() {
@@ -45,12 +46,13 @@ var _ = Describe("service", func() {
 					Name: "action",
 				},
 			}
-			services, err := New(logr.New(nil), actions, nil, nil)
+			services, err := New(logr.New(nil), actions, nil, nil, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(4))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
 		})
 
 		It("probe", func() {
@@ -64,12 +66,13 @@ var _ = Describe("service", func() {
 					Action: "action",
 				},
 			}
-			services, err := New(logr.New(nil), actions, probes, nil)
+			services, err := New(logr.New(nil), actions, probes, nil, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(4))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
 		})
 
 		It("streaming", func() {
@@ -81,12 +84,13 @@ var _ = Describe("service", func() {
 			streamingActions := []string{
 				"action",
 			}
-			services, err := New(logr.New(nil), actions, nil, streamingActions)
+			services, err := New(logr.New(nil), actions, nil, streamingActions, nil)
 			Expect(err).Should(BeNil())
-			Expect(services).Should(HaveLen(3))
+			Expect(services).Should(HaveLen(4))
 			Expect(services[0]).ShouldNot(BeNil())
 			Expect(services[1]).ShouldNot(BeNil())
 			Expect(services[2]).ShouldNot(BeNil())
+			Expect(services[3]).ShouldNot(BeNil())
 		})
 
 		It("probe which has no action", func() {
@@ -103,7 +107,7 @@ var _ = Describe("service", func() {
 					Action: "not-defined",
 				},
 			}
-			_, err := New(logr.New(nil), actions, probes, nil)
+			_, err := New(logr.New(nil), actions, probes, nil, nil)
 			Expect(err).ShouldNot(BeNil())
 		})
 
@@ -117,7 +121,7 @@ var _ = Describe("service", func() {
 				"action",
 				"not-defined",
 			}
-			_, err := New(logr.New(nil), actions, nil, streamingActions)
+			_, err := New(logr.New(nil), actions, nil, streamingActions, nil)
 			Expect(err).ShouldNot(BeNil())
 		})
 	})
@@ -21,10 +21,14 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -37,6 +41,82 @@ const (
 	maxStreamingHandshakePacketSize = 4096
 )
 
+// StreamingClientConfig holds the credentials used to connect to the streaming server of other replicas.
+type StreamingClientConfig struct {
+	// TLS, the server certificate is verified by the CA, and the client certificate is presented if provided.
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	// TokenFile is the file of the bearer token, which is sent as a preface before the handshake packet.
+	TokenFile string
+}
+
+func (c StreamingClientConfig) tlsConfig() (*tls.Config, error) {
+	ca, err := os.ReadFile(c.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read the TLS CA error: %s", err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no valid certificate found in the TLS CA file %s", c.TLSCAFile)
+	}
+	config := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(c.TLSCertFile) > 0 && len(c.TLSKeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load the TLS client certificate error: %s", err.Error())
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// dialStreaming connects to the streaming server at the address, and authenticates itself with the credentials.
+func dialStreaming(ctx context.Context, config StreamingClientConfig, address string, timeout time.Duration) (net.Conn, error) {
+	var (
+		dialer = &net.Dialer{Timeout: timeout}
+		conn   net.Conn
+		err    error
+	)
+	if len(config.TLSCAFile) > 0 {
+		tlsConfig, err1 := config.tlsConfig()
+		if err1 != nil {
+			return nil, err1
+		}
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.TokenFile) > 0 {
+		if err = writeStreamingToken(conn, config.TokenFile); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func writeStreamingToken(conn net.Conn, tokenFile string) error {
+	// read the token for each connection, since the projected token is rotated
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("read the bearer token error: %s", err.Error())
+	}
+	preface := fmt.Sprintf("Bearer %s\n", strings.TrimSpace(string(token)))
+	if _, err = conn.Write([]byte(preface)); err != nil {
+		return fmt.Errorf("write the bearer token to remote error: %s", err.Error())
+	}
+	return nil
+}
+
 func newStreamingService(logger logr.Logger, actionService *actionService, streamingActions []string) (*streamingService, error) {
 	ss := &streamingService{
 		logger:           logger,
@@ -101,6 +181,9 @@ func (s *streamingService) handshake(ctx context.Context, conn net.Conn) (*proto
 }
 
 func (s *streamingService) streaming(ctx context.Context, conn net.Conn, action *proto.Action, req *proto.ActionRequest) error {
+	if action.Exec == nil {
+		return errors.Wrap(proto.ErrNotImplemented, "only exec action is supported for streaming")
+	}
 	errChan, err1 := runCommandX(ctx, action.Exec, req.Parameters, req.TimeoutSeconds, nil, conn, nil)
 	if err1 != nil {
 		return err1
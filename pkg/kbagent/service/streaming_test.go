@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("streaming", func() {
+	Context("dial", func() {
+		var (
+			listener net.Listener
+			accepted chan []string
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			accepted = make(chan []string, 1)
+			go func() {
+				defer GinkgoRecover()
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				lines := make([]string, 0)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						break
+					}
+					lines = append(lines, line)
+				}
+				accepted <- lines
+			}()
+		})
+
+		AfterEach(func() {
+			_ = listener.Close()
+		})
+
+		It("plain", func() {
+			conn, err := dialStreaming(ctx, StreamingClientConfig{}, listener.Addr().String(), time.Second)
+			Expect(err).Should(BeNil())
+			_, err = conn.Write([]byte("handshake\n"))
+			Expect(err).Should(BeNil())
+			Expect(conn.Close()).Should(Succeed())
+			Eventually(accepted).Should(Receive(Equal([]string{"handshake\n"})))
+		})
+
+		It("token", func() {
+			tokenFile := filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(tokenFile, []byte("token\n"), 0600)).Should(Succeed())
+
+			conn, err := dialStreaming(ctx, StreamingClientConfig{TokenFile: tokenFile}, listener.Addr().String(), time.Second)
+			Expect(err).Should(BeNil())
+			_, err = conn.Write([]byte("handshake\n"))
+			Expect(err).Should(BeNil())
+			Expect(conn.Close()).Should(Succeed())
+			Eventually(accepted).Should(Receive(Equal([]string{"Bearer token\n", "handshake\n"})))
+		})
+
+		It("token file not found", func() {
+			config := StreamingClientConfig{TokenFile: filepath.Join(GinkgoT().TempDir(), "token")}
+			_, err := dialStreaming(ctx, config, listener.Addr().String(), time.Second)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("invalid CA", func() {
+			config := StreamingClientConfig{TLSCAFile: filepath.Join(GinkgoT().TempDir(), "ca.crt")}
+			_, err := dialStreaming(ctx, config, listener.Addr().String(), time.Second)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
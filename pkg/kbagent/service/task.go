@@ -36,9 +36,11 @@ import (
 )
 
 type taskService struct {
-	logger        logr.Logger
-	actionService *actionService
-	tasks         []proto.Task
+	logger          logr.Logger
+	actionService   *actionService
+	streamingClient StreamingClientConfig
+	tasks           []proto.Task
+	journal         *taskJournal
 }
 
 type task interface {
@@ -67,6 +69,11 @@ func (s *taskService) runTask(ctx context.Context, task proto.Task) error {
 		return nil
 	}
 
+	finished, err := s.recover(task)
+	if err != nil || finished {
+		return err
+	}
+
 	event := proto.TaskEvent{
 		Instance:  task.Instance,
 		Task:      task.Task,
@@ -74,20 +81,22 @@ func (s *taskService) runTask(ctx context.Context, task proto.Task) error {
 		Replica:   util.PodName(),
 		StartTime: time.Now(),
 	}
+	s.journaling(event)
 
 	notify := func(err error, exit, exited chan struct{}) error {
 		if exit != nil && exited != nil {
 			close(exit)
 			<-exited
 		}
+		event.EndTime = time.Now()
+		if err == nil {
+			event.Code = 0
+		} else {
+			event.Code = -1
+			event.Message = err.Error()
+		}
+		s.journaling(event)
 		if task.NotifyAtFinish {
-			event.EndTime = time.Now()
-			if err == nil {
-				event.Code = 0
-			} else {
-				event.Code = -1
-				event.Message = err.Error()
-			}
 			err1 := s.notify(task, event, true)
 			if err == nil { // the run error takes precedence
 				err = err1
@@ -106,12 +115,45 @@ func (s *taskService) runTask(ctx context.Context, task proto.Task) error {
 	return notify(s.wait(ch), exit, exited)
 }
 
+// recover checks the journal of the task to handle the case that kbagent is restarted, it returns true if the
+// task has finished successfully before, and the latest event is notified again in case it is lost.
+// If the task was interrupted, it will be re-run, and only the outcome of the re-run is notified.
+func (s *taskService) recover(task proto.Task) (bool, error) {
+	latest, err := s.journal.get(task.UID)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to read the journal of task: %s", task.UID))
+		return false, nil // run the task anyway
+	}
+	if latest == nil || latest.Replica != util.PodName() {
+		return false, nil
+	}
+
+	switch {
+	case !latest.EndTime.IsZero() && latest.Code == 0:
+		s.logger.Info(fmt.Sprintf("task %s has finished at %s, skip it", task.UID, latest.EndTime))
+		if task.NotifyAtFinish {
+			return true, s.notify(task, *latest, true)
+		}
+		return true, nil
+	case latest.EndTime.IsZero():
+		s.logger.Info(fmt.Sprintf("task %s is interrupted since %s, re-run it", task.UID, latest.StartTime))
+	}
+	return false, nil
+}
+
+func (s *taskService) journaling(event proto.TaskEvent) {
+	if err := s.journal.append(event); err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to journal the task event: %v", event))
+	}
+}
+
 func (s *taskService) newTask(task proto.Task) task {
 	if task.NewReplica != nil {
 		return &newReplicaTask{
-			logger:        s.logger,
-			actionService: s.actionService,
-			task:          task.NewReplica,
+			logger:          s.logger,
+			actionService:   s.actionService,
+			streamingClient: s.streamingClient,
+			task:            task.NewReplica,
 		}
 	}
 	return nil
@@ -132,6 +174,7 @@ func (s *taskService) report(ctx context.Context, task proto.Task, t task, event
 					eventCopy := event
 					t.status(ctx, &event)
 					if !reflect.DeepEqual(event, eventCopy) {
+						s.journaling(event)
 						_ = s.notify(task, event, false)
 					}
 				}
@@ -0,0 +1,252 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	// DefaultTaskJournalDir is the directory where the task journal is persisted, it should be a volume
+	// shared by the kbagent worker and server, and survives the restart of containers.
+	DefaultTaskJournalDir = "/var/lib/kbagent"
+
+	taskJournalFile = "tasks.journal"
+
+	// the journal is compacted when its size exceeds the threshold, only the latest event of each task is kept,
+	// and the oldest finished tasks are dropped if the number of tasks exceeds the limit.
+	taskJournalCompactThreshold = 1024 * 1024
+	maxTaskJournalFinishedTasks = 128
+)
+
+// taskJournal is an append-only journal of task events, keyed by the task UID.
+// The latest event of a task wins, and a task without the end time is considered as running.
+type taskJournal struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// newTaskJournal returns a journal under the dir, or nil if the dir does not exist, which disables the journal.
+func newTaskJournal(dir string) *taskJournal {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil
+	}
+	return &taskJournal{path: filepath.Join(dir, taskJournalFile)}
+}
+
+func (j *taskJournal) append(event proto.TaskEvent) error {
+	if j == nil {
+		return nil
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if info, err := f.Stat(); err == nil && info.Size() > taskJournalCompactThreshold {
+		return j.compact()
+	}
+	return nil
+}
+
+// get returns the latest event of the task, nil if the task has never been journaled.
+func (j *taskJournal) get(uid string) (*proto.TaskEvent, error) {
+	if j == nil {
+		return nil, nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	events, _, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	return events[uid], nil
+}
+
+// load reads the latest event of each task, and the UIDs of tasks in the order of their first appearance.
+func (j *taskJournal) load() (map[string]*proto.TaskEvent, []string, error) {
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	events := make(map[string]*proto.TaskEvent)
+	uids := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		event := &proto.TaskEvent{}
+		if err = json.Unmarshal(scanner.Bytes(), event); err != nil {
+			continue // the record may be truncated when the agent crashed, ignore it
+		}
+		if _, ok := events[event.UID]; !ok {
+			uids = append(uids, event.UID)
+		}
+		events[event.UID] = event
+	}
+	return events, uids, scanner.Err()
+}
+
+// compact rewrites the journal with the latest event of each task, the running tasks are always kept,
+// and only the most recently finished tasks are kept. The caller must hold the lock.
+func (j *taskJournal) compact() error {
+	events, uids, err := j.load()
+	if err != nil {
+		return err
+	}
+
+	finished := make([]*proto.TaskEvent, 0)
+	for _, uid := range uids {
+		if !events[uid].EndTime.IsZero() {
+			finished = append(finished, events[uid])
+		}
+	}
+	sort.SliceStable(finished, func(i, k int) bool {
+		return finished[i].EndTime.Before(finished[k].EndTime)
+	})
+	for i := 0; i < len(finished)-maxTaskJournalFinishedTasks; i++ {
+		delete(events, finished[i].UID)
+	}
+
+	var buf bytes.Buffer
+	for _, uid := range uids {
+		event, ok := events[uid]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(data, '\n'))
+	}
+
+	// write to a temporary file and rename it to keep the journal intact if the agent crashes
+	tmp := j.path + ".tmp"
+	if err = os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+func newTaskQueryService(logger logr.Logger, journalDir string) *taskQueryService {
+	return &taskQueryService{
+		logger:  logger,
+		journal: newTaskJournal(journalDir),
+	}
+}
+
+// taskQueryService queries the status of tasks from the journal.
+type taskQueryService struct {
+	logger  logr.Logger
+	journal *taskJournal
+}
+
+var _ Service = &taskQueryService{}
+
+func (s *taskQueryService) Kind() string {
+	return proto.ServiceTask.Kind
+}
+
+func (s *taskQueryService) URI() string {
+	return proto.ServiceTask.URI
+}
+
+func (s *taskQueryService) Start() error {
+	return nil
+}
+
+func (s *taskQueryService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *taskQueryService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	req := &proto.TaskRequest{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return s.encode(nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal task request error: %s", err.Error())), nil
+	}
+	return s.HandleQuery(ctx, req.UID)
+}
+
+func (s *taskQueryService) HandleQuery(ctx context.Context, uid string) ([]byte, error) {
+	event, err := s.query(uid)
+	return s.encode(event, err), nil
+}
+
+func (s *taskQueryService) query(uid string) (*proto.TaskEvent, error) {
+	if len(uid) == 0 {
+		return nil, errors.Wrap(proto.ErrBadRequest, "the UID of task is required")
+	}
+	if s.journal == nil {
+		return nil, errors.Wrap(proto.ErrNotImplemented, "the task journal is not enabled")
+	}
+	event, err := s.journal.get(uid)
+	if err != nil {
+		return nil, errors.Wrapf(proto.ErrInternalError, "read task journal error: %s", err.Error())
+	}
+	if event == nil {
+		return nil, errors.Wrapf(proto.ErrNotFound, "task %s is not found", uid)
+	}
+	return event, nil
+}
+
+func (s *taskQueryService) encode(event *proto.TaskEvent, err error) []byte {
+	rsp := &proto.TaskResponse{}
+	if err == nil {
+		rsp.Event = event
+	} else {
+		rsp.Error = proto.Error2Type(err)
+		rsp.Message = err.Error()
+	}
+	data, _ := json.Marshal(rsp)
+	return data
+}
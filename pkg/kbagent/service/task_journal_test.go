@@ -0,0 +1,226 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("task journal", func() {
+	var (
+		dir string
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	Context("journal", func() {
+		It("disabled", func() {
+			journal := newTaskJournal(filepath.Join(dir, "not-exist"))
+			Expect(journal).Should(BeNil())
+
+			Expect(journal.append(proto.TaskEvent{UID: "uid"})).Should(Succeed())
+			event, err := journal.get("uid")
+			Expect(err).Should(BeNil())
+			Expect(event).Should(BeNil())
+		})
+
+		It("latest wins", func() {
+			journal := newTaskJournal(dir)
+			Expect(journal).ShouldNot(BeNil())
+
+			Expect(journal.append(proto.TaskEvent{UID: "uid", Message: "10"})).Should(Succeed())
+			Expect(journal.append(proto.TaskEvent{UID: "other", Message: "20"})).Should(Succeed())
+			Expect(journal.append(proto.TaskEvent{UID: "uid", Message: "30"})).Should(Succeed())
+
+			event, err := journal.get("uid")
+			Expect(err).Should(BeNil())
+			Expect(event).ShouldNot(BeNil())
+			Expect(event.Message).Should(Equal("30"))
+
+			event, err = journal.get("not-exist")
+			Expect(err).Should(BeNil())
+			Expect(event).Should(BeNil())
+		})
+
+		It("truncated record", func() {
+			journal := newTaskJournal(dir)
+			Expect(journal.append(proto.TaskEvent{UID: "uid", Message: "10"})).Should(Succeed())
+
+			f, err := os.OpenFile(journal.path, os.O_APPEND|os.O_WRONLY, 0644)
+			Expect(err).Should(BeNil())
+			_, err = f.WriteString(`{"UID":"uid","messa`)
+			Expect(err).Should(BeNil())
+			Expect(f.Close()).Should(Succeed())
+
+			event, err := journal.get("uid")
+			Expect(err).Should(BeNil())
+			Expect(event).ShouldNot(BeNil())
+			Expect(event.Message).Should(Equal("10"))
+		})
+	})
+
+	Context("compaction", func() {
+		It("keep the latest event of tasks", func() {
+			journal := newTaskJournal(dir)
+			now := time.Now()
+			Expect(journal.append(proto.TaskEvent{UID: "running", StartTime: now})).Should(Succeed())
+			for i := 0; i < maxTaskJournalFinishedTasks+10; i++ {
+				uid := fmt.Sprintf("uid-%d", i)
+				Expect(journal.append(proto.TaskEvent{UID: uid, StartTime: now})).Should(Succeed())
+				Expect(journal.append(proto.TaskEvent{UID: uid, StartTime: now, EndTime: now.Add(time.Duration(i) * time.Second)})).Should(Succeed())
+			}
+			Expect(journal.compact()).Should(Succeed())
+
+			events, uids, err := journal.load()
+			Expect(err).Should(BeNil())
+			Expect(uids).Should(HaveLen(maxTaskJournalFinishedTasks + 1))
+			Expect(events).Should(HaveKey("running"))
+			Expect(events).ShouldNot(HaveKey("uid-0"))
+			Expect(events).ShouldNot(HaveKey("uid-9"))
+			Expect(events).Should(HaveKey("uid-10"))
+			Expect(events["uid-10"].EndTime.IsZero()).Should(BeFalse())
+		})
+
+		It("compact when the size exceeds the threshold", func() {
+			journal := newTaskJournal(dir)
+			message := strings.Repeat("x", 1024)
+			for i := 0; i < taskJournalCompactThreshold/1024+1; i++ {
+				Expect(journal.append(proto.TaskEvent{UID: "uid", Message: message})).Should(Succeed())
+			}
+			info, err := os.Stat(journal.path)
+			Expect(err).Should(BeNil())
+			Expect(info.Size()).Should(BeNumerically("<", taskJournalCompactThreshold))
+
+			event, err := journal.get("uid")
+			Expect(err).Should(BeNil())
+			Expect(event).ShouldNot(BeNil())
+		})
+	})
+
+	Context("query", func() {
+		query := func(service *taskQueryService, uid string) proto.TaskResponse {
+			output, err := service.HandleQuery(ctx, uid)
+			Expect(err).Should(BeNil())
+			rsp := proto.TaskResponse{}
+			Expect(json.Unmarshal(output, &rsp)).Should(Succeed())
+			return rsp
+		}
+
+		It("not enabled", func() {
+			service := newTaskQueryService(logr.New(nil), filepath.Join(dir, "not-exist"))
+			rsp := query(service, "uid")
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotImplemented)))
+		})
+
+		It("bad request", func() {
+			service := newTaskQueryService(logr.New(nil), dir)
+			rsp := query(service, "")
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrBadRequest)))
+		})
+
+		It("not found", func() {
+			service := newTaskQueryService(logr.New(nil), dir)
+			rsp := query(service, "uid")
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotFound)))
+		})
+
+		It("ok", func() {
+			service := newTaskQueryService(logr.New(nil), dir)
+			Expect(service.journal.append(proto.TaskEvent{UID: "uid", Message: "100", Code: 0})).Should(Succeed())
+
+			rsp := query(service, "uid")
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Event).ShouldNot(BeNil())
+			Expect(rsp.Event.Message).Should(Equal("100"))
+
+			payload, _ := json.Marshal(proto.TaskRequest{UID: "uid"})
+			output, err := service.HandleRequest(ctx, payload)
+			Expect(err).Should(BeNil())
+			Expect(json.Unmarshal(output, &rsp)).Should(Succeed())
+			Expect(rsp.Event).ShouldNot(BeNil())
+		})
+	})
+
+	Context("recover", func() {
+		var (
+			task = proto.Task{UID: "uid"}
+		)
+
+		newTaskService := func() *taskService {
+			return &taskService{
+				logger:  logr.New(nil),
+				journal: newTaskJournal(dir),
+			}
+		}
+
+		It("no journal", func() {
+			finished, err := newTaskService().recover(task)
+			Expect(err).Should(BeNil())
+			Expect(finished).Should(BeFalse())
+		})
+
+		It("finished", func() {
+			s := newTaskService()
+			Expect(s.journal.append(proto.TaskEvent{UID: "uid", StartTime: time.Now(), EndTime: time.Now()})).Should(Succeed())
+
+			finished, err := s.recover(task)
+			Expect(err).Should(BeNil())
+			Expect(finished).Should(BeTrue())
+		})
+
+		It("failed", func() {
+			s := newTaskService()
+			Expect(s.journal.append(proto.TaskEvent{UID: "uid", StartTime: time.Now(), EndTime: time.Now(), Code: -1})).Should(Succeed())
+
+			finished, err := s.recover(task)
+			Expect(err).Should(BeNil())
+			Expect(finished).Should(BeFalse())
+		})
+
+		It("interrupted", func() {
+			s := newTaskService()
+			Expect(s.journal.append(proto.TaskEvent{UID: "uid", StartTime: time.Now()})).Should(Succeed())
+
+			finished, err := s.recover(task)
+			Expect(err).Should(BeNil())
+			Expect(finished).Should(BeFalse())
+
+			// no failure is journaled, the outcome of the re-run will be
+			event, err := s.journal.get("uid")
+			Expect(err).Should(BeNil())
+			Expect(event.EndTime.IsZero()).Should(BeTrue())
+			Expect(event.Code).Should(Equal(int32(0)))
+		})
+	})
+})
@@ -24,9 +24,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
@@ -41,9 +43,10 @@ const (
 )
 
 type newReplicaTask struct {
-	logger        logr.Logger
-	actionService *actionService
-	task          *proto.NewReplicaTask
+	logger          logr.Logger
+	actionService   *actionService
+	streamingClient StreamingClientConfig
+	task            *proto.NewReplicaTask
 }
 
 var _ task = &newReplicaTask{}
@@ -53,6 +56,9 @@ func (s *newReplicaTask) run(ctx context.Context) (chan error, error) {
 	if !ok {
 		return nil, fmt.Errorf("%s is not supported", newReplicaDataLoad)
 	}
+	if action.Exec == nil {
+		return nil, errors.Wrapf(proto.ErrNotImplemented, "only exec action is supported for %s", newReplicaDataLoad)
+	}
 
 	conn, err := s.handshake(ctx)
 	if err != nil {
@@ -111,8 +117,6 @@ func (s *newReplicaTask) connectToRemote(ctx context.Context) (net.Conn, error)
 	if s.task.Port == 0 {
 		return nil, fmt.Errorf("remote port is required")
 	}
-	dialer := &net.Dialer{
-		Timeout: newReplicaConnectTimeoutSeconds * time.Second,
-	}
-	return dialer.Dial("tcp", fmt.Sprintf("%s:%d", s.task.Remote, s.task.Port))
+	address := net.JoinHostPort(s.task.Remote, strconv.Itoa(int(s.task.Port)))
+	return dialStreaming(ctx, s.streamingClient, address, newReplicaConnectTimeoutSeconds*time.Second)
 }
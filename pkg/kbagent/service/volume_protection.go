@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/sys/unix"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	defaultVolumeProtectionPeriodSeconds = 60
+
+	// the exceeded status is re-reported every N periods, in case the replica has been switched back to read-write
+	// by others, or the previous event is lost.
+	volumeProtectionResendPeriods = 10
+)
+
+type volumeProtectionRunner struct {
+	logger            logr.Logger
+	config            *proto.VolumeProtection
+	statFunc          func(path string) (uint64, uint64, error)
+	latestStatus      *proto.VolumeProtectionStatus
+	unreportedPeriods int
+}
+
+func newVolumeProtectionRunner(logger logr.Logger, config *proto.VolumeProtection) *volumeProtectionRunner {
+	return &volumeProtectionRunner{
+		logger:   logger.WithValues("probe", proto.VolumeProtectionProbe),
+		config:   config,
+		statFunc: statVolume,
+	}
+}
+
+func (r *volumeProtectionRunner) run() {
+	r.logger.Info("volume protection started", "config", r.config)
+
+	if r.config.PeriodSeconds <= 0 {
+		r.config.PeriodSeconds = defaultVolumeProtectionPeriodSeconds
+	}
+	ticker := time.NewTicker(time.Duration(r.config.PeriodSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.runOnce()
+	}
+}
+
+func (r *volumeProtectionRunner) runOnce() {
+	status, err := r.check()
+	if err != nil {
+		r.logger.Error(err, "failed to check the volume usage")
+		return
+	}
+	if r.shouldReport(status) {
+		r.report(status)
+		r.unreportedPeriods = 0
+	} else {
+		r.unreportedPeriods++
+	}
+	r.latestStatus = status
+}
+
+// shouldReport checks whether the status needs to be reported, the transitions of exceeded volumes are reported
+// immediately, and the exceeded status is re-reported periodically until the usage falls below the high watermark.
+func (r *volumeProtectionRunner) shouldReport(status *proto.VolumeProtectionStatus) bool {
+	if r.changed(status) {
+		return true
+	}
+	return status.HighWatermarkExceeded && r.unreportedPeriods+1 >= volumeProtectionResendPeriods
+}
+
+func (r *volumeProtectionRunner) check() (*proto.VolumeProtectionStatus, error) {
+	status := &proto.VolumeProtectionStatus{}
+	for _, v := range r.config.Volumes {
+		if v.HighWatermark <= 0 || v.HighWatermark > 100 {
+			continue
+		}
+		used, total, err := r.statFunc(v.MountPath)
+		if err != nil {
+			return nil, fmt.Errorf("stat volume %s at %s error: %s", v.Name, v.MountPath, err.Error())
+		}
+		usage := volumeUsage(used, total)
+		exceeded := usage >= v.HighWatermark
+		status.Volumes = append(status.Volumes, proto.VolumeUsage{
+			Name:          v.Name,
+			UsedBytes:     used,
+			TotalBytes:    total,
+			Usage:         usage,
+			HighWatermark: v.HighWatermark,
+			Exceeded:      exceeded,
+		})
+		status.HighWatermarkExceeded = status.HighWatermarkExceeded || exceeded
+	}
+	return status, nil
+}
+
+// changed checks whether the status needs to be reported, only the transitions of exceeded volumes are reported.
+func (r *volumeProtectionRunner) changed(status *proto.VolumeProtectionStatus) bool {
+	if r.latestStatus == nil {
+		// always report the first status if any volume exceeds the high watermark
+		return status.HighWatermarkExceeded
+	}
+	exceeded := func(s *proto.VolumeProtectionStatus) []string {
+		names := make([]string, 0)
+		for _, v := range s.Volumes {
+			if v.Exceeded {
+				names = append(names, v.Name)
+			}
+		}
+		return names
+	}
+	return !reflect.DeepEqual(exceeded(r.latestStatus), exceeded(status))
+}
+
+func (r *volumeProtectionRunner) report(status *proto.VolumeProtectionStatus) {
+	output, err := json.Marshal(status)
+	if err != nil {
+		r.logger.Error(err, "failed to marshal the volume protection status")
+		return
+	}
+	event := &proto.ProbeEvent{
+		Instance: r.config.Instance,
+		Probe:    proto.VolumeProtectionProbe,
+		Code:     0,
+		Output:   output,
+	}
+	msg, err := json.Marshal(event)
+	if err != nil {
+		r.logger.Error(err, "failed to marshal the volume protection event")
+		return
+	}
+	r.logger.Info("send volume protection event", "exceeded", status.HighWatermarkExceeded, "output", string(output))
+	_ = util.SendEventWithMessage(&r.logger, event.Probe, string(msg), false)
+}
+
+func statVolume(path string) (uint64, uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	blockSize := uint64(stat.Bsize)
+	total := uint64(stat.Blocks) * blockSize
+	used := total - uint64(stat.Bfree)*blockSize
+	return used, total, nil
+}
+
+func volumeUsage(used, total uint64) int32 {
+	if total == 0 {
+		return 0
+	}
+	return int32(used * 100 / total)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("volume protection", func() {
+	Context("volume protection", func() {
+		var (
+			usage  map[string]uint64
+			runner *volumeProtectionRunner
+		)
+
+		BeforeEach(func() {
+			usage = map[string]uint64{
+				"/data": 50,
+				"/log":  10,
+			}
+			runner = newVolumeProtectionRunner(logr.New(nil), &proto.VolumeProtection{
+				Instance: "test",
+				Volumes: []proto.VolumeWatermark{
+					{
+						Name:          "data",
+						MountPath:     "/data",
+						HighWatermark: 90,
+					},
+					{
+						Name:          "log",
+						MountPath:     "/log",
+						HighWatermark: 80,
+					},
+				},
+			})
+			runner.statFunc = func(path string) (uint64, uint64, error) {
+				used, ok := usage[path]
+				if !ok {
+					return 0, 0, errors.New("not found")
+				}
+				return used, 100, nil
+			}
+		})
+
+		It("below high watermark", func() {
+			status, err := runner.check()
+			Expect(err).Should(BeNil())
+			Expect(status.HighWatermarkExceeded).Should(BeFalse())
+			Expect(status.Volumes).Should(HaveLen(2))
+			Expect(status.Volumes[0].Usage).Should(Equal(int32(50)))
+			Expect(status.Volumes[1].Usage).Should(Equal(int32(10)))
+			Expect(runner.changed(status)).Should(BeFalse())
+		})
+
+		It("exceed high watermark", func() {
+			usage["/log"] = 80
+			status, err := runner.check()
+			Expect(err).Should(BeNil())
+			Expect(status.HighWatermarkExceeded).Should(BeTrue())
+			Expect(status.Volumes[0].Exceeded).Should(BeFalse())
+			Expect(status.Volumes[1].Exceeded).Should(BeTrue())
+			Expect(runner.changed(status)).Should(BeTrue())
+		})
+
+		It("transitions", func() {
+			usage["/data"] = 95
+			status, err := runner.check()
+			Expect(err).Should(BeNil())
+			Expect(runner.changed(status)).Should(BeTrue())
+			runner.latestStatus = status
+
+			// usage changes but the exceeded volumes keep the same
+			usage["/data"] = 96
+			status, err = runner.check()
+			Expect(err).Should(BeNil())
+			Expect(runner.changed(status)).Should(BeFalse())
+			runner.latestStatus = status
+
+			usage["/data"] = 60
+			status, err = runner.check()
+			Expect(err).Should(BeNil())
+			Expect(status.HighWatermarkExceeded).Should(BeFalse())
+			Expect(runner.changed(status)).Should(BeTrue())
+		})
+
+		It("re-report periodically", func() {
+			usage["/data"] = 95
+			runner.runOnce()
+			Expect(runner.unreportedPeriods).Should(Equal(0))
+
+			for i := 1; i <= volumeProtectionResendPeriods; i++ {
+				status, err := runner.check()
+				Expect(err).Should(BeNil())
+				Expect(runner.shouldReport(status)).Should(Equal(i == volumeProtectionResendPeriods))
+				runner.runOnce()
+			}
+			Expect(runner.unreportedPeriods).Should(Equal(0))
+
+			// no re-report if the usage is below the high watermark
+			usage["/data"] = 60
+			runner.runOnce()
+			for i := 0; i < volumeProtectionResendPeriods; i++ {
+				status, err := runner.check()
+				Expect(err).Should(BeNil())
+				Expect(runner.shouldReport(status)).Should(BeFalse())
+				runner.runOnce()
+			}
+		})
+
+		It("stat error", func() {
+			delete(usage, "/log")
+			_, err := runner.check()
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+})
@@ -46,13 +46,18 @@ const (
 	DefaultHTTPPort      = 3501
 	DefaultStreamingPort = 3502
 
+	TaskJournalVolumeName = "kbagent-task-journal"
+	TaskJournalMountPath  = service.DefaultTaskJournalDir
+
 	actionEnvName    = "KB_AGENT_ACTION"
 	probeEnvName     = "KB_AGENT_PROBE"
 	streamingEnvName = "KB_AGENT_STREAMING"
 	taskEnvName      = "KB_AGENT_TASK"
+
+	volumeProtectionEnvName = "KB_AGENT_VOLUME_PROTECTION"
 )
 
-func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []string) ([]corev1.EnvVar, error) {
+func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []string, volumeProtection *proto.VolumeProtection) ([]corev1.EnvVar, error) {
 	da, dp, err := serializeActionNProbe(actions, probes)
 	if err != nil {
 		return nil, err
@@ -76,6 +81,16 @@ func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []s
 			Value: strings.Join(streaming, ","),
 		})
 	}
+	if volumeProtection != nil && len(volumeProtection.Volumes) > 0 {
+		dv, err := json.Marshal(volumeProtection)
+		if err != nil {
+			return nil, err
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  volumeProtectionEnvName,
+			Value: string(dv),
+		})
+	}
 	return append(util.DefaultEnvVars(), envVars...), nil
 }
 
@@ -126,6 +141,30 @@ func UpdateEnv4Worker(envVars map[string]string, f func(proto.Task) *proto.Task)
 	}, nil
 }
 
+// IsProbeDefined checks whether the probe is defined in the kb-agent server of the pod.
+func IsProbeDefined(pod *corev1.Pod, probe string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != ContainerName {
+			continue
+		}
+		for _, env := range c.Env {
+			if env.Name != probeEnvName || len(env.Value) == 0 {
+				continue
+			}
+			probes := make([]proto.Probe, 0)
+			if err := json.Unmarshal([]byte(env.Value), &probes); err != nil {
+				return false
+			}
+			for _, p := range probes {
+				if p.Action == probe {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	envVars := util.EnvL2M(os.Environ())
 
@@ -137,7 +176,7 @@ func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	if config.Server {
 		return true, runAsServer(logger, config, services)
 	}
-	return false, runAsWorker(logger, services, envVars)
+	return false, runAsWorker(logger, config, services, envVars)
 }
 
 func initialize(logger logr.Logger, envVars map[string]string) ([]service.Service, error) {
@@ -155,7 +194,12 @@ func initialize(logger logr.Logger, envVars map[string]string) ([]service.Servic
 	if len(ds) > 0 {
 		streaming = strings.Split(ds, ",")
 	}
-	return service.New(logger, actions, probes, streaming)
+
+	volumeProtection, err := deserializeVolumeProtection(envVars[volumeProtectionEnvName])
+	if err != nil {
+		return nil, err
+	}
+	return service.New(logger, actions, probes, streaming, volumeProtection)
 }
 
 func getActionProbeNStreamingEnvValues(envVars map[string]string) (string, string, string) {
@@ -230,7 +274,7 @@ func runAsServer(logger logr.Logger, config server.Config, services []service.Se
 	return nil
 }
 
-func runAsWorker(logger logr.Logger, services []service.Service, envVars map[string]string) error {
+func runAsWorker(logger logr.Logger, config server.Config, services []service.Service, envVars map[string]string) error {
 	dt, ok := envVars[taskEnvName]
 	if !ok || len(dt) == 0 {
 		return nil // has no task
@@ -243,7 +287,23 @@ func runAsWorker(logger logr.Logger, services []service.Service, envVars map[str
 		return err
 	}
 
-	if err := service.RunTasks(logger, actionService(services), tasks); err != nil {
+	// serve the task query API while running tasks, the server is not started yet
+	if svc := taskService(services); svc != nil {
+		httpServer := server.NewHTTPServer(logger, config, []service.Service{svc})
+		if err = httpServer.StartNonBlocking(); err != nil {
+			logger.Error(err, "failed to start the HTTP server for task query")
+		} else {
+			defer func() { _ = httpServer.Close() }()
+		}
+	}
+
+	streamingClient := service.StreamingClientConfig{
+		TLSCertFile: config.TLSClientCertFile,
+		TLSKeyFile:  config.TLSClientKeyFile,
+		TLSCAFile:   config.TLSCAFile,
+		TokenFile:   config.TokenFile,
+	}
+	if err := service.RunTasks(logger, actionService(services), tasks, streamingClient); err != nil {
 		return errors.Wrap(err, "failed to run as worker")
 	}
 	return nil
@@ -258,6 +318,15 @@ func actionService(services []service.Service) service.Service {
 	return nil
 }
 
+func taskService(services []service.Service) service.Service {
+	for i, s := range services {
+		if s.Kind() == proto.ServiceTask.Kind {
+			return services[i]
+		}
+	}
+	return nil
+}
+
 func streamingService(services []service.Service) service.Service {
 	for i, s := range services {
 		if s.Kind() == proto.ServiceStreaming.Kind {
@@ -282,3 +351,14 @@ func deserializeTask(dt string) ([]proto.Task, error) {
 	}
 	return tasks, nil
 }
+
+func deserializeVolumeProtection(dv string) (*proto.VolumeProtection, error) {
+	if len(dv) == 0 {
+		return nil, nil
+	}
+	volumeProtection := &proto.VolumeProtection{}
+	if err := json.Unmarshal([]byte(dv), volumeProtection); err != nil {
+		return nil, err
+	}
+	return volumeProtection, nil
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package util
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -31,6 +32,8 @@ const (
 	kbEnvPodName   = "KB_AGENT_POD_NAME"
 	kbEnvPodUID    = "KB_AGENT_POD_UID"
 	kbEnvNodeName  = "KB_AGENT_NODE_NAME"
+
+	kbEnvServiceAccount = "KB_AGENT_SERVICE_ACCOUNT"
 )
 
 func EnvM2L(m map[string]string) []string {
@@ -96,6 +99,25 @@ func DefaultEnvVars() []corev1.EnvVar {
 	}
 }
 
+// ServiceAccountEnvVar returns the env var of the service account of the pod, it is only needed by the token authentication.
+func ServiceAccountEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: kbEnvServiceAccount,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				APIVersion: "v1",
+				FieldPath:  "spec.serviceAccountName",
+			},
+		},
+	}
+}
+
+// ServiceAccountUser returns the user name of the service account of the pod, it references the env vars and is
+// expanded by the kubelet when used in the container args.
+func ServiceAccountUser() string {
+	return fmt.Sprintf("system:serviceaccount:$(%s):$(%s)", kbEnvNamespace, kbEnvServiceAccount)
+}
+
 func namespace() string {
 	return os.Getenv(kbEnvNamespace)
 }
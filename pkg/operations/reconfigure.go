@@ -75,6 +75,11 @@ func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli c
 	}
 
 	if parameters.Status.Phase == parametersv1alpha1.CFinishedPhase {
+		// the parameter may still be rolled back within the rollback window.
+		if remaining := rollbackWindowRemaining(&parameters); remaining > 0 {
+			phase, _, err := syncReconfigureForOps(reqCtx, cli, resource, opsDeepCopy, opsv1alpha1.OpsRunningPhase)
+			return phase, remaining, err
+		}
 		return syncReconfigureForOps(reqCtx, cli, resource, opsDeepCopy, opsv1alpha1.OpsSucceedPhase)
 	}
 
@@ -88,6 +93,21 @@ func syncReconfigureForOps(reqCtx intctrlutil.RequestCtx, cli client.Client, res
 	return phase, noRequeueAfter, nil
 }
 
+func rollbackWindowRemaining(parameter *parametersv1alpha1.Parameter) time.Duration {
+	var remaining time.Duration
+	if parameter.Status.CompletionTime == nil {
+		return remaining
+	}
+	for _, compParameter := range parameter.Spec.ComponentParameters {
+		if compParameter.RollbackPolicy == nil {
+			continue
+		}
+		deadline := parameter.Status.CompletionTime.Add(compParameter.RollbackPolicy.Window.Duration)
+		remaining = max(remaining, time.Until(deadline))
+	}
+	return remaining
+}
+
 func (r *reconfigureAction) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (err error) {
 	if !intctrlutil.ObjectAPIVersionSupported(resource.Cluster) {
 		return intctrlutil.NewFatalError(fmt.Sprintf(`api version "%s" is not supported, you can upgrade the cluster to v1 version`, resource.Cluster.APIVersion))
@@ -121,6 +141,15 @@ func buildReconfigureParameter(ops *opsv1alpha1.OpsRequest) *parametersv1alpha1.
 		if len(reconfigure.Parameters) != 0 {
 			paramBuilder.SetComponentParameters(reconfigure.ComponentName, intctrlutil.TransformComponentParameters(reconfigure.Parameters))
 		}
+		if reconfigure.RevertToRevision != nil {
+			paramBuilder.SetRevertToRevision(reconfigure.ComponentName, *reconfigure.RevertToRevision)
+		}
+		if reconfigure.RollbackPolicy != nil {
+			paramBuilder.SetRollbackPolicy(reconfigure.ComponentName, *reconfigure.RollbackPolicy)
+		}
+		if reconfigure.CanaryPolicy != nil {
+			paramBuilder.SetCanaryPolicy(reconfigure.ComponentName, *reconfigure.CanaryPolicy)
+		}
 	}
 	return paramBuilder.GetObject()
 }
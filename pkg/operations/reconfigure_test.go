@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -118,5 +121,78 @@ var _ = Describe("Reconfigure OpsRequest", func() {
 			Expect(err).Should(BeNil())
 
 		})
+
+		It("Test Reconfigure OpsRequest with rollback and canary policy", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			By("create Reconfiguring opsRequest with rollback and canary policy")
+			ops := testops.NewOpsRequestObj("rollback-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, opsv1alpha1.ReconfiguringType)
+			ops.Spec.Reconfigures = []opsv1alpha1.Reconfigure{
+				{
+					ComponentOps: opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					Parameters: []opsv1alpha1.ParameterPair{
+						{
+							Key:   "max_connections",
+							Value: pointer.String("200"),
+						}},
+					RollbackPolicy: &parametersv1alpha1.RollbackPolicy{
+						Window: metav1.Duration{Duration: 10 * time.Minute},
+					},
+					CanaryPolicy: &parametersv1alpha1.CanaryPolicy{
+						Replicas: 1,
+						BakeTime: metav1.Duration{Duration: time.Minute},
+					},
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			Expect(opsutil.UpdateClusterOpsAnnotations(ctx, k8sClient, opsRes.Cluster, nil)).Should(Succeed())
+
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var param = &parametersv1alpha1.Parameter{}
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), param)).Should(Succeed())
+			Expect(param.Spec.ComponentParameters).Should(HaveLen(1))
+			Expect(param.Spec.ComponentParameters[0].RollbackPolicy).ShouldNot(BeNil())
+			Expect(param.Spec.ComponentParameters[0].CanaryPolicy).Should(BeEquivalentTo(ops.Spec.Reconfigures[0].CanaryPolicy))
+
+			By("the opsRequest keeps running within the rollback window")
+			Expect(testapps.ChangeObjStatus(&testCtx, param, func() {
+				param.Status.Phase = parametersv1alpha1.CFinishedPhase
+				param.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+			})).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).Should(BeNil())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsRunningPhase))
+
+			By("the opsRequest succeeds after the rollback window")
+			Expect(testapps.ChangeObjStatus(&testCtx, param, func() {
+				param.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			})).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).Should(BeNil())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("Test Reconfigure OpsRequest reverting to revision", func() {
+			ops := testops.NewOpsRequestObj("revert-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, opsv1alpha1.ReconfiguringType)
+			ops.Spec.Reconfigures = []opsv1alpha1.Reconfigure{
+				{
+					ComponentOps:     opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					RevertToRevision: pointer.Int64(2),
+				},
+			}
+			param := buildReconfigureParameter(ops)
+			Expect(param.Spec.ComponentParameters).Should(HaveLen(1))
+			Expect(param.Spec.ComponentParameters[0].Parameters).Should(BeEmpty())
+			Expect(*param.Spec.ComponentParameters[0].RevertToRevision).Should(BeEquivalentTo(2))
+		})
 	})
 })
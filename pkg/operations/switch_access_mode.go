@@ -0,0 +1,283 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type switchAccessModeOpsHandler struct{}
+
+var _ OpsHandler = switchAccessModeOpsHandler{}
+
+func init() {
+	switchAccessModeBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        switchAccessModeOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.SwitchAccessModeType, switchAccessModeBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the switch-access-mode request.
+func (r switchAccessModeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewSwitchAccessModeCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks the lifecycle actions and the instances to switch, and initializes the progress details of the replicas.
+func (r switchAccessModeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = make(map[string]opsv1alpha1.OpsRequestComponentStatus)
+	}
+	for _, switchAccessMode := range opsRequest.Spec.SwitchAccessModeList {
+		comps, err := r.listComponents(reqCtx, cli, opsRes.Cluster, switchAccessMode.ComponentName)
+		if err != nil {
+			return err
+		}
+		var (
+			progressDetails []opsv1alpha1.ProgressStatusDetail
+			podNames        []string
+		)
+		for i := range comps {
+			synthesizedComp, err := r.buildSynthesizedComp(reqCtx, cli, &comps[i])
+			if err != nil {
+				return err
+			}
+			if !lifecycle.IsActionDefined(r.action(synthesizedComp, switchAccessMode.Mode)) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" does not define the lifecycle action to switch to %s`,
+					switchAccessMode.ComponentName, switchAccessMode.Mode))
+			}
+			pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, synthesizedComp.Name)
+			if err != nil {
+				return err
+			}
+			for _, pod := range pods {
+				if len(switchAccessMode.Instances) > 0 && !slices.Contains(switchAccessMode.Instances, pod.Name) {
+					continue
+				}
+				podNames = append(podNames, pod.Name)
+				progressDetails = append(progressDetails, opsv1alpha1.ProgressStatusDetail{
+					ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+					Status:    opsv1alpha1.PendingProgressStatus,
+					Message:   fmt.Sprintf("wait to switch the instance %s to %s", pod.Name, switchAccessMode.Mode),
+				})
+			}
+		}
+		for _, ins := range switchAccessMode.Instances {
+			if !slices.Contains(podNames, ins) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" not belongs to the component "%s"`, ins, switchAccessMode.ComponentName))
+			}
+		}
+		opsRequest.Status.Components[switchAccessMode.ComponentName] = opsv1alpha1.OpsRequestComponentStatus{
+			Phase:           appsv1.UpdatingComponentPhase,
+			ProgressDetails: progressDetails,
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for switch-access-mode opsRequest.
+func (r switchAccessModeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest      = opsRes.OpsRequest
+		oldStatus       = opsRequest.Status.DeepCopy()
+		patch           = client.MergeFrom(opsRequest.DeepCopy())
+		expectCount     int32
+		completedCount  int32
+		failedCount     int32
+		opsRequestPhase = opsv1alpha1.OpsRunningPhase
+	)
+	for _, switchAccessMode := range opsRequest.Spec.SwitchAccessModeList {
+		expect, completed, failed, err := r.switchAccessMode(reqCtx, cli, opsRes, switchAccessMode)
+		if err != nil {
+			return "", 0, err
+		}
+		expectCount += expect
+		completedCount += completed
+		failedCount += failed
+	}
+
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
+	if !reflect.DeepEqual(*oldStatus, opsRequest.Status) {
+		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if expectCount == completedCount {
+		opsRequestPhase = opsv1alpha1.OpsSucceedPhase
+		if failedCount > 0 {
+			opsRequestPhase = opsv1alpha1.OpsFailedPhase
+		}
+	}
+	return opsRequestPhase, 5 * time.Second, nil
+}
+
+// SaveLastConfiguration this operation does not change Cluster.spec, empty implementation here.
+func (r switchAccessModeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// switchAccessMode calls the lifecycle action on the pending replicas of the component one by one,
+// and returns the expected, completed and failed count of the replicas.
+func (r switchAccessModeOpsHandler) switchAccessMode(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, switchAccessMode opsv1alpha1.SwitchAccessMode) (int32, int32, int32, error) {
+	var (
+		opsRequest                  = opsRes.OpsRequest
+		compName                    = switchAccessMode.ComponentName
+		compStatus                  = opsRequest.Status.Components[compName]
+		completedCount, failedCount int32
+	)
+	comps, err := r.listComponents(reqCtx, cli, opsRes.Cluster, compName)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for i := range comps {
+		var synthesizedComp *component.SynthesizedComponent
+		pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name,
+			comps[i].Labels[constant.KBAppComponentLabelKey])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, pod := range pods {
+			progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, getProgressObjectKey(constant.PodKind, pod.Name))
+			if progressDetail == nil || progressDetail.Status != opsv1alpha1.PendingProgressStatus {
+				continue
+			}
+			if synthesizedComp == nil {
+				if synthesizedComp, err = r.buildSynthesizedComp(reqCtx, cli, &comps[i]); err != nil {
+					return 0, 0, 0, err
+				}
+			}
+			detail := *progressDetail
+			detail.StartTime = metav1.Now()
+			if err = r.switchReplica(reqCtx, cli, synthesizedComp, pod, switchAccessMode.Mode); err != nil {
+				detail.Status = opsv1alpha1.FailedProgressStatus
+				detail.Message = fmt.Sprintf("failed to switch the instance %s to %s: %s", pod.Name, switchAccessMode.Mode, err.Error())
+			} else {
+				detail.Status = opsv1alpha1.SucceedProgressStatus
+				detail.Message = fmt.Sprintf("switch the instance %s to %s successfully", pod.Name, switchAccessMode.Mode)
+			}
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, detail)
+		}
+	}
+
+	// the replicas that have been deleted during the operation are considered failed.
+	for i := range compStatus.ProgressDetails {
+		progressDetail := &compStatus.ProgressDetails[i]
+		if progressDetail.Status == opsv1alpha1.PendingProgressStatus && !r.podExists(reqCtx, cli, opsRes, progressDetail.ObjectKey) {
+			detail := *progressDetail
+			detail.Status = opsv1alpha1.FailedProgressStatus
+			detail.Message = "the instance is not found"
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, detail)
+		}
+		if isCompletedProgressStatus(progressDetail.Status) {
+			completedCount++
+			if progressDetail.Status == opsv1alpha1.FailedProgressStatus {
+				failedCount++
+			}
+		}
+	}
+	opsRequest.Status.Components[compName] = compStatus
+	return int32(len(compStatus.ProgressDetails)), completedCount, failedCount, nil
+}
+
+func (r switchAccessModeOpsHandler) switchReplica(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod, mode opsv1alpha1.AccessMode) error {
+	if mode == opsv1alpha1.ReadOnlyAccessMode {
+		return component.SetReplicaReadonly(reqCtx.Ctx, cli, synthesizedComp, pod, component.ReplicaReadonlyByOpsRequest)
+	}
+	if component.GetReplicaReadonlySource(pod) == component.ReplicaReadonlyByVolumeProtection {
+		// the volume protection switches the replica back to read-write once the usage falls below the high watermark
+		return fmt.Errorf("the instance is switched to read-only by the volume protection, " +
+			"it can't be switched to read-write until the volume space usage falls below the high watermark")
+	}
+	return component.SetReplicaReadwrite(reqCtx.Ctx, cli, synthesizedComp, pod)
+}
+
+func (r switchAccessModeOpsHandler) podExists(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, objectKey string) bool {
+	podName := strings.TrimPrefix(objectKey, getProgressObjectKey(constant.PodKind, ""))
+	pod := &corev1.Pod{}
+	err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: podName, Namespace: opsRes.Cluster.Namespace}, pod)
+	return err == nil || !apierrors.IsNotFound(err)
+}
+
+func (r switchAccessModeOpsHandler) action(synthesizedComp *component.SynthesizedComponent, mode opsv1alpha1.AccessMode) *appsv1.Action {
+	if synthesizedComp.LifecycleActions == nil {
+		return nil
+	}
+	if mode == opsv1alpha1.ReadOnlyAccessMode {
+		return synthesizedComp.LifecycleActions.Readonly
+	}
+	return synthesizedComp.LifecycleActions.Readwrite
+}
+
+func (r switchAccessModeOpsHandler) buildSynthesizedComp(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	comp *appsv1.Component) (*component.SynthesizedComponent, error) {
+	compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, comp.Spec.CompDef)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx.Ctx, cli, compDef, comp)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp.TemplateVars, _, err = component.ResolveTemplateNEnvVars(reqCtx.Ctx, cli, synthesizedComp, compDef.Spec.Vars)
+	if err != nil {
+		return nil, err
+	}
+	return synthesizedComp, nil
+}
+
+func (r switchAccessModeOpsHandler) listComponents(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1.Cluster,
+	componentName string) ([]appsv1.Component, error) {
+	if cluster.Spec.GetComponentByName(componentName) != nil {
+		comp, err := component.GetComponentByName(reqCtx.Ctx, cli, cluster.Namespace,
+			constant.GenerateClusterComponentName(cluster.Name, componentName))
+		if err != nil {
+			return nil, err
+		}
+		return []appsv1.Component{*comp}, nil
+	}
+	return intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, cluster, componentName)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testops "github.com/apecloud/kubeblocks/pkg/testutil/operations"
+)
+
+var _ = Describe("SwitchAccessMode OpsRequest", func() {
+	var (
+		compDefName = "test-compdef-"
+		clusterName = "test-cluster-"
+		compDefObj  *appsv1.ComponentDefinition
+		clusterObj  *appsv1.Cluster
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ComponentSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("Test OpsRequest", func() {
+		var reqCtx intctrlutil.RequestCtx
+		var opsRes *OpsResource
+
+		BeforeEach(func() {
+			By("Create a componentDefinition obj.")
+			compDefObj = testapps.NewComponentDefinitionFactory(compDefName).
+				WithRandomName().
+				SetDefaultSpec().
+				SetLifecycleAction("Readonly", testapps.NewLifecycleAction("readonly")).
+				SetLifecycleAction("Readwrite", testapps.NewLifecycleAction("readwrite")).
+				Create(&testCtx).
+				GetObject()
+
+			By("Creating a cluster")
+			clusterObj = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+				WithRandomName().
+				AddComponent(defaultCompName, compDefObj.GetName()).
+				SetReplicas(2).
+				Create(&testCtx).GetObject()
+
+			By("creating a component")
+			_ = testapps.NewComponentFactory(testCtx.DefaultNamespace, clusterObj.Name+"-"+defaultCompName, compDefObj.Name).
+				AddAppManagedByLabel().
+				AddAppInstanceLabel(clusterObj.Name).
+				AddAppComponentLabel(defaultCompName).
+				AddAnnotations(constant.KBAppClusterUIDKey, string(clusterObj.UID)).
+				Create(&testCtx).
+				GetObject()
+
+			By("Creating Pods of the component")
+			container := corev1.Container{
+				Name:            "mock-container-name",
+				Image:           testapps.ApeCloudMySQLImage,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+			}
+			for i := 0; i < 2; i++ {
+				_ = testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-%s-%d", clusterObj.Name, defaultCompName, i)).
+					AddContainer(container).
+					AddAppInstanceLabel(clusterObj.Name).
+					AddAppComponentLabel(defaultCompName).
+					AddAppManagedByLabel().
+					Create(&testCtx).GetObject()
+			}
+
+			By("mock cluster is Running and the status operations")
+			Expect(testapps.ChangeObjStatus(&testCtx, clusterObj, func() {
+				clusterObj.Status.Phase = appsv1.RunningClusterPhase
+				clusterObj.Status.Components = map[string]appsv1.ClusterComponentStatus{
+					defaultCompName: {
+						Phase: appsv1.RunningComponentPhase,
+					},
+				}
+			})).Should(Succeed())
+
+			reqCtx = intctrlutil.RequestCtx{
+				Ctx:      testCtx.Ctx,
+				Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+
+			opsRes = &OpsResource{
+				Cluster:  clusterObj,
+				Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+		})
+
+		testSwitchAccessMode := func(mode opsv1alpha1.AccessMode, action string, instances []string, expectCount int) {
+			By("create switchAccessMode opsRequest")
+			ops := testops.NewOpsRequestObj("ops-switch-access-mode-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, opsv1alpha1.SwitchAccessModeType)
+			ops.Spec.SwitchAccessModeList = []opsv1alpha1.SwitchAccessMode{
+				{
+					ComponentOps: opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					Mode:         mode,
+					Instances:    instances,
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+
+			By("mock switchAccessMode OpsRequest phase is Creating")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsCreatingPhase))
+
+			By("do switchAccessMode action")
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, opsv1alpha1.ConditionTypeFailed)).Should(BeNil())
+			Expect(opsRes.OpsRequest.Status.Components[defaultCompName].ProgressDetails).Should(HaveLen(expectCount))
+
+			testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Times(expectCount).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+					GinkgoWriter.Printf("ActionRequest: %#v\n", req)
+					Expect(req.Action).Should(Equal(action))
+					Expect(req.Parameters["KB_POD_FQDN"]).ShouldNot(BeEmpty())
+					rsp := kbagentproto.ActionResponse{Message: "mock success"}
+					return rsp, nil
+				})
+			})
+
+			By("do reconcile switchAccessMode action")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Progress).Should(Equal(fmt.Sprintf("%d/%d", expectCount, expectCount)))
+			for _, detail := range opsRes.OpsRequest.Status.Components[defaultCompName].ProgressDetails {
+				Expect(detail.Status).Should(Equal(opsv1alpha1.SucceedProgressStatus))
+			}
+		}
+
+		It("Test switchAccessMode OpsRequest to ReadOnly for all instances", func() {
+			testSwitchAccessMode(opsv1alpha1.ReadOnlyAccessMode, "readonly", nil, 2)
+		})
+
+		It("Test switchAccessMode OpsRequest to ReadWrite for the specified instance", func() {
+			instanceName := fmt.Sprintf("%s-%s-%d", clusterObj.Name, defaultCompName, 1)
+			testSwitchAccessMode(opsv1alpha1.ReadWriteAccessMode, "readwrite", []string{instanceName}, 1)
+		})
+
+		It("Test switchAccessMode OpsRequest to ReadWrite for the instance switched to read-only by the volume protection", func() {
+			By("mock the instance is switched to read-only by the volume protection")
+			pod := &corev1.Pod{}
+			podKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: fmt.Sprintf("%s-%s-%d", clusterObj.Name, defaultCompName, 0)}
+			Expect(k8sClient.Get(testCtx.Ctx, podKey, pod)).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, pod, func(obj *corev1.Pod) {
+				if obj.Annotations == nil {
+					obj.Annotations = map[string]string{}
+				}
+				obj.Annotations[constant.ReplicaReadonlyAnnotationKey] = component.ReplicaReadonlyByVolumeProtection
+			})).Should(Succeed())
+
+			ops := testops.NewOpsRequestObj("ops-switch-access-mode-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, opsv1alpha1.SwitchAccessModeType)
+			ops.Spec.SwitchAccessModeList = []opsv1alpha1.SwitchAccessMode{
+				{
+					ComponentOps: opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					Mode:         opsv1alpha1.ReadWriteAccessMode,
+					Instances:    []string{podKey.Name},
+				},
+			}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Times(0)
+			})
+
+			By("the instance keeps read-only until the volume space usage falls below the high watermark")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			details := opsRes.OpsRequest.Status.Components[defaultCompName].ProgressDetails
+			Expect(details).Should(HaveLen(1))
+			Expect(details[0].Status).Should(Equal(opsv1alpha1.FailedProgressStatus))
+			Expect(k8sClient.Get(testCtx.Ctx, podKey, pod)).Should(Succeed())
+			Expect(component.GetReplicaReadonlySource(pod)).Should(Equal(component.ReplicaReadonlyByVolumeProtection))
+		})
+	})
+})
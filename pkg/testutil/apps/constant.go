@@ -238,7 +238,7 @@ var (
 			},
 			Switchover:       nil,
 			MemberJoin:       nil,
-			MemberLeave:      NewLifecycleAction("member-leave"),
+			MemberLeave:      &appsv1.StepAction{Action: *NewLifecycleAction("member-leave")},
 			Readonly:         nil,
 			Readwrite:        nil,
 			DataDump:         nil,
@@ -41,6 +41,16 @@ func (factory *MockRolloutFactory) SetClusterName(clusterName string) *MockRollo
 	return factory
 }
 
+func (factory *MockRolloutFactory) SetPaused(paused bool) *MockRolloutFactory {
+	factory.Get().Spec.Paused = paused
+	return factory
+}
+
+func (factory *MockRolloutFactory) SetAbort(abort bool) *MockRolloutFactory {
+	factory.Get().Spec.Abort = abort
+	return factory
+}
+
 func (factory *MockRolloutFactory) AddComponent(compName string) *MockRolloutFactory {
 	comp := appsv1alpha1.RolloutComponent{
 		Name: compName,
@@ -101,3 +111,40 @@ func (factory *MockRolloutFactory) SetReplicas(replicas int32) *MockRolloutFacto
 		comp.Replicas = ptr.To(intstr.FromInt32(replicas))
 	})
 }
+
+func (factory *MockRolloutFactory) AddSharding(shardingName string) *MockRolloutFactory {
+	sharding := appsv1alpha1.RolloutSharding{
+		Name: shardingName,
+	}
+	factory.Get().Spec.Shardings = append(factory.Get().Spec.Shardings, sharding)
+	return factory
+}
+
+type updateRollShardingFn func(*appsv1alpha1.RolloutSharding)
+
+func (factory *MockRolloutFactory) updateLastSharding(update updateRollShardingFn) *MockRolloutFactory {
+	shardings := factory.Get().Spec.Shardings
+	if len(shardings) > 0 {
+		update(&shardings[len(shardings)-1])
+	}
+	factory.Get().Spec.Shardings = shardings
+	return factory
+}
+
+func (factory *MockRolloutFactory) SetShardingServiceVersion(serviceVersion string) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.ServiceVersion = ptr.To(serviceVersion)
+	})
+}
+
+func (factory *MockRolloutFactory) SetShardingDef(shardingDef string) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.ShardingDef = ptr.To(shardingDef)
+	})
+}
+
+func (factory *MockRolloutFactory) SetMaxConcurrentShards(shards intstr.IntOrString) *MockRolloutFactory {
+	return factory.updateLastSharding(func(sharding *appsv1alpha1.RolloutSharding) {
+		sharding.MaxConcurrentShards = ptr.To(shards)
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+type BackupReplicationPolicyFactory struct {
+	testapps.BaseFactory[dpv1alpha1.BackupReplicationPolicy, *dpv1alpha1.BackupReplicationPolicy, BackupReplicationPolicyFactory]
+}
+
+func NewBackupReplicationPolicyFactory(namespace, name string) *BackupReplicationPolicyFactory {
+	f := &BackupReplicationPolicyFactory{}
+	f.Init(namespace, name, &dpv1alpha1.BackupReplicationPolicy{}, f)
+	return f
+}
+
+func (f *BackupReplicationPolicyFactory) SetBackupPolicyName(backupPolicyName string) *BackupReplicationPolicyFactory {
+	f.Get().Spec.BackupPolicyName = backupPolicyName
+	return f
+}
+
+func (f *BackupReplicationPolicyFactory) SetBackupMethods(methods ...string) *BackupReplicationPolicyFactory {
+	f.Get().Spec.BackupMethods = methods
+	return f
+}
+
+func (f *BackupReplicationPolicyFactory) AddTarget(backupRepoName string,
+	retentionPeriod dpv1alpha1.RetentionPeriod) *BackupReplicationPolicyFactory {
+	f.Get().Spec.Targets = append(f.Get().Spec.Targets, dpv1alpha1.BackupReplicationTarget{
+		BackupRepoName:  backupRepoName,
+		RetentionPeriod: retentionPeriod,
+	})
+	return f
+}
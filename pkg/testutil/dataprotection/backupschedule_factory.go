@@ -54,3 +54,8 @@ func (f *BackupScheduleFactory) SetSchedules(schedules []dpv1alpha1.SchedulePoli
 	f.Get().Spec.Schedules = schedules
 	return f
 }
+
+func (f *BackupScheduleFactory) SetScheduleMode(mode dpv1alpha1.ScheduleMode) *BackupScheduleFactory {
+	f.Get().Spec.ScheduleMode = mode
+	return f
+}
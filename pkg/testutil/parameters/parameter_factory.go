@@ -20,6 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package parameters
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
@@ -66,3 +69,15 @@ func (f *MockParameterFactory) AddCustomTemplate(tpl string, templateName, ns st
 	}
 	return f
 }
+
+func (f *MockParameterFactory) SetRevertToRevision(revision int64) *MockParameterFactory {
+	f.Get().Spec.ComponentParameters[0].RevertToRevision = &revision
+	return f
+}
+
+func (f *MockParameterFactory) SetRollbackPolicy(window time.Duration) *MockParameterFactory {
+	f.Get().Spec.ComponentParameters[0].RollbackPolicy = &parametersv1alpha1.RollbackPolicy{
+		Window: metav1.Duration{Duration: window},
+	}
+	return f
+}